@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// runBackfill implements `uhc backfill`: queues jobs on the SQLite backfill
+// queue and manages them. The server's queue runs queued jobs, picking up new
+// ones within a minute.
+func runBackfill(args []string) int {
+	fs := newFlagSet("backfill")
+	asJSON := fs.Bool("json", false, "Print jobs as JSON")
+	date := fs.String("date", "", "games: process one date (YYYY-MM-DD)")
+	days := fs.Int("days", 0, "games: process the last N days (1-30)")
+	team := fs.String("team", "", "pbp: one team code (default all teams)")
+	games := fs.Int("games", 10, "pbp: last N games per team (1-20)")
+	id := fs.Int64("id", 0, "jobs: show one job with its failed games")
+	limit := fs.Int("limit", 20, "jobs: number of recent jobs to list")
+	action, args := splitAction(args)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if action == "" {
+		return usageError(fs, "expected games, pbp, jobs, cancel or retry")
+	}
+
+	if err := services.InitDatabase(services.DefaultDatabasePath); err != nil {
+		return fail(err)
+	}
+	defer services.GetDatabase().Close()
+	queue := services.GetBackfillQueue()
+
+	switch action {
+	case "games":
+		params := services.BackfillParams{Date: *date, Days: *days}
+		switch {
+		case *date != "" && *days != 0:
+			return usageError(fs, "use -date or -days, not both")
+		case *date != "":
+			if _, err := time.Parse("2006-01-02", *date); err != nil {
+				return usageError(fs, "invalid -date %q (expected YYYY-MM-DD)", *date)
+			}
+		case *days < 1 || *days > 30:
+			return usageError(fs, "games needs -date or -days between 1 and 30")
+		}
+		return enqueueJob(queue, services.BackfillKindGameResults, params, *asJSON)
+
+	case "pbp":
+		if *games < 1 || *games > 20 {
+			return usageError(fs, "-games must be between 1 and 20")
+		}
+		params := services.BackfillParams{Team: strings.ToUpper(*team), Games: *games}
+		return enqueueJob(queue, services.BackfillKindPlayByPlay, params, *asJSON)
+
+	case "jobs":
+		if *id != 0 {
+			job, err := queue.Job(*id)
+			if err != nil {
+				return fail(err)
+			}
+			return printJobs([]services.BackfillJob{*job}, *asJSON)
+		}
+		jobs, err := queue.Jobs(*limit)
+		if err != nil {
+			return fail(err)
+		}
+		return printJobs(jobs, *asJSON)
+
+	case "cancel", "retry":
+		jobID, err := jobIDArg(fs, action)
+		if err != nil {
+			return usageError(fs, "%v", err)
+		}
+		if action == "cancel" {
+			err = queue.Cancel(jobID)
+		} else {
+			err = queue.Retry(jobID)
+		}
+		if err != nil {
+			return fail(err)
+		}
+		job, err := queue.Job(jobID)
+		if err != nil {
+			return fail(err)
+		}
+		if code := printJobs([]services.BackfillJob{*job}, *asJSON); code != 0 || *asJSON {
+			return code
+		}
+		if action == "cancel" && job.Status == services.BackfillJobRunning {
+			fmt.Fprintf(out, "\nCancel requested; the server stops the job before its next game attempt.\n")
+		}
+		return 0
+	}
+
+	return usageError(fs, "unknown backfill action %q", action)
+}
+
+// jobIDArg reads the job ID following cancel or retry
+func jobIDArg(fs *flag.FlagSet, action string) (int64, error) {
+	if fs.NArg() != 1 {
+		return 0, fmt.Errorf("%s needs a job ID", action)
+	}
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid job ID %q", fs.Arg(0))
+	}
+	return id, nil
+}
+
+// enqueueJob queues a backfill and prints it
+func enqueueJob(queue *services.BackfillQueue, kind string, params services.BackfillParams, asJSON bool) int {
+	job, err := queue.Enqueue(kind, params)
+	if err != nil {
+		return fail(err)
+	}
+	if code := printJobs([]services.BackfillJob{*job}, asJSON); code != 0 || asJSON {
+		return code
+	}
+	fmt.Fprintf(out, "\nQueued; the server's backfill queue will run it. Track it with: uhc backfill jobs -id %d\n", job.ID)
+	return 0
+}
+
+// printJobs prints jobs as JSON or a table, followed by any failed games
+func printJobs(jobs []services.BackfillJob, asJSON bool) int {
+	if asJSON {
+		if len(jobs) == 1 {
+			return printJSON(jobs[0])
+		}
+		return printJSON(jobs)
+	}
+
+	tw := newTable("ID", "KIND", "STATUS", "PARAMS", "DONE", "FAILED", "TOTAL", "PROGRESS", "ETA", "CREATED")
+	for _, job := range jobs {
+		params, _ := json.Marshal(job.Params)
+		eta := "-"
+		if job.ETA != nil {
+			eta = job.ETA.Local().Format("15:04:05")
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\n",
+			job.ID, job.Kind, job.Status, params, job.DoneGames, job.FailedGames, job.TotalGames,
+			percent(job.Progress), eta, job.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	tw.Flush()
+
+	for _, job := range jobs {
+		if job.Error != "" {
+			fmt.Fprintf(out, "\nJob %d error: %s\n", job.ID, job.Error)
+		}
+		if len(job.Failures) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "\nJob %d failed games:\n", job.ID)
+		ftw := newTable("GAME", "ATTEMPTS", "ERROR")
+		for _, failure := range job.Failures {
+			fmt.Fprintf(ftw, "%d\t%d\t%s\n", failure.GameID, failure.Attempts, failure.Error)
+		}
+		ftw.Flush()
+	}
+	return 0
+}
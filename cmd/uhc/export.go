@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// runExport implements `uhc export`: writes stored results or predictions as
+// JSON or CSV, optionally limited to a date range
+func runExport(args []string) int {
+	fs := newFlagSet("export")
+	format := fs.String("format", "json", "Output format: json or csv")
+	output := fs.String("o", "", "Write to this file instead of stdout")
+	from := fs.String("from", "", "Only games on or after this date (YYYY-MM-DD)")
+	to := fs.String("to", "", "Only games on or before this date (YYYY-MM-DD)")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		return usageError(fs, "expected results or predictions")
+	}
+	if *format != "json" && *format != "csv" {
+		return usageError(fs, "invalid -format %q", *format)
+	}
+
+	inRange, err := dateRange(*from, *to)
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	var header []string
+	var rows [][]string
+	var records interface{}
+
+	switch fs.Arg(0) {
+	case "results":
+		games, err := services.InitFeatureSnapshotService().LoadCompletedGames()
+		if err != nil {
+			return fail(err)
+		}
+		filtered := games[:0]
+		for _, game := range games {
+			if inRange(game.GameDate) {
+				filtered = append(filtered, game)
+			}
+		}
+		records = filtered
+
+		header = []string{"game_id", "date", "season", "game_type", "away", "away_score", "home", "home_score", "winner", "win_type", "venue"}
+		for _, game := range filtered {
+			rows = append(rows, []string{
+				strconv.Itoa(game.GameID), game.GameDate.Format("2006-01-02"), strconv.Itoa(game.Season), strconv.Itoa(game.GameType),
+				game.AwayTeam.TeamCode, strconv.Itoa(game.AwayTeam.Score), game.HomeTeam.TeamCode, strconv.Itoa(game.HomeTeam.Score),
+				game.Winner, game.WinType, game.Venue,
+			})
+		}
+
+	case "predictions":
+		predictions, err := services.InitPredictionStorageService().GetAllPredictions()
+		if err != nil {
+			return fail(err)
+		}
+		filtered := predictions[:0]
+		for _, prediction := range predictions {
+			if inRange(prediction.GameDate) {
+				filtered = append(filtered, prediction)
+			}
+		}
+		sort.Slice(filtered, func(i, j int) bool { return filtered[i].GameDate.Before(filtered[j].GameDate) })
+		records = filtered
+
+		header = []string{"game_id", "date", "away", "home", "predicted_winner", "home_win_prob", "away_win_prob",
+			"confidence", "predicted_score", "predicted_at", "actual_winner", "correct"}
+		for _, stored := range filtered {
+			actualWinner, correct := "", ""
+			if stored.ActualResult != nil {
+				actualWinner = stored.ActualResult.WinningTeam
+			}
+			if stored.Accuracy != nil {
+				correct = strconv.FormatBool(stored.Accuracy.WinnerCorrect)
+			}
+			p := stored.Prediction
+			rows = append(rows, []string{
+				strconv.Itoa(stored.GameID), stored.GameDate.Format("2006-01-02"), stored.AwayTeam, stored.HomeTeam,
+				p.Prediction.Winner, formatProb(p.HomeTeam.WinProbability), formatProb(p.AwayTeam.WinProbability),
+				formatProb(p.Confidence), p.Prediction.PredictedScore, stored.PredictedAt.Format(time.RFC3339),
+				actualWinner, correct,
+			})
+		}
+
+	default:
+		return usageError(fs, "unknown export %q", fs.Arg(0))
+	}
+
+	w := out
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fail(err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if *format == "json" {
+		prev := out
+		out = w
+		defer func() { out = prev }()
+		return printJSON(records)
+	}
+	if err := writeCSV(w, header, rows); err != nil {
+		return fail(err)
+	}
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "✅ Exported %d rows to %s\n", len(rows), *output)
+	}
+	return 0
+}
+
+// dateRange returns a filter for dates within the inclusive [from, to] range;
+// either end may be empty
+func dateRange(from, to string) (func(time.Time) bool, error) {
+	var start, end time.Time
+	var err error
+	if from != "" {
+		if start, err = time.Parse("2006-01-02", from); err != nil {
+			return nil, fmt.Errorf("invalid -from %q (expected YYYY-MM-DD)", from)
+		}
+	}
+	if to != "" {
+		if end, err = time.Parse("2006-01-02", to); err != nil {
+			return nil, fmt.Errorf("invalid -to %q (expected YYYY-MM-DD)", to)
+		}
+		end = end.AddDate(0, 0, 1)
+	}
+	return func(t time.Time) bool {
+		return (start.IsZero() || !t.Before(start)) && (end.IsZero() || t.Before(end))
+	}, nil
+}
+
+// writeCSV writes a header row and data rows
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// formatProb formats a 0-1 probability for CSV
+func formatProb(p float64) string {
+	return strconv.FormatFloat(p, 'f', 4, 64)
+}
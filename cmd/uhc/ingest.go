@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// runIngest implements `uhc ingest`: pulls full past seasons from the NHL API
+// into data/history and data/results for offline training. Reruns skip
+// whatever is already stored, so an interrupted run can simply be repeated.
+// It rewrites the monthly results files the server appends to, so it refuses
+// to run while the server holds the data lock.
+func runIngest(args []string) int {
+	fs := newFlagSet("ingest")
+	seasonsFlag := fs.String("seasons", "", "Comma-separated seasons, e.g. 20222023,20232024, or a range 20202021-20232024")
+	teamsFlag := fs.String("teams", "", "Comma-separated team codes, including past ones like ARI (default every team in each season)")
+	datasetsFlag := fs.String("datasets", strings.Join(services.AllHistoryDatasets, ","), "Comma-separated datasets to ingest")
+	playoffs := fs.Bool("playoffs", true, "Include playoff games")
+	force := fs.Bool("force", false, "Refetch data that is already stored")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 0 {
+		return usageError(fs, "unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	seasons, err := parseSeasonList(*seasonsFlag)
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	ingester, err := services.NewHistoricalIngester(services.HistoricalIngestOptions{
+		Seasons:         seasons,
+		Teams:           splitList(strings.ToUpper(*teamsFlag)),
+		Datasets:        splitList(*datasetsFlag),
+		IncludePlayoffs: *playoffs,
+		DataDir:         "data",
+		Force:           *force,
+	})
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	if err := services.AcquireDataLock("uhc ingest"); err != nil {
+		return fail(fmt.Errorf("%w; stop the server before ingesting so results files aren't written concurrently", err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reports, err := ingester.Run(ctx)
+
+	tw := newTable("SEASON", "GAMES", "DATASET", "FETCHED", "STORED")
+	failures := 0
+	for _, report := range reports {
+		for _, dataset := range services.AllHistoryDatasets {
+			fetched, stored := report.Fetched[dataset], report.Skipped[dataset]
+			if fetched == 0 && stored == 0 {
+				continue
+			}
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t%d\n", report.Season, report.Games, dataset, fetched, stored)
+		}
+		failures += len(report.Failures)
+	}
+	tw.Flush()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⏸️ Ingestion stopped: %v (rerun the same command to resume)\n", err)
+		return 1
+	}
+	if failures > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️ %d items failed; see data/history/<season>/manifest.json and rerun to retry them\n", failures)
+		return 1
+	}
+	fmt.Fprintln(os.Stderr, "✅ Ingestion complete")
+	return 0
+}
+
+// parseSeasonList parses "20222023,20232024" or "20202021-20232024"
+func parseSeasonList(s string) ([]int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("-seasons is required")
+	}
+
+	seen := make(map[int]bool)
+	for _, part := range splitList(s) {
+		first, last := part, part
+		if i := strings.Index(part, "-"); i >= 0 {
+			first, last = part[:i], part[i+1:]
+		}
+		from, err1 := strconv.Atoi(first)
+		to, err2 := strconv.Atoi(last)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("invalid season %q", part)
+		}
+		for _, season := range []int{from, to} {
+			if err := services.ValidateSeason(season); err != nil {
+				return nil, err
+			}
+		}
+		if from > to {
+			return nil, fmt.Errorf("invalid season range %q", part)
+		}
+		for season := from; season <= to; season += 10001 {
+			seen[season] = true
+		}
+	}
+
+	seasons := make([]int, 0, len(seen))
+	for season := range seen {
+		seasons = append(seasons, season)
+	}
+	sort.Ints(seasons)
+	return seasons, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
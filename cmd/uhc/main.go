@@ -0,0 +1,181 @@
+// Command uhc runs the dashboard's operational tasks (predictions, playoff
+// simulations, training, historical ingestion, backfills, exports and
+// maintenance) straight from the
+// services package, so cron jobs and debugging don't need a running server.
+//
+// Run it from the directory holding data/ (the server's working directory),
+// or point it there with -dir.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// command is one uhc subcommand
+type command struct {
+	usage   string
+	summary string
+	run     func(args []string) int
+}
+
+// commands is filled in init because the commands' flag sets read their
+// usage from it
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"predict":     {"predict [-json] [-store] <gameID | AWAY@HOME>", "Predict a game with the full ensemble", runPredict},
+		"simulate":    {"simulate [-json] [-n 5000] <team>", "Run the playoff odds Monte Carlo simulation", runSimulate},
+		"train":       {"train [-json] <gbm|lstm|rf|bayesian|stacking>", "Refit a model from stored results", runTrain},
+		"backfill":    {"backfill <games|pbp|jobs|cancel|retry> [flags]", "Queue and manage backfill jobs", runBackfill},
+		"ingest":      {"ingest -seasons 20222023,20232024 [flags]", "Ingest past seasons into data/history and data/results for training", runIngest},
+		"export":      {"export [-format json|csv] [-o file] <results|predictions>", "Export stored results or predictions", runExport},
+		"cache":       {"cache clear [api|predictions|all]", "Clear on-disk caches", runCache},
+		"config":      {"config [-json] [-f file]", "Validate and print the effective config (secrets redacted)", runConfig},
+		"db":          {"db migrate [-json]", "Apply pending database migrations", runDB},
+		"healthcheck": {"healthcheck [-json]", "Check the NHL API, data directories and database", runHealthcheck},
+	}
+}
+
+// out receives command output. Services report progress with fmt.Print, so
+// main points os.Stdout at stderr and keeps the real stdout for results,
+// which keeps -json output clean for pipes.
+var out io.Writer = os.Stdout
+
+func main() {
+	fs := flag.NewFlagSet("uhc", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "Working directory containing data/")
+	quiet := fs.Bool("q", false, "Suppress service logs")
+	fs.Usage = usage
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+
+	args := fs.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "❌ Unknown command %q\n\n", args[0])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := os.Chdir(*dir); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	stdout := os.Stdout
+	out = stdout
+	if *quiet {
+		devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		os.Stdout = devNull
+		log.SetOutput(ioutil.Discard)
+	} else {
+		os.Stdout = os.Stderr
+	}
+
+	code := cmd.run(args[1:])
+	os.Stdout = stdout
+	os.Exit(code)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: uhc [-dir path] [-q] <command> [flags] [args]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(os.Stderr, 0, 0, 3, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s\t%s\n", commands[name].usage, commands[name].summary)
+	}
+	tw.Flush()
+	fmt.Fprintf(os.Stderr, "\nRun 'uhc <command> -h' for a command's flags.\n")
+}
+
+// newFlagSet returns a subcommand flag set whose usage line comes from the
+// command table
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: uhc %s\n\n%s\n\n", commands[name].usage, commands[name].summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses subcommand flags, returning the exit code to stop with
+// when parsing didn't succeed
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0, false
+		}
+		return 2, false
+	}
+	return 0, true
+}
+
+// splitAction separates a leading action word (`backfill games`, `cache
+// clear`) from the flags and arguments after it
+func splitAction(args []string) (string, []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return "", args
+	}
+	return args[0], args[1:]
+}
+
+// usageError reports a bad invocation and returns the usage exit code
+func usageError(fs *flag.FlagSet, format string, a ...interface{}) int {
+	fmt.Fprintf(os.Stderr, "❌ "+format+"\n\n", a...)
+	fs.Usage()
+	return 2
+}
+
+// fail reports an error and returns the failure exit code
+func fail(err error) int {
+	fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+	return 1
+}
+
+// printJSON writes v to the command output as indented JSON
+func printJSON(v interface{}) int {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fail(err)
+	}
+	return 0
+}
+
+// newTable returns a tab-aligned writer on the command output; rows are
+// tab-separated and the caller must Flush
+func newTable(headers ...string) *tabwriter.Writer {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	return tw
+}
+
+// percent formats a 0-1 probability
+func percent(p float64) string {
+	return fmt.Sprintf("%.1f%%", p*100)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+	"gopkg.in/yaml.v3"
+)
+
+// runCache implements `uhc cache clear`: empties the on-disk API response
+// and prediction caches. A running server keeps its in-memory copies (and
+// saves the API cache again on shutdown), so clear it through the server's
+// endpoints or restart it afterwards.
+func runCache(args []string) int {
+	fs := newFlagSet("cache")
+	action, args := splitAction(args)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if action != "clear" {
+		return usageError(fs, "expected clear")
+	}
+	if fs.NArg() > 1 {
+		return usageError(fs, "expected at most one cache name")
+	}
+
+	target := "all"
+	if fs.NArg() == 1 {
+		target = fs.Arg(0)
+	}
+	if target != "all" && target != "api" && target != "predictions" {
+		return usageError(fs, "unknown cache %q (expected api, predictions or all)", target)
+	}
+
+	tw := newTable("CACHE", "CLEARED")
+	if target == "all" || target == "api" {
+		services.InitAPICacheService()
+		apiCache := services.GetAPICacheService()
+		entries, _ := apiCache.GetStats()["cache_size"].(int)
+		apiCache.Clear()
+		if err := apiCache.SaveCache(); err != nil {
+			return fail(err)
+		}
+		fmt.Fprintf(tw, "api\t%d\n", entries)
+	}
+	if target == "all" || target == "predictions" {
+		fmt.Fprintf(tw, "predictions\t%d\n", services.GetPredictionCache().ClearCache())
+	}
+	tw.Flush()
+	return 0
+}
+
+// runConfig implements `uhc config`: loads the config the server would use
+// (config.yaml or UHC_CONFIG, plus environment overrides) and prints it with
+// API keys redacted. It exits 1 when the config is invalid, so it can check a
+// file before deploying it.
+func runConfig(args []string) int {
+	fs := newFlagSet("config")
+	asJSON := fs.Bool("json", false, "Print the config as JSON")
+	path := fs.String("f", services.AppConfigPath(), "Config file to check")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 0 {
+		return usageError(fs, "expected no arguments")
+	}
+
+	config, overrides, fileLoaded, err := services.ReadAppConfig(*path)
+	if err != nil {
+		return fail(err)
+	}
+	if *asJSON {
+		return printJSON(config.Redacted())
+	}
+
+	if !fileLoaded {
+		fmt.Fprintf(out, "# %s not found, using defaults\n", *path)
+	}
+	for _, name := range overrides {
+		fmt.Fprintf(out, "# overridden by %s\n", name)
+	}
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config.Redacted()); err != nil {
+		return fail(err)
+	}
+	return 0
+}
+
+// runDB implements `uhc db migrate`: applies pending schema migrations to
+// data/hockey.db and lists every migration's status
+func runDB(args []string) int {
+	fs := newFlagSet("db")
+	asJSON := fs.Bool("json", false, "Print migration status as JSON")
+	action, args := splitAction(args)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if action != "migrate" {
+		return usageError(fs, "expected migrate")
+	}
+
+	// InitDatabase applies pending migrations before returning
+	if err := services.InitDatabase(services.DefaultDatabasePath); err != nil {
+		return fail(err)
+	}
+	db := services.GetDatabase()
+	defer db.Close()
+
+	statuses, err := db.MigrationStatus()
+	if err != nil {
+		return fail(err)
+	}
+	if *asJSON {
+		return printJSON(statuses)
+	}
+
+	tw := newTable("VERSION", "DESCRIPTION", "APPLIED")
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", status.Version, status.Description, applied)
+	}
+	tw.Flush()
+	return 0
+}
+
+// runHealthcheck implements `uhc healthcheck`: runs the checks that don't
+// need a running server and exits 1 when any is unhealthy, so it can gate
+// cron jobs and container health probes
+func runHealthcheck(args []string) int {
+	fs := newFlagSet("healthcheck")
+	asJSON := fs.Bool("json", false, "Print the health status as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if err := services.InitDatabase(services.DefaultDatabasePath); err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+	} else {
+		defer services.GetDatabase().Close()
+	}
+
+	health := services.GetHealthCheckService().RunOfflineHealthChecks()
+
+	code := 0
+	if health.Status == "unhealthy" {
+		code = 1
+	}
+	if *asJSON {
+		if printJSON(health) != 0 {
+			return 1
+		}
+		return code
+	}
+
+	names := make([]string, 0, len(health.Checks))
+	for name := range health.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := newTable("CHECK", "STATUS", "MESSAGE")
+	for _, name := range names {
+		check := health.Checks[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", check.Name, check.Status, check.Message)
+	}
+	tw.Flush()
+	fmt.Fprintf(out, "\nOverall: %s\n", health.Status)
+	return code
+}
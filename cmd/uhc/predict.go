@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// runPredict implements `uhc predict`: runs the same ensemble the daily
+// prediction job uses for one game, by NHL game ID or as a hypothetical
+// AWAY@HOME matchup
+func runPredict(args []string) int {
+	fs := newFlagSet("predict")
+	asJSON := fs.Bool("json", false, "Print the full prediction as JSON")
+	store := fs.Bool("store", false, "Save the prediction like the daily job does (game IDs only)")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		return usageError(fs, "expected one game ID or AWAY@HOME matchup")
+	}
+
+	game, err := resolveGame(fs.Arg(0))
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+	if *store && game.GameID == 0 {
+		return usageError(fs, "-store needs an NHL game ID")
+	}
+
+	services.LoadHyperparameterConfig()
+	storage := services.InitPredictionStorageService()
+	services.InitFeatureSnapshotService()
+	daily := services.InitDailyPredictionService(storage, services.NewEnsemblePredictionService(game.HomeTeam))
+
+	prediction, err := daily.PredictGame(*game)
+	if err != nil {
+		return fail(err)
+	}
+	if *store {
+		if err := storage.StorePrediction(game.GameID, game.GameDate, game.HomeTeam, game.AwayTeam, prediction); err != nil {
+			return fail(fmt.Errorf("failed to store prediction: %w", err))
+		}
+	}
+
+	if *asJSON {
+		return printJSON(prediction)
+	}
+
+	result := prediction.Prediction
+	fmt.Fprintf(out, "%s @ %s", game.AwayTeam, game.HomeTeam)
+	if game.GameID != 0 {
+		fmt.Fprintf(out, "  (game %d, %s)", game.GameID, game.GameDate.Local().Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintf(out, "\nPick: %s %s  score %s  confidence %s  [%s]\n\n",
+		result.Winner, percent(result.WinProbability), result.PredictedScore, percent(result.Confidence), result.EnsembleMethod)
+
+	tw := newTable("MODEL", "HOME WIN", "SCORE", "CONFIDENCE", "WEIGHT")
+	modelResults := append([]models.ModelResult(nil), result.ModelResults...)
+	sort.Slice(modelResults, func(i, j int) bool { return modelResults[i].Weight > modelResults[j].Weight })
+	for _, mr := range modelResults {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%.3f\n", mr.ModelName, percent(mr.WinProbability), mr.PredictedScore, percent(mr.Confidence), mr.Weight)
+	}
+	tw.Flush()
+	return 0
+}
+
+// resolveGame turns a game ID into the scheduled matchup, or an AWAY@HOME
+// argument into a hypothetical game starting now
+func resolveGame(arg string) (*services.UpcomingGame, error) {
+	if parts := strings.Split(strings.ToUpper(arg), "@"); len(parts) == 2 {
+		if parts[0] == "" || parts[1] == "" || parts[0] == parts[1] {
+			return nil, fmt.Errorf("invalid matchup %q", arg)
+		}
+		return &services.UpcomingGame{AwayTeam: parts[0], HomeTeam: parts[1], GameDate: time.Now()}, nil
+	}
+
+	gameID, err := strconv.Atoi(arg)
+	if err != nil || gameID <= 0 {
+		return nil, fmt.Errorf("invalid game %q (expected a game ID like 2024020123 or AWAY@HOME)", arg)
+	}
+
+	landing, err := services.GetNHLAPIClient().Landing(context.Background(), gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up game %d: %w", gameID, err)
+	}
+	gameDate, err := time.Parse(time.RFC3339, landing.StartTimeUTC)
+	if err != nil {
+		gameDate, _ = time.Parse("2006-01-02", landing.GameDate)
+	}
+	return &services.UpcomingGame{
+		GameID:   gameID,
+		GameDate: gameDate,
+		HomeTeam: landing.HomeTeam.Abbrev,
+		AwayTeam: landing.AwayTeam.Abbrev,
+		Venue:    landing.Venue.Default,
+	}, nil
+}
+
+// runSimulate implements `uhc simulate`: Monte Carlo playoff odds for a team,
+// always computed fresh
+func runSimulate(args []string) int {
+	fs := newFlagSet("simulate")
+	asJSON := fs.Bool("json", false, "Print the full simulation as JSON")
+	simulations := fs.Int("n", 5000, "Number of seasons to simulate")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		return usageError(fs, "expected one team code")
+	}
+	if *simulations < 1 {
+		return usageError(fs, "-n must be positive")
+	}
+	team := strings.ToUpper(fs.Arg(0))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	services.LoadHyperparameterConfig()
+	simulator := services.NewPlayoffSimulationService(services.NewEnsemblePredictionService(team))
+	sim, err := simulator.SimulatePlayoffOddsContext(ctx, team, *simulations, true)
+	if err != nil {
+		return fail(err)
+	}
+
+	if *asJSON {
+		return printJSON(sim)
+	}
+
+	tw := newTable("TEAM", "SIMS", "PLAYOFFS", "DIVISION", "WILD CARD", "AVG PTS", "P10-P90", "AVG RANK")
+	fmt.Fprintf(tw, "%s\t%d\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f\t%d-%d\t%.1f\n",
+		sim.TeamCode, sim.TotalSimulations, sim.PlayoffOddsPercent, sim.DivisionOddsPercent, sim.WildCardOddsPercent,
+		sim.AvgFinalPoints, sim.PercentileP10, sim.PercentileP90, sim.AvgConferenceRank)
+	tw.Flush()
+	return 0
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// runTrain implements `uhc train`: refits one model from data/results and
+// saves it for the server to load on its next start. A running server saves
+// its in-memory models over those files, so train refuses to run while the
+// server holds the data lock.
+func runTrain(args []string) int {
+	fs := newFlagSet("train")
+	asJSON := fs.Bool("json", false, "Print the training summary as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		return usageError(fs, "expected one model: %s", strings.Join(services.TrainableModels, ", "))
+	}
+
+	if err := services.AcquireDataLock("uhc train"); err != nil {
+		return fail(fmt.Errorf("%w; stop the server before training so it doesn't overwrite the new model", err))
+	}
+
+	services.LoadHyperparameterConfig()
+	services.InitTrainingMetricsService()
+
+	summary, err := services.TrainModel(fs.Arg(0))
+	if err != nil {
+		return fail(err)
+	}
+
+	if *asJSON {
+		return printJSON(summary)
+	}
+
+	tw := newTable("MODEL", "GAMES", "DURATION")
+	fmt.Fprintf(tw, "%s\t%d\t%.1fs\n", summary.Model, summary.Games, float64(summary.DurationMs)/1000)
+	tw.Flush()
+
+	if report := summary.Stacking; report != nil {
+		fmt.Fprintf(out, "\nSelected combiner: %s (%d examples)\n\n", report.Selected, report.Examples)
+		names := make([]string, 0, len(report.Combiners))
+		for name := range report.Combiners {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		tw = newTable("COMBINER", "ACCURACY", "LOG LOSS", "BRIER")
+		for _, name := range names {
+			result := report.Combiners[name]
+			fmt.Fprintf(tw, "%s\t%s\t%.4f\t%.4f\n", name, percent(result.Accuracy), result.LogLoss, result.BrierScore)
+		}
+		tw.Flush()
+	}
+	return 0
+}
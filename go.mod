@@ -5,4 +5,5 @@ go 1.23.3
 require (
 	github.com/mattn/go-sqlite3 v1.14.32
 	golang.org/x/net v0.41.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleArchitectureSearch returns neural network architecture search results.
+// POST (or ?run=true) starts a new search in the background; optional params:
+// workers, budget (minutes), epochs, promote=true.
+func HandleArchitectureSearch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	search := services.GetArchitectureSearchService()
+
+	if r.Method != http.MethodPost && r.URL.Query().Get("run") != "true" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"running":          search.IsRunning(),
+			"bestArchitecture": search.GetBestArchitecture(),
+			"topArchitectures": search.GetTopArchitectures(5),
+		})
+		return
+	}
+
+	evalSvc := services.GetEvaluationService()
+	if evalSvc == nil {
+		http.Error(w, `{"error": "Evaluation Service not available"}`, http.StatusServiceUnavailable)
+		return
+	}
+	if search.IsRunning() {
+		http.Error(w, `{"error": "Architecture search already running"}`, http.StatusConflict)
+		return
+	}
+
+	split, err := evalSvc.CreateTrainTestSplit(0.70, 0.15, 0.15)
+	if err != nil {
+		http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusBadRequest)
+		return
+	}
+
+	opts := services.DefaultArchitectureSearchOptions()
+	query := r.URL.Query()
+	if workers, err := strconv.Atoi(query.Get("workers")); err == nil && workers > 0 {
+		opts.Workers = workers
+	}
+	if budget, err := strconv.Atoi(query.Get("budget")); err == nil && budget > 0 {
+		opts.TimeBudget = time.Duration(budget) * time.Minute
+	}
+	if epochs, err := strconv.Atoi(query.Get("epochs")); err == nil && epochs > 0 {
+		opts.MaxEpochs = epochs
+	}
+	opts.Promote = query.Get("promote") == "true"
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "processing",
+		"message": "Architecture search started. Check server logs for progress.",
+		"options": opts,
+		"split": map[string]int{
+			"train":      split.TrainSize,
+			"validation": split.ValidationSize,
+			"test":       split.TestSize,
+		},
+	})
+
+	go func() {
+		if err := search.RunSearchWithOptions(split.TrainingSet, split.ValidationSet, split.TestSet, opts); err != nil {
+			log.Printf("⚠️ Architecture search failed: %v", err)
+		}
+	}()
+}
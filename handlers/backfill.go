@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -10,7 +11,8 @@ import (
 	"github.com/jaredshillingburg/go_uhc/services"
 )
 
-// HandleBackfillPlayByPlay triggers a backfill of play-by-play data
+// HandleBackfillPlayByPlay queues a backfill of play-by-play data for one
+// team (?team=) or every team (?all=true). Progress is at /api/backfill/jobs.
 func HandleBackfillPlayByPlay(w http.ResponseWriter, r *http.Request) {
 	// Get query parameters
 	teamCode := r.URL.Query().Get("team")
@@ -25,46 +27,32 @@ func HandleBackfillPlayByPlay(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	pbpService := services.GetPlayByPlayService()
-	if pbpService == nil {
+	if services.GetPlayByPlayService() == nil {
 		http.Error(w, "Play-by-Play service not available", http.StatusServiceUnavailable)
 		return
 	}
 
-	// Respond immediately and process in background
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusAccepted)
-
-	response := map[string]interface{}{
-		"status": "accepted",
-	}
 
+	params := services.BackfillParams{Games: numGames}
+	var message string
 	if allTeams {
-		response["message"] = fmt.Sprintf("Backfilling all teams (last %d games). Check server logs for progress.", numGames)
-		json.NewEncoder(w).Encode(response)
-
-		// Run in background
-		go func() {
-			pbpService.BackfillAllTeams(numGames)
-		}()
+		message = fmt.Sprintf("Backfilling all teams (last %d games)", numGames)
 	} else if teamCode != "" {
-		response["message"] = fmt.Sprintf("Backfilling %s (last %d games). Check server logs for progress.", teamCode, numGames)
-		response["team"] = teamCode
-		response["games"] = numGames
-		json.NewEncoder(w).Encode(response)
-
-		// Run in background
-		go func() {
-			pbpService.BackfillPlayByPlayData(teamCode, numGames)
-		}()
+		params.Team = teamCode
+		message = fmt.Sprintf("Backfilling %s (last %d games)", teamCode, numGames)
 	} else {
-		response["error"] = "Must specify 'team' parameter or 'all=true'"
-		response["status"] = "error"
-		json.NewEncoder(w).Encode(response)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "error",
+			"error":  "Must specify 'team' parameter or 'all=true'",
+		})
+		return
 	}
+
+	enqueueBackfill(w, services.BackfillKindPlayByPlay, params, message)
 }
 
-// HandleBackfillGameResults manually triggers processing of games from a specific date or date range
+// HandleBackfillGameResults queues processing of games from a specific date or date range
 // Usage: /api/backfill-games?date=2025-01-27 or /api/backfill-games?days=7
 func HandleBackfillGameResults(w http.ResponseWriter, r *http.Request) {
 	grs := services.GetGameResultsService()
@@ -84,22 +72,15 @@ func HandleBackfillGameResults(w http.ResponseWriter, r *http.Request) {
 
 	if dateStr != "" {
 		// Process games from a specific date
-		parsedDate, err := time.Parse("2006-01-02", dateStr)
-		if err != nil {
+		if _, err := time.Parse("2006-01-02", dateStr); err != nil {
 			response["status"] = "error"
 			response["error"] = fmt.Sprintf("Invalid date format: %s (expected YYYY-MM-DD)", dateStr)
 			json.NewEncoder(w).Encode(response)
 			return
 		}
 
-		response["date"] = dateStr
-		response["message"] = fmt.Sprintf("Processing games from %s. Check server logs for progress.", dateStr)
-		json.NewEncoder(w).Encode(response)
-
-		// Run in background
-		go func() {
-			grs.BackfillGamesForDate(parsedDate)
-		}()
+		enqueueBackfill(w, services.BackfillKindGameResults, services.BackfillParams{Date: dateStr},
+			fmt.Sprintf("Processing games from %s", dateStr))
 	} else if daysStr != "" {
 		// Process games from the last N days
 		days, err := strconv.Atoi(daysStr)
@@ -110,14 +91,8 @@ func HandleBackfillGameResults(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		response["days"] = days
-		response["message"] = fmt.Sprintf("Processing games from last %d days. Check server logs for progress.", days)
-		json.NewEncoder(w).Encode(response)
-
-		// Run in background
-		go func() {
-			grs.BackfillGamesForDays(days)
-		}()
+		enqueueBackfill(w, services.BackfillKindGameResults, services.BackfillParams{Days: days},
+			fmt.Sprintf("Processing games from last %d days", days))
 	} else {
 		// Just trigger a check for missed games
 		response["message"] = "Checking for missed games from past 7 days..."
@@ -129,6 +104,105 @@ func HandleBackfillGameResults(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// enqueueBackfill queues a backfill job and responds with it
+func enqueueBackfill(w http.ResponseWriter, kind string, params services.BackfillParams, message string) {
+	job, err := services.GetBackfillQueue().Enqueue(kind, params)
+	if err != nil {
+		http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":    "queued",
+		"message":   message + ". Track progress at /api/backfill/jobs?id=" + strconv.FormatInt(job.ID, 10),
+		"jobId":     job.ID,
+		"job":       job,
+		"statusUrl": "/api/backfill/jobs?id=" + strconv.FormatInt(job.ID, 10),
+	})
+}
+
+// HandleBackfillJobs lists recent backfill jobs with their progress and ETA
+// (?id= for one job, including its failed games). POST with ?id= and
+// ?action=cancel or retry controls a job.
+func HandleBackfillJobs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	queue := services.GetBackfillQueue()
+	idStr := r.URL.Query().Get("id")
+
+	if r.Method == http.MethodGet && idStr == "" {
+		limit := 20
+		if parsed, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+		jobs, err := queue.Jobs(limit)
+		if err != nil {
+			http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"running": queue.IsRunning(),
+			"jobs":    jobs,
+		})
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, `{"error": "Method not allowed. Use GET or POST."}`, http.StatusMethodNotAllowed)
+		return
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, `{"error": "id parameter is required"}`, http.StatusBadRequest)
+		return
+	}
+
+	action := ""
+	if r.Method == http.MethodPost {
+		action = r.URL.Query().Get("action")
+		switch action {
+		case "cancel":
+			err = queue.Cancel(id)
+		case "retry":
+			err = queue.Retry(id)
+		default:
+			http.Error(w, `{"error": "action must be cancel or retry"}`, http.StatusBadRequest)
+			return
+		}
+	}
+
+	var job *services.BackfillJob
+	if err == nil {
+		job, err = queue.Job(id)
+	}
+
+	switch {
+	case errors.Is(err, services.ErrBackfillJobNotFound):
+		http.Error(w, `{"error": "Unknown backfill job"}`, http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrBackfillJobFinished),
+		errors.Is(err, services.ErrBackfillJobActive),
+		errors.Is(err, services.ErrBackfillNothingToRetry):
+		http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusInternalServerError)
+		return
+	}
+
+	if action == "" {
+		json.NewEncoder(w).Encode(job)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"action":  action,
+		"job":     job,
+	})
+}
+
 // HandleCheckUnprocessedPredictions manually triggers checking for predictions without results
 func HandleCheckUnprocessedPredictions(w http.ResponseWriter, r *http.Request) {
 	grs := services.GetGameResultsService()
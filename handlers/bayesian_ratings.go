@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleBayesianRatings returns team rating posteriors (means and variances),
+// league parameters and calibration. ?team=XXX returns that team's rating
+// history; ?home=XXX&away=YYY returns the predictive distribution for a matchup.
+func HandleBayesianRatings(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	ratings := services.GetBayesianRatingModel()
+	query := r.URL.Query()
+
+	if team := strings.ToUpper(query.Get("team")); team != "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"team":    team,
+			"history": ratings.GetRatingHistory(team),
+		})
+		return
+	}
+
+	home, away := strings.ToUpper(query.Get("home")), strings.ToUpper(query.Get("away"))
+	if home != "" || away != "" {
+		if home == "" || away == "" {
+			http.Error(w, `{"error": "home and away parameters required"}`, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"homeTeam":     home,
+			"awayTeam":     away,
+			"distribution": ratings.PredictiveDistribution(home, away),
+		})
+		return
+	}
+
+	intercept, homeIce := ratings.GetLeagueParameters()
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ratings":     ratings.GetTeamRatings(),
+		"intercept":   intercept,
+		"homeIce":     homeIce,
+		"calibration": ratings.GetCalibration(),
+	})
+}
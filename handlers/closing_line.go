@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleClosingLineValue returns closing line value, model-vs-market log loss and
+// the simulated Kelly bankroll as JSON
+func HandleClosingLineValue(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	clvService := services.GetClosingLineService()
+	if clvService == nil {
+		http.Error(w, `{"error": "Closing line service not initialized"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	// Pick up any settled predictions recorded while the service was unavailable
+	if r.URL.Query().Get("sync") == "true" {
+		clvService.SyncWithStoredPredictions()
+	}
+
+	json.NewEncoder(w).Encode(clvService.GetReport())
+}
+
+// HandleClosingLinePopup returns the HTML for the market comparison dashboard panel
+func HandleClosingLinePopup(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	clvService := services.GetClosingLineService()
+	if clvService == nil {
+		fmt.Fprint(w, `<div class="stats-error">Closing line tracking unavailable</div>`)
+		return
+	}
+
+	fmt.Fprint(w, generateClosingLinePopupHTML(clvService.GetReport()))
+}
+
+// generateClosingLinePopupHTML renders the closing line value report
+func generateClosingLinePopupHTML(report *models.ClosingLineReport) string {
+	var html strings.Builder
+
+	verdict := "Market is ahead of the model"
+	verdictClass := "stat-warning"
+	if report.BeatsMarket {
+		verdict = "Model beats the closing line"
+		verdictClass = "stat-success"
+	}
+	if report.GamesTracked == 0 {
+		verdict = "Waiting for settled games with market data"
+		verdictClass = ""
+	}
+
+	html.WriteString(`
+<div class="system-stats-popup">
+	<div class="stats-header">
+		<h3>💹 Model vs Market</h3>
+		<button class="close-popup" onclick="closeClosingLinePopup()">✕</button>
+	</div>
+
+	<div class="stats-section">
+		<h4>📉 Closing Line Value</h4>
+		<div class="stats-grid">`)
+	html.WriteString(clvStatItem("Games Tracked:", fmt.Sprintf("%d", report.GamesTracked), ""))
+	html.WriteString(clvStatItem("Average CLV:", fmt.Sprintf("%+.2f%%", report.AvgCLV*100), clvClass(report.AvgCLV)))
+	html.WriteString(clvStatItem("Beat the Close:", fmt.Sprintf("%.1f%%", report.PositiveCLVRate*100), ""))
+	html.WriteString(clvStatItem("Verdict:", verdict, verdictClass))
+	html.WriteString(`
+		</div>
+	</div>
+
+	<div class="stats-section">
+		<h4>🎯 Log Loss (lower is better)</h4>
+		<div class="stats-grid">`)
+	html.WriteString(clvStatItem("Model Log Loss:", fmt.Sprintf("%.4f", report.ModelLogLoss), ""))
+	html.WriteString(clvStatItem("Closing Line Log Loss:", fmt.Sprintf("%.4f", report.MarketLogLoss), ""))
+	html.WriteString(clvStatItem("Model Brier:", fmt.Sprintf("%.4f", report.ModelBrier), ""))
+	html.WriteString(clvStatItem("Closing Line Brier:", fmt.Sprintf("%.4f", report.MarketBrier), ""))
+	html.WriteString(clvStatItem("Model Accuracy:", fmt.Sprintf("%.1f%%", report.ModelAccuracy*100), ""))
+	html.WriteString(clvStatItem("Market Accuracy:", fmt.Sprintf("%.1f%%", report.MarketAccuracy*100), ""))
+	html.WriteString(`
+		</div>
+	</div>
+
+	<div class="stats-section">
+		<h4>💰 Simulated Kelly Bankroll</h4>
+		<div class="stats-grid">`)
+	kelly := report.Kelly
+	html.WriteString(clvStatItem("Strategy:", fmt.Sprintf("%.0f%% Kelly, max %.0f%% stake, %.0f%% min edge",
+		kelly.KellyMultiplier*100, kelly.MaxStakePct*100, kelly.MinEdge*100), ""))
+	html.WriteString(clvStatItem("Bankroll:", fmt.Sprintf("$%.2f → $%.2f", kelly.StartingBankroll, kelly.FinalBankroll),
+		clvClass(kelly.FinalBankroll-kelly.StartingBankroll)))
+	html.WriteString(clvStatItem("Bets (Won):", fmt.Sprintf("%d (%d)", kelly.BetsPlaced, kelly.BetsWon), ""))
+	html.WriteString(clvStatItem("ROI:", fmt.Sprintf("%+.1f%%", kelly.ROI*100), clvClass(kelly.ROI)))
+	html.WriteString(clvStatItem("Max Drawdown:", fmt.Sprintf("%.1f%%", kelly.MaxDrawdown*100), ""))
+	html.WriteString(`
+		</div>
+	</div>
+
+	<div class="stats-section">
+		<h4>🗓️ Recent Games</h4>
+		<div class="predictions-list">`)
+
+	for _, record := range report.RecentRecords {
+		correctClass := "incorrect"
+		if record.CLV > 0 {
+			correctClass = "correct"
+		}
+		html.WriteString(fmt.Sprintf(`
+			<div class="prediction-item %s">
+				<div class="prediction-game">%s @ %s</div>
+				<div class="prediction-details">
+					<span class="prediction-winner">Model: %.1f%% home | Open: %.1f%% → Close: %.1f%%</span>
+					<span class="prediction-actual">Lean: %s, CLV %+.2f%%</span>
+				</div>
+				<div class="prediction-date">%s</div>
+			</div>`,
+			correctClass, record.AwayTeam, record.HomeTeam,
+			record.ModelHomeWinProb*100, record.OpeningHomeImplied*100, record.ClosingHomeImplied*100,
+			record.ModelSide, record.CLV*100,
+			record.GameDate.Format("Jan 2")))
+	}
+
+	if len(report.RecentRecords) == 0 {
+		html.WriteString(`<p style="text-align: center; color: #aaa; font-style: italic;">No settled games with market history yet (requires ODDS_API_KEY)</p>`)
+	}
+
+	html.WriteString(`
+		</div>
+	</div>
+</div>
+`)
+
+	return html.String()
+}
+
+// clvStatItem renders a single label/value pair for the popup grid
+func clvStatItem(label, value, valueClass string) string {
+	return fmt.Sprintf(`
+			<div class="stat-item">
+				<span class="stat-label">%s</span>
+				<span class="stat-value %s">%s</span>
+			</div>`, label, valueClass, value)
+}
+
+// clvClass colors positive values green and negative values amber
+func clvClass(value float64) string {
+	if value > 0 {
+		return "stat-success"
+	}
+	if value < 0 {
+		return "stat-warning"
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleAppConfig returns the effective configuration with API keys redacted,
+// along with the file it came from, the environment overrides applied and any
+// changes waiting on a restart. POST with ?action=reload re-reads the file and
+// applies the settings that are safe to change while running.
+func HandleAppConfig(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	if r.Method == http.MethodGet {
+		json.NewEncoder(w).Encode(services.GetAppConfigStatus())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error": "Method not allowed. Use GET or POST."}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if r.URL.Query().Get("action") != "reload" {
+		http.Error(w, `{"error": "action must be reload"}`, http.StatusBadRequest)
+		return
+	}
+
+	status, err := services.ReloadAppConfig()
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"errors":  strings.Split(err.Error(), "\n"),
+			"current": status,
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"config":  status,
+	})
+}
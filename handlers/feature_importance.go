@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/jaredshillingburg/go_uhc/services"
 )
@@ -50,3 +52,59 @@ func HandleFeatureImportanceMarkdown(w http.ResponseWriter, r *http.Request) {
 
 	w.Write([]byte(markdown))
 }
+
+// HandlePermutationImportance returns the latest held-out permutation and
+// drop-column importance report. POST (or ?run=true) refits the models before
+// the held-out slice and re-scores every feature in the background; params:
+// repeats, holdout (fraction of games), dropColumn (max refit candidates), seed.
+func HandlePermutationImportance(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	permutation := services.GetPermutationImportanceService()
+
+	if r.Method != http.MethodPost && r.URL.Query().Get("run") != "true" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"running": permutation.IsRunning(),
+			"report":  permutation.GetReport(),
+		})
+		return
+	}
+
+	if permutation.IsRunning() {
+		http.Error(w, `{"error": "Permutation importance already running"}`, http.StatusConflict)
+		return
+	}
+
+	query := r.URL.Query()
+	opts := services.DefaultPermutationImportanceOptions()
+	if repeats, err := strconv.Atoi(query.Get("repeats")); err == nil {
+		opts.Repeats = repeats
+	}
+	if holdout, err := strconv.ParseFloat(query.Get("holdout"), 64); err == nil {
+		opts.HoldoutFraction = holdout
+	}
+	if dropColumn, err := strconv.Atoi(query.Get("dropColumn")); err == nil {
+		opts.MaxDropColumn = dropColumn
+	}
+	if seed, err := strconv.ParseInt(query.Get("seed"), 10, 64); err == nil {
+		opts.Seed = seed
+	}
+
+	if err := services.ValidatePermutationImportanceOptions(opts); err != nil {
+		http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusBadRequest)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "processing",
+		"message": "Permutation importance started. Check server logs for progress.",
+		"options": opts,
+	})
+
+	go func() {
+		if _, err := permutation.Run(opts); err != nil {
+			log.Printf("⚠️ Permutation importance failed: %v", err)
+		}
+	}()
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -18,6 +20,24 @@ var (
 	teamConfig            *models.TeamConfig
 )
 
+// Time limits for handlers whose work would otherwise outlive the client
+const (
+	predictionTimeout = 60 * time.Second
+	simulationTimeout = 2 * time.Minute
+)
+
+// requestAborted reports whether the request's context ended the work,
+// replying 504 if its deadline passed. A client that disconnected gets no reply.
+func requestAborted(ctx context.Context, w http.ResponseWriter) bool {
+	switch ctx.Err() {
+	case nil:
+		return false
+	case context.DeadlineExceeded:
+		http.Error(w, `{"error": "Request timed out"}`, http.StatusGatewayTimeout)
+	}
+	return true
+}
+
 // Init initializes the handlers with shared state from main
 func Init(
 	schedule *models.Game,
@@ -197,6 +217,12 @@ func HandleRateLimiterMetrics(w http.ResponseWriter, r *http.Request) {
 	rateLimiter := services.GetNHLRateLimiter()
 	metrics := rateLimiter.GetMetrics()
 
+	// Retries, stale serves and circuit breaker state per endpoint
+	clientJSON, err := json.MarshalIndent(metrics.Client, "  ", "  ")
+	if err != nil {
+		clientJSON = []byte("null")
+	}
+
 	// Format as JSON
 	fmt.Fprintf(w, `{
   "totalRequests": %d,
@@ -207,7 +233,8 @@ func HandleRateLimiterMetrics(w http.ResponseWriter, r *http.Request) {
   "delayedRequests": %d,
   "totalWaitTime": "%v",
   "averageWaitTime": "%v",
-  "currentUtilization": %.2f
+  "currentUtilization": %.2f,
+  "client": %s
 }`,
 		metrics.TotalRequests,
 		metrics.RequestsInWindow,
@@ -217,5 +244,6 @@ func HandleRateLimiterMetrics(w http.ResponseWriter, r *http.Request) {
 		metrics.DelayedRequests,
 		metrics.TotalWaitTime,
 		metrics.AverageWaitTime,
-		metrics.CurrentUtilization)
+		metrics.CurrentUtilization,
+		clientJSON)
 }
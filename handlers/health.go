@@ -46,6 +46,13 @@ func HandleHealthCheckSimple(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok"))
 }
 
+// HandleLifecycleStatus returns the state of each background service managed
+// by the lifecycle manager, in start order
+func HandleLifecycleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services.GetLifecycleManager().Status())
+}
+
 // HandleHealth is an alias for HandleHealthCheck (for backwards compatibility)
 func HandleHealth(w http.ResponseWriter, r *http.Request) {
 	HandleHealthCheck(w, r)
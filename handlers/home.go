@@ -2213,10 +2213,11 @@ func HandleHome(w http.ResponseWriter, r *http.Request) {
         </div>
         
         <div class="model-insights-section hockey-season-only">
-            <h2 style="margin: 0 0 15px 0; font-size: 1.4em;">🤖 AI Insights <span id="predictions-stats-icon" style="cursor: pointer; font-size: 0.8em; margin-left: 10px;" title="View Prediction Statistics">📊</span><span id="tier-list-icon" style="cursor: pointer; font-size: 0.8em; margin-left: 10px;" title="View NHL Power Rankings Tier List">🏆</span></h2>
+            <h2 style="margin: 0 0 15px 0; font-size: 1.4em;">🤖 AI Insights <span id="predictions-stats-icon" style="cursor: pointer; font-size: 0.8em; margin-left: 10px;" title="View Prediction Statistics">📊</span><span id="tier-list-icon" style="cursor: pointer; font-size: 0.8em; margin-left: 10px;" title="View NHL Power Rankings Tier List">🏆</span><span id="closing-line-icon" style="cursor: pointer; font-size: 0.8em; margin-left: 10px;" title="View Model vs Market (Closing Line Value)">💹</span></h2>
             <div id="model-insights-content">
                 <p>Loading AI model insights...</p>
             </div>
+            <div id="live-win-probability-content" style="display: none; margin-top: 15px;"></div>
             <div class="last-updated">
                 Model insights update in real-time
             </div>
@@ -2776,6 +2777,22 @@ func HandleHome(w http.ResponseWriter, r *http.Request) {
                     tierListIcon.style.transform = 'scale(1)';
                 });
             }
+            
+            // Closing line value icon functionality
+            const closingLineIcon = document.getElementById('closing-line-icon');
+            if (closingLineIcon) {
+                closingLineIcon.addEventListener('click', openClosingLinePopup);
+                
+                // Add hover effect
+                closingLineIcon.addEventListener('mouseover', function() {
+                    closingLineIcon.style.transform = 'scale(1.3)';
+                    closingLineIcon.style.transition = 'transform 0.2s ease';
+                });
+                
+                closingLineIcon.addEventListener('mouseout', function() {
+                    closingLineIcon.style.transform = 'scale(1)';
+                });
+            }
         });
         
         // Functions for predictions popup
@@ -2851,6 +2868,138 @@ func HandleHome(w http.ResponseWriter, r *http.Request) {
                 overlay.classList.remove('active');
             }
         }
+        
+        // Live updates pushed over Server-Sent Events
+        const liveWinProbabilityGames = {};
+        
+        function renderLiveWinProbability() {
+            const container = document.getElementById('live-win-probability-content');
+            if (!container) {
+                return;
+            }
+            
+            const games = Object.values(liveWinProbabilityGames);
+            if (games.length === 0) {
+                container.style.display = 'none';
+                return;
+            }
+            
+            let html = '<h3 style="margin: 0 0 10px 0; font-size: 1.1em;">📈 Live Win Probability</h3>';
+            games.forEach(function(game) {
+                const homePct = (game.currentHomeWinProb * 100).toFixed(1);
+                const awayPct = (100 - game.currentHomeWinProb * 100).toFixed(1);
+                const status = game.isFinal ? 'Final' : ('P' + game.period + ' ' + game.timeRemaining + ' · ' + game.strengthState);
+                
+                // Sparkline of the home win probability timeline
+                const points = (game.timeline || []).map(function(point, i, all) {
+                    const x = all.length > 1 ? (i / (all.length - 1)) * 300 : 0;
+                    const y = 40 - point.homeWinProb * 40;
+                    return x.toFixed(1) + ',' + y.toFixed(1);
+                }).join(' ');
+                
+                html += '<div style="background: rgba(255,255,255,0.08); padding: 10px; border-radius: 8px; margin-bottom: 10px;">' +
+                    '<div style="display: flex; justify-content: space-between; font-weight: bold;">' +
+                        '<span>' + game.awayTeam + ' ' + game.awayScore + ' - ' + game.homeScore + ' ' + game.homeTeam + '</span>' +
+                        '<span style="font-size: 0.85em; color: #aaa;">' + status + '</span>' +
+                    '</div>' +
+                    '<div style="display: flex; height: 14px; border-radius: 7px; overflow: hidden; margin: 8px 0;">' +
+                        '<div style="width: ' + awayPct + '%; background: #e74c3c;" title="' + game.awayTeam + ' ' + awayPct + '%"></div>' +
+                        '<div style="width: ' + homePct + '%; background: #2ecc71;" title="' + game.homeTeam + ' ' + homePct + '%"></div>' +
+                    '</div>' +
+                    '<div style="display: flex; justify-content: space-between; font-size: 0.8em; color: #ddd;">' +
+                        '<span>' + game.awayTeam + ' ' + awayPct + '%</span>' +
+                        '<span>Pregame: ' + (game.pregameHomeWinProb * 100).toFixed(1) + '% ' + game.homeTeam + '</span>' +
+                        '<span>' + game.homeTeam + ' ' + homePct + '%</span>' +
+                    '</div>' +
+                    '<svg viewBox="0 0 300 40" preserveAspectRatio="none" style="width: 100%; height: 40px; margin-top: 6px;">' +
+                        '<line x1="0" y1="20" x2="300" y2="20" stroke="rgba(255,255,255,0.2)" stroke-dasharray="4"></line>' +
+                        '<polyline fill="none" stroke="#2ecc71" stroke-width="2" points="' + points + '"></polyline>' +
+                    '</svg>' +
+                '</div>';
+            });
+            
+            container.innerHTML = html;
+            container.style.display = 'block';
+        }
+        
+        function connectPushStream() {
+            if (!window.EventSource) {
+                return;
+            }
+            
+            // Single push channel for live scores, lineups, re-predictions and simulations
+            const source = new EventSource('/api/push/stream');
+            source.addEventListener('score_update', loadBanner);
+            source.addEventListener('goal', function(event) {
+                const goal = JSON.parse(event.data);
+                console.log('🚨 Goal: ' + goal.awayTeam + ' ' + goal.awayScore + ' - ' + goal.homeScore + ' ' + goal.homeTeam + ' (' + goal.description + ')');
+                loadBanner();
+            });
+            source.addEventListener('lineup_confirmed', function() {
+                loadBanner();
+                loadUpcomingGames();
+            });
+            source.addEventListener('reprediction', function() {
+                loadUpcomingGames();
+                loadModelInsights();
+            });
+            source.addEventListener('simulation_complete', loadPlayoffOdds);
+            source.addEventListener('win_probability', function(event) {
+                const game = JSON.parse(event.data);
+                liveWinProbabilityGames[game.gameId] = game;
+                renderLiveWinProbability();
+                
+                // Keep final games on screen briefly, then drop them
+                if (game.isFinal) {
+                    setTimeout(function() {
+                        delete liveWinProbabilityGames[game.gameId];
+                        renderLiveWinProbability();
+                    }, 10 * 60 * 1000);
+                }
+            });
+            source.onerror = function() {
+                console.warn('Push stream disconnected, browser will retry');
+            };
+        }
+        
+        document.addEventListener('DOMContentLoaded', connectPushStream);
+        
+        // Functions for closing line value popup
+        function openClosingLinePopup() {
+            fetch('/closing-line-popup')
+                .then(response => response.text())
+                .then(html => {
+                    // Create overlay
+                    let overlay = document.getElementById('closing-line-popup-overlay');
+                    if (!overlay) {
+                        overlay = document.createElement('div');
+                        overlay.id = 'closing-line-popup-overlay';
+                        overlay.className = 'stats-popup-overlay';
+                        document.body.appendChild(overlay);
+                        
+                        // Close on overlay click
+                        overlay.addEventListener('click', function(e) {
+                            if (e.target === overlay) {
+                                closeClosingLinePopup();
+                            }
+                        });
+                    }
+                    
+                    overlay.innerHTML = html;
+                    overlay.classList.add('active');
+                })
+                .catch(error => {
+                    console.error('Failed to load closing line value:', error);
+                    alert('Failed to load model vs market statistics');
+                });
+        }
+        
+        function closeClosingLinePopup() {
+            const overlay = document.getElementById('closing-line-popup-overlay');
+            if (overlay) {
+                overlay.classList.remove('active');
+            }
+        }
     </script>
 </body>
 </html>`
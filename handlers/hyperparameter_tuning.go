@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleHyperparameterTuning returns the tuned hyperparameter config and recent runs.
+// POST (or ?run=true) tunes one model in the background; params: model (required),
+// strategy (random|bayesian), trials, folds, budget (minutes).
+func HandleHyperparameterTuning(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	tuning := services.GetHyperparameterTuningService()
+
+	if r.Method != http.MethodPost && r.URL.Query().Get("run") != "true" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"config":     services.GetHyperparameterConfig(),
+			"models":     services.TunableModels(),
+			"recentRuns": tuning.GetRecentRuns(10),
+		})
+		return
+	}
+
+	query := r.URL.Query()
+	model := query.Get("model")
+	if model == "" {
+		http.Error(w, `{"error": "model parameter required"}`, http.StatusBadRequest)
+		return
+	}
+	if tuning.IsRunning(model) {
+		http.Error(w, `{"error": "Tuning already running for this model"}`, http.StatusConflict)
+		return
+	}
+
+	opts := services.DefaultTuningOptions(model)
+	if strategy := query.Get("strategy"); strategy != "" {
+		opts.Strategy = strategy
+	}
+	if trials, err := strconv.Atoi(query.Get("trials")); err == nil && trials > 0 {
+		opts.Trials = trials
+	}
+	if folds, err := strconv.Atoi(query.Get("folds")); err == nil && folds > 1 {
+		opts.Folds = folds
+	}
+	if budget, err := strconv.Atoi(query.Get("budget")); err == nil && budget > 0 {
+		opts.TimeBudget = time.Duration(budget) * time.Minute
+	}
+
+	if err := services.ValidateTuningOptions(opts); err != nil {
+		http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusBadRequest)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "processing",
+		"message": "Hyperparameter tuning started. Check server logs for progress.",
+		"options": opts,
+	})
+
+	go func() {
+		if _, err := tuning.Tune(opts); err != nil {
+			log.Printf("⚠️ Hyperparameter tuning failed: %v", err)
+		}
+	}()
+}
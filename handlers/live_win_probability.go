@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleLiveWinProbability returns live win probability for games in progress,
+// or the full timeline for a single game when ?gameId= is provided
+func HandleLiveWinProbability(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	wpService := services.GetLiveWinProbabilityService()
+	if wpService == nil {
+		http.Error(w, `{"error": "Live win probability service not initialized"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	if gameIDStr := r.URL.Query().Get("gameId"); gameIDStr != "" {
+		gameID, err := strconv.Atoi(gameIDStr)
+		if err != nil {
+			http.Error(w, `{"error": "Invalid gameId"}`, http.StatusBadRequest)
+			return
+		}
+
+		game, err := wpService.GetGame(gameID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(`{"error": "%s"}`, err.Error()), http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(game)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"games":       wpService.GetLiveGames(),
+		"lastUpdated": time.Now(),
+	})
+}
+
+// HandleLiveWinProbabilityStream streams win probability updates as Server-Sent Events
+func HandleLiveWinProbabilityStream(w http.ResponseWriter, r *http.Request) {
+	wpService := services.GetLiveWinProbabilityService()
+	if wpService == nil {
+		http.Error(w, "Live win probability service not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Send the current state of every live game first, then follow the push hub
+	servePushStream(w, r, []string{services.PushEventWinProbability}, func(w http.ResponseWriter) {
+		for _, game := range wpService.GetLiveGames() {
+			writeWinProbabilityEvent(w, game)
+		}
+	})
+}
+
+// writeWinProbabilityEvent writes one SSE "win_probability" event
+func writeWinProbabilityEvent(w http.ResponseWriter, game *models.LiveWinProbability) {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", services.PushEventWinProbability, data)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"net/http"
@@ -17,9 +18,15 @@ func HandleModelInsights(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(r.Context(), predictionTimeout)
+	defer cancel()
+
 	// Get fresh prediction with all model insights
-	prediction, err := predictionService.PredictNextGame()
+	prediction, err := predictionService.PredictNextGameContext(ctx)
 	if err != nil {
+		if requestAborted(ctx, w) {
+			return
+		}
 		w.Write([]byte(`<div class="model-insight-error">Unable to generate insights: ` + template.HTMLEscapeString(err.Error()) + `</div>`))
 		return
 	}
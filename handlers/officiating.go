@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleOfficiating returns league officiating averages and per-official tendencies,
+// or the officiating factor for a single game when ?gameId= is provided
+func HandleOfficiating(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	officiatingService := services.GetOfficiatingService()
+	if officiatingService == nil {
+		http.Error(w, `{"error": "Officiating service not initialized"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	if gameIDStr := r.URL.Query().Get("gameId"); gameIDStr != "" {
+		gameID, err := strconv.Atoi(gameIDStr)
+		if err != nil {
+			http.Error(w, `{"error": "Invalid gameId"}`, http.StatusBadRequest)
+			return
+		}
+
+		// Look the crew up if it isn't known yet; failures are cached
+		officiatingService.FetchAssignment(gameID)
+		json.NewEncoder(w).Encode(officiatingService.GetOfficiatingFactor(gameID))
+		return
+	}
+
+	// Pick up newly dropped assignment files and stored play-by-play
+	if r.URL.Query().Get("sync") == "true" {
+		officiatingService.LoadAssignmentDrops()
+		officiatingService.SyncWithStoredPlayByPlay()
+	}
+
+	json.NewEncoder(w).Encode(officiatingService.GetSummary())
+}
@@ -89,8 +89,27 @@ func GetFeatureImportance(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(features)
 }
 
-// GetLowValueFeatures returns features with low importance
+// GetLowValueFeatures returns features with low importance. Once a held-out
+// permutation importance run exists its prune recommendations are returned
+// instead of the heuristic scores (pass source=heuristic for the old list).
 func GetLowValueFeatures(w http.ResponseWriter, r *http.Request) {
+	permutation := services.GetPermutationImportanceService()
+	if report := permutation.GetReport(); report != nil && r.URL.Query().Get("source") != "heuristic" {
+		candidates := permutation.PruneCandidates()
+		response := map[string]interface{}{
+			"source":       "permutation",
+			"holdoutGames": report.HoldoutGames,
+			"completedAt":  report.CompletedAt,
+			"count":        len(candidates),
+			"features":     featureNames(candidates),
+			"details":      candidates,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	featureAnalyzer := services.GetFeatureImportanceAnalyzer()
 	if featureAnalyzer == nil {
 		http.Error(w, "Feature importance analyzer not available", http.StatusServiceUnavailable)
@@ -109,6 +128,7 @@ func GetLowValueFeatures(w http.ResponseWriter, r *http.Request) {
 	lowValueFeatures := featureAnalyzer.GetLowValueFeatures(threshold)
 	
 	response := map[string]interface{}{
+		"source":    "heuristic",
 		"threshold": threshold,
 		"count":     len(lowValueFeatures),
 		"features":  lowValueFeatures,
@@ -203,6 +223,9 @@ func GetPhase1AnalyticsDashboard(w http.ResponseWriter, r *http.Request) {
 	if featureAnalyzer != nil {
 		topFeatures := featureAnalyzer.GetTopFeatures(10)
 		lowValueFeatures := featureAnalyzer.GetLowValueFeatures(0.2)
+		if permutation := services.GetPermutationImportanceService(); permutation.GetReport() != nil {
+			lowValueFeatures = featureNames(permutation.PruneCandidates())
+		}
 		
 		dashboard["featureImportance"] = map[string]interface{}{
 			"top10Features":       topFeatures,
@@ -223,6 +246,15 @@ func GetPhase1AnalyticsDashboard(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(dashboard)
 }
 
+// featureNames lists the names of importance estimates
+func featureNames(estimates []services.FeatureImportanceEstimate) []string {
+	names := make([]string, len(estimates))
+	for i, estimate := range estimates {
+		names[i] = estimate.Feature
+	}
+	return names
+}
+
 // Helper function
 func min(a, b int) int {
 	if a < b {
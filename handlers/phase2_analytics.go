@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/jaredshillingburg/go_uhc/models"
 	"github.com/jaredshillingburg/go_uhc/services"
@@ -143,6 +144,49 @@ func GetGoalieMatchupHistory(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// GetGoalieStarterProbabilities returns each team's estimated starting goalie probabilities
+func GetGoalieStarterProbabilities(w http.ResponseWriter, r *http.Request) {
+	// Parse from query params: /api/goalie-starters?home=UTA&away=BOS&date=2025-01-15
+	homeTeam := strings.ToUpper(r.URL.Query().Get("home"))
+	awayTeam := strings.ToUpper(r.URL.Query().Get("away"))
+
+	if homeTeam == "" || awayTeam == "" {
+		http.Error(w, "Missing home or away team parameter", http.StatusBadRequest)
+		return
+	}
+
+	gameDate := time.Now()
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			http.Error(w, "Invalid date (expected YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		gameDate = parsed
+	}
+
+	goalieService := services.GetGoalieService()
+	if goalieService == nil {
+		http.Error(w, "Goalie service not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	response := map[string]interface{}{}
+	if estimate, err := goalieService.EstimateStarterProbabilities(homeTeam, awayTeam, gameDate); err == nil {
+		response["home"] = estimate
+	} else {
+		response["homeError"] = err.Error()
+	}
+	if estimate, err := goalieService.EstimateStarterProbabilities(awayTeam, homeTeam, gameDate); err == nil {
+		response["away"] = estimate
+	} else {
+		response["awayError"] = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // GetRestImpactAnalysis returns rest impact analysis for a team
 func GetRestImpactAnalysis(w http.ResponseWriter, r *http.Request) {
 	// Parse from URL path: /api/rest-impact/:team
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -12,8 +13,8 @@ import (
 )
 
 // CalculatePlayoffOdds analyzes current standings to determine Utah's playoff chances
-// Now uses ML-powered Monte Carlo simulation for accurate odds
-func CalculatePlayoffOdds() (*models.PlayoffOdds, error) {
+// Now uses ML-powered Monte Carlo simulation for accurate odds, abandoned once ctx is done
+func CalculatePlayoffOdds(ctx context.Context) (*models.PlayoffOdds, error) {
 	// Get current standings
 	standings, err := services.GetStandings()
 	if err != nil {
@@ -71,7 +72,10 @@ func CalculatePlayoffOdds() (*models.PlayoffOdds, error) {
 	historicalThreshold := 96
 
 	// Calculate playoff odds using ML simulation (Phase 5.2: Adaptive simulation count)
-	playoffOdds, divisionOdds, wildCardOdds, mlSimulation := calculateMLPlayoffOddsAdaptive(utahTeam.TeamAbbrev.Default, utahTeam, westernTeams)
+	playoffOdds, divisionOdds, wildCardOdds, mlSimulation := calculateMLPlayoffOddsAdaptive(ctx, utahTeam.TeamAbbrev.Default, utahTeam, westernTeams)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// Determine what Utah needs
 	pointsNeeded := calculatePointsNeeded(westernTeams, utahTeam, historicalThreshold)
@@ -350,8 +354,14 @@ func calculateWildCardOdds(westernTeams []models.TeamStanding, utahTeam *models.
 
 // HandlePlayoffOdds serves the playoff odds as HTML
 func HandlePlayoffOdds(w http.ResponseWriter, r *http.Request) {
-	odds, err := CalculatePlayoffOdds()
+	ctx, cancel := context.WithTimeout(r.Context(), simulationTimeout)
+	defer cancel()
+
+	odds, err := CalculatePlayoffOdds(ctx)
 	if err != nil {
+		if requestAborted(ctx, w) {
+			return
+		}
 		http.Error(w, "Error calculating playoff odds: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -605,9 +615,9 @@ func calculateMLPlayoffOddsWithStrategy(teamCode, strategy string) (playoffOdds,
 }
 
 // calculateMLPlayoffOddsAdaptive runs ML-powered Monte Carlo simulation with adaptive count (Phase 5.2)
-func calculateMLPlayoffOddsAdaptive(teamCode string, team *models.TeamStanding, conferenceTeams []models.TeamStanding) (playoffOdds, divisionOdds, wildCardOdds float64, simulation *services.SeasonSimulation) {
+func calculateMLPlayoffOddsAdaptive(ctx context.Context, teamCode string, team *models.TeamStanding, conferenceTeams []models.TeamStanding) (playoffOdds, divisionOdds, wildCardOdds float64, simulation *services.SeasonSimulation) {
 	// Calculate optimal simulation count based on urgency
-	config := services.DefaultSimulationConfig()
+	config := &services.GetAppConfig().Simulation
 	simCount := services.CalculateAdaptiveSimulationCount(team, conferenceTeams, config)
 
 	// Get recommendation explanation
@@ -627,7 +637,7 @@ func calculateMLPlayoffOddsAdaptive(teamCode string, team *models.TeamStanding,
 	}
 
 	// Run adaptive simulations
-	sim, err := simService.SimulatePlayoffOdds(teamCode, simCount)
+	sim, err := simService.SimulatePlayoffOddsContext(ctx, teamCode, simCount, false)
 	if err != nil {
 		fmt.Printf("⚠️ Playoff simulation error: %v\n", err)
 		return 50.0, 30.0, 20.0, &services.SeasonSimulation{
@@ -671,8 +681,14 @@ func HandleWhatIf(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := simService.SimulateWhatIf(teamCode, scenario, 2000)
+	ctx, cancel := context.WithTimeout(r.Context(), simulationTimeout)
+	defer cancel()
+
+	result, err := simService.SimulateWhatIfContext(ctx, teamCode, scenario, 2000)
 	if err != nil {
+		if requestAborted(ctx, w) {
+			return
+		}
 		http.Error(w, fmt.Sprintf("Simulation error: %v", err), http.StatusInternalServerError)
 		return
 	}
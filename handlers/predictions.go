@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"strings"
 
@@ -49,9 +51,15 @@ func HandleGamePrediction(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	ctx, cancel := context.WithTimeout(r.Context(), predictionTimeout)
+	defer cancel()
+
 	// Generate fresh prediction (DON'T cache - always get latest data)
-	prediction, err := predictionService.PredictNextGame()
+	prediction, err := predictionService.PredictNextGameContext(ctx)
 	if err != nil {
+		if requestAborted(ctx, w) {
+			return
+		}
 		fmt.Printf("Error generating prediction: %v\n", err)
 		http.Error(w, fmt.Sprintf(`{"error": "Failed to generate prediction: %v"}`, err), http.StatusInternalServerError)
 		return
@@ -81,9 +89,15 @@ func HandlePredictionWidget(w http.ResponseWriter, r *http.Request) {
 	if cachedPrediction != nil {
 		prediction = cachedPrediction
 	} else {
+		ctx, cancel := context.WithTimeout(r.Context(), predictionTimeout)
+		defer cancel()
+
 		var err error
-		prediction, err = predictionService.PredictNextGame()
+		prediction, err = predictionService.PredictNextGameContext(ctx)
 		if err != nil {
+			if requestAborted(ctx, w) {
+				return
+			}
 			fmt.Printf("Error generating prediction: %v\n", err)
 			fmt.Fprintf(w, `<div class="prediction-error">Unable to generate prediction: %v</div>`, err)
 			return
@@ -121,6 +135,9 @@ func formatPredictionHTML(prediction *models.GamePrediction) string {
 	// Build model analysis HTML
 	modelAnalysisHTML := buildModelAnalysisHTML(prediction.Prediction.ModelResults)
 
+	// Build per-feature attribution HTML
+	explanationHTML := buildExplanationHTML(prediction.Prediction.Explanation, prediction.HomeTeam.Code)
+
 	// Build key factors HTML
 	keyFactorsHTML := buildKeyFactorsHTML(prediction.KeyFactors)
 
@@ -169,6 +186,8 @@ func formatPredictionHTML(prediction *models.GamePrediction) string {
 		
 		%s <!-- Model Analysis -->
 		
+		%s <!-- Why This Prediction -->
+		
 		%s <!-- Key Strategic Factors -->
 
 		<div class="prediction-footer">
@@ -400,6 +419,45 @@ func formatPredictionHTML(prediction *models.GamePrediction) string {
 		margin-top: 4px;
 	}
 
+	.explanation-row {
+		display: grid;
+		grid-template-columns: 40%% 1fr 60px;
+		align-items: center;
+		gap: 8px;
+		margin: 4px 0;
+		font-size: 0.85em;
+	}
+
+	.explanation-bar {
+		position: relative;
+		height: 8px;
+		background: rgba(255,255,255,0.1);
+		border-radius: 4px;
+	}
+
+	.explanation-fill {
+		position: absolute;
+		top: 0;
+		height: 100%%;
+		border-radius: 4px;
+	}
+
+	.explanation-fill.positive {
+		left: 50%%;
+		background: #4CAF50;
+	}
+
+	.explanation-fill.negative {
+		right: 50%%;
+		background: #f44336;
+	}
+
+	.explanation-summary {
+		font-size: 0.8em;
+		color: #cccccc;
+		margin-top: 8px;
+	}
+
 	/* Responsive Design */
 	@media (max-width: 768px) {
 		.prediction-matchup {
@@ -425,6 +483,7 @@ func formatPredictionHTML(prediction *models.GamePrediction) string {
 		advancedAnalyticsHTML,
 		situationalHTML,
 		modelAnalysisHTML,
+		explanationHTML,
 		keyFactorsHTML,
 		prediction.GeneratedAt.Format("3:04 PM"), prediction.Prediction.GameType, gameTypeIcon, prediction.Prediction.EnsembleMethod)
 
@@ -816,6 +875,39 @@ func buildKeyFactorsHTML(keyFactors []string) string {
 	return html.String()
 }
 
+// buildExplanationHTML renders the ensemble's per-feature attribution as
+// bars pushing toward the home team (right) or the away team (left)
+func buildExplanationHTML(explanation *models.PredictionExplanation, homeCode string) string {
+	if explanation == nil || len(explanation.Contributions) == 0 {
+		return ""
+	}
+
+	largest := 0.0
+	for _, c := range explanation.Contributions {
+		largest = math.Max(largest, math.Abs(c.Contribution))
+	}
+
+	var html strings.Builder
+	html.WriteString(`<div class="explanation-section">`)
+	html.WriteString(`<h4 class="section-title">🧠 Why This Prediction</h4>`)
+	for _, c := range explanation.Contributions {
+		class := "positive"
+		if c.Contribution < 0 {
+			class = "negative"
+		}
+		width := 0.0
+		if largest > 0 {
+			width = math.Abs(c.Contribution) / largest * 50
+		}
+		html.WriteString(fmt.Sprintf(`<div class="explanation-row"><span>%s</span><div class="explanation-bar"><div class="explanation-fill %s" style="width: %.0f%%"></div></div><span>%+.1f%%</span></div>`,
+			c.Feature, class, width, c.Contribution*100))
+	}
+	html.WriteString(fmt.Sprintf(`<div class="explanation-summary">%s win probability: %.1f%% baseline → %.1f%% across %d models (%.0f%% of ensemble weight)</div>`,
+		homeCode, explanation.BaseValue*100, explanation.Prediction*100, len(explanation.Models), explanation.ExplainedWeight*100))
+	html.WriteString(`</div>`)
+	return html.String()
+}
+
 // Helper functions for formatting
 func getConfidenceColor(confidence float64) string {
 	if confidence > 0.8 {
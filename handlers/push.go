@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandlePushStream streams dashboard updates (scores, goals, lineups,
+// re-predictions, simulations) as Server-Sent Events. Clients may pass
+// ?events=goal,score_update to receive only some event types.
+func HandlePushStream(w http.ResponseWriter, r *http.Request) {
+	var eventTypes []string
+	if events := r.URL.Query().Get("events"); events != "" {
+		eventTypes = strings.Split(events, ",")
+	}
+
+	servePushStream(w, r, eventTypes, nil)
+}
+
+// HandlePushStats returns push hub statistics as JSON
+func HandlePushStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	hub := services.GetPushHub()
+	if hub == nil {
+		http.Error(w, `{"error": "Push hub not initialized"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	json.NewEncoder(w).Encode(hub.GetStats())
+}
+
+// servePushStream registers an SSE client with the push hub and writes events
+// until the client disconnects. onConnect can send an initial snapshot.
+func servePushStream(w http.ResponseWriter, r *http.Request, eventTypes []string, onConnect func(w http.ResponseWriter)) {
+	hub := services.GetPushHub()
+	if hub == nil {
+		http.Error(w, "Push hub not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	// Replay anything missed since the browser's last connection
+	var client *services.PushClient
+	var missed []*services.PushEvent
+	if lastID, err := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64); err == nil {
+		client, missed = hub.RegisterSince(r.RemoteAddr, eventTypes, lastID)
+	} else {
+		client = hub.Register(r.RemoteAddr, eventTypes)
+	}
+	defer hub.Unregister(client)
+
+	// Ask the browser to wait 5s before reconnecting
+	fmt.Fprint(w, "retry: 5000\n\n")
+
+	for _, event := range missed {
+		writePushEvent(w, event)
+	}
+
+	if onConnect != nil {
+		onConnect(w)
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(25 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-client.Events:
+			if !ok {
+				return
+			}
+			writePushEvent(w, event)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// writePushEvent writes one SSE event, using the event type as the SSE event name
+func writePushEvent(w http.ResponseWriter, event *services.PushEvent) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleSchedulerJobs lists the scheduled background jobs with their next
+// run and recent run history (?job= for just one). POST with ?job= and
+// ?action=pause, resume or trigger controls a job.
+func HandleSchedulerJobs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	scheduler := services.GetJobScheduler()
+	name := r.URL.Query().Get("job")
+
+	if r.Method == http.MethodGet {
+		if name == "" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"running": scheduler.IsRunning(),
+				"jobs":    scheduler.Status(),
+			})
+			return
+		}
+		status, err := scheduler.JobStatus(name)
+		if err != nil {
+			http.Error(w, `{"error": "Unknown job"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(status)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error": "Method not allowed. Use GET or POST."}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if name == "" {
+		http.Error(w, `{"error": "job parameter is required"}`, http.StatusBadRequest)
+		return
+	}
+
+	action := r.URL.Query().Get("action")
+	var err error
+	switch action {
+	case "pause":
+		err = scheduler.Pause(name)
+	case "resume":
+		err = scheduler.Resume(name)
+	case "trigger":
+		err = scheduler.Trigger(name)
+	default:
+		http.Error(w, `{"error": "action must be pause, resume or trigger"}`, http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrJobNotFound):
+		http.Error(w, `{"error": "Unknown job"}`, http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrJobRunning):
+		http.Error(w, `{"error": "Job already running"}`, http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusInternalServerError)
+		return
+	}
+
+	status, _ := scheduler.JobStatus(name)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"action":  action,
+		"job":     status,
+	})
+}
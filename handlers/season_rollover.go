@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+	"github.com/jaredshillingburg/go_uhc/utils"
+)
+
+// HandleSeasonRollover returns which season the rating models are in and the
+// history of rollovers. POST (or ?run=true) rolls them into the current
+// season in the background; ?from= and ?to= override the seasons.
+func HandleSeasonRollover(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	rollover := services.GetSeasonRolloverService()
+	state := rollover.GetState()
+
+	if r.Method != http.MethodPost && r.URL.Query().Get("run") != "true" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"modelSeason":   state.CurrentSeason,
+			"currentSeason": utils.GetCurrentSeason(),
+			"running":       rollover.IsRunning(),
+			"rollovers":     state.Rollovers,
+		})
+		return
+	}
+
+	fromSeason, toSeason := state.CurrentSeason, utils.GetCurrentSeason()
+	if fromSeason == 0 {
+		fromSeason = utils.GetPreviousSeason()
+	}
+	if from, err := strconv.Atoi(r.URL.Query().Get("from")); err == nil {
+		fromSeason = from
+	}
+	if to, err := strconv.Atoi(r.URL.Query().Get("to")); err == nil {
+		toSeason = to
+	}
+
+	if toSeason <= fromSeason {
+		http.Error(w, `{"error": "Models are already in the current season; pass to to force a rollover"}`, http.StatusBadRequest)
+		return
+	}
+	if state.CurrentSeason != 0 && fromSeason != state.CurrentSeason {
+		http.Error(w, fmt.Sprintf(`{"error": "Models are in season %d; from must match it"}`, state.CurrentSeason), http.StatusConflict)
+		return
+	}
+	if rollover.IsRunning() {
+		http.Error(w, `{"error": "Season rollover already running"}`, http.StatusConflict)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "processing",
+		"message": "Season rollover started. Check server logs for progress.",
+	})
+
+	go func() {
+		if _, err := rollover.Run(fromSeason, toSeason); err != nil {
+			log.Printf("⚠️ Season rollover failed: %v", err)
+		}
+	}()
+}
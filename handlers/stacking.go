@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// HandleStacking returns the latest combiner comparison and the active combiner.
+// POST (or ?run=true) rebuilds out-of-fold base predictions, re-runs the
+// comparison and switches the ensemble to the winner in the background.
+func HandleStacking(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	stacking := services.GetStackingService()
+
+	if r.Method != http.MethodPost && r.URL.Query().Get("run") != "true" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"combiner":   services.GetMetaLearnerModel().Combiner(),
+			"running":    stacking.IsRunning(),
+			"oofSources": services.StackingOOFSources,
+			"report":     stacking.GetReport(),
+		})
+		return
+	}
+
+	if stacking.IsRunning() {
+		http.Error(w, `{"error": "Stacking already running"}`, http.StatusConflict)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "processing",
+		"message": "Stacking started. Check server logs for progress.",
+	})
+
+	go func() {
+		if _, err := stacking.Run(); err != nil {
+			log.Printf("⚠️ Stacking failed: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+// registerScheduledJobs declares every recurring background job. Schedules
+// are cron expressions in local time (see services.ParseJobSchedule); run
+// history, pauses and manual triggers are at /api/scheduler/jobs.
+func registerScheduledJobs(dailyPredictionService *services.DailyPredictionService) {
+	scheduler := services.GetJobScheduler()
+
+	scheduler.MustRegister(services.ScheduledJob{
+		Name:        "schedule-refresh",
+		Description: fmt.Sprintf("Refresh %s's next game and upcoming games", teamConfig.Code),
+		Schedule:    "0 0 * * *",
+		CatchUp:     true,
+		Timeout:     5 * time.Minute,
+		Run:         refreshSchedule,
+	})
+	if currentSeasonStatus.IsHockeySeason {
+		scheduler.MustRegister(services.ScheduledJob{
+			Name:        "player-stats-refresh",
+			Description: "Refresh league skater and goalie leaders",
+			Schedule:    "@every 30m",
+			Jitter:      time.Minute,
+			Timeout:     5 * time.Minute,
+			Run:         refreshPlayerStats,
+		})
+	}
+
+	scheduler.MustRegister(services.ScheduledJob{
+		Name:        "daily-predictions",
+		Description: "Predict all upcoming NHL games",
+		Schedule:    "0 6 * * *",
+		RunOnStart:  true,
+		StartDelay:  10 * time.Second, // Let services finish initializing
+		Run:         dailyPredictionService.RunScheduled,
+	})
+
+	if grs := services.GetGameResultsService(); grs != nil {
+		scheduler.MustRegister(services.ScheduledJob{
+			Name:        "completed-games-check",
+			Description: "Process newly completed games league-wide and feed them to the models",
+			Schedule:    fmt.Sprintf("@every %v", grs.CheckInterval()),
+			RunOnStart:  true,
+			Run:         grs.CheckForCompletedGames,
+		})
+		scheduler.MustRegister(services.ScheduledJob{
+			Name:        "unprocessed-predictions-check",
+			Description: "Fetch results for stored predictions that never got one",
+			Schedule:    "@every 24h",
+			RunOnStart:  true,
+			Run: func(ctx context.Context) error {
+				if grs.IsRunning() {
+					grs.CheckUnprocessedPredictions()
+				}
+				return ctx.Err()
+			},
+		})
+	}
+
+	if liveSys := services.GetLivePredictionSystem(); liveSys != nil {
+		modelScheduler := liveSys.GetModelScheduler()
+		scheduler.MustRegister(services.ScheduledJob{
+			Name:        "model-updates",
+			Description: "Queue a full refresh of the live-updating models",
+			Schedule:    fmt.Sprintf("@every %v", modelScheduler.UpdateInterval()),
+			Run:         modelScheduler.RunPeriodicUpdates,
+		})
+	}
+
+	if evalSvc := services.GetEvaluationService(); evalSvc != nil {
+		scheduler.MustRegister(services.ScheduledJob{
+			Name:        "model-save",
+			Description: "Save model weights and batch training queues",
+			Schedule:    "*/30 * * * *",
+			Run: func(ctx context.Context) error {
+				return evalSvc.SaveModelsAndQueues()
+			},
+		})
+	}
+
+	if officiating := services.GetOfficiatingService(); officiating != nil {
+		scheduler.MustRegister(services.ScheduledJob{
+			Name:        "officiating-assignments",
+			Description: "Look up announced officiating crews for the next day's games",
+			Schedule:    "@every 1h",
+			RunOnStart:  true,
+			StartDelay:  time.Minute,
+			Run: func(ctx context.Context) error {
+				games, err := dailyPredictionService.UpcomingGames()
+				if err != nil {
+					return err
+				}
+				officiating.PrefetchAssignments(ctx, games)
+				return nil
+			},
+		})
+	}
+
+	if market := services.GetBettingMarketService(); market != nil && market.IsEnabled() {
+		scheduler.MustRegister(services.ScheduledJob{
+			Name:        "odds-poll",
+			Description: "Record betting lines for upcoming games, including a closing line just before puck drop",
+			Schedule:    "*/5 * * * *",
+			Timeout:     time.Minute,
+			Run:         market.PollOdds,
+		})
+	}
+
+	scheduler.MustRegister(services.ScheduledJob{
+		Name:        "config-reload",
+		Description: "Reload the config file when it changes and apply its hot-reloadable settings",
+		Schedule:    "@every 30s",
+		Run:         services.ReloadAppConfigIfChanged,
+	})
+
+	scheduler.MustRegister(services.ScheduledJob{
+		Name:        "season-rollover-check",
+		Description: "Roll the rating models into a new season once training camps open",
+		Schedule:    "0 4 * * *",
+		RunOnStart:  true,
+		Run: func(ctx context.Context) error {
+			services.GetSeasonRolloverService().CheckCalendar(time.Now())
+			return nil
+		},
+	})
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/services"
+)
+
+const (
+	// shutdownTimeout bounds the whole shutdown, HTTP drain included
+	shutdownTimeout = 45 * time.Second
+
+	// httpDrainTimeout is how long in-flight requests get to finish
+	httpDrainTimeout = 30 * time.Second
+)
+
+// registerBackgroundServices registers the background services with the
+// lifecycle manager. Order matters: services start top to bottom and stop
+// bottom to top, so the HTTP server drains first, then the background
+// context is cancelled to stop loops and backfills, then each service is
+// stopped, and finally caches and pending writes are flushed to disk.
+func registerBackgroundServices(server *http.Server, dailyPredictionService *services.DailyPredictionService) {
+	lifecycle := services.GetLifecycleManager()
+
+	// Persistence: started first so it's flushed last
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "database",
+		Start: func() error {
+			return services.InitDatabase(services.DefaultDatabasePath)
+		},
+		Stop: func(ctx context.Context) error {
+			return services.GetDatabase().Close()
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "batch-writer",
+		Start: func() error {
+			services.InitBatchWriter(30*time.Second, 100)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return services.GetBatchWriter().Stop()
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "lazy-cache",
+		Start: func() error {
+			services.InitLazyCacheManager()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return services.GetLazyCacheManager().SaveAll()
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "api-cache",
+		Stop: func(ctx context.Context) error {
+			if apiCache := services.GetAPICacheService(); apiCache != nil {
+				return apiCache.SaveCache()
+			}
+			return nil
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "training-metrics",
+		Stop: func(ctx context.Context) error {
+			if trainingMetrics := services.GetTrainingMetricsService(); trainingMetrics != nil {
+				return trainingMetrics.SaveMetrics()
+			}
+			return nil
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "model-evaluation",
+		Start: func() error {
+			if services.GetEvaluationService() == nil {
+				return fmt.Errorf("evaluation service not initialized")
+			}
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return services.GetEvaluationService().SaveModelsAndQueues()
+		},
+		StopTimeout: 30 * time.Second,
+	})
+
+	// Model updates and game monitoring
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "live-prediction-system",
+		Start: func() error {
+			liveSys := services.GetLivePredictionSystem()
+			if liveSys == nil {
+				return fmt.Errorf("live prediction system not initialized")
+			}
+			return liveSys.Start()
+		},
+		Stop: func(ctx context.Context) error {
+			return services.StopLivePredictionSystem()
+		},
+		Health: func() error {
+			if liveSys := services.GetLivePredictionSystem(); liveSys == nil || !liveSys.IsRunning() {
+				return fmt.Errorf("live prediction system not running")
+			}
+			return nil
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "game-results",
+		Start: func() error {
+			grs := services.GetGameResultsService()
+			if grs == nil {
+				return fmt.Errorf("game results service not initialized")
+			}
+			grs.Start()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return services.StopGameResultsService()
+		},
+		Health: func() error {
+			if grs := services.GetGameResultsService(); grs == nil || !grs.IsRunning() {
+				return fmt.Errorf("game monitoring not running")
+			}
+			return nil
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "daily-predictions",
+		Start: func() error {
+			dailyPredictionService.Start()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			dailyPredictionService.Stop()
+			return nil
+		},
+		Health: func() error {
+			if !dailyPredictionService.IsRunning() {
+				return fmt.Errorf("daily prediction schedule not running")
+			}
+			return nil
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "live-win-probability",
+		Start: func() error {
+			services.GetLiveWinProbabilityService().Start()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			services.GetLiveWinProbabilityService().Stop()
+			return nil
+		},
+		Health: func() error {
+			if !services.GetLiveWinProbabilityService().IsRunning() {
+				return fmt.Errorf("live win probability polling not running")
+			}
+			return nil
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "pregame-lineups",
+		Start: func() error {
+			lineups := services.GetPreGameLineupService()
+			if lineups == nil {
+				return fmt.Errorf("pre-game lineup service not initialized")
+			}
+			lineups.StartMonitoring()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			services.GetPreGameLineupService().StopMonitoring()
+			return nil
+		},
+	})
+
+	// Recurring jobs: started after the services they drive, stopped before
+	// them (waiting for in-flight runs once the background context is cancelled)
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "job-scheduler",
+		Start: func() error {
+			registerScheduledJobs(dailyPredictionService)
+			services.GetJobScheduler().Start()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return services.GetJobScheduler().Stop(ctx)
+		},
+		Health: func() error {
+			if !services.GetJobScheduler().IsRunning() {
+				return fmt.Errorf("job scheduler not running")
+			}
+			return nil
+		},
+		StopTimeout: 30 * time.Second,
+	})
+
+	// Backfill queue: resumes jobs interrupted by the last shutdown; on stop
+	// the running job checkpoints and is requeued
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name:  "backfill-queue",
+		Start: services.GetBackfillQueue().Start,
+		Stop: func(ctx context.Context) error {
+			return services.GetBackfillQueue().Stop(ctx)
+		},
+		Health: func() error {
+			if !services.GetBackfillQueue().IsRunning() {
+				return fmt.Errorf("backfill queue not running")
+			}
+			return nil
+		},
+		StopTimeout: 30 * time.Second,
+	})
+
+	// Syncs and backfills: these exit on their own once the background
+	// context is cancelled
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "officiating-sync",
+		Start: func() error {
+			officiating := services.GetOfficiatingService()
+			if officiating == nil {
+				return fmt.Errorf("officiating service not initialized")
+			}
+			go officiating.SyncWithStoredPlayByPlay()
+			return nil
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "closing-line-sync",
+		Start: func() error {
+			closingLine := services.GetClosingLineService()
+			if closingLine == nil {
+				return fmt.Errorf("closing line service not initialized")
+			}
+			go closingLine.SyncWithStoredPredictions()
+			return nil
+		},
+	})
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name:  "play-by-play-backfill",
+		Start: startPlayByPlayBackfill,
+	})
+
+	// Cancelled after the HTTP drain so in-flight requests can still use
+	// shared NHL API fetches, and before the services above are stopped
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name: "background-context",
+		Stop: func(ctx context.Context) error {
+			services.CancelBackground()
+			return nil
+		},
+	})
+
+	// HTTP server: started last, drained first
+	lifecycle.MustRegister(services.LifecycleComponent{
+		Name:     "http-server",
+		Required: true,
+		Start: func() error {
+			// Bind here so a busy port or bad address fails startup with
+			// the error instead of exiting from the serving goroutine
+			addr := server.Addr
+			if addr == "" {
+				addr = ":http" // As ListenAndServe
+			}
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", addr, err)
+			}
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					log.Fatalf("❌ Server stopped unexpectedly: %v", err)
+				}
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			fmt.Println("⏳ Draining in-flight HTTP requests...")
+			return server.Shutdown(ctx)
+		},
+		StopTimeout: httpDrainTimeout,
+	})
+}
+
+// startPlayByPlayBackfill queues a backfill of play-by-play data for ALL NHL
+// teams (league-wide) so it doesn't block server startup. If the previous
+// run's job was interrupted it is resumed instead.
+func startPlayByPlayBackfill() error {
+	if services.GetPlayByPlayService() == nil {
+		return fmt.Errorf("play-by-play service not initialized")
+	}
+
+	job, err := services.GetBackfillQueue().Enqueue(services.BackfillKindPlayByPlay, services.BackfillParams{Games: 10})
+	if err != nil {
+		return fmt.Errorf("failed to queue play-by-play backfill: %w", err)
+	}
+	fmt.Printf("🌐 League-wide xG backfill queued as job %d (last 10 games per team, 32 teams)\n", job.ID)
+	fmt.Println("⏱️ Progress at /api/backfill/jobs")
+	return nil
+}
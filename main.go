@@ -1,13 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -74,20 +74,27 @@ func initializeDataDirectories() error {
 		"data/architecture_search",
 		"data/time_weighted_stats",
 		"data/feature_importance",
+		"data/closing_line",
+		"data/live_win_probability",
+		"data/officiating",
+		"data/feature_snapshots",
+		"data/config",
+		"data/tuning",
+		"data/stacking",
+		"data/season_rollover",
 	}
 	
 	for _, dir := range directories {
-		fullPath := "/app/" + dir
-		if err := os.MkdirAll(fullPath, 0755); err != nil {
+		if err := os.MkdirAll(dir, 0755); err != nil {
 			// Don't fail - directory might already exist or be created by PVC
 			fmt.Printf("   ⚠️  Warning: Could not create %s: %v (continuing anyway)\n", dir, err)
 		}
 	}
 	
 	// Try to create a test file to verify write permissions
-	testFile := "/app/data/.write_test"
+	testFile := "data/.write_test"
 	if err := os.WriteFile(testFile, []byte("test"), 0644); err != nil {
-		fmt.Printf("   ⚠️  Warning: Cannot write to /app/data directory: %v\n", err)
+		fmt.Printf("   ⚠️  Warning: Cannot write to data directory: %v\n", err)
 		fmt.Printf("   The application will attempt to create files as needed.\n")
 		fmt.Printf("   If you see permission errors, add fsGroup: 1001 to your Kubernetes securityContext\n")
 	} else {
@@ -100,14 +107,9 @@ func initializeDataDirectories() error {
 }
 
 func main() {
-	// Get default team code from environment variable if set
-	defaultTeam := os.Getenv("TEAM_CODE")
-	if defaultTeam == "" {
-		defaultTeam = "UTA"
-	}
-	
-	// Parse command line arguments
-	teamCodeFlag := flag.String("team", defaultTeam, "NHL team code (e.g., UTA, COL, NYR, BOS)")
+	// Parse command line arguments. Flags override config.yaml and the
+	// environment (see services.AppConfig and config.example.yaml).
+	teamCodeFlag := flag.String("team", "", "NHL team code (e.g., UTA, COL, NYR, BOS; default from config or TEAM_CODE, else UTA)")
 
 	// Weather API key flags (optional - for enabling weather analysis)
 	openWeatherAPIKey := flag.String("openweather-key", "", "OpenWeatherMap API key for weather analysis")
@@ -119,42 +121,56 @@ func main() {
 
 	flag.Parse()
 
-	// Set environment variables from command line flags if provided
-	// This allows command line flags to override environment variables
+	// Set the config's environment overrides from command line flags if
+	// provided, so flags win over both the config file and the environment
+	if *teamCodeFlag != "" {
+		os.Setenv("UHC_SERVER_TEAM", *teamCodeFlag)
+	}
 	if *openWeatherAPIKey != "" {
-		os.Setenv("OPENWEATHER_API_KEY", *openWeatherAPIKey)
+		os.Setenv("UHC_API_KEYS_OPEN_WEATHER", *openWeatherAPIKey)
 		fmt.Printf("🌦️ OpenWeatherMap API key set via command line\n")
 	}
 	if *weatherAPIKey != "" {
-		os.Setenv("WEATHER_API_KEY", *weatherAPIKey)
+		os.Setenv("UHC_API_KEYS_WEATHER_API", *weatherAPIKey)
 		fmt.Printf("🌦️ WeatherAPI key set via command line\n")
 	}
 	if *accuWeatherAPIKey != "" {
-		os.Setenv("ACCUWEATHER_API_KEY", *accuWeatherAPIKey)
+		os.Setenv("UHC_API_KEYS_ACCU_WEATHER", *accuWeatherAPIKey)
 		fmt.Printf("🌦️ AccuWeather API key set via command line\n")
 	}
 	if *oddsAPIKey != "" {
-		os.Setenv("ODDS_API_KEY", *oddsAPIKey)
+		os.Setenv("UHC_API_KEYS_ODDS", *oddsAPIKey)
 		fmt.Printf("💰 Odds API key set via command line\n")
 	}
 
+	// Load and validate the central config; refuse to start on a bad one
+	appConfig, err := services.LoadAppConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if appConfig.Server.WorkDir != "" {
+		if err := os.Chdir(appConfig.Server.WorkDir); err != nil {
+			log.Fatalf("❌ Cannot use working directory %s: %v", appConfig.Server.WorkDir, err)
+		}
+	}
+
 	// Initialize team configuration
-	teamCode := strings.ToUpper(*teamCodeFlag)
-	teamConfig = models.GetTeamConfigByCode(teamCode)
+	teamConfig = models.GetTeamConfigByCode(appConfig.Server.Team)
 
 	fmt.Printf("Starting NHL Web Application for %s (%s)...\n", teamConfig.Name, teamConfig.Code)
 
-	// Validate team code
-	if !models.IsValidTeamCode(teamCode) {
-		fmt.Printf("Warning: Team code '%s' not found, using default team %s\n", teamCode, teamConfig.Code)
-	}
-
 	// Initialize data directories (replaces docker-entrypoint.sh)
 	if err := initializeDataDirectories(); err != nil {
 		log.Printf("⚠️ Warning during directory initialization: %v\n", err)
 		// Don't fail - the application can still work
 	}
 
+	// The server saves its in-memory models over data/ periodically and on
+	// shutdown, so it can't share the directory with offline training
+	if err := services.AcquireDataLock("server"); err != nil {
+		log.Fatalf("❌ Cannot start: %v", err)
+	}
+
 	// Initialize schedule data on startup
 	fmt.Printf("Initializing schedule data for %s...\n", teamConfig.Code)
 	game, err := services.GetTeamSchedule(teamConfig.Code)
@@ -238,12 +254,6 @@ func main() {
 		}
 	}
 
-	// Start background fetchers
-	go scheduleFetcher()
-	if currentSeasonStatus.IsHockeySeason {
-		go playerStatsFetcher()
-	}
-
 	// Initialize handlers with cached data
 	handlers.Init(
 		&cachedSchedule,
@@ -263,6 +273,9 @@ func main() {
 		&cachedTeamGoalieStats,
 	)
 
+	// Load tuned model hyperparameters before any model is constructed
+	services.LoadHyperparameterConfig()
+
 	// Initialize AI predictions (available for testing regardless of season)
 	fmt.Println("Initializing AI prediction service...")
 	handlers.InitPredictions(teamConfig.Code)
@@ -307,6 +320,14 @@ func main() {
 	services.InitPlayByPlayService(systemStatsService)
 	fmt.Println("✅ Play-by-Play Analytics Service initialized (Expected Goals ready)")
 
+	// Initialize Officiating Service for referee tendencies (uses stored play-by-play)
+	fmt.Println("Initializing Officiating Service...")
+	if err := services.InitializeOfficiatingService(); err != nil {
+		fmt.Printf("⚠️ Warning: Failed to initialize officiating service: %v\n", err)
+	} else {
+		fmt.Println("✅ Officiating Service initialized (referee tendencies ready)")
+	}
+
 	// Initialize Shift Analysis Service for line chemistry and coaching tendencies
 	fmt.Println("Initializing Shift Analysis Service (Line Chemistry Engine)...")
 	services.InitShiftAnalysisService(systemStatsService)
@@ -322,26 +343,6 @@ func main() {
 	services.InitGameSummaryService(systemStatsService)
 	fmt.Println("✅ Game Summary Analytics Service initialized (Enhanced Context ready)")
 
-	// Backfill play-by-play data for ALL NHL teams (league-wide)
-	fmt.Println("🌐 Starting league-wide xG backfill (last 10 games per team, 32 teams)...")
-	fmt.Println("⏱️ This will take ~10-15 minutes but provides 32x more training data")
-	pbpService := services.GetPlayByPlayService()
-	if pbpService != nil {
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					fmt.Printf("⚠️ Panic during play-by-play backfill: %v\n", r)
-				}
-			}()
-			// Run league-wide backfill in background to not block server startup
-			if err := pbpService.BackfillAllTeams(10); err != nil {
-				fmt.Printf("⚠️ Warning: Failed to backfill play-by-play data: %v\n", err)
-			} else {
-				fmt.Println("✅ League-wide Play-by-Play backfill complete (320 games processed)")
-			}
-		}()
-	}
-
 	// Initialize Live Prediction System for real-time model updates
 	fmt.Println("Initializing Live Prediction System...")
 	if err := services.InitializeLivePredictionSystem(teamConfig.Code); err != nil {
@@ -351,6 +352,14 @@ func main() {
 		fmt.Printf("✅ Live Prediction System initialized for %s\n", teamConfig.Code)
 	}
 
+	// Initialize Live Win Probability Service for in-game win probability
+	fmt.Println("📈 Initializing Live Win Probability Service...")
+	services.InitLiveWinProbabilityService()
+	fmt.Println("✅ Live Win Probability Service initialized")
+
+	// Initialize Push Hub for Server-Sent Events to the dashboard
+	services.InitPushHub()
+
 	// Initialize Playoff Simulation Service for ML-powered playoff odds
 	fmt.Println("Initializing ML-powered Playoff Simulation Service...")
 	liveSys := services.GetLivePredictionSystem()
@@ -368,13 +377,13 @@ func main() {
 	fmt.Println("📝 Initializing Prediction Storage Service...")
 	predictionStorage := services.InitPredictionStorageService()
 	fmt.Println("✅ Prediction Storage Service initialized")
+	services.InitFeatureSnapshotService()
 
 	// Initialize Daily Prediction Service for all NHL games
 	fmt.Println("🎯 Initializing Daily Prediction Service...")
 	ensembleService := services.NewEnsemblePredictionService(teamConfig.Code)
 	dailyPredictionService := services.InitDailyPredictionService(predictionStorage, ensembleService)
-	dailyPredictionService.Start()
-	fmt.Println("✅ Daily Prediction Service started (generates predictions for all NHL games)")
+	fmt.Println("✅ Daily Prediction Service initialized (generates predictions for all NHL games)")
 
 	// Initialize Smart Re-Prediction Service
 	fmt.Println("🔄 Initializing Smart Re-Prediction Service...")
@@ -393,12 +402,6 @@ func main() {
 		fmt.Printf("⚠️ Warning: Failed to initialize evaluation service: %v\n", err)
 	} else {
 		fmt.Printf("✅ Model Evaluation Service initialized\n")
-		
-		// Start periodic auto-save (every 30 minutes)
-		if evalSvc := services.GetEvaluationService(); evalSvc != nil {
-			evalSvc.StartPeriodicSave()
-			fmt.Println("✅ Periodic model save started (every 30 minutes)")
-		}
 	}
 }
 
@@ -470,6 +473,14 @@ func main() {
 		fmt.Printf("✅ Betting Market Service initialized\n")
 	}
 
+	// Closing Line Value tracking (model vs market over the season)
+	fmt.Println("Initializing Closing Line Value Service...")
+	if err := services.InitializeClosingLineService(); err != nil {
+		fmt.Printf("⚠️ Warning: Failed to initialize closing line service: %v\n", err)
+	} else {
+		fmt.Printf("✅ Closing Line Value Service initialized\n")
+	}
+
 	// Schedule Context Service
 	fmt.Println("Initializing Schedule Context Service...")
 	if err := services.InitializeScheduleContextService(); err != nil {
@@ -481,7 +492,7 @@ func main() {
 	// Pre-Game Lineup Service
 	fmt.Println("Initializing Pre-Game Lineup Service...")
 	services.InitPreGameLineupService(teamConfig.Code)
-	fmt.Println("✅ Pre-Game Lineup Service initialized (monitoring upcoming games)")
+	fmt.Println("✅ Pre-Game Lineup Service initialized")
 
 	fmt.Println("🎉 Phase 4 services ready! Predictions now include:")
 	fmt.Println("   🥅 Goalie Intelligence (+3-4% accuracy)")
@@ -741,6 +752,18 @@ func main() {
 	http.HandleFunc("/api/predictions/trigger", handlers.HandleTriggerDailyPredictions)
 	http.HandleFunc("/predictions-stats-popup", handlers.HandlePredictionsStatsPopup)
 
+	// Closing line value endpoints (model vs betting market)
+	http.HandleFunc("/api/closing-line-value", handlers.HandleClosingLineValue)
+	http.HandleFunc("/closing-line-popup", handlers.HandleClosingLinePopup)
+
+	// Live in-game win probability endpoints
+	http.HandleFunc("/api/live-win-probability", handlers.HandleLiveWinProbability)
+	http.HandleFunc("/api/live-win-probability/stream", handlers.HandleLiveWinProbabilityStream)
+
+	// Push channel (Server-Sent Events) for live dashboard updates
+	http.HandleFunc("/api/push/stream", handlers.HandlePushStream)
+	http.HandleFunc("/api/push/stats", handlers.HandlePushStats)
+
 	// Pre-Game Lineup endpoints
 	http.HandleFunc("/api/lineup", handlers.HandleLineup)
 	http.HandleFunc("/lineup", handlers.HandleLineupHTML)
@@ -751,9 +774,25 @@ func main() {
 	
 	// Game Results backfill endpoint (for processing missed games)
 	http.HandleFunc("/api/backfill-games", handlers.HandleBackfillGameResults)
+	http.HandleFunc("/api/backfill/jobs", handlers.HandleBackfillJobs)
 	
 	// Force training endpoint (for training on existing completed games)
 	http.HandleFunc("/api/force-training", handlers.HandleForceTraining)
+
+	// Neural network architecture search (GET results, POST to run)
+	http.HandleFunc("/api/architecture-search", handlers.HandleArchitectureSearch)
+
+	// Hyperparameter tuning (GET config and recent runs, POST to tune a model)
+	http.HandleFunc("/api/hyperparameter-tuning", handlers.HandleHyperparameterTuning)
+
+	// Stacked meta-learner (GET combiner comparison, POST to rebuild OOF predictions and reselect)
+	http.HandleFunc("/api/stacking", handlers.HandleStacking)
+
+	// Bayesian state-space team ratings (posteriors, history, matchup distributions)
+	http.HandleFunc("/api/bayesian-ratings", handlers.HandleBayesianRatings)
+
+	// Season rollover (GET model season and history, POST to regress ratings into the new season)
+	http.HandleFunc("/api/season-rollover", handlers.HandleSeasonRollover)
 	
 	// Check unprocessed predictions endpoint
 	http.HandleFunc("/api/check-predictions", handlers.HandleCheckUnprocessedPredictions)
@@ -768,6 +807,13 @@ func main() {
 	http.HandleFunc("/system-stats", handlers.HandleSystemStats)
 	http.HandleFunc("/system-stats-popup", handlers.HandleSystemStatsPopup)
 	http.HandleFunc("/api/health", handlers.HandleHealth) // Alternative endpoint
+	http.HandleFunc("/api/lifecycle", handlers.HandleLifecycleStatus)
+
+	// Scheduled jobs (GET list and run history, POST ?job=&action=pause|resume|trigger)
+	http.HandleFunc("/api/scheduler/jobs", handlers.HandleSchedulerJobs)
+
+	// Effective config with secrets redacted (GET), hot reload (POST ?action=reload)
+	http.HandleFunc("/api/config", handlers.HandleAppConfig)
 
 	// Team Tier List endpoints
 	http.HandleFunc("/tier-list-popup", handlers.HandleTierListPopup)
@@ -776,6 +822,7 @@ func main() {
 	// Feature Importance Analysis endpoints
 	http.HandleFunc("/api/feature-importance", handlers.HandleFeatureImportance)
 	http.HandleFunc("/api/feature-importance/markdown", handlers.HandleFeatureImportanceMarkdown)
+	http.HandleFunc("/api/feature-importance/permutation", handlers.HandlePermutationImportance)
 
 	// Training Metrics endpoints
 	http.HandleFunc("/api/training-metrics", handlers.HandleTrainingMetrics)
@@ -808,6 +855,8 @@ func main() {
 	http.HandleFunc("/api/phase2/dashboard", handlers.GetPhase2AnalyticsDashboard)
 	http.HandleFunc("/api/head-to-head/", handlers.GetHeadToHeadMatchup)
 	http.HandleFunc("/api/goalie-matchup", handlers.GetGoalieMatchupHistory)
+	http.HandleFunc("/api/goalie-starters", handlers.GetGoalieStarterProbabilities)
+	http.HandleFunc("/api/officiating", handlers.HandleOfficiating)
 	http.HandleFunc("/api/rest-impact/", handlers.GetRestImpactAnalysis)
 	http.HandleFunc("/api/rest-advantage", handlers.GetRestAdvantageComparison)
 	http.HandleFunc("/api/rest-rankings", handlers.GetAllRestImpactRankings)
@@ -843,194 +892,143 @@ func main() {
 
 	http.HandleFunc("/", handlers.HandleHome)
 
+	// Register every background service with the lifecycle manager. They
+	// start in order once all services are wired up and stop in reverse,
+	// beginning with draining in-flight HTTP requests.
+	server := &http.Server{Addr: appConfig.Server.Addr}
+	registerBackgroundServices(server, dailyPredictionService)
+
 	// Set up graceful shutdown
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
-	go func() {
-		<-c
-		fmt.Println("\n🛑 Shutting down server...")
-
-		// Save API cache before shutdown
-		apiCache := services.GetAPICacheService()
-		if apiCache != nil {
-			fmt.Println("💾 Saving API cache...")
-			if err := apiCache.SaveCache(); err != nil {
-				fmt.Printf("⚠️ Warning: Failed to save API cache: %v\n", err)
-			} else {
-				fmt.Println("✅ API cache saved")
-			}
-		}
-
-		// Save training metrics before shutdown
-		trainingMetrics := services.GetTrainingMetricsService()
-		if trainingMetrics != nil {
-			fmt.Println("💾 Saving training metrics...")
-			if err := trainingMetrics.SaveMetrics(); err != nil {
-				fmt.Printf("⚠️ Warning: Failed to save training metrics: %v\n", err)
-			} else {
-				fmt.Println("✅ Training metrics saved")
-			}
-		}
-
-		// Stop the daily prediction service
-		if dailyPredictionService != nil {
-			dailyPredictionService.Stop()
-		}
-
-		// Stop the game results service
-		if err := services.StopGameResultsService(); err != nil {
-			fmt.Printf("⚠️ Warning: Error stopping game results service: %v\n", err)
-		} else {
-			fmt.Println("✅ Game results service stopped")
-		}
-
-		// Stop the live prediction system
-		if err := services.StopLivePredictionSystem(); err != nil {
-			fmt.Printf("⚠️ Warning: Error stopping live prediction system: %v\n", err)
-		} else {
-			fmt.Println("✅ Live prediction system stopped")
-		}
-
-		fmt.Println("👋 Server shutdown complete")
-		os.Exit(0)
-	}()
-
-	fmt.Println("Server starting on http://localhost:8080")
+	fmt.Printf("Server starting on %s\n", appConfig.Server.Addr)
 	fmt.Println("Schedule will be automatically updated every night at midnight")
 	fmt.Println("🤖 Live prediction models will update automatically every hour")
 	fmt.Println("Press Ctrl+C to shutdown gracefully")
 
-	// Start HTTP server with proper error handling
-	if err := http.ListenAndServe(":8080", nil); err != nil {
+	lifecycle := services.GetLifecycleManager()
+	if err := lifecycle.StartAll(); err != nil {
 		log.Fatalf("❌ Server failed to start: %v", err)
 	}
-}
-
-func scheduleFetcher() {
-	for {
-		// Calculate time until next midnight
-		now := time.Now()
-		nextMidnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
-		sleepDuration := nextMidnight.Sub(now)
 
-		fmt.Printf("Next schedule update scheduled for: %s (in %v)\n", nextMidnight.Format("2006-01-02 15:04:05"), sleepDuration)
+	<-c
+	fmt.Println("\n🛑 Shutting down server...")
 
-		// Sleep until midnight
-		time.Sleep(sleepDuration)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := lifecycle.StopAll(ctx); err != nil {
+		fmt.Printf("⚠️ Warning: Shutdown finished with errors: %v\n", err)
+	}
 
-		// Fetch new schedule
-		fmt.Printf("Fetching updated schedule for %s...\n", teamConfig.Code)
-		game, err := services.GetTeamSchedule(teamConfig.Code)
-		if err != nil {
-			fmt.Printf("Error fetching schedule: %v\n", err)
-		} else {
-			// Update cached schedule
-			cacheMu.Lock()
-			cachedSchedule = game
-			cachedScheduleUpdated = time.Now()
-			cacheMu.Unlock()
-			// Safe access with checks for empty team names
-			awayTeam := "Unknown"
-			homeTeam := "Unknown"
-			if game.AwayTeam.CommonName.Default != "" {
-				awayTeam = game.AwayTeam.CommonName.Default
-			}
-			if game.HomeTeam.CommonName.Default != "" {
-				homeTeam = game.HomeTeam.CommonName.Default
-			}
-			fmt.Printf("Schedule updated: %s vs %s on %s\n",
-				awayTeam, homeTeam, game.GameDate)
-
-			// Send update to channel only if we successfully fetched a valid game
-			select {
-			case scheduleChannel <- game:
-				// Successfully sent to channel
-			default:
-				// Channel is full, skip this update
-			}
-		}
+	fmt.Println("👋 Server shutdown complete")
+}
 
-		// Also fetch upcoming games if we're in hockey season
-		if currentSeasonStatus.IsHockeySeason {
-			fmt.Printf("Fetching updated upcoming games for %s...\n", teamConfig.Code)
-			upcomingGames, err := services.GetTeamUpcomingGames(teamConfig.Code)
-			if err != nil {
-				fmt.Printf("Error fetching upcoming games: %v\n", err)
-			} else {
-				// Update cached upcoming games
-				cacheMu.Lock()
-				cachedUpcomingGames = upcomingGames
-				cacheMu.Unlock()
-				fmt.Printf("Upcoming games updated: %d games found\n", len(upcomingGames))
-
-				// Send update to channel only if we successfully fetched games
-				select {
-				case upcomingGamesChannel <- upcomingGames:
-					// Successfully sent to channel
-				default:
-					// Channel is full, skip this update
-				}
-			}
-		}
+// refreshSchedule fetches the next game and, in season, the upcoming games
+// for the configured team and updates the cached copies
+func refreshSchedule(ctx context.Context) error {
+	fmt.Printf("Fetching updated schedule for %s...\n", teamConfig.Code)
+	game, err := services.GetTeamSchedule(teamConfig.Code)
+	if err != nil {
+		return fmt.Errorf("fetching schedule: %w", err)
 	}
-}
 
-func playerStatsFetcher() {
-	for {
-		// Sleep for 30 minutes during hockey season
-		sleepDuration := 30 * time.Minute
-		fmt.Printf("Next player stats update scheduled for: %s (in %v)\n",
-			time.Now().Add(sleepDuration).Format("2006-01-02 15:04:05"),
-			sleepDuration)
+	// Update cached schedule
+	cacheMu.Lock()
+	cachedSchedule = game
+	cachedScheduleUpdated = time.Now()
+	cacheMu.Unlock()
+	// Safe access with checks for empty team names
+	awayTeam := "Unknown"
+	homeTeam := "Unknown"
+	if game.AwayTeam.CommonName.Default != "" {
+		awayTeam = game.AwayTeam.CommonName.Default
+	}
+	if game.HomeTeam.CommonName.Default != "" {
+		homeTeam = game.HomeTeam.CommonName.Default
+	}
+	fmt.Printf("Schedule updated: %s vs %s on %s\n",
+		awayTeam, homeTeam, game.GameDate)
+
+	// Send update to channel only if we successfully fetched a valid game
+	select {
+	case scheduleChannel <- game:
+		// Successfully sent to channel
+	default:
+		// Channel is full, skip this update
+	}
 
-		time.Sleep(sleepDuration)
+	// Also fetch upcoming games if we're in hockey season
+	if !currentSeasonStatus.IsHockeySeason {
+		return nil
+	}
+	fmt.Printf("Fetching updated upcoming games for %s...\n", teamConfig.Code)
+	upcomingGames, err := services.GetTeamUpcomingGamesContext(ctx, teamConfig.Code)
+	if err != nil {
+		return fmt.Errorf("fetching upcoming games: %w", err)
+	}
 
-		// Fetch updated player stats
-		fmt.Println("Fetching updated player stats...")
-		playerLeaders, err := services.GetPlayerStatsLeaders()
-		if err != nil {
-			fmt.Printf("Error fetching player stats: %v\n", err)
-			continue
-		}
+	// Update cached upcoming games
+	cacheMu.Lock()
+	cachedUpcomingGames = upcomingGames
+	cacheMu.Unlock()
+	fmt.Printf("Upcoming games updated: %d games found\n", len(upcomingGames))
+
+	// Send update to channel only if we successfully fetched games
+	select {
+	case upcomingGamesChannel <- upcomingGames:
+		// Successfully sent to channel
+	default:
+		// Channel is full, skip this update
+	}
+	return nil
+}
 
-		// Update cached player stats
-		cacheMu.Lock()
-		cachedPlayerStats = playerLeaders
-		cachedTeamPlayerStats = services.GetTeamPlayerStats(playerLeaders, teamConfig.Code)
-		cacheMu.Unlock()
-		fmt.Printf("Player stats updated: %d goals, %d assists, %d points leaders\n",
-			len(playerLeaders.Goals), len(playerLeaders.Assists), len(playerLeaders.Points))
+// refreshPlayerStats fetches the league skater and goalie leaders and
+// updates the cached copies
+func refreshPlayerStats(ctx context.Context) error {
+	fmt.Println("Fetching updated player stats...")
+	playerLeaders, err := services.GetPlayerStatsLeaders()
+	if err != nil {
+		return fmt.Errorf("fetching player stats: %w", err)
+	}
 
-		// Fetch updated goalie stats
-		fmt.Println("Fetching updated goalie stats...")
-		goalieLeaders, err := services.GetGoalieStatsLeaders()
-		if err != nil {
-			fmt.Printf("Error fetching goalie stats: %v\n", err)
-			continue
-		}
+	// Update cached player stats
+	cacheMu.Lock()
+	cachedPlayerStats = playerLeaders
+	cachedTeamPlayerStats = services.GetTeamPlayerStats(playerLeaders, teamConfig.Code)
+	cacheMu.Unlock()
+	fmt.Printf("Player stats updated: %d goals, %d assists, %d points leaders\n",
+		len(playerLeaders.Goals), len(playerLeaders.Assists), len(playerLeaders.Points))
+
+	// Fetch updated goalie stats
+	fmt.Println("Fetching updated goalie stats...")
+	goalieLeaders, err := services.GetGoalieStatsLeaders()
+	if err != nil {
+		return fmt.Errorf("fetching goalie stats: %w", err)
+	}
 
-		// Update cached goalie stats
-		cacheMu.Lock()
-		cachedGoalieStats = goalieLeaders
-		cachedTeamGoalieStats = services.GetTeamGoalieStats(goalieLeaders, teamConfig.Code)
-		cacheMu.Unlock()
-		fmt.Printf("Goalie stats updated: %d wins, %d save%%, %d GAA leaders\n",
-			len(goalieLeaders.Wins), len(goalieLeaders.SavePct), len(goalieLeaders.GAA))
-
-		// Send updates to channels
-		select {
-		case playerStatsChannel <- playerLeaders:
-			// Successfully sent to channel
-		default:
-			// Channel is full, skip this update
-		}
+	// Update cached goalie stats
+	cacheMu.Lock()
+	cachedGoalieStats = goalieLeaders
+	cachedTeamGoalieStats = services.GetTeamGoalieStats(goalieLeaders, teamConfig.Code)
+	cacheMu.Unlock()
+	fmt.Printf("Goalie stats updated: %d wins, %d save%%, %d GAA leaders\n",
+		len(goalieLeaders.Wins), len(goalieLeaders.SavePct), len(goalieLeaders.GAA))
+
+	// Send updates to channels
+	select {
+	case playerStatsChannel <- playerLeaders:
+		// Successfully sent to channel
+	default:
+		// Channel is full, skip this update
+	}
 
-		select {
-		case goalieStatsChannel <- goalieLeaders:
-			// Successfully sent to channel
-		default:
-			// Channel is full, skip this update
-		}
+	select {
+	case goalieStatsChannel <- goalieLeaders:
+		// Successfully sent to channel
+	default:
+		// Channel is full, skip this update
 	}
+	return ctx.Err()
 }
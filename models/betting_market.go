@@ -63,6 +63,10 @@ type MarketConsensus struct {
 	AvgTotalLine     float64 `json:"avgTotalLine"`
 	AvgHomeSpread    float64 `json:"avgHomeSpread"`
 
+	// Median bookmaker prices (averaging American odds doesn't give a price)
+	MedianHomeMoneyline int `json:"medianHomeMoneyline"`
+	MedianAwayMoneyline int `json:"medianAwayMoneyline"`
+
 	// Consensus Probabilities
 	ConsensusHomeWinPct float64 `json:"consensusHomeWinPct"`
 	ConsensusAwayWinPct float64 `json:"consensusAwayWinPct"`
@@ -138,6 +142,9 @@ type BettingMarketHistory struct {
 	HomeTeam string `json:"homeTeam"`
 	AwayTeam string `json:"awayTeam"`
 
+	// Scheduled puck drop as reported by the odds feed
+	CommenceTime time.Time `json:"commenceTime"`
+
 	// Historical Data Points
 	DataPoints []MarketDataPoint `json:"dataPoints"`
 
@@ -161,6 +168,11 @@ type MarketDataPoint struct {
 	TotalLine     float64   `json:"totalLine"`
 	HomeBetPct    float64   `json:"homeBetPct"`
 	HomeMoneyPct  float64   `json:"homeMoneyPct"`
+
+	// Moneylines are the median bookmaker prices; the implied probabilities
+	// are the vig-free bookmaker consensus
+	ImpliedHomeWinPct float64 `json:"impliedHomeWinPct,omitempty"`
+	ImpliedAwayWinPct float64 `json:"impliedAwayWinPct,omitempty"`
 }
 
 // KeyMarketMovement represents significant line moves
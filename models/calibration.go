@@ -46,6 +46,18 @@ type RecalibrationEvent struct {
 	PerformanceSnapshot map[string]float64 `json:"performanceSnapshot"` // Accuracy before change
 	Reasoning      string             `json:"reasoning"`      // Human-readable explanation
 	PredictionCount int               `json:"predictionCount"` // Total predictions so far
+	Validation     []WeightValidationFold `json:"validation,omitempty"` // Walk-forward check of the new weights
+}
+
+// WeightValidationFold is one walk-forward check of candidate ensemble weights:
+// candidates are fit on the training window and scored on the games after it
+type WeightValidationFold struct {
+	Fold           int                `json:"fold"`
+	TrainSize      int                `json:"trainSize"`
+	TestSize       int                `json:"testSize"`
+	CurrentBrier   float64            `json:"currentBrier"`   // Existing weights on the test window
+	CandidateBrier float64            `json:"candidateBrier"` // Weights fit on the training window
+	ModelBrier     map[string]float64 `json:"modelBrier"`     // Each model alone on the test window
 }
 
 // RecalibrationHistory stores the history of weight adjustments
@@ -0,0 +1,106 @@
+package models
+
+import "time"
+
+// ClosingLineRecord captures market lines and our model's view for a single settled game
+type ClosingLineRecord struct {
+	GameID   int       `json:"gameId"`
+	GameDate time.Time `json:"gameDate"`
+	HomeTeam string    `json:"homeTeam"`
+	AwayTeam string    `json:"awayTeam"`
+
+	// Our Model
+	ModelHomeWinProb float64   `json:"modelHomeWinProb"` // Ensemble home win probability
+	PredictedAt      time.Time `json:"predictedAt"`
+
+	// Opening Line (first observed market data point)
+	OpeningHomeML      int       `json:"openingHomeML"`
+	OpeningAwayML      int       `json:"openingAwayML"`
+	OpeningHomeImplied float64   `json:"openingHomeImplied"` // Vig-free implied probability
+	OpeningAwayImplied float64   `json:"openingAwayImplied"`
+	OpeningTimestamp   time.Time `json:"openingTimestamp"`
+
+	// Closing Line (last observed market data point before puck drop)
+	ClosingHomeML      int       `json:"closingHomeML"`
+	ClosingAwayML      int       `json:"closingAwayML"`
+	ClosingHomeImplied float64   `json:"closingHomeImplied"` // Vig-free implied probability
+	ClosingAwayImplied float64   `json:"closingAwayImplied"`
+	ClosingTimestamp   time.Time `json:"closingTimestamp"`
+	MarketDataPoints   int       `json:"marketDataPoints"`
+
+	// Closing Line Value
+	ModelSide string  `json:"modelSide"` // "home", "away" or "none" - side our model favored vs the opening line
+	ModelEdge float64 `json:"modelEdge"` // Model probability minus opening implied probability for ModelSide
+	CLV       float64 `json:"clv"`       // Closing implied minus opening implied for ModelSide (positive = beat the close)
+
+	// Outcome & Scoring
+	HomeWon       bool    `json:"homeWon"`
+	ModelLogLoss  float64 `json:"modelLogLoss"`
+	MarketLogLoss float64 `json:"marketLogLoss"` // Log loss of the closing line
+	ModelBrier    float64 `json:"modelBrier"`
+	MarketBrier   float64 `json:"marketBrier"`
+
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// KellyBet represents a single simulated Kelly wager
+type KellyBet struct {
+	GameID        int       `json:"gameId"`
+	GameDate      time.Time `json:"gameDate"`
+	Matchup       string    `json:"matchup"`
+	Side          string    `json:"side"`          // "home" or "away"
+	Odds          int       `json:"odds"`          // American odds taken (opening line)
+	KellyFraction float64   `json:"kellyFraction"` // Fraction of bankroll staked
+	Stake         float64   `json:"stake"`
+	Profit        float64   `json:"profit"`
+	Won           bool      `json:"won"`
+	BankrollAfter float64   `json:"bankrollAfter"`
+}
+
+// BankrollPoint is a point on the simulated bankroll curve
+type BankrollPoint struct {
+	Date     time.Time `json:"date"`
+	Bankroll float64   `json:"bankroll"`
+}
+
+// KellySimulation summarizes a season of simulated Kelly wagering
+type KellySimulation struct {
+	StartingBankroll float64         `json:"startingBankroll"`
+	FinalBankroll    float64         `json:"finalBankroll"`
+	KellyMultiplier  float64         `json:"kellyMultiplier"` // e.g. 0.25 = quarter Kelly
+	MaxStakePct      float64         `json:"maxStakePct"`
+	MinEdge          float64         `json:"minEdge"`
+	BetsPlaced       int             `json:"betsPlaced"`
+	BetsWon          int             `json:"betsWon"`
+	TotalStaked      float64         `json:"totalStaked"`
+	TotalProfit      float64         `json:"totalProfit"`
+	ROI              float64         `json:"roi"` // Profit / total staked
+	MaxDrawdown      float64         `json:"maxDrawdown"`
+	BankrollHistory  []BankrollPoint `json:"bankrollHistory"`
+	Bets             []KellyBet      `json:"bets"`
+}
+
+// ClosingLineReport aggregates closing line value and market comparison metrics
+type ClosingLineReport struct {
+	GamesTracked int `json:"gamesTracked"`
+
+	// Closing Line Value
+	AvgCLV          float64 `json:"avgClv"`
+	PositiveCLVRate float64 `json:"positiveClvRate"` // Fraction of games where we beat the close
+	GamesWithLean   int     `json:"gamesWithLean"`   // Games where the model disagreed with the opening line
+
+	// Model vs Market
+	ModelLogLoss     float64 `json:"modelLogLoss"`
+	MarketLogLoss    float64 `json:"marketLogLoss"`
+	ModelBrier       float64 `json:"modelBrier"`
+	MarketBrier      float64 `json:"marketBrier"`
+	ModelAccuracy    float64 `json:"modelAccuracy"`
+	MarketAccuracy   float64 `json:"marketAccuracy"`
+	BeatsMarket      bool    `json:"beatsMarket"`      // Model log loss lower than closing line log loss
+	LogLossAdvantage float64 `json:"logLossAdvantage"` // Market log loss minus model log loss
+
+	Kelly KellySimulation `json:"kelly"`
+
+	RecentRecords []ClosingLineRecord `json:"recentRecords"`
+	GeneratedAt   time.Time           `json:"generatedAt"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// FeatureSnapshot is the fully enriched factor set the ensemble used for a game
+type FeatureSnapshot struct {
+	GameID      int               `json:"gameId"`
+	HomeTeam    string            `json:"homeTeam"`
+	AwayTeam    string            `json:"awayTeam"`
+	HomeFactors PredictionFactors `json:"homeFactors"`
+	AwayFactors PredictionFactors `json:"awayFactors"`
+	Source      string            `json:"source"` // "prediction" or "reconstructed"
+	CapturedAt  time.Time         `json:"capturedAt"`
+}
+
+// TrainingExample pairs a completed game with the factors known before puck drop
+type TrainingExample struct {
+	Game        CompletedGame
+	HomeFactors *PredictionFactors
+	AwayFactors *PredictionFactors
+	Source      string // "prediction" or "reconstructed"
+}
+
+// TrainingSetSummary describes how a training set was assembled
+type TrainingSetSummary struct {
+	Games         int `json:"games"`
+	FromSnapshots int `json:"fromSnapshots"`
+	Reconstructed int `json:"reconstructed"`
+	Skipped       int `json:"skipped"`  // No prior games to reconstruct from
+	Excluded      int `json:"excluded"` // Reconstructed games left out once enough snapshots exist
+}
@@ -20,6 +20,7 @@ type GoalieInfo struct {
 
 	// Season Stats
 	SeasonGamesPlayed    int     `json:"seasonGamesPlayed"`
+	SeasonGamesStarted   int     `json:"seasonGamesStarted"`
 	SeasonWins           int     `json:"seasonWins"`
 	SeasonLosses         int     `json:"seasonLosses"`
 	SeasonOTLosses       int     `json:"seasonOTLosses"`
@@ -108,6 +109,66 @@ type GoalieComparison struct {
 	// Impact on Game
 	WinProbabilityImpact float64 `json:"winProbabilityImpact"` // Adjust win % by this much
 	Confidence           float64 `json:"confidence"`           // 0.0-1.0
+
+	// Starter Probabilities (factors above are blended across likely starters)
+	HomeStarter *GoalieStarterEstimate `json:"homeStarter,omitempty"`
+	AwayStarter *GoalieStarterEstimate `json:"awayStarter,omitempty"`
+}
+
+// GoalieStarterCandidate is one goalie's estimated chance of starting a game
+type GoalieStarterCandidate struct {
+	PlayerID         int      `json:"playerId"`
+	Name             string   `json:"name"`
+	Probability      float64  `json:"probability"`
+	IsDesignated     bool     `json:"isDesignated"`     // Team's No. 1 goalie
+	RestDays         int      `json:"restDays"`         // Days since last start (-1 = unknown)
+	StartsLast7Days  int      `json:"startsLast7Days"`
+	SeasonStartShare float64  `json:"seasonStartShare"` // Share of the tandem's starts this season
+	Factors          []string `json:"factors,omitempty"`
+}
+
+// GoalieStarterEstimate is the starter probability distribution for one team in one game
+type GoalieStarterEstimate struct {
+	TeamCode     string                   `json:"teamCode"`
+	Opponent     string                   `json:"opponent"`
+	GameDate     time.Time                `json:"gameDate"`
+	Source       string                   `json:"source"` // "confirmed", "team_news" or "model"
+	Confirmed    bool                     `json:"confirmed"`
+	IsBackToBack bool                     `json:"isBackToBack"`
+	Candidates   []GoalieStarterCandidate `json:"candidates"`
+}
+
+// MostLikely returns the candidate with the highest starting probability
+func (e *GoalieStarterEstimate) MostLikely() *GoalieStarterCandidate {
+	var best *GoalieStarterCandidate
+	for i := range e.Candidates {
+		if best == nil || e.Candidates[i].Probability > best.Probability {
+			best = &e.Candidates[i]
+		}
+	}
+	return best
+}
+
+// GoalieGameLogResponse represents the NHL API goalie game log
+type GoalieGameLogResponse struct {
+	SeasonID   int                  `json:"seasonId"`
+	GameTypeID int                  `json:"gameTypeId"`
+	GameLog    []GoalieGameLogEntry `json:"gameLog"`
+}
+
+// GoalieGameLogEntry is a single game from the NHL API goalie game log
+type GoalieGameLogEntry struct {
+	GameID         int     `json:"gameId"`
+	TeamAbbrev     string  `json:"teamAbbrev"`
+	HomeRoadFlag   string  `json:"homeRoadFlag"` // "H" or "R"
+	GameDate       string  `json:"gameDate"`     // "2006-01-02"
+	OpponentAbbrev string  `json:"opponentAbbrev"`
+	GamesStarted   int     `json:"gamesStarted"`
+	Decision       string  `json:"decision"` // "W", "L", "O"
+	ShotsAgainst   int     `json:"shotsAgainst"`
+	GoalsAgainst   int     `json:"goalsAgainst"`
+	SavePctg       float64 `json:"savePctg"`
+	TOI            string  `json:"toi"` // "MM:SS"
 }
 
 // GoalieDepth represents team's goalie situation
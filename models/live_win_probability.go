@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// WinProbabilityPoint is a single point on a game's live win probability timeline
+type WinProbabilityPoint struct {
+	EventID          int       `json:"eventId"`
+	Timestamp        time.Time `json:"timestamp"`
+	Period           int       `json:"period"`
+	PeriodType       string    `json:"periodType"`       // "REG", "OT", "SO"
+	SecondsRemaining int       `json:"secondsRemaining"` // Seconds left in the period
+	GameSecondsLeft  int       `json:"gameSecondsLeft"`  // Seconds left in regulation (0 once in OT)
+	HomeScore        int       `json:"homeScore"`
+	AwayScore        int       `json:"awayScore"`
+	StrengthState    string    `json:"strengthState"` // e.g. "5v5", "5v4", "6v5" (home v away)
+	HomeWinProb      float64   `json:"homeWinProb"`
+	EventType        string    `json:"eventType"` // "goal", "penalty", "period-start", "clock", ...
+	Description      string    `json:"description"`
+}
+
+// LiveWinProbability holds the in-game win probability state and timeline for a game
+type LiveWinProbability struct {
+	GameID   int    `json:"gameId"`
+	HomeTeam string `json:"homeTeam"`
+	AwayTeam string `json:"awayTeam"`
+	GameType int    `json:"gameType"` // 2=regular season, 3=playoffs
+
+	GameState          string  `json:"gameState"`
+	PregameHomeWinProb float64 `json:"pregameHomeWinProb"` // Prior from the stored GamePrediction
+	PriorSource        string  `json:"priorSource"`        // "prediction" or "league_average"
+
+	CurrentHomeWinProb float64 `json:"currentHomeWinProb"`
+	HomeScore          int     `json:"homeScore"`
+	AwayScore          int     `json:"awayScore"`
+	Period             int     `json:"period"`
+	TimeRemaining      string  `json:"timeRemaining"`
+	StrengthState      string  `json:"strengthState"`
+
+	// Scoring rates (goals per 60 minutes at even strength) implied by the prior
+	HomeGoalRate float64 `json:"homeGoalRate"`
+	AwayGoalRate float64 `json:"awayGoalRate"`
+
+	Timeline    []WinProbabilityPoint `json:"timeline"`
+	LastEventID int                   `json:"lastEventId"`
+	IsFinal     bool                  `json:"isFinal"`
+	LastUpdated time.Time             `json:"lastUpdated"`
+}
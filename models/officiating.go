@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// OfficialsAssignment lists the on-ice officials assigned to a game
+type OfficialsAssignment struct {
+	GameID    int       `json:"gameId"`
+	GameDate  time.Time `json:"gameDate,omitempty"`
+	HomeTeam  string    `json:"homeTeam,omitempty"`
+	AwayTeam  string    `json:"awayTeam,omitempty"`
+	Referees  []string  `json:"referees"`
+	Linesmen  []string  `json:"linesmen"`
+	Source    string    `json:"source"` // "gamecenter" or "file"
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// GameOfficiatingRecord combines a game's officials with its penalty outcomes
+type GameOfficiatingRecord struct {
+	GameID   int       `json:"gameId"`
+	GameDate time.Time `json:"gameDate"`
+	HomeTeam string    `json:"homeTeam"`
+	AwayTeam string    `json:"awayTeam"`
+	Referees []string  `json:"referees"`
+	Linesmen []string  `json:"linesmen"`
+
+	HomePenalties       int `json:"homePenalties"`
+	AwayPenalties       int `json:"awayPenalties"`
+	HomePIM             int `json:"homePim"`
+	AwayPIM             int `json:"awayPim"`
+	HomePPOpportunities int `json:"homePpOpportunities"` // Power plays awarded to the home team
+	AwayPPOpportunities int `json:"awayPpOpportunities"`
+	TotalGoals          int `json:"totalGoals"`
+
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// OfficialProfile summarizes an official's tendencies over the games they worked
+type OfficialProfile struct {
+	Name        string    `json:"name"`
+	Role        string    `json:"role"` // "referee" or "linesman"
+	GamesWorked int       `json:"gamesWorked"`
+	LastWorked  time.Time `json:"lastWorked"`
+
+	PenaltiesPerGame       float64 `json:"penaltiesPerGame"` // Both teams combined
+	HomePenaltiesPerGame   float64 `json:"homePenaltiesPerGame"`
+	AwayPenaltiesPerGame   float64 `json:"awayPenaltiesPerGame"`
+	PPOpportunitiesPerGame float64 `json:"ppOpportunitiesPerGame"` // Both teams combined
+	HomePPDifferential     float64 `json:"homePpDifferential"`     // Home PP minus away PP per game
+	GoalsPerGame           float64 `json:"goalsPerGame"`
+
+	// Shrunk towards league average, used for predictions
+	PenaltyRateIndex float64 `json:"penaltyRateIndex"` // 1.0 = league average
+	HomeBias         float64 `json:"homeBias"`         // Extra home PP per game vs league (positive favors home)
+}
+
+// OfficiatingFactor is the officiating adjustment for a single game
+type OfficiatingFactor struct {
+	GameID       int      `json:"gameId"`
+	Referees     []string `json:"referees"`
+	Linesmen     []string `json:"linesmen"`
+	CrewKnown    bool     `json:"crewKnown"`
+	GamesSampled int      `json:"gamesSampled"` // Games behind the referees' profiles
+
+	PenaltyRateMultiplier   float64 `json:"penaltyRateMultiplier"` // 1.0 = league average
+	HomeBias                float64 `json:"homeBias"`              // Extra home PP per game vs league
+	ExpectedHomePPOpps      float64 `json:"expectedHomePpOpps"`
+	ExpectedAwayPPOpps      float64 `json:"expectedAwayPpOpps"`
+	ExpectedTotalGoalsDelta float64 `json:"expectedTotalGoalsDelta"` // Crew goals/game vs league
+	Confidence              float64 `json:"confidence"`              // 0.0-1.0
+}
+
+// OfficiatingSummary is the league-wide officiating overview
+type OfficiatingSummary struct {
+	GamesRecorded          int               `json:"gamesRecorded"`
+	AssignmentsKnown       int               `json:"assignmentsKnown"`
+	LeaguePenaltiesPerGame float64           `json:"leaguePenaltiesPerGame"`
+	LeaguePPPerGame        float64           `json:"leaguePpPerGame"`
+	LeagueHomePPEdge       float64           `json:"leagueHomePpEdge"` // Home PP minus away PP per game
+	LeagueGoalsPerGame     float64           `json:"leagueGoalsPerGame"`
+	Referees               []OfficialProfile `json:"referees"`
+	Linesmen               []OfficialProfile `json:"linesmen"`
+	LastUpdated            time.Time         `json:"lastUpdated"`
+}
@@ -10,12 +10,22 @@ type PlayByPlayResponse struct {
 	GameDate     string       `json:"gameDate"`
 	Venue        VenueInfo    `json:"venue"`
 	StartTimeUTC string       `json:"startTimeUTC"`
+	GameState    string       `json:"gameState"` // "FUT", "PRE", "LIVE", "CRIT", "FINAL", "OFF"
+	Clock        GameClock    `json:"clock"`
 	Plays        []PlayEvent  `json:"plays"`
 	RosterSpots  []RosterSpot `json:"rosterSpots"`
 	HomeTeam     BoxscoreTeam `json:"homeTeam"`
 	AwayTeam     BoxscoreTeam `json:"awayTeam"`
 }
 
+// GameClock represents the live game clock in the play-by-play response
+type GameClock struct {
+	TimeRemaining    string `json:"timeRemaining"`
+	SecondsRemaining int    `json:"secondsRemaining"`
+	Running          bool   `json:"running"`
+	InIntermission   bool   `json:"inIntermission"`
+}
+
 // PlayEvent represents a single event in the game
 type PlayEvent struct {
 	EventID           int              `json:"eventId"`
@@ -50,6 +60,8 @@ type PlayDetails struct {
 	CommittedByPlayerID int    `json:"committedByPlayerId,omitempty"` // Penalty
 	WinningPlayerID     int    `json:"winningPlayerId,omitempty"`     // Faceoff winner
 	LosingPlayerID      int    `json:"losingPlayerId,omitempty"`      // Faceoff loser
+	HomeScore           int    `json:"homeScore,omitempty"`           // Score after a goal
+	AwayScore           int    `json:"awayScore,omitempty"`           // Score after a goal
 }
 
 // ============================================================================
@@ -125,8 +137,9 @@ type TeamPlayAnalytics struct {
 	PossessionRatio float64 `json:"possessionRatio"` // Takeaways / (Takeaways + Giveaways)
 
 	// Penalties
-	PenaltiesTaken   int `json:"penaltiesTaken"`
-	PenaltyMinutes   int `json:"penaltyMinutes"`
+	PenaltiesTaken     int `json:"penaltiesTaken"`
+	PenaltyMinutes     int `json:"penaltyMinutes"`
+	PowerPlayPenalties int `json:"powerPlayPenalties"` // Minors/majors that gave the opponent a power play
 	PowerPlayShots   int `json:"powerPlayShots"`
 	ShortHandedShots int `json:"shortHandedShots"`
 
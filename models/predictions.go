@@ -26,26 +26,60 @@ type PredictionTeam struct {
 
 // PredictionResult holds the main prediction outcome
 type PredictionResult struct {
-	Winner         string             `json:"winner"`         // Team code of predicted winner
-	WinProbability float64            `json:"winProbability"` // 0.0 to 1.0
-	PredictedScore string             `json:"predictedScore"` // e.g., "4-2"
-	IsUpset        bool               `json:"isUpset"`        // True if underdog predicted to win
-	GameType       string             `json:"gameType"`       // "blowout", "close", "toss-up"
-	ModelResults   []ModelResult      `json:"modelResults"`   // Results from individual models
-	EnsembleMethod string             `json:"ensembleMethod"` // How models were combined
-	Confidence     float64            `json:"confidence"`     // Overall ensemble confidence (calibrated in Phase 3)
-	Quality        *PredictionQuality `json:"quality,omitempty"` // Phase 3: Prediction quality assessment
-	Context        *GameContext       `json:"context,omitempty"` // Phase 3: Game context used for prediction
+	Winner         string                 `json:"winner"`                // Team code of predicted winner
+	WinProbability float64                `json:"winProbability"`        // 0.0 to 1.0
+	PredictedScore string                 `json:"predictedScore"`        // e.g., "4-2"
+	IsUpset        bool                   `json:"isUpset"`               // True if underdog predicted to win
+	GameType       string                 `json:"gameType"`              // "blowout", "close", "toss-up"
+	ModelResults   []ModelResult          `json:"modelResults"`          // Results from individual models
+	EnsembleMethod string                 `json:"ensembleMethod"`        // How models were combined
+	Confidence     float64                `json:"confidence"`            // Overall ensemble confidence (calibrated in Phase 3)
+	Quality        *PredictionQuality     `json:"quality,omitempty"`     // Phase 3: Prediction quality assessment
+	Context        *GameContext           `json:"context,omitempty"`     // Phase 3: Game context used for prediction
+	Explanation    *PredictionExplanation `json:"explanation,omitempty"` // Ensemble-weighted feature attribution
 }
 
 // ModelResult represents prediction from a single model
 type ModelResult struct {
-	ModelName      string  `json:"modelName"`      // e.g., "Statistical", "Bayesian", "Monte Carlo"
-	WinProbability float64 `json:"winProbability"` // This model's win probability
-	Confidence     float64 `json:"confidence"`     // This model's confidence
-	PredictedScore string  `json:"predictedScore"` // This model's score prediction
-	Weight         float64 `json:"weight"`         // Weight in ensemble (0-1)
-	ProcessingTime int64   `json:"processingTime"` // Time taken in milliseconds
+	ModelName      string                  `json:"modelName"`              // e.g., "Statistical", "Bayesian", "Monte Carlo"
+	WinProbability float64                 `json:"winProbability"`         // This model's win probability
+	Confidence     float64                 `json:"confidence"`             // This model's confidence
+	PredictedScore string                  `json:"predictedScore"`         // This model's score prediction
+	Weight         float64                 `json:"weight"`                 // Weight in ensemble (0-1)
+	ProcessingTime int64                   `json:"processingTime"`         // Time taken in milliseconds
+	Distribution   *PredictiveDistribution `json:"distribution,omitempty"` // Full predictive distribution, for models that have one
+	Explanation    *PredictionExplanation  `json:"explanation,omitempty"`  // Per-feature attribution, for models that support it
+}
+
+// PredictionExplanation attributes a home win probability to model inputs
+type PredictionExplanation struct {
+	Method          string                `json:"method"`                    // "tree_shap", "integrated_gradients", "occlusion", "shapley", "weighted"
+	BaseValue       float64               `json:"baseValue"`                 // Home win probability with every input at its baseline
+	Prediction      float64               `json:"prediction"`                // BaseValue plus every contribution
+	Contributions   []FeatureContribution `json:"contributions"`             // Largest first; the tail is folded into "Other features"
+	Models          []string              `json:"models,omitempty"`          // Ensemble explanations: models that were explained
+	ExplainedWeight float64               `json:"explainedWeight,omitempty"` // Ensemble explanations: share of ensemble weight covered
+}
+
+// FeatureContribution is one input's share of a prediction's departure from its baseline
+type FeatureContribution struct {
+	Feature      string  `json:"feature"`
+	Value        float64 `json:"value,omitempty"` // Input value as the model saw it
+	Contribution float64 `json:"contribution"`    // Home win probability points
+}
+
+// PredictiveDistribution describes a model's predictive distribution for one
+// game, integrating over its uncertainty in team strength
+type PredictiveDistribution struct {
+	HomeWinProbability float64 `json:"homeWinProbability"`
+	HomeWinStdDev      float64 `json:"homeWinStdDev"` // Spread of P(home win) from rating uncertainty
+	HomeWinLower       float64 `json:"homeWinLower"`  // 90% interval
+	HomeWinUpper       float64 `json:"homeWinUpper"`
+	TieProbability     float64 `json:"tieProbability"` // Level after regulation
+	HomeGoalsMean      float64 `json:"homeGoalsMean"`
+	HomeGoalsVariance  float64 `json:"homeGoalsVariance"`
+	AwayGoalsMean      float64 `json:"awayGoalsMean"`
+	AwayGoalsVariance  float64 `json:"awayGoalsVariance"`
 }
 
 // PredictionFactors holds data used for predictions with advanced analytics integration
@@ -147,6 +181,12 @@ type PredictionFactors struct {
 	MatchupHistoryImpact   float64 `json:"matchupHistoryImpact"`   // -0.05 to +0.05
 	OpponentSpecificAdjust float64 `json:"opponentSpecificAdjust"` // Combined adjustment (-0.15 to +0.15)
 
+	// Officiating (assigned referees' tendencies)
+	RefereeHomeAdvantage    float64 `json:"refereeHomeAdvantage"`    // Extra PP per game this crew gives this team's side vs league
+	RefereePenaltyRate      float64 `json:"refereePenaltyRate"`      // Crew penalty rate (1.0 = league average, 0 = unknown)
+	ExpectedPPOpportunities float64 `json:"expectedPPOpportunities"` // Expected power plays for this team (0 = unknown)
+	RefereeGoalsDelta       float64 `json:"refereeGoalsDelta"`       // This team's share of the crew's goals/game vs league
+
 	// ============================================================================
 	// PHASE 6: FEATURE ENGINEERING (+40 features)
 	// ============================================================================
@@ -64,6 +64,18 @@ type TeamStanding struct {
 	WildcardSequence      int    `json:"wildcardSequence"`
 }
 
+// StandingsSeasonsResponse lists every season the standings endpoint covers
+type StandingsSeasonsResponse struct {
+	Seasons []StandingsSeason `json:"seasons"`
+}
+
+// StandingsSeason is the date range a season's standings cover
+type StandingsSeason struct {
+	ID             int    `json:"id"`
+	StandingsStart string `json:"standingsStart"` // YYYY-MM-DD
+	StandingsEnd   string `json:"standingsEnd"`   // YYYY-MM-DD
+}
+
 type TeamNameInfo struct {
 	Default string `json:"default"`
 	Fr      string `json:"fr,omitempty"`
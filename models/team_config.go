@@ -429,6 +429,29 @@ func GetTeamConfigByCode(code string) TeamConfig {
 	return GetDefaultTeamConfig()
 }
 
+// historicalTeamCodes are abbreviations of relocated or renamed franchises
+// that appear in past seasons' NHL API data
+var historicalTeamCodes = []string{
+	"ARI", // Arizona Coyotes, through 2023-24
+	"PHX", // Phoenix Coyotes, through 2013-14
+	"ATL", // Atlanta Thrashers, through 2010-11
+	"HFD", // Hartford Whalers, through 1996-97
+	"QUE", // Quebec Nordiques, through 1994-95
+	"WIN", // Winnipeg Jets (1979), through 1995-96
+	"MNS", // Minnesota North Stars, through 1992-93
+}
+
+// IsHistoricalTeamCode checks if a team code belongs to a franchise that
+// has since relocated or been renamed
+func IsHistoricalTeamCode(code string) bool {
+	for _, historicalCode := range historicalTeamCodes {
+		if code == historicalCode {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValidTeamCode checks if a team code is supported
 func IsValidTeamCode(code string) bool {
 	validCodes := []string{
@@ -0,0 +1,102 @@
+// Package nhlapi is a typed client for the NHL web API (api-web.nhle.com).
+//
+// It owns the endpoint URLs and decodes every response into the shared
+// models types, so when the upstream format changes there is one place to
+// adapt. Transport (rate limiting, caching, retries) is left to the Fetcher
+// the client is built with; the services package supplies one backed by
+// MakeAPICallContext.
+package nhlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefaultBaseURL is the NHL web API root every endpoint path is joined to
+const DefaultBaseURL = "https://api-web.nhle.com/v1"
+
+// Game types used by season-scoped endpoints
+const (
+	GameTypePreseason     = 1
+	GameTypeRegularSeason = 2
+	GameTypePlayoffs      = 3
+)
+
+// Fetcher returns the raw body of a GET request for url
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// FetcherFunc adapts a plain function to a Fetcher
+type FetcherFunc func(ctx context.Context, url string) ([]byte, error)
+
+// Fetch calls f(ctx, url)
+func (f FetcherFunc) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
+// Client fetches NHL API endpoints and decodes them into models types
+type Client struct {
+	baseURL string
+	fetcher Fetcher
+}
+
+// NewClient creates a client that fetches through fetcher
+func NewClient(fetcher Fetcher) *Client {
+	return &Client{baseURL: DefaultBaseURL, fetcher: fetcher}
+}
+
+// WithBaseURL returns a copy of the client rooted at baseURL
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	clone := *c
+	clone.baseURL = strings.TrimRight(baseURL, "/")
+	return &clone
+}
+
+// URL returns the full URL for an endpoint path such as "standings/now"
+func (c *Client) URL(path string) string {
+	return c.baseURL + "/" + strings.TrimLeft(path, "/")
+}
+
+// FetchError is returned when the fetcher fails for an endpoint
+type FetchError struct {
+	Endpoint string
+	URL      string
+	Err      error
+}
+
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("nhlapi: fetching %s: %v", e.Endpoint, e.Err)
+}
+
+func (e *FetchError) Unwrap() error { return e.Err }
+
+// DecodeError is returned when a response doesn't match the expected
+// shape, which usually means the upstream format changed
+type DecodeError struct {
+	Endpoint string
+	URL      string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("nhlapi: decoding %s: %v", e.Endpoint, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// get fetches path and decodes the body into out. endpoint names the
+// endpoint in errors (e.g. "play-by-play").
+func (c *Client) get(ctx context.Context, endpoint, path string, out interface{}) error {
+	url := c.URL(path)
+	body, err := c.fetcher.Fetch(ctx, url)
+	if err != nil {
+		return &FetchError{Endpoint: endpoint, URL: url, Err: err}
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return &DecodeError{Endpoint: endpoint, URL: url, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package nhlapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Run `go test ./nhlapi -update` after changing a model or fixture to
+// rewrite the golden files, then review the diff.
+var update = flag.Bool("update", false, "rewrite testdata/*.golden.json")
+
+// fixtureFetcher serves testdata/<fixture> for exactly one URL
+func fixtureFetcher(t *testing.T, wantURL, fixture string) Fetcher {
+	t.Helper()
+	return FetcherFunc(func(ctx context.Context, url string) ([]byte, error) {
+		if url != wantURL {
+			return nil, fmt.Errorf("unexpected URL %s, want %s", url, wantURL)
+		}
+		return os.ReadFile(filepath.Join("testdata", fixture+".json"))
+	})
+}
+
+// ================================================================================================
+// GOLDEN FILE TESTS
+// ================================================================================================
+
+func TestClientGolden(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		fixture string
+		url     string
+		call    func(c *Client) (interface{}, error)
+	}{
+		{"club_schedule_week", DefaultBaseURL + "/club-schedule/UTA/week/now",
+			func(c *Client) (interface{}, error) { return c.WeekSchedule(ctx, "UTA") }},
+		{"club_schedule_season", DefaultBaseURL + "/club-schedule-season/UTA/20252026",
+			func(c *Client) (interface{}, error) { return c.SeasonSchedule(ctx, "UTA", 20252026) }},
+		{"scoreboard", DefaultBaseURL + "/scoreboard/UTA/now",
+			func(c *Client) (interface{}, error) { return c.Scoreboard(ctx, "UTA") }},
+		{"scoreboard_league", DefaultBaseURL + "/scoreboard/now",
+			func(c *Client) (interface{}, error) { return c.LeagueScoreboard(ctx) }},
+		{"scoreboard_date", DefaultBaseURL + "/scoreboard/2025-10-11",
+			func(c *Client) (interface{}, error) {
+				return c.LeagueScoreboardForDate(ctx, time.Date(2025, 10, 11, 0, 0, 0, 0, time.UTC))
+			}},
+		{"standings", DefaultBaseURL + "/standings/now",
+			func(c *Client) (interface{}, error) { return c.Standings(ctx) }},
+		{"standings_date", DefaultBaseURL + "/standings/2024-04-18",
+			func(c *Client) (interface{}, error) {
+				return c.StandingsForDate(ctx, time.Date(2024, 4, 18, 0, 0, 0, 0, time.UTC))
+			}},
+		{"standings_season", DefaultBaseURL + "/standings-season",
+			func(c *Client) (interface{}, error) { return c.StandingsSeasons(ctx) }},
+		{"club_stats", DefaultBaseURL + "/club-stats/UTA/20252026/2",
+			func(c *Client) (interface{}, error) {
+				return c.ClubStatsForSeason(ctx, "UTA", 20252026, GameTypeRegularSeason)
+			}},
+		{"roster", DefaultBaseURL + "/roster/UTA/current",
+			func(c *Client) (interface{}, error) { return c.Roster(ctx, "UTA") }},
+		{"player_game_log", DefaultBaseURL + "/player/8478402/game-log/20242025/2",
+			func(c *Client) (interface{}, error) {
+				return c.PlayerGameLog(ctx, 8478402, 20242025, GameTypeRegularSeason)
+			}},
+		{"goalie_game_log", DefaultBaseURL + "/player/8480045/game-log/now",
+			func(c *Client) (interface{}, error) { return c.GoalieGameLog(ctx, 8480045) }},
+		{"landing", DefaultBaseURL + "/gamecenter/2025020018/landing",
+			func(c *Client) (interface{}, error) { return c.Landing(ctx, 2025020018) }},
+		{"boxscore", DefaultBaseURL + "/gamecenter/2025020018/boxscore",
+			func(c *Client) (interface{}, error) { return c.Boxscore(ctx, 2025020018) }},
+		{"boxscore_lineup", DefaultBaseURL + "/gamecenter/2025020031/boxscore",
+			func(c *Client) (interface{}, error) { return c.BoxscoreLineup(ctx, 2025020031) }},
+		{"play_by_play", DefaultBaseURL + "/gamecenter/2025020018/play-by-play",
+			func(c *Client) (interface{}, error) { return c.PlayByPlay(ctx, 2025020018) }},
+		{"shifts", DefaultBaseURL + "/gamecenter/2025020018/shifts",
+			func(c *Client) (interface{}, error) { return c.Shifts(ctx, 2025020018) }},
+		{"summary", DefaultBaseURL + "/gamecenter/2025020018/summary",
+			func(c *Client) (interface{}, error) { return c.Summary(ctx, 2025020018) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			client := NewClient(fixtureFetcher(t, tt.url, tt.fixture))
+			resp, err := tt.call(client)
+			if err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+
+			got, err := json.MarshalIndent(resp, "", "  ")
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			got = append(got, '\n')
+
+			golden := filepath.Join("testdata", tt.fixture+".golden.json")
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatalf("failed to write golden file: %v", err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("decoded %s doesn't match %s\ngot:\n%s", tt.fixture, golden, got)
+			}
+		})
+	}
+}
+
+// ================================================================================================
+// ERROR TESTS
+// ================================================================================================
+
+func TestClientDecodeError(t *testing.T) {
+	client := NewClient(FetcherFunc(func(ctx context.Context, url string) ([]byte, error) {
+		return []byte(`{"games": {"unexpected": "object"}}`), nil
+	}))
+
+	_, err := client.WeekSchedule(context.Background(), "UTA")
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Expected DecodeError, got %v", err)
+	}
+	if decodeErr.Endpoint != "club-schedule" {
+		t.Errorf("Expected endpoint club-schedule, got %s", decodeErr.Endpoint)
+	}
+}
+
+func TestClientFetchError(t *testing.T) {
+	upstream := errors.New("circuit open")
+	client := NewClient(FetcherFunc(func(ctx context.Context, url string) ([]byte, error) {
+		return nil, upstream
+	}))
+
+	_, err := client.PlayByPlay(context.Background(), 2025020018)
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) {
+		t.Fatalf("Expected FetchError, got %v", err)
+	}
+	if !errors.Is(err, upstream) {
+		t.Errorf("Expected FetchError to wrap the fetcher's error")
+	}
+	if fetchErr.URL != DefaultBaseURL+"/gamecenter/2025020018/play-by-play" {
+		t.Errorf("Unexpected URL %s", fetchErr.URL)
+	}
+}
+
+func TestClientWithBaseURL(t *testing.T) {
+	client := NewClient(nil).WithBaseURL("http://localhost:8080/v1/")
+	if got := client.URL("/standings/now"); got != "http://localhost:8080/v1/standings/now" {
+		t.Errorf("Unexpected URL %s", got)
+	}
+}
@@ -0,0 +1,63 @@
+package nhlapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// Landing fetches a game's gamecenter landing page
+func (c *Client) Landing(ctx context.Context, gameID int) (*models.LandingPageResponse, error) {
+	var resp models.LandingPageResponse
+	if err := c.get(ctx, "landing", fmt.Sprintf("gamecenter/%d/landing", gameID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Boxscore fetches a game's boxscore
+func (c *Client) Boxscore(ctx context.Context, gameID int) (*models.BoxscoreResponse, error) {
+	var resp models.BoxscoreResponse
+	if err := c.get(ctx, "boxscore", fmt.Sprintf("gamecenter/%d/boxscore", gameID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// BoxscoreLineup fetches a game's boxscore decoded as dressed players and
+// scratches, which is all pre-game lineup checks need
+func (c *Client) BoxscoreLineup(ctx context.Context, gameID int) (*models.GameCenterLineupResponse, error) {
+	var resp models.GameCenterLineupResponse
+	if err := c.get(ctx, "boxscore", fmt.Sprintf("gamecenter/%d/boxscore", gameID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PlayByPlay fetches every play event in a game
+func (c *Client) PlayByPlay(ctx context.Context, gameID int) (*models.PlayByPlayResponse, error) {
+	var resp models.PlayByPlayResponse
+	if err := c.get(ctx, "play-by-play", fmt.Sprintf("gamecenter/%d/play-by-play", gameID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Shifts fetches per-player shift data for a game
+func (c *Client) Shifts(ctx context.Context, gameID int) (*models.ShiftDataResponse, error) {
+	var resp models.ShiftDataResponse
+	if err := c.get(ctx, "shifts", fmt.Sprintf("gamecenter/%d/shifts", gameID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Summary fetches a game's gamecenter summary
+func (c *Client) Summary(ctx context.Context, gameID int) (*models.GameSummaryResponse, error) {
+	var resp models.GameSummaryResponse
+	if err := c.get(ctx, "summary", fmt.Sprintf("gamecenter/%d/summary", gameID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,82 @@
+package nhlapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// WeekSchedule fetches a team's games for the current week
+func (c *Client) WeekSchedule(ctx context.Context, teamCode string) (*models.ScheduleResponse, error) {
+	var resp models.ScheduleResponse
+	if err := c.get(ctx, "club-schedule", fmt.Sprintf("club-schedule/%s/week/now", teamCode), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SeasonSchedule fetches a team's full schedule for a season (e.g. 20252026)
+func (c *Client) SeasonSchedule(ctx context.Context, teamCode string, season int) (*models.ScheduleResponse, error) {
+	var resp models.ScheduleResponse
+	if err := c.get(ctx, "club-schedule-season", fmt.Sprintf("club-schedule-season/%s/%d", teamCode, season), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Scoreboard fetches the scoreboard around today for a team
+func (c *Client) Scoreboard(ctx context.Context, teamCode string) (*models.ScoreboardResponse, error) {
+	var resp models.ScoreboardResponse
+	if err := c.get(ctx, "scoreboard", fmt.Sprintf("scoreboard/%s/now", teamCode), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// LeagueScoreboard fetches every game around today, league-wide
+func (c *Client) LeagueScoreboard(ctx context.Context) (*models.ScoreboardResponse, error) {
+	var resp models.ScoreboardResponse
+	if err := c.get(ctx, "scoreboard", "scoreboard/now", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// LeagueScoreboardForDate fetches every game around a date, league-wide
+func (c *Client) LeagueScoreboardForDate(ctx context.Context, date time.Time) (*models.ScoreboardResponse, error) {
+	var resp models.ScoreboardResponse
+	if err := c.get(ctx, "scoreboard", "scoreboard/"+date.Format("2006-01-02"), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Standings fetches the current league standings
+func (c *Client) Standings(ctx context.Context) (*models.StandingsResponse, error) {
+	var resp models.StandingsResponse
+	if err := c.get(ctx, "standings", "standings/now", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StandingsForDate fetches the league standings as of a date, so past
+// seasons' standings list the teams that existed then
+func (c *Client) StandingsForDate(ctx context.Context, date time.Time) (*models.StandingsResponse, error) {
+	var resp models.StandingsResponse
+	if err := c.get(ctx, "standings", "standings/"+date.Format("2006-01-02"), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StandingsSeasons fetches the date range of every season's standings
+func (c *Client) StandingsSeasons(ctx context.Context) (*models.StandingsSeasonsResponse, error) {
+	var resp models.StandingsSeasonsResponse
+	if err := c.get(ctx, "standings-season", "standings-season", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
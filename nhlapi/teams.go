@@ -0,0 +1,63 @@
+package nhlapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// ClubStats fetches a team's current skater and goalie stats
+func (c *Client) ClubStats(ctx context.Context, teamCode string) (*models.ClubStatsResponse, error) {
+	var resp models.ClubStatsResponse
+	if err := c.get(ctx, "club-stats", fmt.Sprintf("club-stats/%s/now", teamCode), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ClubStatsForSeason fetches a team's skater and goalie stats for a season
+// and game type (see GameTypeRegularSeason)
+func (c *Client) ClubStatsForSeason(ctx context.Context, teamCode string, season, gameType int) (*models.ClubStatsResponse, error) {
+	var resp models.ClubStatsResponse
+	if err := c.get(ctx, "club-stats", fmt.Sprintf("club-stats/%s/%d/%d", teamCode, season, gameType), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Roster fetches a team's current roster
+func (c *Client) Roster(ctx context.Context, teamCode string) (*models.TeamRosterResponse, error) {
+	var resp models.TeamRosterResponse
+	if err := c.get(ctx, "roster", fmt.Sprintf("roster/%s/current", teamCode), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RosterForSeason fetches a team's roster for a season
+func (c *Client) RosterForSeason(ctx context.Context, teamCode string, season int) (*models.TeamRosterResponse, error) {
+	var resp models.TeamRosterResponse
+	if err := c.get(ctx, "roster", fmt.Sprintf("roster/%s/%d", teamCode, season), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PlayerGameLog fetches a skater's game-by-game log for a season and game type
+func (c *Client) PlayerGameLog(ctx context.Context, playerID, season, gameType int) (*models.PlayerGameLogResponse, error) {
+	var resp models.PlayerGameLogResponse
+	if err := c.get(ctx, "player-game-log", fmt.Sprintf("player/%d/game-log/%d/%d", playerID, season, gameType), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GoalieGameLog fetches a goalie's game-by-game log for the current season
+func (c *Client) GoalieGameLog(ctx context.Context, playerID int) (*models.GoalieGameLogResponse, error) {
+	var resp models.GoalieGameLogResponse
+	if err := c.get(ctx, "player-game-log", fmt.Sprintf("player/%d/game-log/now", playerID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -1,35 +1,71 @@
 package services
 
 import (
+	"container/list"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
 
 // CacheEntry represents a cached API response
 type CacheEntry struct {
-	Key        string    `json:"key"`
-	Data       []byte    `json:"data"`
-	CachedAt   time.Time `json:"cached_at"`
-	ExpiresAt  time.Time `json:"expires_at"`
-	Endpoint   string    `json:"endpoint"`
-	HitCount   int       `json:"hit_count"`
-	LastAccess time.Time `json:"last_access"`
+	Key          string    `json:"key"`
+	Data         []byte    `json:"data"`
+	CachedAt     time.Time `json:"cached_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Endpoint     string    `json:"endpoint"`
+	HitCount     int       `json:"hit_count"`
+	LastAccess   time.Time `json:"last_access"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Permanent    bool      `json:"permanent,omitempty"` // Final game data, never expires
+
+	element *list.Element // Position in the LRU list
 }
 
-// APICacheService manages caching of NHL API responses
+// size approximates the entry's memory footprint for the LRU bound
+func (e *CacheEntry) size() int64 {
+	return int64(len(e.Data) + len(e.Endpoint) + len(e.ETag) + len(e.LastModified) + cacheEntryOverhead)
+}
+
+// cacheState is where an entry sits in its lifetime
+type cacheState int
+
+const (
+	cacheMissing cacheState = iota
+	cacheFresh              // Within TTL (or permanent): serve as is
+	cacheStale              // Past TTL but within the stale-while-revalidate window
+	cacheExpired            // Past the window: only served if the upstream fails
+)
+
+// APICacheService manages caching of NHL API responses. Responses are kept
+// with their ETag/Last-Modified so expired entries can be revalidated with a
+// conditional request, served stale while that happens in the background,
+// and bounded in total size by evicting the least recently used.
 type APICacheService struct {
 	cache     map[string]*CacheEntry
-	cacheMu   sync.RWMutex
+	lru       *list.List // Keys, most recently used at the front
+	sizeBytes int64
+	maxBytes  int64
+	cacheMu   sync.Mutex
 	cacheDir  string
-	hits      int64
-	misses    int64
-	statsMu   sync.RWMutex
-	evictions int64
+
+	revalidating   map[string]bool // URLs with a background revalidation in flight
+	revalidatingMu sync.Mutex
+
+	hits          int64
+	misses        int64
+	evictions     int64
+	staleServed   int64
+	revalidations int64
+	notModified   int64
+	statsMu       sync.RWMutex
 }
 
 var (
@@ -39,21 +75,47 @@ var (
 
 const (
 	apiCacheDir = "data/cache/api"
+
+	// apiCacheMaxBytes bounds the in-memory cache; least recently used
+	// entries are evicted past it
+	apiCacheMaxBytes = 64 << 20
+
+	// cacheEntryOverhead approximates per-entry bookkeeping in bytes
+	cacheEntryOverhead = 256
+
+	// staleRetention is how long expired entries are kept so they can be
+	// served when the NHL API is failing
+	staleRetention = 24 * time.Hour
+
+	// compactionInterval is how often expired entries are dropped and the
+	// on-disk cache rewritten
+	compactionInterval = time.Hour
+
+	// TTLs for game-state-aware caching
+	liveGameTTL = 10 * time.Second
+	preGameTTL  = time.Minute
 )
 
 // InitAPICacheService initializes the global API cache service
 func InitAPICacheService() {
 	apiCacheServiceOnce.Do(func() {
-		apiCacheService = &APICacheService{
-			cache:    make(map[string]*CacheEntry),
-			cacheDir: apiCacheDir,
-		}
+		apiCacheService = newAPICacheService(apiCacheDir, apiCacheMaxBytes)
 		apiCacheService.LoadCache()
 		// Start background cleanup goroutine
 		go apiCacheService.cleanupExpiredEntries()
 	})
 }
 
+func newAPICacheService(cacheDir string, maxBytes int64) *APICacheService {
+	return &APICacheService{
+		cache:        make(map[string]*CacheEntry),
+		lru:          list.New(),
+		maxBytes:     maxBytes,
+		cacheDir:     cacheDir,
+		revalidating: make(map[string]bool),
+	}
+}
+
 // GetAPICacheService returns the singleton instance
 func GetAPICacheService() *APICacheService {
 	return apiCacheService
@@ -65,56 +127,232 @@ func (acs *APICacheService) generateCacheKey(url string) string {
 	return fmt.Sprintf("%x", hash[:16]) // Use first 16 bytes for shorter keys
 }
 
-// Get retrieves a cached response if it exists and is not expired
+// Get retrieves a cached response if it exists and is fresh
 func (acs *APICacheService) Get(url string) ([]byte, bool) {
+	entry, state := acs.lookup(url)
+	if state != cacheFresh {
+		return nil, false
+	}
+	return entry.Data, true
+}
+
+// lookup returns a copy of the entry for url and its state, counting a hit
+// for fresh or stale entries and a miss otherwise
+func (acs *APICacheService) lookup(url string) (CacheEntry, cacheState) {
 	key := acs.generateCacheKey(url)
+	now := time.Now()
 
-	acs.cacheMu.RLock()
+	acs.cacheMu.Lock()
 	entry, exists := acs.cache[key]
-	acs.cacheMu.RUnlock()
-
 	if !exists {
+		acs.cacheMu.Unlock()
 		acs.recordMiss()
-		return nil, false
+		return CacheEntry{}, cacheMissing
 	}
 
-	// Check if expired
-	if time.Now().After(entry.ExpiresAt) {
+	state := entry.state(now)
+	if state == cacheFresh || state == cacheStale {
+		entry.HitCount++
+		entry.LastAccess = now
+		acs.lru.MoveToFront(entry.element)
+	}
+	copied := *entry
+	copied.element = nil
+	acs.cacheMu.Unlock()
+
+	switch state {
+	case cacheFresh:
+		acs.recordHit()
+	case cacheStale:
+		acs.recordHit()
+		acs.statsMu.Lock()
+		acs.staleServed++
+		acs.statsMu.Unlock()
+	default:
 		acs.recordMiss()
-		// Remove expired entry
-		acs.cacheMu.Lock()
-		delete(acs.cache, key)
-		acs.cacheMu.Unlock()
-		return nil, false
 	}
+	return copied, state
+}
+
+// state classifies the entry at now
+func (e *CacheEntry) state(now time.Time) cacheState {
+	if e.Permanent || now.Before(e.ExpiresAt) {
+		return cacheFresh
+	}
+	if now.Sub(e.ExpiresAt) <= staleWhileRevalidateWindow(e.ExpiresAt.Sub(e.CachedAt)) {
+		return cacheStale
+	}
+	return cacheExpired
+}
+
+// staleWhileRevalidateWindow is how long past expiry an entry may still be
+// served while it is refreshed in the background: ten TTLs, at least five
+// minutes, and never longer than staleRetention
+func staleWhileRevalidateWindow(ttl time.Duration) time.Duration {
+	window := 10 * ttl
+	if window < 5*time.Minute {
+		window = 5 * time.Minute
+	}
+	if window > staleRetention {
+		window = staleRetention
+	}
+	return window
+}
+
+// GetStale returns a cached response even if it has expired, along with when
+// it was cached, for serving in place of an upstream failure
+func (acs *APICacheService) GetStale(url string) ([]byte, time.Time, bool) {
+	key := acs.generateCacheKey(url)
 
-	// Update hit count and last access
 	acs.cacheMu.Lock()
-	entry.HitCount++
-	entry.LastAccess = time.Now()
-	acs.cacheMu.Unlock()
+	defer acs.cacheMu.Unlock()
 
-	acs.recordHit()
-	return entry.Data, true
+	entry, exists := acs.cache[key]
+	if !exists {
+		return nil, time.Time{}, false
+	}
+	return entry.Data, entry.CachedAt, true
+}
+
+// Validators returns the ETag and Last-Modified of the cached response for
+// url, for a conditional request
+func (acs *APICacheService) Validators(url string) (etag, lastModified string, ok bool) {
+	key := acs.generateCacheKey(url)
+
+	acs.cacheMu.Lock()
+	defer acs.cacheMu.Unlock()
+
+	entry, exists := acs.cache[key]
+	if !exists || (entry.ETag == "" && entry.LastModified == "") {
+		return "", "", false
+	}
+	return entry.ETag, entry.LastModified, true
 }
 
 // Set stores an API response in the cache with a TTL
 func (acs *APICacheService) Set(url string, data []byte, ttl time.Duration) {
+	acs.put(&CacheEntry{
+		Data:      data,
+		ExpiresAt: time.Now().Add(ttl),
+	}, url)
+}
+
+// Store caches a fresh response with its validators, choosing the TTL from
+// the endpoint and the state of any games in the body. Returns the TTL used,
+// 0 for permanent entries.
+func (acs *APICacheService) Store(url string, data []byte, etag, lastModified string) time.Duration {
+	ttl, permanent := GetTTLForResponse(url, data)
+	acs.put(&CacheEntry{
+		Data:         data,
+		ExpiresAt:    time.Now().Add(ttl),
+		ETag:         etag,
+		LastModified: lastModified,
+		Permanent:    permanent,
+	}, url)
+	if permanent {
+		return 0
+	}
+	return ttl
+}
+
+// Revalidated extends the cached response for url after the NHL API answered
+// 304 Not Modified, returning its body. Returns false if the entry has since
+// been evicted.
+func (acs *APICacheService) Revalidated(url string) ([]byte, bool) {
 	key := acs.generateCacheKey(url)
+	now := time.Now()
+
+	acs.cacheMu.Lock()
+	entry, exists := acs.cache[key]
+	if exists {
+		ttl, permanent := GetTTLForResponse(url, entry.Data)
+		entry.CachedAt = now
+		entry.ExpiresAt = now.Add(ttl)
+		entry.Permanent = permanent
+		entry.LastAccess = now
+		acs.lru.MoveToFront(entry.element)
+	}
+	acs.cacheMu.Unlock()
 
-	entry := &CacheEntry{
-		Key:        key,
-		Data:       data,
-		CachedAt:   time.Now(),
-		ExpiresAt:  time.Now().Add(ttl),
-		Endpoint:   url,
-		HitCount:   0,
-		LastAccess: time.Now(),
+	if !exists {
+		return nil, false
 	}
+	acs.statsMu.Lock()
+	acs.notModified++
+	acs.statsMu.Unlock()
+	return entry.Data, true
+}
+
+// put inserts or replaces the entry for url and evicts past the size bound
+func (acs *APICacheService) put(entry *CacheEntry, url string) {
+	now := time.Now()
+	entry.Key = acs.generateCacheKey(url)
+	entry.Endpoint = url
+	entry.CachedAt = now
+	entry.LastAccess = now
 
 	acs.cacheMu.Lock()
-	acs.cache[key] = entry
+	if existing, ok := acs.cache[entry.Key]; ok {
+		entry.HitCount = existing.HitCount
+		acs.removeLocked(existing)
+	}
+	acs.insertLocked(entry)
+	evicted := acs.evictLocked()
 	acs.cacheMu.Unlock()
+
+	acs.recordEvictions(evicted)
+}
+
+// insertLocked adds entry at the front of the LRU; caller holds cacheMu
+func (acs *APICacheService) insertLocked(entry *CacheEntry) {
+	entry.element = acs.lru.PushFront(entry.Key)
+	acs.cache[entry.Key] = entry
+	acs.sizeBytes += entry.size()
+}
+
+// removeLocked drops entry; caller holds cacheMu
+func (acs *APICacheService) removeLocked(entry *CacheEntry) {
+	if entry.element != nil {
+		acs.lru.Remove(entry.element)
+	}
+	delete(acs.cache, entry.Key)
+	acs.sizeBytes -= entry.size()
+}
+
+// evictLocked removes least recently used entries until the cache fits in
+// maxBytes, always keeping the most recent one; caller holds cacheMu
+func (acs *APICacheService) evictLocked() int {
+	evicted := 0
+	for acs.maxBytes > 0 && acs.sizeBytes > acs.maxBytes && acs.lru.Len() > 1 {
+		oldest := acs.lru.Back()
+		acs.removeLocked(acs.cache[oldest.Value.(string)])
+		evicted++
+	}
+	return evicted
+}
+
+// beginRevalidation claims the background revalidation of url, returning
+// false if one is already in flight
+func (acs *APICacheService) beginRevalidation(url string) bool {
+	acs.revalidatingMu.Lock()
+	defer acs.revalidatingMu.Unlock()
+
+	if acs.revalidating[url] {
+		return false
+	}
+	acs.revalidating[url] = true
+
+	acs.statsMu.Lock()
+	acs.revalidations++
+	acs.statsMu.Unlock()
+	return true
+}
+
+// endRevalidation releases a claim from beginRevalidation
+func (acs *APICacheService) endRevalidation(url string) {
+	acs.revalidatingMu.Lock()
+	delete(acs.revalidating, url)
+	acs.revalidatingMu.Unlock()
 }
 
 // GetTTLForEndpoint returns the appropriate TTL based on the endpoint type
@@ -153,6 +391,78 @@ func GetTTLForEndpoint(url string) time.Duration {
 	}
 }
 
+// GetTTLForResponse refines GetTTLForEndpoint using the state of the games in
+// the response. Any game in progress caps the TTL at seconds; a response
+// whose games are all final never changes, so it is cached permanently
+// unless it's a "now" endpoint that will point at different games tomorrow.
+func GetTTLForResponse(url string, body []byte) (time.Duration, bool) {
+	ttl := GetTTLForEndpoint(url)
+
+	states := responseGameStates(body)
+	if len(states) == 0 {
+		return ttl, false
+	}
+
+	allFinal := true
+	for _, state := range states {
+		switch state {
+		case "LIVE", "CRIT":
+			return liveGameTTL, false
+		case "PRE":
+			if ttl > preGameTTL {
+				ttl = preGameTTL
+			}
+			allFinal = false
+		case "FINAL", "OFF":
+		default:
+			allFinal = false
+		}
+	}
+
+	if allFinal && !contains(url, "/now") {
+		return ttl, true
+	}
+	return ttl, false
+}
+
+// responseGameStates extracts game states from the response shapes the NHL
+// API uses: a single game (gamecenter), a games list (score, club schedule)
+// or games grouped by date (scoreboard)
+func responseGameStates(body []byte) []string {
+	var shape struct {
+		GameState string `json:"gameState"`
+		Games     []struct {
+			GameState string `json:"gameState"`
+		} `json:"games"`
+		GamesByDate []struct {
+			Games []struct {
+				GameState string `json:"gameState"`
+			} `json:"games"`
+		} `json:"gamesByDate"`
+	}
+	if len(body) == 0 || body[0] != '{' || json.Unmarshal(body, &shape) != nil {
+		return nil
+	}
+
+	var states []string
+	if shape.GameState != "" {
+		states = append(states, shape.GameState)
+	}
+	for _, game := range shape.Games {
+		if game.GameState != "" {
+			states = append(states, game.GameState)
+		}
+	}
+	for _, day := range shape.GamesByDate {
+		for _, game := range day.Games {
+			if game.GameState != "" {
+				states = append(states, game.GameState)
+			}
+		}
+	}
+	return states
+}
+
 // contains is a helper to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr ||
@@ -174,7 +484,9 @@ func indexOf(s, substr string) int {
 func (acs *APICacheService) Invalidate(url string) {
 	key := acs.generateCacheKey(url)
 	acs.cacheMu.Lock()
-	delete(acs.cache, key)
+	if entry, ok := acs.cache[key]; ok {
+		acs.removeLocked(entry)
+	}
 	acs.cacheMu.Unlock()
 }
 
@@ -182,16 +494,14 @@ func (acs *APICacheService) Invalidate(url string) {
 func (acs *APICacheService) InvalidatePattern(pattern string) int {
 	count := 0
 	acs.cacheMu.Lock()
-	for key, entry := range acs.cache {
+	for _, entry := range acs.cache {
 		if contains(entry.Endpoint, pattern) {
-			delete(acs.cache, key)
+			acs.removeLocked(entry)
 			count++
 		}
 	}
 	acs.cacheMu.Unlock()
-	acs.statsMu.Lock()
-	acs.evictions += int64(count)
-	acs.statsMu.Unlock()
+	acs.recordEvictions(count)
 	return count
 }
 
@@ -200,22 +510,32 @@ func (acs *APICacheService) Clear() {
 	acs.cacheMu.Lock()
 	count := len(acs.cache)
 	acs.cache = make(map[string]*CacheEntry)
+	acs.lru.Init()
+	acs.sizeBytes = 0
 	acs.cacheMu.Unlock()
-	acs.statsMu.Lock()
-	acs.evictions += int64(count)
-	acs.statsMu.Unlock()
+	acs.recordEvictions(count)
 }
 
 // GetStats returns cache statistics
 func (acs *APICacheService) GetStats() map[string]interface{} {
-	acs.cacheMu.RLock()
+	acs.cacheMu.Lock()
 	cacheSize := len(acs.cache)
-	acs.cacheMu.RUnlock()
+	sizeBytes := acs.sizeBytes
+	permanent := 0
+	for _, entry := range acs.cache {
+		if entry.Permanent {
+			permanent++
+		}
+	}
+	acs.cacheMu.Unlock()
 
 	acs.statsMu.RLock()
 	hits := acs.hits
 	misses := acs.misses
 	evictions := acs.evictions
+	staleServed := acs.staleServed
+	revalidations := acs.revalidations
+	notModified := acs.notModified
 	acs.statsMu.RUnlock()
 
 	total := hits + misses
@@ -225,12 +545,18 @@ func (acs *APICacheService) GetStats() map[string]interface{} {
 	}
 
 	return map[string]interface{}{
-		"cache_size":     cacheSize,
-		"hits":           hits,
-		"misses":         misses,
-		"evictions":      evictions,
-		"hit_rate":       fmt.Sprintf("%.1f%%", hitRate),
-		"total_requests": total,
+		"cache_size":        cacheSize,
+		"size_bytes":        sizeBytes,
+		"max_bytes":         acs.maxBytes,
+		"permanent_entries": permanent,
+		"hits":              hits,
+		"misses":            misses,
+		"evictions":         evictions,
+		"stale_served":      staleServed,
+		"revalidations":     revalidations,
+		"not_modified":      notModified,
+		"hit_rate":          fmt.Sprintf("%.1f%%", hitRate),
+		"total_requests":    total,
 	}
 }
 
@@ -248,31 +574,57 @@ func (acs *APICacheService) recordMiss() {
 	acs.statsMu.Unlock()
 }
 
-// cleanupExpiredEntries removes expired entries every 5 minutes
+// recordEvictions adds to the eviction counter
+func (acs *APICacheService) recordEvictions(count int) {
+	if count == 0 {
+		return
+	}
+	acs.statsMu.Lock()
+	acs.evictions += int64(count)
+	acs.statsMu.Unlock()
+}
+
+// cleanupExpiredEntries compacts the cache every hour: entries expired for
+// longer than staleRetention are dropped and the on-disk copy rewritten
 func (acs *APICacheService) cleanupExpiredEntries() {
-	ticker := time.NewTicker(5 * time.Minute)
+	ticker := time.NewTicker(compactionInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		now := time.Now()
-		count := 0
+	done := BackgroundContext().Done()
 
-		acs.cacheMu.Lock()
-		for key, entry := range acs.cache {
-			if now.After(entry.ExpiresAt) {
-				delete(acs.cache, key)
-				count++
-			}
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
 		}
-		acs.cacheMu.Unlock()
 
-		if count > 0 {
-			acs.statsMu.Lock()
-			acs.evictions += int64(count)
-			acs.statsMu.Unlock()
-			fmt.Printf("🗑️ Cleaned up %d expired cache entries\n", count)
+		if err := acs.Compact(); err != nil {
+			fmt.Printf("⚠️ API cache compaction failed: %v\n", err)
+		}
+	}
+}
+
+// Compact drops entries expired for longer than staleRetention and rewrites
+// the on-disk cache so it only holds what's in memory
+func (acs *APICacheService) Compact() error {
+	now := time.Now()
+	count := 0
+
+	acs.cacheMu.Lock()
+	for _, entry := range acs.cache {
+		if !entry.Permanent && now.Sub(entry.ExpiresAt) > staleRetention {
+			acs.removeLocked(entry)
+			count++
 		}
 	}
+	acs.cacheMu.Unlock()
+
+	if count > 0 {
+		acs.recordEvictions(count)
+		fmt.Printf("🗑️ Cleaned up %d expired cache entries\n", count)
+	}
+	return acs.SaveCache()
 }
 
 // LoadCache loads the cache from disk
@@ -292,43 +644,68 @@ func (acs *APICacheService) LoadCache() {
 		return
 	}
 
+	// Insert least recently used first so the LRU order survives a restart
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastAccess.Before(entries[j].LastAccess)
+	})
+
 	now := time.Now()
 	loaded := 0
+	stale := 0
 	expired := 0
 
 	acs.cacheMu.Lock()
 	for _, entry := range entries {
-		// Only load entries that haven't expired
-		if now.Before(entry.ExpiresAt) {
-			acs.cache[entry.Key] = entry
-			loaded++
-		} else {
+		// Keep recently expired entries as a fallback for upstream failures
+		if !entry.Permanent && now.Sub(entry.ExpiresAt) > staleRetention {
 			expired++
+			continue
+		}
+		if existing, ok := acs.cache[entry.Key]; ok {
+			acs.removeLocked(existing)
+		}
+		acs.insertLocked(entry)
+		loaded++
+		if entry.state(now) != cacheFresh {
+			stale++
 		}
 	}
+	evicted := acs.evictLocked()
 	acs.cacheMu.Unlock()
 
-	fmt.Printf("✅ API cache loaded: %d entries (skipped %d expired)\n", loaded, expired)
+	fmt.Printf("✅ API cache loaded: %d entries, %d stale (skipped %d expired, evicted %d over size limit)\n",
+		loaded-evicted, stale, expired, evicted)
 }
 
-// SaveCache persists the cache to disk
+// SaveCache persists the cache to disk, writing to a temporary file first so
+// a crash mid-write can't leave a truncated cache behind
 func (acs *APICacheService) SaveCache() error {
-	acs.cacheMu.RLock()
+	acs.cacheMu.Lock()
 	entries := make([]*CacheEntry, 0, len(acs.cache))
 	for _, entry := range acs.cache {
-		entries = append(entries, entry)
+		copied := *entry
+		copied.element = nil
+		entries = append(entries, &copied)
 	}
-	acs.cacheMu.RUnlock()
+	acs.cacheMu.Unlock()
 
 	data, err := json.MarshalIndent(entries, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal cache: %w", err)
 	}
 
+	if err := os.MkdirAll(acs.cacheDir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
 	cacheFile := filepath.Join(acs.cacheDir, "api_cache.json")
-	if err := ioutil.WriteFile(cacheFile, data, 0644); err != nil {
+	tmpFile := cacheFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, 0644); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
+	if err := os.Rename(tmpFile, cacheFile); err != nil {
+		return fmt.Errorf("failed to replace cache file: %w", err)
+	}
 
 	return nil
 }
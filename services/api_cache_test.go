@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTTLForResponse(t *testing.T) {
+	const (
+		boxscore   = "https://api-web.nhle.com/v1/gamecenter/2025020018/boxscore"
+		schedule   = "https://api-web.nhle.com/v1/club-schedule-season/UTA/20252026"
+		scoreboard = "https://api-web.nhle.com/v1/scoreboard/now"
+		datedBoard = "https://api-web.nhle.com/v1/scoreboard/2025-10-11"
+	)
+	tests := []struct {
+		name      string
+		url       string
+		body      string
+		ttl       time.Duration
+		permanent bool
+	}{
+		{"live game", boxscore, `{"gameState":"LIVE"}`, liveGameTTL, false},
+		{"critical game", boxscore, `{"gameState":"CRIT"}`, liveGameTTL, false},
+		{"final game", boxscore, `{"gameState":"OFF"}`, time.Minute, true},
+		{"future game", boxscore, `{"gameState":"FUT"}`, time.Minute, false},
+		{"pregame caps a long TTL", schedule, `{"games":[{"gameState":"OFF"},{"gameState":"PRE"}]}`, preGameTTL, false},
+		{"live game in a list wins", schedule, `{"games":[{"gameState":"FINAL"},{"gameState":"LIVE"},{"gameState":"PRE"}]}`, liveGameTTL, false},
+		{"season still to come", schedule, `{"games":[{"gameState":"OFF"},{"gameState":"FUT"}]}`, 24 * time.Hour, false},
+		{"finished season", schedule, `{"games":[{"gameState":"OFF"},{"gameState":"FINAL"}]}`, 24 * time.Hour, true},
+		{"games by date", datedBoard, `{"gamesByDate":[{"games":[{"gameState":"FINAL"}]},{"games":[{"gameState":"OFF"}]}]}`, 5 * time.Minute, true},
+		{"now endpoint is never permanent", scoreboard, `{"gamesByDate":[{"games":[{"gameState":"FINAL"}]}]}`, time.Minute, false},
+		{"no game states", schedule, `{"games":[]}`, 24 * time.Hour, false},
+		{"array body", schedule, `[{"gameState":"LIVE"}]`, 24 * time.Hour, false},
+		{"invalid JSON", boxscore, `{"gameState":`, time.Minute, false},
+		{"empty body", boxscore, ``, time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, permanent := GetTTLForResponse(tt.url, []byte(tt.body))
+			if ttl != tt.ttl || permanent != tt.permanent {
+				t.Errorf("GetTTLForResponse(%s, %s) = (%v, %v), want (%v, %v)",
+					tt.url, tt.body, ttl, permanent, tt.ttl, tt.permanent)
+			}
+		})
+	}
+}
@@ -0,0 +1,509 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// CENTRAL APPLICATION CONFIG
+// ============================================================================
+
+// DefaultConfigPath is read when UHC_CONFIG doesn't name another file. A
+// missing file is fine: every setting has a built-in default.
+const DefaultConfigPath = "config.yaml"
+
+// redactedValue replaces secrets in the config returned by Redacted
+const redactedValue = "********"
+
+// AppConfig is the typed configuration for the server and CLI. Values come
+// from built-in defaults, then the YAML file, then environment variables.
+// Every field can be overridden with UHC_<SECTION>_<FIELD> (e.g.
+// UHC_RATE_LIMIT_MAX_REQUESTS); fields with an env tag also honor that legacy
+// variable name.
+type AppConfig struct {
+	Server           ServerConfig             `json:"server" yaml:"server"`
+	APIKeys          APIKeyConfig             `json:"apiKeys" yaml:"apiKeys"`
+	RateLimit        RateLimitConfig          `json:"rateLimit" yaml:"rateLimit"`
+	Simulation       SimulationConfig         `json:"simulation" yaml:"simulation"`
+	DynamicWeighting DynamicWeightingSettings `json:"dynamicWeighting" yaml:"dynamicWeighting"`
+	CrossValidation  CrossValidationSettings  `json:"crossValidation" yaml:"crossValidation"`
+	RePrediction     RePredictionStrategy     `json:"reprediction" yaml:"reprediction"`
+}
+
+// ServerConfig holds settings that only take effect at startup
+type ServerConfig struct {
+	Team    string `json:"team" yaml:"team" env:"TEAM_CODE"` // NHL team code (e.g., UTA, COL)
+	Addr    string `json:"addr" yaml:"addr"`                 // HTTP listen address
+	WorkDir string `json:"workDir" yaml:"workDir"`           // Directory data/ lives under ("" = current directory)
+}
+
+// APIKeyConfig holds third-party API keys. They are redacted by /api/config.
+type APIKeyConfig struct {
+	OpenWeather string `json:"openWeather" yaml:"openWeather" env:"OPENWEATHER_API_KEY"`
+	WeatherAPI  string `json:"weatherAPI" yaml:"weatherAPI" env:"WEATHER_API_KEY"`
+	AccuWeather string `json:"accuWeather" yaml:"accuWeather" env:"ACCUWEATHER_API_KEY"`
+	Odds        string `json:"odds" yaml:"odds" env:"ODDS_API_KEY"`
+}
+
+// RateLimitConfig is the global NHL API rate limit
+type RateLimitConfig struct {
+	MaxRequests int           `json:"maxRequests" yaml:"maxRequests"` // Requests allowed per window
+	Window      time.Duration `json:"window" yaml:"window"`           // Rate limiting window
+	MinDelay    time.Duration `json:"minDelay" yaml:"minDelay"`       // Minimum delay between consecutive requests
+}
+
+// ConfigStatus describes the loaded config for /api/config and `uhc config`
+type ConfigStatus struct {
+	Path           string     `json:"path"`
+	FileLoaded     bool       `json:"fileLoaded"`
+	LoadedAt       time.Time  `json:"loadedAt"`
+	EnvOverrides   []string   `json:"envOverrides"`
+	PendingRestart []string   `json:"pendingRestart"`
+	Config         *AppConfig `json:"config"`
+}
+
+// DefaultAppConfig returns the built-in configuration
+func DefaultAppConfig() *AppConfig {
+	return &AppConfig{
+		Server: ServerConfig{
+			Team: "UTA",
+			Addr: ":8080",
+		},
+		RateLimit: RateLimitConfig{
+			MaxRequests: 60,                     // 60 requests per minute (conservative)
+			Window:      time.Minute,            // 1 minute window
+			MinDelay:    500 * time.Millisecond, // 500ms between calls (polite)
+		},
+		Simulation:       *DefaultSimulationConfig(),
+		DynamicWeighting: DefaultDynamicWeightingSettings(),
+		CrossValidation:  DefaultCrossValidationSettings(),
+		RePrediction:     DefaultRePredictionStrategy(),
+	}
+}
+
+// Validate checks every setting and reports all problems at once
+func (c *AppConfig) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(models.IsValidTeamCode(c.Server.Team), "server.team: unknown team code %q", c.Server.Team)
+	check(c.Server.Addr != "", "server.addr: must not be empty")
+
+	check(c.RateLimit.MaxRequests > 0, "rateLimit.maxRequests: must be positive")
+	check(c.RateLimit.Window > 0, "rateLimit.window: must be positive")
+	check(c.RateLimit.MinDelay >= 0, "rateLimit.minDelay: must not be negative")
+
+	sim := c.Simulation
+	check(sim.MinSimulations > 0, "simulation.minSimulations: must be positive")
+	check(sim.MinSimulations <= sim.DefaultSimulations && sim.DefaultSimulations <= sim.MaxSimulations,
+		"simulation: need minSimulations <= defaultSimulations <= maxSimulations (got %d, %d, %d)",
+		sim.MinSimulations, sim.DefaultSimulations, sim.MaxSimulations)
+
+	dw := c.DynamicWeighting
+	check(dw.RecencyDecayRate > 0 && dw.RecencyDecayRate <= 1, "dynamicWeighting.recencyDecayRate: must be in (0, 1]")
+	check(dw.PerformanceThreshold >= 0 && dw.PerformanceThreshold < 1, "dynamicWeighting.performanceThreshold: must be in [0, 1)")
+	check(dw.SmoothingStrength >= 0 && dw.SmoothingStrength <= 1, "dynamicWeighting.smoothingStrength: must be in [0, 1]")
+	switch dw.AdaptationSpeed {
+	case "conservative", "moderate", "aggressive":
+	default:
+		check(false, "dynamicWeighting.adaptationSpeed: must be conservative, moderate or aggressive (got %q)", dw.AdaptationSpeed)
+	}
+	check(dw.MinEvaluationPeriod >= 0, "dynamicWeighting.minEvaluationPeriod: must not be negative")
+	check(dw.WeightUpdateFrequency > 0, "dynamicWeighting.weightUpdateFrequency: must be positive")
+
+	cv := c.CrossValidation
+	check(cv.RollingOrigin.TrainWindow >= 0, "crossValidation.rollingOrigin.trainWindow: must not be negative")
+	check(cv.RollingOrigin.MinTrain > 0, "crossValidation.rollingOrigin.minTrain: must be positive")
+	check(cv.RollingOrigin.Gap >= 0, "crossValidation.rollingOrigin.gap: must not be negative")
+	check(cv.RollingOrigin.Horizon > 0, "crossValidation.rollingOrigin.horizon: must be positive")
+	check(cv.RollingOrigin.MaxFolds >= 0, "crossValidation.rollingOrigin.maxFolds: must not be negative")
+	check(cv.MinHistoricalData > 0, "crossValidation.minHistoricalData: must be positive")
+	check(cv.ValidationWindow > 0, "crossValidation.validationWindow: must be positive")
+	check(cv.BootstrapSamples > 0, "crossValidation.bootstrapSamples: must be positive")
+	check(cv.ConfidenceLevel > 0 && cv.ConfidenceLevel < 1, "crossValidation.confidenceLevel: must be in (0, 1)")
+	check(cv.CalibrationBins >= 2, "crossValidation.calibrationBins: must be at least 2")
+	check(cv.UpdateFrequency > 0, "crossValidation.updateFrequency: must be positive")
+
+	rp := c.RePrediction
+	check(rp.EarlySeasonThreshold > 0 && rp.EarlySeasonThreshold < rp.MidSeasonThreshold,
+		"reprediction: need 0 < earlySeasonThreshold < midSeasonThreshold (got %d, %d)", rp.EarlySeasonThreshold, rp.MidSeasonThreshold)
+	check(rp.NearTermDays > 0, "reprediction.nearTermDays: must be positive")
+	check(rp.DirectImpactGames > 0, "reprediction.directImpactGames: must be positive")
+	check(rp.AccuracyThreshold >= 0 && rp.AccuracyThreshold < 1, "reprediction.accuracyThreshold: must be in [0, 1)")
+	check(rp.MinHoursBetween >= 0, "reprediction.minHoursBetween: must not be negative")
+	check(rp.MaxPredictionsPerBatch > 0, "reprediction.maxPredictionsPerBatch: must be positive")
+
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy with API keys masked
+func (c *AppConfig) Redacted() *AppConfig {
+	redacted := *c
+	for _, key := range []*string{&redacted.APIKeys.OpenWeather, &redacted.APIKeys.WeatherAPI,
+		&redacted.APIKeys.AccuWeather, &redacted.APIKeys.Odds} {
+		if *key != "" {
+			*key = redactedValue
+		}
+	}
+	return &redacted
+}
+
+// ============================================================================
+// LOADING
+// ============================================================================
+
+var (
+	appConfig          *AppConfig
+	appConfigStatus    ConfigStatus
+	appConfigModTime   time.Time
+	appConfigOptional  bool // The file is the default path, which may be missing
+	appConfigOnce      sync.Once
+	appConfigMu        sync.RWMutex
+	appConfigListeners []func(*AppConfig)
+)
+
+// AppConfigPath returns the config file path, from UHC_CONFIG if set
+func AppConfigPath() string {
+	if path := os.Getenv("UHC_CONFIG"); path != "" {
+		return path
+	}
+	return DefaultConfigPath
+}
+
+// ReadAppConfig builds a config from defaults, the file at path and the
+// environment, and validates it. It also returns the environment variables
+// that were applied and whether the file existed; only the default path may
+// be missing.
+func ReadAppConfig(path string) (*AppConfig, []string, bool, error) {
+	return readAppConfig(path, path == DefaultConfigPath)
+}
+
+// readAppConfig is ReadAppConfig with whether the file may be missing
+func readAppConfig(path string, optional bool) (*AppConfig, []string, bool, error) {
+	config := DefaultAppConfig()
+
+	fileLoaded := false
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && err != io.EOF {
+			return nil, nil, false, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		fileLoaded = true
+	case !os.IsNotExist(err) || !optional:
+		// An explicitly named config file must exist
+		return nil, nil, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	overrides, err := applyEnvOverrides(reflect.ValueOf(config).Elem(), "UHC")
+	if err != nil {
+		return nil, nil, fileLoaded, err
+	}
+	config.Server.Team = strings.ToUpper(config.Server.Team)
+
+	if err := config.Validate(); err != nil {
+		return nil, overrides, fileLoaded, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
+	return config, overrides, fileLoaded, nil
+}
+
+// LoadAppConfig reads and validates the config for startup; the server
+// refuses to start on an invalid config rather than run on partial settings
+func LoadAppConfig() (*AppConfig, error) {
+	var loadErr error
+	appConfigOnce.Do(func() {
+		loadErr = loadAppConfig()
+	})
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	return GetAppConfig(), nil
+}
+
+// loadAppConfig installs the config from AppConfigPath, or the defaults if it
+// is invalid. The path is made absolute first because the server changes to
+// server.workDir after loading, and reloads must read the same file.
+func loadAppConfig() error {
+	path := AppConfigPath()
+	optional := path == DefaultConfigPath
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	config, overrides, fileLoaded, err := readAppConfig(path, optional)
+	if err != nil {
+		config, overrides, fileLoaded = DefaultAppConfig(), nil, false
+	}
+
+	appConfigMu.Lock()
+	appConfig = config
+	appConfigModTime = configModTime(path)
+	appConfigOptional = optional
+	appConfigStatus = ConfigStatus{
+		Path:         path,
+		FileLoaded:   fileLoaded,
+		LoadedAt:     time.Now(),
+		EnvOverrides: overrides,
+	}
+	appConfigMu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if fileLoaded {
+		log.Printf("⚙️ Loaded config from %s (%d environment overrides)", path, len(overrides))
+	} else {
+		log.Printf("⚙️ No config file at %s, using defaults (%d environment overrides)", path, len(overrides))
+	}
+	return nil
+}
+
+// GetAppConfig returns a copy of the current config. Callers that never ran
+// LoadAppConfig get the defaults plus the environment, or plain defaults if
+// those are invalid.
+func GetAppConfig() *AppConfig {
+	appConfigOnce.Do(func() {
+		if err := loadAppConfig(); err != nil {
+			log.Printf("⚠️ %v (using defaults)", err)
+		}
+	})
+
+	appConfigMu.RLock()
+	defer appConfigMu.RUnlock()
+	config := *appConfig
+	return &config
+}
+
+// GetAppConfigStatus returns the redacted config with where it came from
+func GetAppConfigStatus() ConfigStatus {
+	config := GetAppConfig()
+
+	appConfigMu.RLock()
+	defer appConfigMu.RUnlock()
+	status := appConfigStatus
+	status.EnvOverrides = append([]string{}, status.EnvOverrides...)
+	status.PendingRestart = append([]string{}, status.PendingRestart...)
+	status.Config = config.Redacted()
+	return status
+}
+
+// OnAppConfigChange registers fn to run with the new config after each
+// successful reload
+func OnAppConfigChange(fn func(*AppConfig)) {
+	appConfigMu.Lock()
+	defer appConfigMu.Unlock()
+	appConfigListeners = append(appConfigListeners, fn)
+}
+
+// ============================================================================
+// HOT RELOAD
+// ============================================================================
+
+// ReloadAppConfigIfChanged reloads the config when the file's modification
+// time has changed since it was last read
+func ReloadAppConfigIfChanged(ctx context.Context) error {
+	GetAppConfig()
+
+	appConfigMu.RLock()
+	path, modTime := appConfigStatus.Path, appConfigModTime
+	appConfigMu.RUnlock()
+
+	if configModTime(path).Equal(modTime) {
+		return nil
+	}
+	_, err := ReloadAppConfig()
+	return err
+}
+
+// ReloadAppConfig re-reads the config and applies the safe tunables
+// (rateLimit, simulation, reprediction) to the running services. Changes to
+// other sections only take effect on restart and are reported in
+// ConfigStatus.PendingRestart. An invalid file, or one that has disappeared
+// since it was loaded, leaves the running config untouched.
+func ReloadAppConfig() (ConfigStatus, error) {
+	GetAppConfig()
+
+	appConfigMu.RLock()
+	path := appConfigStatus.Path
+	optional := appConfigOptional && !appConfigStatus.FileLoaded
+	appConfigMu.RUnlock()
+
+	modTime := configModTime(path)
+	next, overrides, fileLoaded, err := readAppConfig(path, optional)
+	if err != nil {
+		// Don't retry the same broken file on every check
+		appConfigMu.Lock()
+		appConfigModTime = modTime
+		appConfigMu.Unlock()
+		log.Printf("⚠️ Config reload failed, keeping current settings: %v", err)
+		return GetAppConfigStatus(), err
+	}
+
+	appConfigMu.Lock()
+	current := appConfig
+	applied := *current
+	applied.RateLimit = next.RateLimit
+	applied.Simulation = next.Simulation
+	applied.RePrediction = next.RePrediction
+
+	var pending []string
+	if !reflect.DeepEqual(current.Server, next.Server) {
+		pending = append(pending, "server")
+	}
+	if !reflect.DeepEqual(current.APIKeys, next.APIKeys) {
+		pending = append(pending, "apiKeys")
+	}
+	if !reflect.DeepEqual(current.DynamicWeighting, next.DynamicWeighting) {
+		pending = append(pending, "dynamicWeighting")
+	}
+	if !reflect.DeepEqual(current.CrossValidation, next.CrossValidation) {
+		pending = append(pending, "crossValidation")
+	}
+
+	appConfig = &applied
+	appConfigModTime = modTime
+	appConfigStatus.FileLoaded = fileLoaded
+	appConfigStatus.LoadedAt = time.Now()
+	appConfigStatus.EnvOverrides = overrides
+	appConfigStatus.PendingRestart = pending
+	listeners := append([]func(*AppConfig){}, appConfigListeners...)
+	appConfigMu.Unlock()
+
+	GetNHLRateLimiter().SetLimits(applied.RateLimit.MaxRequests, applied.RateLimit.Window, applied.RateLimit.MinDelay)
+	for _, fn := range listeners {
+		config := applied
+		fn(&config)
+	}
+
+	log.Printf("⚙️ Reloaded config from %s", path)
+	if len(pending) > 0 {
+		log.Printf("⚠️ Config changes to %s take effect after a restart", strings.Join(pending, ", "))
+	}
+	return GetAppConfigStatus(), nil
+}
+
+// configModTime returns the file's modification time, or zero if it doesn't exist
+func configModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// ============================================================================
+// ENVIRONMENT OVERRIDES
+// ============================================================================
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnvOverrides walks the config struct and sets each field from its
+// environment variable, returning the names of the variables applied
+func applyEnvOverrides(v reflect.Value, prefix string) ([]string, error) {
+	var applied []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		envName := prefix + "_" + envSegment(name)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			nested, err := applyEnvOverrides(v.Field(i), envName)
+			if err != nil {
+				return nil, err
+			}
+			applied = append(applied, nested...)
+			continue
+		}
+
+		// The UHC_ name wins over the legacy one when both are set
+		for _, key := range []string{field.Tag.Get("env"), envName} {
+			raw, ok := os.LookupEnv(key)
+			if key == "" || !ok || raw == "" {
+				continue
+			}
+			if err := setFromString(v.Field(i), raw); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			applied = append(applied, key)
+		}
+	}
+	return applied, nil
+}
+
+// envSegment turns a camelCase YAML key into SNAKE_CASE (maxRequests ->
+// MAX_REQUESTS, apiKeys -> API_KEYS, weatherAPI -> WEATHER_API)
+func envSegment(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// setFromString parses raw into a config field
+func setFromString(field reflect.Value, raw string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,971 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Backfill kinds
+const (
+	// BackfillKindGameResults processes completed games into the models
+	// (GameResultsService), for one date or the last N days
+	BackfillKindGameResults = "game-results"
+
+	// BackfillKindPlayByPlay analyzes play-by-play for the last N games of
+	// one team or every team (PlayByPlayService)
+	BackfillKindPlayByPlay = "play-by-play"
+)
+
+// Backfill job statuses
+const (
+	BackfillJobQueued    = "queued"
+	BackfillJobRunning   = "running"
+	BackfillJobCompleted = "completed"
+	BackfillJobFailed    = "failed"
+	BackfillJobCancelled = "cancelled"
+)
+
+// Backfill task (per-game) statuses
+const (
+	BackfillTaskPending = "pending"
+	BackfillTaskRunning = "running"
+	BackfillTaskDone    = "done"
+	BackfillTaskFailed  = "failed"
+)
+
+const (
+	// backfillMaxAttempts is how many times a game is tried before it's
+	// marked failed (retry the job to give failed games another go)
+	backfillMaxAttempts = 3
+
+	// backfillRetryDelay is multiplied by the attempt number between tries
+	backfillRetryDelay = 5 * time.Second
+
+	// maxBackfillWorkers caps concurrent games per job. Every NHL API call
+	// still waits on NHLRateLimiter (60/min, 500ms apart), so more workers
+	// than this would only queue up on the limiter.
+	maxBackfillWorkers = 4
+
+	// backfillPollInterval is how often an idle queue rechecks for jobs
+	backfillPollInterval = time.Minute
+)
+
+var (
+	// ErrBackfillJobNotFound is returned for an unknown job ID
+	ErrBackfillJobNotFound = errors.New("backfill job not found")
+
+	// ErrBackfillJobFinished is returned when cancelling a job that has
+	// already finished
+	ErrBackfillJobFinished = errors.New("backfill job already finished")
+
+	// ErrBackfillJobActive is returned when retrying a job that is still
+	// queued or running
+	ErrBackfillJobActive = errors.New("backfill job is still queued or running")
+
+	// ErrBackfillNothingToRetry is returned when retrying a job with no
+	// failed games
+	ErrBackfillNothingToRetry = errors.New("backfill job has no failed games to retry")
+)
+
+// BackfillParams describe what a backfill job covers
+type BackfillParams struct {
+	Team  string `json:"team,omitempty"`  // Play-by-play: one team (empty = all teams)
+	Games int    `json:"games,omitempty"` // Play-by-play: last N games per team
+	Date  string `json:"date,omitempty"`  // Game results: one date (YYYY-MM-DD)
+	Days  int    `json:"days,omitempty"`  // Game results: the last N days
+}
+
+// BackfillJob is a queued backfill and its progress
+type BackfillJob struct {
+	ID         int64          `json:"id"`
+	Kind       string         `json:"kind"`
+	Params     BackfillParams `json:"params"`
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	StartedAt  *time.Time     `json:"startedAt,omitempty"`
+	FinishedAt *time.Time     `json:"finishedAt,omitempty"`
+
+	// Progress over the job's games
+	TotalGames     int        `json:"totalGames"`
+	DoneGames      int        `json:"doneGames"`
+	FailedGames    int        `json:"failedGames"`
+	RemainingGames int        `json:"remainingGames"`
+	Progress       float64    `json:"progress"` // Finished (done or failed) / total, 0-1
+	AvgGameSeconds float64    `json:"avgGameSeconds,omitempty"`
+	ETA            *time.Time `json:"eta,omitempty"`
+
+	// Failures lists failed games (single-job lookups only)
+	Failures []BackfillFailure `json:"failures,omitempty"`
+}
+
+// BackfillFailure is a game that exhausted its attempts
+type BackfillFailure struct {
+	GameID   int    `json:"gameId"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+}
+
+// backfillKind plans a job into games and processes one game
+type backfillKind struct {
+	workers int
+	plan    func(ctx context.Context, params BackfillParams) ([]int, error)
+	run     func(ctx context.Context, gameID int) error
+}
+
+// BackfillQueue runs backfills as durable jobs in SQLite. A job is planned
+// into one task per game on first run; each finished game is checkpointed,
+// so a restart resumes where it left off. Jobs run one at a time, oldest
+// first.
+type BackfillQueue struct {
+	mutex   sync.Mutex
+	started bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	wake    chan struct{}
+
+	activeJob       int64
+	cancelActive    context.CancelFunc
+	cancelRequested bool
+}
+
+var (
+	backfillQueue     *BackfillQueue
+	backfillQueueOnce sync.Once
+)
+
+// GetBackfillQueue returns the global backfill queue. It stores its jobs in
+// the global database, so InitDatabase must be called before it is used.
+func GetBackfillQueue() *BackfillQueue {
+	backfillQueueOnce.Do(func() {
+		backfillQueue = &BackfillQueue{
+			wake: make(chan struct{}, 1),
+		}
+	})
+	return backfillQueue
+}
+
+// lookupBackfillKind returns how to plan and run a kind of backfill
+func lookupBackfillKind(kind string) (backfillKind, bool) {
+	switch kind {
+	case BackfillKindGameResults:
+		// One at a time so the models learn games in order
+		return backfillKind{workers: 1, plan: planGameResultsBackfill, run: runGameResultsBackfill}, true
+	case BackfillKindPlayByPlay:
+		return backfillKind{workers: 2, plan: planPlayByPlayBackfill, run: runPlayByPlayBackfill}, true
+	}
+	return backfillKind{}, false
+}
+
+// database returns the global database or an error if it isn't initialized
+func (q *BackfillQueue) database() (*Database, error) {
+	db := GetDatabase()
+	if db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return db, nil
+}
+
+// ============================================================================
+// LIFECYCLE
+// ============================================================================
+
+// Start requeues jobs interrupted by the last shutdown and starts working
+// through the queue
+func (q *BackfillQueue) Start() error {
+	db, err := q.database()
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.started {
+		return nil
+	}
+
+	db.mu.Lock()
+	_, err = db.db.Exec(`UPDATE backfill_tasks SET status = ? WHERE status = ?`, BackfillTaskPending, BackfillTaskRunning)
+	var resumed sql.Result
+	if err == nil {
+		resumed, err = db.db.Exec(`UPDATE backfill_jobs SET status = ? WHERE status = ?`, BackfillJobQueued, BackfillJobRunning)
+	}
+	db.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to requeue interrupted backfills: %w", err)
+	}
+	if n, _ := resumed.RowsAffected(); n > 0 {
+		log.Printf("🔁 Resuming %d interrupted backfill job(s)", n)
+	}
+
+	q.started = true
+	q.ctx, q.cancel = context.WithCancel(BackgroundContext())
+	q.wg.Add(1)
+	go q.dispatch(q.ctx)
+	log.Printf("📦 Backfill queue started")
+	return nil
+}
+
+// Stop interrupts the running job, which is requeued to resume on the next
+// start, and waits for it to checkpoint, or until ctx is done
+func (q *BackfillQueue) Stop(ctx context.Context) error {
+	q.mutex.Lock()
+	if !q.started {
+		q.mutex.Unlock()
+		return nil
+	}
+	q.started = false
+	q.cancel()
+	q.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Printf("⏹️ Backfill queue stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("backfill still running at shutdown: %w", ctx.Err())
+	}
+}
+
+// IsRunning reports whether the queue has been started and not stopped
+func (q *BackfillQueue) IsRunning() bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.started
+}
+
+// dispatch runs queued jobs until ctx is cancelled
+func (q *BackfillQueue) dispatch(ctx context.Context) {
+	defer q.wg.Done()
+
+	for ctx.Err() == nil {
+		job, err := q.nextQueuedJob()
+		if err != nil {
+			log.Printf("⚠️ Backfill queue: %v", err)
+		}
+		if job != nil {
+			q.runJob(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-q.wake:
+		case <-time.After(backfillPollInterval):
+		}
+	}
+}
+
+// notify wakes the dispatcher if it's idle
+func (q *BackfillQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ============================================================================
+// JOBS
+// ============================================================================
+
+// Enqueue adds a backfill job. If an identical job is already queued or
+// running, that job is returned instead.
+func (q *BackfillQueue) Enqueue(kind string, params BackfillParams) (*BackfillJob, error) {
+	if _, ok := lookupBackfillKind(kind); !ok {
+		return nil, fmt.Errorf("unknown backfill kind %q", kind)
+	}
+	db, err := q.database()
+	if err != nil {
+		return nil, err
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode backfill params: %w", err)
+	}
+
+	db.mu.Lock()
+	var id int64
+	err = db.db.QueryRow(`SELECT id FROM backfill_jobs WHERE kind = ? AND params = ? AND status IN (?, ?) ORDER BY id LIMIT 1`,
+		kind, string(paramsJSON), BackfillJobQueued, BackfillJobRunning).Scan(&id)
+	if err == sql.ErrNoRows {
+		var result sql.Result
+		result, err = db.db.Exec(`INSERT INTO backfill_jobs (kind, params, status, created_at) VALUES (?, ?, ?, ?)`,
+			kind, string(paramsJSON), BackfillJobQueued, time.Now())
+		if err == nil {
+			id, err = result.LastInsertId()
+		}
+		if err == nil {
+			log.Printf("📦 Queued %s backfill job %d %s", kind, id, paramsJSON)
+		}
+	}
+	db.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue backfill: %w", err)
+	}
+
+	q.notify()
+	return q.Job(id)
+}
+
+// Job returns a job's status, progress and failed games
+func (q *BackfillQueue) Job(id int64) (*BackfillJob, error) {
+	db, err := q.database()
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	job, err := scanBackfillJob(db.db.QueryRow(backfillJobSelect+` WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrBackfillJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := loadBackfillProgress(db.db, job); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.db.Query(`SELECT game_id, attempts, last_error FROM backfill_tasks WHERE job_id = ? AND status = ? ORDER BY game_id`,
+		id, BackfillTaskFailed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var f BackfillFailure
+		if err := rows.Scan(&f.GameID, &f.Attempts, &f.Error); err != nil {
+			return nil, err
+		}
+		job.Failures = append(job.Failures, f)
+	}
+	return job, rows.Err()
+}
+
+// Jobs returns the most recent jobs, newest first
+func (q *BackfillQueue) Jobs(limit int) ([]BackfillJob, error) {
+	db, err := q.database()
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	rows, err := db.db.Query(backfillJobSelect+` ORDER BY id DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	var jobs []BackfillJob
+	for rows.Next() {
+		job, err := scanBackfillJob(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range jobs {
+		if err := loadBackfillProgress(db.db, &jobs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return jobs, nil
+}
+
+// Cancel stops a queued or running job. Finished games stay checkpointed;
+// Retry picks the job back up. A job running in another process (the server,
+// when called from the uhc CLI) gets a persisted cancel request that its
+// queue acts on before the next game attempt.
+func (q *BackfillQueue) Cancel(id int64) error {
+	db, err := q.database()
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	if q.activeJob == id && q.cancelActive != nil {
+		q.cancelRequested = true
+		q.cancelActive()
+		q.mutex.Unlock()
+		log.Printf("🛑 Cancelling backfill job %d", id)
+		return nil
+	}
+	q.mutex.Unlock()
+
+	db.mu.Lock()
+	result, err := db.db.Exec(`UPDATE backfill_jobs SET status = ?, finished_at = ? WHERE id = ? AND status = ?`,
+		BackfillJobCancelled, time.Now(), id, BackfillJobQueued)
+	db.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		log.Printf("🛑 Cancelled queued backfill job %d", id)
+		return nil
+	}
+
+	db.mu.Lock()
+	result, err = db.db.Exec(`UPDATE backfill_jobs SET cancel_requested = 1 WHERE id = ? AND status = ?`,
+		id, BackfillJobRunning)
+	db.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		log.Printf("🛑 Requested cancellation of running backfill job %d", id)
+		return nil
+	}
+
+	if _, err := q.Job(id); err != nil {
+		return err
+	}
+	return ErrBackfillJobFinished
+}
+
+// checkCancelRequest cancels the active job if Cancel was called for it from
+// another process, reporting whether it did
+func (q *BackfillQueue) checkCancelRequest(db *Database, jobID int64) bool {
+	var requested bool
+	db.mu.RLock()
+	err := db.db.QueryRow(`SELECT cancel_requested FROM backfill_jobs WHERE id = ?`, jobID).Scan(&requested)
+	db.mu.RUnlock()
+	if err != nil || !requested {
+		return false
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.activeJob != jobID || q.cancelActive == nil {
+		return false
+	}
+	if !q.cancelRequested {
+		log.Printf("🛑 Cancelling backfill job %d (requested from another process)", jobID)
+	}
+	q.cancelRequested = true
+	q.cancelActive()
+	return true
+}
+
+// Retry requeues a finished job: failed games get fresh attempts, and a
+// cancelled or failed job carries on with the games it hadn't reached
+func (q *BackfillQueue) Retry(id int64) error {
+	job, err := q.Job(id)
+	if err != nil {
+		return err
+	}
+	if job.Status == BackfillJobQueued || job.Status == BackfillJobRunning {
+		return ErrBackfillJobActive
+	}
+	if job.Status == BackfillJobCompleted && job.FailedGames == 0 {
+		return ErrBackfillNothingToRetry
+	}
+
+	db, err := q.database()
+	if err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	_, err = db.db.Exec(`UPDATE backfill_tasks SET status = ?, attempts = 0, last_error = '' WHERE job_id = ? AND status = ?`,
+		BackfillTaskPending, id, BackfillTaskFailed)
+	if err == nil {
+		_, err = db.db.Exec(`UPDATE backfill_jobs SET status = ?, error = '', finished_at = NULL, cancel_requested = 0 WHERE id = ?`,
+			BackfillJobQueued, id)
+	}
+	db.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🔁 Requeued backfill job %d (%d failed games)", id, job.FailedGames)
+	q.notify()
+	return nil
+}
+
+// ============================================================================
+// RUNNING JOBS
+// ============================================================================
+
+// queuedBackfillJob is what the dispatcher needs to run a job
+type queuedBackfillJob struct {
+	id      int64
+	kind    string
+	params  BackfillParams
+	planned bool
+}
+
+// nextQueuedJob returns the oldest queued job, or nil if there is none
+func (q *BackfillQueue) nextQueuedJob() (*queuedBackfillJob, error) {
+	db, err := q.database()
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var job queuedBackfillJob
+	var params string
+	err = db.db.QueryRow(`SELECT id, kind, params, planned FROM backfill_jobs WHERE status = ? ORDER BY id LIMIT 1`,
+		BackfillJobQueued).Scan(&job.id, &job.kind, &params, &job.planned)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load next job: %w", err)
+	}
+	if err := json.Unmarshal([]byte(params), &job.params); err != nil {
+		return nil, fmt.Errorf("failed to decode params of job %d: %w", job.id, err)
+	}
+	return &job, nil
+}
+
+// runJob plans the job if needed, then works through its pending games
+func (q *BackfillQueue) runJob(ctx context.Context, job *queuedBackfillJob) {
+	db, err := q.database()
+	if err != nil {
+		return
+	}
+
+	kind, ok := lookupBackfillKind(job.kind)
+	if !ok {
+		q.finishJob(db, job.id, BackfillJobFailed, fmt.Sprintf("unknown backfill kind %q", job.kind))
+		return
+	}
+
+	// Claim the job; it may have been cancelled since it was picked
+	db.mu.Lock()
+	now := time.Now()
+	result, err := db.db.Exec(`UPDATE backfill_jobs SET status = ?, started_at = COALESCE(started_at, ?) WHERE id = ? AND status = ?`,
+		BackfillJobRunning, now, job.id, BackfillJobQueued)
+	db.mu.Unlock()
+	if err != nil {
+		log.Printf("⚠️ Failed to start backfill job %d: %v", job.id, err)
+		sleepContext(ctx, backfillPollInterval)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	q.mutex.Lock()
+	q.activeJob, q.cancelActive, q.cancelRequested = job.id, cancel, false
+	q.mutex.Unlock()
+	defer func() {
+		q.mutex.Lock()
+		q.activeJob, q.cancelActive = 0, nil
+		q.mutex.Unlock()
+	}()
+
+	log.Printf("▶️ Backfill job %d (%s) started", job.id, job.kind)
+
+	if !job.planned {
+		gameIDs, err := kind.plan(jobCtx, job.params)
+		if err == nil {
+			err = q.saveTasks(db, job.id, gameIDs)
+		}
+		if err != nil {
+			if jobCtx.Err() != nil {
+				q.interruptJob(ctx, db, job.id)
+				return
+			}
+			log.Printf("❌ Backfill job %d failed to plan: %v", job.id, err)
+			q.finishJob(db, job.id, BackfillJobFailed, err.Error())
+			return
+		}
+		log.Printf("📋 Backfill job %d planned: %d games", job.id, len(gameIDs))
+	}
+
+	tasks, err := q.pendingTasks(db, job.id)
+	if err != nil {
+		log.Printf("❌ Backfill job %d: %v", job.id, err)
+		q.finishJob(db, job.id, BackfillJobFailed, err.Error())
+		return
+	}
+
+	workers := kind.workers
+	if workers > maxBackfillWorkers {
+		workers = maxBackfillWorkers
+	}
+	taskCh := make(chan pendingBackfillTask)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range taskCh {
+				q.runTask(jobCtx, db, job.id, kind, task)
+			}
+		}()
+	}
+	for _, task := range tasks {
+		if jobCtx.Err() != nil {
+			break
+		}
+		taskCh <- task
+	}
+	close(taskCh)
+	wg.Wait()
+
+	if jobCtx.Err() != nil {
+		q.interruptJob(ctx, db, job.id)
+		return
+	}
+
+	progress, _ := q.Job(job.id)
+	errMsg := ""
+	if progress != nil && progress.FailedGames > 0 {
+		errMsg = fmt.Sprintf("%d of %d games failed", progress.FailedGames, progress.TotalGames)
+	}
+	q.finishJob(db, job.id, BackfillJobCompleted, errMsg)
+	if progress != nil {
+		log.Printf("🎉 Backfill job %d complete: %d done, %d failed", job.id, progress.DoneGames, progress.FailedGames)
+	}
+}
+
+// interruptJob records why a job stopped early: cancelled jobs are final,
+// jobs stopped by shutdown go back in the queue to resume on restart
+func (q *BackfillQueue) interruptJob(ctx context.Context, db *Database, id int64) {
+	q.mutex.Lock()
+	cancelled := q.cancelRequested
+	q.mutex.Unlock()
+
+	if cancelled && ctx.Err() == nil {
+		q.finishJob(db, id, BackfillJobCancelled, "")
+		log.Printf("🛑 Backfill job %d cancelled", id)
+		return
+	}
+
+	db.mu.Lock()
+	_, err := db.db.Exec(`UPDATE backfill_jobs SET status = ? WHERE id = ?`, BackfillJobQueued, id)
+	db.mu.Unlock()
+	if err != nil {
+		log.Printf("⚠️ Failed to requeue backfill job %d: %v", id, err)
+		return
+	}
+	log.Printf("⏸️ Backfill job %d interrupted; it will resume on next start", id)
+}
+
+// finishJob sets a job's final status
+func (q *BackfillQueue) finishJob(db *Database, id int64, status, errMsg string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, err := db.db.Exec(`UPDATE backfill_jobs SET status = ?, error = ?, finished_at = ? WHERE id = ?`,
+		status, errMsg, time.Now(), id); err != nil {
+		log.Printf("⚠️ Failed to update backfill job %d: %v", id, err)
+	}
+}
+
+// saveTasks stores a job's games and marks it planned, in one transaction
+func (q *BackfillQueue) saveTasks(db *Database, jobID int64, gameIDs []int) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO backfill_tasks (job_id, game_id, status, updated_at) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, gameID := range gameIDs {
+		if _, err := stmt.Exec(jobID, gameID, BackfillTaskPending, now); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`UPDATE backfill_jobs SET planned = 1 WHERE id = ?`, jobID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// pendingBackfillTask is a game still to be processed
+type pendingBackfillTask struct {
+	gameID   int
+	attempts int
+}
+
+// pendingTasks returns a job's unfinished games in game ID (roughly
+// chronological) order
+func (q *BackfillQueue) pendingTasks(db *Database, jobID int64) ([]pendingBackfillTask, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	rows, err := db.db.Query(`SELECT game_id, attempts FROM backfill_tasks WHERE job_id = ? AND status = ? ORDER BY game_id`,
+		jobID, BackfillTaskPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pending games: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []pendingBackfillTask
+	for rows.Next() {
+		var task pendingBackfillTask
+		if err := rows.Scan(&task.gameID, &task.attempts); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// runTask processes one game, retrying with backoff, and checkpoints the
+// outcome. An attempt interrupted by cancellation isn't counted.
+func (q *BackfillQueue) runTask(ctx context.Context, db *Database, jobID int64, kind backfillKind, task pendingBackfillTask) {
+	for task.attempts < backfillMaxAttempts {
+		if q.checkCancelRequest(db, jobID) || ctx.Err() != nil {
+			return
+		}
+		q.updateTask(db, jobID, task.gameID, BackfillTaskRunning, task.attempts+1, "", 0)
+
+		start := time.Now()
+		err := kind.run(ctx, task.gameID)
+		if ctx.Err() != nil {
+			q.updateTask(db, jobID, task.gameID, BackfillTaskPending, task.attempts, "", 0)
+			return
+		}
+		task.attempts++
+		if err == nil {
+			q.updateTask(db, jobID, task.gameID, BackfillTaskDone, task.attempts, "", time.Since(start))
+			return
+		}
+
+		if task.attempts >= backfillMaxAttempts {
+			log.Printf("❌ Backfill job %d: game %d failed after %d attempts: %v", jobID, task.gameID, task.attempts, err)
+			q.updateTask(db, jobID, task.gameID, BackfillTaskFailed, task.attempts, err.Error(), 0)
+			return
+		}
+		log.Printf("⚠️ Backfill job %d: game %d attempt %d failed, retrying: %v", jobID, task.gameID, task.attempts, err)
+		q.updateTask(db, jobID, task.gameID, BackfillTaskPending, task.attempts, err.Error(), 0)
+		if sleepContext(ctx, backfillRetryDelay*time.Duration(task.attempts)) != nil {
+			return
+		}
+	}
+}
+
+// updateTask checkpoints a game's status
+func (q *BackfillQueue) updateTask(db *Database, jobID int64, gameID int, status string, attempts int, errMsg string, duration time.Duration) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, err := db.db.Exec(`UPDATE backfill_tasks SET status = ?, attempts = ?, last_error = ?, duration_ms = ?, updated_at = ? WHERE job_id = ? AND game_id = ?`,
+		status, attempts, errMsg, duration.Milliseconds(), time.Now(), jobID, gameID); err != nil {
+		log.Printf("⚠️ Failed to checkpoint game %d of backfill job %d: %v", gameID, jobID, err)
+	}
+}
+
+// ============================================================================
+// QUERIES
+// ============================================================================
+
+const backfillJobSelect = `SELECT id, kind, params, status, error, created_at, started_at, finished_at FROM backfill_jobs`
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanBackfillJob reads a row selected with backfillJobSelect
+func scanBackfillJob(row rowScanner) (*BackfillJob, error) {
+	var job BackfillJob
+	var params string
+	var startedAt, finishedAt sql.NullTime
+	if err := row.Scan(&job.ID, &job.Kind, &params, &job.Status, &job.Error, &job.CreatedAt, &startedAt, &finishedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(params), &job.Params); err != nil {
+		return nil, fmt.Errorf("failed to decode params of job %d: %w", job.ID, err)
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
+
+// loadBackfillProgress fills in a job's game counts, progress and ETA. The
+// ETA assumes the remaining games take as long as the finished ones did.
+func loadBackfillProgress(db *sql.DB, job *BackfillJob) error {
+	rows, err := db.Query(`SELECT status, COUNT(*), COALESCE(SUM(duration_ms), 0) FROM backfill_tasks WHERE job_id = ? GROUP BY status`, job.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var doneMillis int64
+	for rows.Next() {
+		var status string
+		var count int
+		var millis int64
+		if err := rows.Scan(&status, &count, &millis); err != nil {
+			return err
+		}
+		job.TotalGames += count
+		switch status {
+		case BackfillTaskDone:
+			job.DoneGames = count
+			doneMillis = millis
+		case BackfillTaskFailed:
+			job.FailedGames = count
+		default:
+			job.RemainingGames += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if job.TotalGames > 0 {
+		job.Progress = float64(job.DoneGames+job.FailedGames) / float64(job.TotalGames)
+	}
+	if job.DoneGames > 0 {
+		job.AvgGameSeconds = float64(doneMillis) / float64(job.DoneGames) / 1000
+	}
+	if job.Status == BackfillJobRunning && job.DoneGames > 0 && job.RemainingGames > 0 {
+		workers := 1
+		if kind, ok := lookupBackfillKind(job.Kind); ok && kind.workers > 1 {
+			workers = kind.workers
+		}
+		remaining := time.Duration(job.AvgGameSeconds * float64(job.RemainingGames) / float64(workers) * float64(time.Second))
+		eta := time.Now().Add(remaining)
+		job.ETA = &eta
+	}
+	return nil
+}
+
+// ============================================================================
+// BACKFILL KINDS
+// ============================================================================
+
+// planGameResultsBackfill lists the unprocessed completed games on one date
+// or over the last N days, oldest first
+func planGameResultsBackfill(ctx context.Context, params BackfillParams) ([]int, error) {
+	grs := GetGameResultsService()
+	if grs == nil {
+		return nil, fmt.Errorf("game results service not available")
+	}
+
+	var dates []time.Time
+	if params.Date != "" {
+		date, err := time.Parse("2006-01-02", params.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", params.Date, err)
+		}
+		dates = append(dates, date)
+	} else {
+		days := params.Days
+		if days <= 0 {
+			days = 7
+		}
+		for i := days; i >= 1; i-- {
+			dates = append(dates, time.Now().AddDate(0, 0, -i))
+		}
+	}
+
+	var gameIDs []int
+	for _, date := range dates {
+		games, err := grs.unprocessedGamesForDate(ctx, date)
+		if err != nil {
+			return nil, err
+		}
+		for _, game := range games {
+			gameIDs = append(gameIDs, game.GameID)
+		}
+	}
+	return gameIDs, nil
+}
+
+func runGameResultsBackfill(ctx context.Context, gameID int) error {
+	grs := GetGameResultsService()
+	if grs == nil {
+		return fmt.Errorf("game results service not available")
+	}
+	return grs.BackfillGame(ctx, gameID)
+}
+
+// planPlayByPlayBackfill lists the last N completed games of one team or of
+// every team, without duplicates. Teams whose schedule can't be fetched are
+// skipped unless all of them fail.
+func planPlayByPlayBackfill(ctx context.Context, params BackfillParams) ([]int, error) {
+	pbp := GetPlayByPlayService()
+	if pbp == nil {
+		return nil, fmt.Errorf("play-by-play service not available")
+	}
+
+	teams := NHLTeamCodes
+	if params.Team != "" {
+		teams = []string{params.Team}
+	}
+	numGames := params.Games
+	if numGames <= 0 {
+		numGames = 10
+	}
+
+	seen := make(map[int]bool)
+	var lastErr error
+	for _, team := range teams {
+		games, err := pbp.getCompletedGames(ctx, team, numGames)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Printf("⚠️ Skipping %s in play-by-play backfill: %v", team, err)
+			lastErr = err
+			continue
+		}
+		for _, game := range games {
+			seen[game.ID] = true
+		}
+	}
+	if len(seen) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	gameIDs := make([]int, 0, len(seen))
+	for gameID := range seen {
+		gameIDs = append(gameIDs, gameID)
+	}
+	sort.Ints(gameIDs)
+	return gameIDs, nil
+}
+
+func runPlayByPlayBackfill(ctx context.Context, gameID int) error {
+	pbp := GetPlayByPlayService()
+	if pbp == nil {
+		return fmt.Errorf("play-by-play service not available")
+	}
+	return pbp.BackfillGame(ctx, gameID)
+}
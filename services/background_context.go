@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// The background context is the root for work that isn't tied to a single
+// HTTP request: polling loops, periodic saves, backfills and fetches shared
+// between callers. It is cancelled once at shutdown so that work stops
+// instead of running to completion.
+var backgroundCtx, cancelBackgroundCtx = context.WithCancel(context.Background())
+
+// BackgroundContext returns the context cancelled at shutdown
+func BackgroundContext() context.Context {
+	return backgroundCtx
+}
+
+// CancelBackground cancels the background context, stopping loops and
+// backfills started with it. Safe to call more than once.
+func CancelBackground() {
+	cancelBackgroundCtx()
+}
+
+// sleepContext waits for d or until ctx is done, returning ctx.Err() if it
+// was cancelled first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,715 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// BayesianRatingModel is a dynamic state-space model of team strength. Each
+// team has a Gaussian belief over log-scale offense and defense, and the
+// league has beliefs over a scoring intercept and home ice. Goals are Poisson
+// with log rate intercept + home + offense - opposing defense; after every
+// result the beliefs get an extended Kalman (Laplace) update, and between
+// games their variances grow as a random walk so ratings can drift.
+type BayesianRatingModel struct {
+	teams          map[string]*TeamRatingPosterior
+	history        map[string][]TeamRatingSnapshot
+	intercept      GaussianBelief
+	homeIce        GaussianBelief
+	processedGames map[int]bool
+	lastGameDate   time.Time
+	calibration    BayesianRatingCalibration
+	weight         float64
+	dataDir        string
+	lastUpdated    time.Time
+	quiet          bool // Suppresses per-game logs during replays
+	mutex          sync.RWMutex
+}
+
+// GaussianBelief is a normal posterior over one latent parameter
+type GaussianBelief struct {
+	Mean     float64 `json:"mean"`
+	Variance float64 `json:"variance"`
+}
+
+// TeamRatingPosterior is the current belief about one team
+type TeamRatingPosterior struct {
+	TeamCode    string         `json:"teamCode"`
+	Offense     GaussianBelief `json:"offense"` // Log goals-for multiplier
+	Defense     GaussianBelief `json:"defense"` // Log goals-against suppression
+	GamesPlayed int            `json:"gamesPlayed"`
+	LastGame    time.Time      `json:"lastGame"`
+}
+
+// Net returns the team's expected log goal-rate advantage and its variance
+func (p TeamRatingPosterior) Net() GaussianBelief {
+	return GaussianBelief{
+		Mean:     p.Offense.Mean + p.Defense.Mean,
+		Variance: p.Offense.Variance + p.Defense.Variance,
+	}
+}
+
+// TeamRatingSnapshot records a team's posterior after one game
+type TeamRatingSnapshot struct {
+	Date     time.Time      `json:"date"`
+	GameID   int            `json:"gameId"`
+	Opponent string         `json:"opponent"`
+	WasHome  bool           `json:"wasHome"`
+	Score    string         `json:"score"`
+	Offense  GaussianBelief `json:"offense"`
+	Defense  GaussianBelief `json:"defense"`
+}
+
+// BayesianRatingCalibration tracks how the model's pre-game probabilities
+// compare with outcomes, in ten probability bins
+type BayesianRatingCalibration struct {
+	Games   int                `json:"games"`
+	LogLoss float64            `json:"logLoss"`
+	Brier   float64            `json:"brier"`
+	Bins    [10]CalibrationBin `json:"bins"`
+}
+
+// CalibrationBin accumulates predictions falling in one probability range
+type CalibrationBin struct {
+	Count         int     `json:"count"`
+	PredictedSum  float64 `json:"predictedSum"`
+	HomeWins      int     `json:"homeWins"`
+	MeanPredicted float64 `json:"meanPredicted"`
+	ObservedRate  float64 `json:"observedRate"`
+}
+
+// bayesianRatingData is the persisted model state
+type bayesianRatingData struct {
+	Teams          map[string]*TeamRatingPosterior `json:"teams"`
+	History        map[string][]TeamRatingSnapshot `json:"history"`
+	Intercept      GaussianBelief                  `json:"intercept"`
+	HomeIce        GaussianBelief                  `json:"homeIce"`
+	ProcessedGames map[int]bool                    `json:"processedGames"`
+	LastGameDate   time.Time                       `json:"lastGameDate"`
+	Calibration    BayesianRatingCalibration       `json:"calibration"`
+	LastUpdated    time.Time                       `json:"lastUpdated"`
+	Version        string                          `json:"version"`
+}
+
+const (
+	bayesPriorTeamVariance    = 0.15 * 0.15 // New teams: strength within ~±30% of average
+	bayesTeamNoisePerDay      = 0.00002     // Random-walk drift in offense/defense
+	bayesInterceptNoisePerDay = 0.00001     // League scoring environment drift
+	bayesHomeNoisePerDay      = 0.000001
+	bayesMaxHistory           = 164 // Two seasons of snapshots per team
+)
+
+// gaussHermite5 returns the 5-point Gauss-Hermite rule for a standard normal
+func gaussHermite5() (nodes, weights [5]float64) {
+	inner, outer := math.Sqrt(5-math.Sqrt(10)), math.Sqrt(5+math.Sqrt(10))
+	nodes = [5]float64{-outer, -inner, 0, inner, outer}
+	for i, x := range nodes {
+		he4 := x*x*x*x - 6*x*x + 3
+		weights[i] = 120.0 / (25.0 * he4 * he4) // n! / (n² He₄(x)²)
+	}
+	return nodes, weights
+}
+
+var (
+	bayesianRatingModel     *BayesianRatingModel
+	bayesianRatingModelOnce sync.Once
+)
+
+// NewBayesianRatingModel creates the rating model, loading saved state or
+// replaying stored results when there is none
+func NewBayesianRatingModel() *BayesianRatingModel {
+	bayesianRatingModelOnce.Do(func() {
+		bayesianRatingModel = newBayesianRatingState()
+		bayesianRatingModel.dataDir = "data/models"
+		os.MkdirAll(bayesianRatingModel.dataDir, 0755)
+
+		if err := bayesianRatingModel.load(); err != nil {
+			log.Printf("📐 No saved Bayesian ratings (%v); replaying stored results", err)
+			bayesianRatingModel.replayStoredResults()
+		} else {
+			log.Printf("📐 Bayesian ratings loaded: %d teams, %d games", len(bayesianRatingModel.teams), len(bayesianRatingModel.processedGames))
+		}
+	})
+	return bayesianRatingModel
+}
+
+// GetBayesianRatingModel returns the singleton instance
+func GetBayesianRatingModel() *BayesianRatingModel {
+	if bayesianRatingModel == nil {
+		return NewBayesianRatingModel()
+	}
+	return bayesianRatingModel
+}
+
+// newBayesianRatingState returns a model with league priors and no teams
+func newBayesianRatingState() *BayesianRatingModel {
+	return &BayesianRatingModel{
+		teams:          make(map[string]*TeamRatingPosterior),
+		history:        make(map[string][]TeamRatingSnapshot),
+		intercept:      GaussianBelief{Mean: math.Log(3.0), Variance: 0.01},
+		homeIce:        GaussianBelief{Mean: math.Log(1.05), Variance: 0.0025},
+		processedGames: make(map[int]bool),
+		weight:         0.08,
+		lastUpdated:    time.Now(),
+	}
+}
+
+// Rebuild discards the learned ratings and replays every stored result,
+// returning the number of games replayed
+func (brm *BayesianRatingModel) Rebuild() int {
+	fresh := newBayesianRatingState()
+
+	brm.mutex.Lock()
+	brm.teams = fresh.teams
+	brm.history = fresh.history
+	brm.intercept = fresh.intercept
+	brm.homeIce = fresh.homeIce
+	brm.processedGames = fresh.processedGames
+	brm.lastGameDate = time.Time{}
+	brm.calibration = BayesianRatingCalibration{}
+	brm.mutex.Unlock()
+
+	return brm.replayStoredResults()
+}
+
+// replayStoredResults seeds the ratings from completed games on disk
+func (brm *BayesianRatingModel) replayStoredResults() int {
+	games, err := loadCompletedGamesFrom("data/results")
+	if err != nil || len(games) == 0 {
+		return 0
+	}
+
+	brm.mutex.Lock()
+	brm.quiet = true
+	for i, game := range games {
+		if i > 0 && game.Season > games[i-1].Season {
+			brm.rollover(seasonRatingCarryover, nil, nil)
+		}
+		brm.update(tuningGameResult(game))
+	}
+	brm.quiet = false
+	brm.mutex.Unlock()
+
+	log.Printf("📐 Bayesian ratings seeded from %d stored games", len(games))
+	if err := brm.save(); err != nil {
+		log.Printf("⚠️ Failed to save Bayesian ratings: %v", err)
+	}
+	return len(games)
+}
+
+// replayBayesianRatings runs a fresh model over date-sorted games, returning
+// each game's home win probability from before its result was applied
+func replayBayesianRatings(games []models.CompletedGame) []float64 {
+	probs := make([]float64, len(games))
+	replayBayesianRatingsWith(games, func(i int, replay *BayesianRatingModel) {
+		game := games[i]
+		probs[i] = replay.predictiveDistribution(game.HomeTeam.TeamCode, game.AwayTeam.TeamCode, game.GameDate).HomeWinProbability
+	})
+	return probs
+}
+
+// replayBayesianRatingsWith runs a fresh model over date-sorted games, calling
+// visit with the model as it stood before each game's result was applied
+func replayBayesianRatingsWith(games []models.CompletedGame, visit func(i int, replay *BayesianRatingModel)) {
+	replay := newBayesianRatingState()
+	replay.quiet = true
+
+	for i, game := range games {
+		if i > 0 && game.Season > games[i-1].Season {
+			replay.rollover(seasonRatingCarryover, nil, nil)
+		}
+		visit(i, replay)
+		replay.update(tuningGameResult(game))
+	}
+}
+
+// GetName implements PredictionModel
+func (brm *BayesianRatingModel) GetName() string {
+	return "Bayesian Ratings"
+}
+
+// GetWeight implements PredictionModel
+func (brm *BayesianRatingModel) GetWeight() float64 {
+	return brm.weight
+}
+
+// Predict implements PredictionModel using the full predictive distribution
+func (brm *BayesianRatingModel) Predict(homeFactors, awayFactors *models.PredictionFactors) (*models.ModelResult, error) {
+	start := time.Now()
+
+	dist := brm.PredictiveDistribution(homeFactors.TeamCode, awayFactors.TeamCode)
+
+	homeGoals := int(math.Round(dist.HomeGoalsMean))
+	awayGoals := int(math.Round(dist.AwayGoalsMean))
+	if homeGoals == awayGoals {
+		if dist.HomeWinProbability >= 0.5 {
+			homeGoals++
+		} else {
+			awayGoals++
+		}
+	}
+
+	// Confidence falls as rating uncertainty widens the spread of P(win)
+	confidence := math.Max(0.30, math.Min(0.95, 1.0-4.0*dist.HomeWinStdDev))
+
+	return &models.ModelResult{
+		ModelName:      brm.GetName(),
+		WinProbability: dist.HomeWinProbability,
+		Confidence:     confidence,
+		PredictedScore: fmt.Sprintf("%d-%d", homeGoals, awayGoals),
+		Weight:         brm.weight,
+		ProcessingTime: time.Since(start).Milliseconds(),
+		Distribution:   &dist,
+	}, nil
+}
+
+// PredictiveDistribution integrates the Poisson score model over the rating
+// posteriors, so P(home win) reflects how unsure we are about both teams
+func (brm *BayesianRatingModel) PredictiveDistribution(homeTeam, awayTeam string) models.PredictiveDistribution {
+	brm.mutex.RLock()
+	defer brm.mutex.RUnlock()
+
+	date := time.Now()
+	if brm.lastGameDate.After(date) {
+		date = brm.lastGameDate
+	}
+	return brm.predictiveDistribution(homeTeam, awayTeam, date)
+}
+
+// predictiveDistribution evaluates the distribution for a game on date; callers hold the lock
+func (brm *BayesianRatingModel) predictiveDistribution(homeTeam, awayTeam string, date time.Time) models.PredictiveDistribution {
+	home := brm.posterior(homeTeam, date)
+	away := brm.posterior(awayTeam, date)
+
+	// Log scoring rates are jointly normal; they share only the intercept
+	homeMean := brm.intercept.Mean + brm.homeIce.Mean + home.Offense.Mean - away.Defense.Mean
+	awayMean := brm.intercept.Mean + away.Offense.Mean - home.Defense.Mean
+	homeVar := brm.intercept.Variance + brm.homeIce.Variance + home.Offense.Variance + away.Defense.Variance
+	awayVar := brm.intercept.Variance + away.Offense.Variance + home.Defense.Variance
+	covariance := brm.intercept.Variance
+
+	winMean, winSq, tie := integrateWinProbability(homeMean, awayMean, homeVar, awayVar, covariance)
+	stdDev := math.Sqrt(math.Max(winSq-winMean*winMean, 0))
+
+	// Lognormal moments for the goal rates, plus Poisson noise for goals
+	homeRate := math.Exp(homeMean + homeVar/2)
+	awayRate := math.Exp(awayMean + awayVar/2)
+
+	return models.PredictiveDistribution{
+		HomeWinProbability: winMean,
+		HomeWinStdDev:      stdDev,
+		HomeWinLower:       math.Max(0, winMean-1.645*stdDev),
+		HomeWinUpper:       math.Min(1, winMean+1.645*stdDev),
+		TieProbability:     tie,
+		HomeGoalsMean:      homeRate,
+		HomeGoalsVariance:  homeRate + (math.Exp(homeVar)-1)*homeRate*homeRate,
+		AwayGoalsMean:      awayRate,
+		AwayGoalsVariance:  awayRate + (math.Exp(awayVar)-1)*awayRate*awayRate,
+	}
+}
+
+// integrateWinProbability returns E[P(win)], E[P(win)²] and E[P(tie)] over
+// jointly normal log scoring rates
+func integrateWinProbability(homeMean, awayMean, homeVar, awayVar, covariance float64) (float64, float64, float64) {
+	// Cholesky factor of the 2x2 covariance
+	l11 := math.Sqrt(homeVar)
+	l21 := covariance / l11
+	l22 := math.Sqrt(math.Max(awayVar-l21*l21, 1e-12))
+
+	nodes, weights := gaussHermite5()
+	var winMean, winSq, tie float64
+	for i, z1 := range nodes {
+		for j, z2 := range nodes {
+			w := weights[i] * weights[j]
+			lambdaHome := math.Exp(homeMean + l11*z1)
+			lambdaAway := math.Exp(awayMean + l21*z1 + l22*z2)
+			p, t := poissonWinTieProbabilities(lambdaHome, lambdaAway)
+			winMean += w * p
+			winSq += w * p * p
+			tie += w * t
+		}
+	}
+	return winMean, winSq, tie
+}
+
+// Explain attributes P(home win) to home ice and each team's offense and
+// defense with exact Shapley values. The baseline sets every rating to the
+// league average while keeping the posterior uncertainty.
+func (brm *BayesianRatingModel) Explain(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionExplanation, error) {
+	brm.mutex.RLock()
+	defer brm.mutex.RUnlock()
+
+	date := time.Now()
+	if brm.lastGameDate.After(date) {
+		date = brm.lastGameDate
+	}
+	names := []string{
+		"Home Ice",
+		homeFactors.TeamCode + " Offense Rating", awayFactors.TeamCode + " Defense Rating",
+		awayFactors.TeamCode + " Offense Rating", homeFactors.TeamCode + " Defense Rating",
+	}
+	values, homeVar, awayVar := brm.termValues(homeFactors.TeamCode, awayFactors.TeamCode, date)
+	value := func(mask int) float64 {
+		return bayesianTermWinProbability(values, mask, brm.intercept, homeVar, awayVar)
+	}
+
+	phi := exactShapley(len(values), value)
+	all := 1<<len(values) - 1
+	return newPredictionExplanation("shapley", value(0), value(all), names, values, phi), nil
+}
+
+// termValues returns Explain's log-rate terms for a game on date, with the
+// variances of both log scoring rates; callers hold the lock
+func (brm *BayesianRatingModel) termValues(homeTeam, awayTeam string, date time.Time) ([]float64, float64, float64) {
+	home := brm.posterior(homeTeam, date)
+	away := brm.posterior(awayTeam, date)
+
+	// The first three move the home rate, the last two the away rate
+	values := []float64{brm.homeIce.Mean, home.Offense.Mean, -away.Defense.Mean, away.Offense.Mean, -home.Defense.Mean}
+	homeVar := brm.intercept.Variance + brm.homeIce.Variance + home.Offense.Variance + away.Defense.Variance
+	awayVar := brm.intercept.Variance + away.Offense.Variance + home.Defense.Variance
+	return values, homeVar, awayVar
+}
+
+// bayesianTermWinProbability is E[P(home win)] from the log-rate terms in
+// mask; terms outside it contribute nothing, but the variances stay whole
+func bayesianTermWinProbability(values []float64, mask int, intercept GaussianBelief, homeVar, awayVar float64) float64 {
+	homeMean, awayMean := intercept.Mean, intercept.Mean
+	for i, v := range values {
+		if mask&(1<<i) == 0 {
+			continue
+		}
+		if i < 3 {
+			homeMean += v
+		} else {
+			awayMean += v
+		}
+	}
+	winMean, _, _ := integrateWinProbability(homeMean, awayMean, homeVar, awayVar, intercept.Variance)
+	return winMean
+}
+
+// poissonWinTieProbabilities returns P(home wins) and P(level after regulation)
+// for independent Poisson scores. Overtime goes to whichever team scores first,
+// which for Poisson rates is home with probability λh/(λh+λa).
+func poissonWinTieProbabilities(lambdaHome, lambdaAway float64) (float64, float64) {
+	const maxGoals = 15
+	pmf := func(lambda float64) []float64 {
+		p := make([]float64, maxGoals+1)
+		p[0] = math.Exp(-lambda)
+		for k := 1; k <= maxGoals; k++ {
+			p[k] = p[k-1] * lambda / float64(k)
+		}
+		return p
+	}
+
+	home, away := pmf(lambdaHome), pmf(lambdaAway)
+	win, tie := 0.0, 0.0
+	for h := 0; h <= maxGoals; h++ {
+		for a := 0; a <= maxGoals; a++ {
+			if h > a {
+				win += home[h] * away[a]
+			} else if h == a {
+				tie += home[h] * away[a]
+			}
+		}
+	}
+	return win + tie*lambdaHome/(lambdaHome+lambdaAway), tie
+}
+
+// posterior returns a team's belief propagated to date; callers hold the lock
+func (brm *BayesianRatingModel) posterior(teamCode string, date time.Time) TeamRatingPosterior {
+	team, exists := brm.teams[teamCode]
+	if !exists {
+		return TeamRatingPosterior{
+			TeamCode: teamCode,
+			Offense:  GaussianBelief{Variance: bayesPriorTeamVariance},
+			Defense:  GaussianBelief{Variance: bayesPriorTeamVariance},
+		}
+	}
+
+	propagated := *team
+	if !team.LastGame.IsZero() && date.After(team.LastGame) {
+		drift := bayesTeamNoisePerDay * date.Sub(team.LastGame).Hours() / 24
+		propagated.Offense.Variance = math.Min(propagated.Offense.Variance+drift, bayesPriorTeamVariance)
+		propagated.Defense.Variance = math.Min(propagated.Defense.Variance+drift, bayesPriorTeamVariance)
+	}
+	return propagated
+}
+
+// processGameResult updates the ratings with a final score
+func (brm *BayesianRatingModel) processGameResult(gameResult *models.GameResult) error {
+	if gameResult.GameState != "FINAL" && gameResult.GameState != "OFF" {
+		return fmt.Errorf("game %d not finished yet", gameResult.GameID)
+	}
+
+	brm.mutex.Lock()
+	updated := brm.update(gameResult)
+	brm.mutex.Unlock()
+
+	if !updated {
+		return nil
+	}
+	return brm.save()
+}
+
+// update applies one game; callers hold the lock. Returns false for duplicates.
+func (brm *BayesianRatingModel) update(gameResult *models.GameResult) bool {
+	if brm.processedGames[gameResult.GameID] {
+		return false
+	}
+
+	date := gameResult.GameDate
+	homeCode, awayCode := gameResult.HomeTeam, gameResult.AwayTeam
+
+	// Score the pre-game prediction before learning from the result
+	prior := brm.predictiveDistribution(homeCode, awayCode, date)
+	brm.recordCalibration(prior.HomeWinProbability, gameResult.HomeScore > gameResult.AwayScore)
+
+	// Time update: let beliefs drift since they were last touched
+	if !brm.lastGameDate.IsZero() && date.After(brm.lastGameDate) {
+		days := date.Sub(brm.lastGameDate).Hours() / 24
+		brm.intercept.Variance += bayesInterceptNoisePerDay * days
+		brm.homeIce.Variance += bayesHomeNoisePerDay * days
+	}
+	home := brm.posterior(homeCode, date)
+	away := brm.posterior(awayCode, date)
+
+	// Shootout winners are credited a goal that wasn't scored at even strength
+	homeGoals, awayGoals := float64(gameResult.HomeScore), float64(gameResult.AwayScore)
+	if gameResult.IsShootout {
+		if homeGoals > awayGoals {
+			homeGoals--
+		} else {
+			awayGoals--
+		}
+	}
+
+	// Measurement updates: home goals, then away goals
+	kalmanPoissonUpdate(homeGoals, []*GaussianBelief{&brm.intercept, &brm.homeIce, &home.Offense, &away.Defense}, []float64{1, 1, 1, -1})
+	kalmanPoissonUpdate(awayGoals, []*GaussianBelief{&brm.intercept, &away.Offense, &home.Defense}, []float64{1, 1, -1})
+
+	home.GamesPlayed++
+	away.GamesPlayed++
+	home.LastGame, away.LastGame = date, date
+	brm.teams[homeCode] = &home
+	brm.teams[awayCode] = &away
+	brm.processedGames[gameResult.GameID] = true
+	if date.After(brm.lastGameDate) {
+		brm.lastGameDate = date
+	}
+	brm.lastUpdated = time.Now()
+
+	brm.recordSnapshot(home, date, gameResult.GameID, awayCode, true, fmt.Sprintf("%d-%d", gameResult.HomeScore, gameResult.AwayScore))
+	brm.recordSnapshot(away, date, gameResult.GameID, homeCode, false, fmt.Sprintf("%d-%d", gameResult.AwayScore, gameResult.HomeScore))
+
+	if !brm.quiet {
+		log.Printf("📐 Bayesian ratings: %s net %+.3f (±%.3f), %s net %+.3f (±%.3f) [Game %d]",
+			homeCode, home.Net().Mean, math.Sqrt(home.Net().Variance),
+			awayCode, away.Net().Mean, math.Sqrt(away.Net().Variance), gameResult.GameID)
+	}
+	return true
+}
+
+// kalmanPoissonUpdate conditions independent Gaussian beliefs on a Poisson
+// count whose log rate is Σ coef·θ, using one Newton (Laplace) step from the
+// prior mean. Off-diagonal covariance is dropped afterwards, as in Glicko.
+func kalmanPoissonUpdate(goals float64, beliefs []*GaussianBelief, coefs []float64) {
+	eta, spread := 0.0, 0.0
+	for i, belief := range beliefs {
+		eta += coefs[i] * belief.Mean
+		spread += coefs[i] * coefs[i] * belief.Variance
+	}
+	lambda := math.Exp(eta)
+	denominator := 1 + lambda*spread
+
+	for i, belief := range beliefs {
+		gain := belief.Variance * coefs[i] / denominator
+		belief.Mean += gain * (goals - lambda)
+		belief.Variance -= lambda * belief.Variance * belief.Variance * coefs[i] * coefs[i] / denominator
+	}
+}
+
+// recordCalibration adds one pre-game probability to the reliability bins
+func (brm *BayesianRatingModel) recordCalibration(p float64, homeWon bool) {
+	y := 0.0
+	if homeWon {
+		y = 1.0
+	}
+	clamped := math.Max(0.001, math.Min(0.999, p))
+
+	c := &brm.calibration
+	c.LogLoss = (c.LogLoss*float64(c.Games) - (y*math.Log(clamped) + (1-y)*math.Log(1-clamped))) / float64(c.Games+1)
+	c.Brier = (c.Brier*float64(c.Games) + (p-y)*(p-y)) / float64(c.Games+1)
+	c.Games++
+
+	bin := &c.Bins[int(math.Min(p*10, 9))]
+	bin.Count++
+	bin.PredictedSum += p
+	if homeWon {
+		bin.HomeWins++
+	}
+	bin.MeanPredicted = bin.PredictedSum / float64(bin.Count)
+	bin.ObservedRate = float64(bin.HomeWins) / float64(bin.Count)
+}
+
+// recordSnapshot appends a team's posterior to its history
+func (brm *BayesianRatingModel) recordSnapshot(team TeamRatingPosterior, date time.Time, gameID int, opponent string, wasHome bool, score string) {
+	history := append(brm.history[team.TeamCode], TeamRatingSnapshot{
+		Date:     date,
+		GameID:   gameID,
+		Opponent: opponent,
+		WasHome:  wasHome,
+		Score:    score,
+		Offense:  team.Offense,
+		Defense:  team.Defense,
+	})
+	if len(history) > bayesMaxHistory {
+		history = history[len(history)-bayesMaxHistory:]
+	}
+	brm.history[team.TeamCode] = history
+}
+
+// ApplySeasonRollover carries each team's belief into a new season: means
+// shrink toward average (plus any roster-driven offense shift) and variances
+// widen toward the prior, more so for teams with heavy roster turnover
+func (brm *BayesianRatingModel) ApplySeasonRollover(carryover float64, offenseShifts, turnover map[string]float64) {
+	brm.mutex.Lock()
+	brm.rollover(carryover, offenseShifts, turnover)
+	brm.lastUpdated = time.Now()
+	brm.mutex.Unlock()
+
+	if err := brm.save(); err != nil {
+		log.Printf("⚠️ Failed to save Bayesian ratings after season rollover: %v", err)
+	}
+	log.Printf("🔄 Bayesian ratings rolled over: %.0f%% carried over, %d teams", carryover*100, len(brm.teams))
+}
+
+// rollover applies the between-season AR(1) transition; callers hold the lock
+func (brm *BayesianRatingModel) rollover(carryover float64, offenseShifts, turnover map[string]float64) {
+	rho2 := carryover * carryover
+	regress := func(belief GaussianBelief, shift, extraVariance float64) GaussianBelief {
+		return GaussianBelief{
+			Mean:     carryover*belief.Mean + shift,
+			Variance: math.Min(rho2*belief.Variance+(1-rho2)*bayesPriorTeamVariance+extraVariance, bayesPriorTeamVariance),
+		}
+	}
+
+	for code := range brm.teams {
+		team := brm.posterior(code, brm.lastGameDate)
+		extra := turnover[code] * bayesPriorTeamVariance * 0.5
+		team.Offense = regress(team.Offense, offenseShifts[code], extra)
+		team.Defense = regress(team.Defense, 0, extra)
+		if !brm.lastGameDate.IsZero() {
+			team.LastGame = brm.lastGameDate // Drift up to here is now folded in
+		}
+		brm.teams[code] = &team
+	}
+}
+
+// GetTeamRatings returns every team's posterior, strongest first
+func (brm *BayesianRatingModel) GetTeamRatings() []TeamRatingPosterior {
+	brm.mutex.RLock()
+	defer brm.mutex.RUnlock()
+
+	ratings := make([]TeamRatingPosterior, 0, len(brm.teams))
+	for code := range brm.teams {
+		ratings = append(ratings, brm.posterior(code, brm.lastGameDate))
+	}
+	sort.Slice(ratings, func(i, j int) bool {
+		return ratings[i].Net().Mean > ratings[j].Net().Mean
+	})
+	return ratings
+}
+
+// GetRatingHistory returns a team's posterior after each of its recent games
+func (brm *BayesianRatingModel) GetRatingHistory(teamCode string) []TeamRatingSnapshot {
+	brm.mutex.RLock()
+	defer brm.mutex.RUnlock()
+
+	history := make([]TeamRatingSnapshot, len(brm.history[teamCode]))
+	copy(history, brm.history[teamCode])
+	return history
+}
+
+// GetLeagueParameters returns the scoring intercept and home-ice beliefs
+func (brm *BayesianRatingModel) GetLeagueParameters() (GaussianBelief, GaussianBelief) {
+	brm.mutex.RLock()
+	defer brm.mutex.RUnlock()
+	return brm.intercept, brm.homeIce
+}
+
+// GetCalibration returns the reliability of the model's pre-game probabilities
+func (brm *BayesianRatingModel) GetCalibration() BayesianRatingCalibration {
+	brm.mutex.RLock()
+	defer brm.mutex.RUnlock()
+	return brm.calibration
+}
+
+// save persists the model state
+func (brm *BayesianRatingModel) save() error {
+	if brm.dataDir == "" {
+		return nil // Throwaway replay models aren't persisted
+	}
+
+	brm.mutex.RLock()
+	data, err := json.MarshalIndent(bayesianRatingData{
+		Teams:          brm.teams,
+		History:        brm.history,
+		Intercept:      brm.intercept,
+		HomeIce:        brm.homeIce,
+		ProcessedGames: brm.processedGames,
+		LastGameDate:   brm.lastGameDate,
+		Calibration:    brm.calibration,
+		LastUpdated:    brm.lastUpdated,
+		Version:        "1.0",
+	}, "", "  ")
+	brm.mutex.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal Bayesian ratings: %w", err)
+	}
+
+	return ioutil.WriteFile(filepath.Join(brm.dataDir, "bayesian_ratings.json"), data, 0644)
+}
+
+// load restores the model state from disk
+func (brm *BayesianRatingModel) load() error {
+	data, err := ioutil.ReadFile(filepath.Join(brm.dataDir, "bayesian_ratings.json"))
+	if err != nil {
+		return err
+	}
+
+	var state bayesianRatingData
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse Bayesian ratings: %w", err)
+	}
+
+	brm.mutex.Lock()
+	defer brm.mutex.Unlock()
+
+	if state.Teams != nil {
+		brm.teams = state.Teams
+	}
+	if state.History != nil {
+		brm.history = state.History
+	}
+	if state.ProcessedGames != nil {
+		brm.processedGames = state.ProcessedGames
+	}
+	brm.intercept = state.Intercept
+	brm.homeIce = state.HomeIce
+	brm.lastGameDate = state.LastGameDate
+	brm.calibration = state.Calibration
+	brm.lastUpdated = state.LastUpdated
+	return nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,6 +10,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -27,11 +30,12 @@ type BettingMarketService struct {
 	httpClient  *http.Client
 	isEnabled   bool
 	lastUpdated time.Time
+	lastPoll    time.Time // Last full odds board fetch by PollOdds
 }
 
 // NewBettingMarketService creates a new betting market service
 func NewBettingMarketService() *BettingMarketService {
-	apiKey := os.Getenv("ODDS_API_KEY")
+	apiKey := GetAppConfig().APIKeys.Odds
 
 	service := &BettingMarketService{
 		apiKey:     apiKey,
@@ -92,7 +96,8 @@ func (bms *BettingMarketService) GetMarketAdjustment(homeTeam, awayTeam string,
 	return adjustment, nil
 }
 
-// GetMarketConsensus aggregates odds from multiple bookmakers
+// GetMarketConsensus aggregates odds from multiple bookmakers for the matchup
+// whose scheduled start is closest to gameDate
 func (bms *BettingMarketService) GetMarketConsensus(homeTeam, awayTeam string, gameDate time.Time) (*models.MarketConsensus, error) {
 	bms.mutex.RLock()
 	key := fmt.Sprintf("%s_vs_%s_%s", homeTeam, awayTeam, gameDate.Format("2006-01-02"))
@@ -112,22 +117,217 @@ func (bms *BettingMarketService) GetMarketConsensus(homeTeam, awayTeam string, g
 
 	// Calculate consensus
 	consensus := bms.calculateConsensus(odds)
+	if consensus == nil {
+		return nil, fmt.Errorf("no odds available for %s vs %s", homeTeam, awayTeam)
+	}
 
-	// Cache result
+	// Cache result and record line history for closing line tracking
 	bms.mutex.Lock()
 	bms.consensus[key] = consensus
+	bms.recordMarketHistory(consensus)
+	if err := bms.saveMarketData(); err != nil {
+		log.Printf("⚠️ Could not save market data: %v", err)
+	}
 	bms.mutex.Unlock()
 
 	return consensus, nil
 }
 
-// DetectMarketSignal detects sharp money and significant line moves
-func (bms *BettingMarketService) DetectMarketSignal(homeTeam, awayTeam string, gameDate time.Time) (*models.MarketSignal, error) {
+// PollOdds records a line history snapshot for every upcoming game on the odds
+// board. It is called frequently by the scheduler but only spends an API request
+// when the board is due for its periodic refresh or a tracked game is about to
+// start, so each game gets its opening line early and a closing line taken
+// within closingLineWindow of puck drop.
+func (bms *BettingMarketService) PollOdds(ctx context.Context) error {
+	if !bms.isEnabled {
+		return nil
+	}
+
+	const (
+		boardRefreshInterval = 3 * time.Hour
+		closingLineWindow    = 10 * time.Minute
+		lookahead            = 48 * time.Hour
+	)
+
+	now := time.Now()
 	bms.mutex.RLock()
-	key := fmt.Sprintf("%s_vs_%s_%s", homeTeam, awayTeam, gameDate.Format("2006-01-02"))
-	history := bms.history[key]
+	due := now.Sub(bms.lastPoll) >= boardRefreshInterval
+	for _, history := range bms.history {
+		if due {
+			break
+		}
+		start := history.CommenceTime
+		if start.IsZero() || !start.After(now) || start.Sub(now) > closingLineWindow {
+			continue
+		}
+		n := len(history.DataPoints)
+		if n == 0 || history.DataPoints[n-1].Timestamp.Before(start.Add(-closingLineWindow)) {
+			due = true
+		}
+	}
 	bms.mutex.RUnlock()
 
+	if !due {
+		return nil
+	}
+
+	board, err := bms.fetchOddsBoard(ctx)
+	if err != nil {
+		return err
+	}
+
+	recorded := 0
+	bms.mutex.Lock()
+	defer bms.mutex.Unlock()
+
+	bms.lastPoll = now
+	for _, game := range board {
+		homeCode, awayCode := oddsTeamCode(game.HomeTeam), oddsTeamCode(game.AwayTeam)
+		if homeCode == "" || awayCode == "" {
+			continue
+		}
+		if !game.CommenceTime.After(now) || game.CommenceTime.Sub(now) > lookahead {
+			continue
+		}
+
+		consensus := bms.calculateConsensus(bms.convertAPIResponseToOdds(game, homeCode, awayCode))
+		if consensus == nil {
+			continue
+		}
+		bms.recordMarketHistory(consensus)
+		recorded++
+	}
+
+	if recorded > 0 {
+		if err := bms.saveMarketData(); err != nil {
+			return fmt.Errorf("could not save market data: %w", err)
+		}
+	}
+
+	log.Printf("💰 Odds poll recorded lines for %d upcoming games", recorded)
+	return nil
+}
+
+// marketHistoryKey identifies a game's line history by matchup and scheduled start
+func marketHistoryKey(homeTeam, awayTeam string, commenceTime time.Time) string {
+	return fmt.Sprintf("%s_vs_%s_%s", homeTeam, awayTeam, commenceTime.UTC().Format("2006-01-02T15:04Z"))
+}
+
+// recordMarketHistory appends a consensus snapshot to the game's line history.
+// Lines taken at or after puck drop are live odds and are not recorded.
+// Caller must hold bms.mutex.
+func (bms *BettingMarketService) recordMarketHistory(consensus *models.MarketConsensus) {
+	if consensus.GameDate.IsZero() || !consensus.LastUpdated.Before(consensus.GameDate) {
+		return
+	}
+
+	key := marketHistoryKey(consensus.HomeTeam, consensus.AwayTeam, consensus.GameDate)
+	history := bms.history[key]
+	if history == nil {
+		history = &models.BettingMarketHistory{
+			GameID:       consensus.GameID,
+			HomeTeam:     consensus.HomeTeam,
+			AwayTeam:     consensus.AwayTeam,
+			CommenceTime: consensus.GameDate,
+		}
+		bms.history[key] = history
+	}
+
+	// Store a real price and the vig-free consensus, not averaged American odds
+	homeImplied, awayImplied := consensus.ConsensusHomeWinPct, consensus.ConsensusAwayWinPct
+	if total := homeImplied + awayImplied; total > 0 {
+		homeImplied, awayImplied = homeImplied/total, awayImplied/total
+	}
+	point := models.MarketDataPoint{
+		Timestamp:         consensus.LastUpdated,
+		HomeMoneyline:     consensus.MedianHomeMoneyline,
+		AwayMoneyline:     consensus.MedianAwayMoneyline,
+		TotalLine:         consensus.AvgTotalLine,
+		ImpliedHomeWinPct: homeImplied,
+		ImpliedAwayWinPct: awayImplied,
+	}
+
+	if n := len(history.DataPoints); n > 0 {
+		first := history.DataPoints[0]
+		previous := history.DataPoints[n-1]
+
+		// Flag significant moves between consecutive snapshots
+		move := point.ImpliedHomeWinPct - previous.ImpliedHomeWinPct
+		if math.Abs(move) >= 0.02 {
+			direction := "toward_home"
+			if move < 0 {
+				direction = "toward_away"
+			}
+			history.KeyMovements = append(history.KeyMovements, models.KeyMarketMovement{
+				Timestamp:    point.Timestamp,
+				MovementType: "steam",
+				Direction:    direction,
+				Magnitude:    math.Abs(move),
+				Description:  fmt.Sprintf("Home implied probability moved %+.1f%%", move*100),
+			})
+		}
+
+		history.TotalMovement = point.ImpliedHomeWinPct - first.ImpliedHomeWinPct
+		switch {
+		case history.TotalMovement > 0.01:
+			history.FinalLineDirection = "toward_home"
+		case history.TotalMovement < -0.01:
+			history.FinalLineDirection = "toward_away"
+		default:
+			history.FinalLineDirection = "stable"
+		}
+	}
+
+	history.DataPoints = append(history.DataPoints, point)
+	history.LastUpdated = time.Now()
+}
+
+// GetMarketHistory returns a copy of the stored line history for the matchup
+// whose scheduled start is closest to gameDate. The window is wide enough for a
+// date-only gameDate at local midnight to find an evening game stored in UTC.
+func (bms *BettingMarketService) GetMarketHistory(homeTeam, awayTeam string, gameDate time.Time) *models.BettingMarketHistory {
+	bms.mutex.RLock()
+	defer bms.mutex.RUnlock()
+
+	history := bms.findHistoryLocked(homeTeam, awayTeam, gameDate)
+	if history == nil || len(history.DataPoints) == 0 {
+		return nil
+	}
+
+	copied := *history
+	copied.DataPoints = append([]models.MarketDataPoint(nil), history.DataPoints...)
+	copied.KeyMovements = append([]models.KeyMarketMovement(nil), history.KeyMovements...)
+	return &copied
+}
+
+// findHistoryLocked returns the line history for the matchup starting closest
+// to gameDate. Caller must hold bms.mutex.
+func (bms *BettingMarketService) findHistoryLocked(homeTeam, awayTeam string, gameDate time.Time) *models.BettingMarketHistory {
+	var best *models.BettingMarketHistory
+	bestGap := 36 * time.Hour
+	for _, history := range bms.history {
+		if history.HomeTeam != homeTeam || history.AwayTeam != awayTeam || history.CommenceTime.IsZero() {
+			continue
+		}
+		gap := history.CommenceTime.Sub(gameDate)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap <= bestGap {
+			best, bestGap = history, gap
+		}
+	}
+	return best
+}
+
+// IsEnabled reports whether the service has an odds API key configured
+func (bms *BettingMarketService) IsEnabled() bool {
+	return bms.isEnabled
+}
+
+// DetectMarketSignal detects sharp money and significant line moves
+func (bms *BettingMarketService) DetectMarketSignal(homeTeam, awayTeam string, gameDate time.Time) (*models.MarketSignal, error) {
+	history := bms.GetMarketHistory(homeTeam, awayTeam, gameDate)
 	if history == nil || len(history.DataPoints) < 2 {
 		return nil, fmt.Errorf("insufficient historical data")
 	}
@@ -155,8 +355,10 @@ func (bms *BettingMarketService) DetectMarketSignal(homeTeam, awayTeam string, g
 	}
 
 	// Determine which side the signal favors
-	latestMove := history.KeyMovements[len(history.KeyMovements)-1]
-	signal.SignalSide = latestMove.Direction
+	if len(history.KeyMovements) > 0 {
+		latestMove := history.KeyMovements[len(history.KeyMovements)-1]
+		signal.SignalSide = latestMove.Direction
+	}
 
 	signal.Confidence = signal.SignalStrength
 	signal.LastUpdated = time.Now()
@@ -164,13 +366,48 @@ func (bms *BettingMarketService) DetectMarketSignal(homeTeam, awayTeam string, g
 	return signal, nil
 }
 
-// fetchOddsFromAPI fetches odds from The Odds API
+// fetchOddsFromAPI fetches every bookmaker's odds for the matchup whose
+// scheduled start is closest to gameDate
 func (bms *BettingMarketService) fetchOddsFromAPI(homeTeam, awayTeam string, gameDate time.Time) ([]*models.BettingOdds, error) {
-	// The Odds API endpoint for NHL
-	url := fmt.Sprintf("%s/sports/icehockey_nhl/odds?apiKey=%s&regions=us&markets=h2h,spreads,totals",
+	board, err := bms.fetchOddsBoard(BackgroundContext())
+	if err != nil {
+		return nil, err
+	}
+
+	// A matchup can appear more than once on the board (home-and-home series),
+	// so pick the game nearest the requested date
+	var selected *OddsAPIResponse
+	var bestGap time.Duration
+	for i := range board {
+		if !bms.matchesTeams(board[i], homeTeam, awayTeam) {
+			continue
+		}
+		gap := board[i].CommenceTime.Sub(gameDate)
+		if gap < 0 {
+			gap = -gap
+		}
+		if selected == nil || gap < bestGap {
+			selected, bestGap = &board[i], gap
+		}
+	}
+
+	if selected == nil {
+		return nil, nil
+	}
+	return bms.convertAPIResponseToOdds(*selected, homeTeam, awayTeam), nil
+}
+
+// fetchOddsBoard fetches the full NHL odds board from The Odds API
+func (bms *BettingMarketService) fetchOddsBoard(ctx context.Context) ([]OddsAPIResponse, error) {
+	url := fmt.Sprintf("%s/sports/icehockey_nhl/odds?apiKey=%s&regions=us&markets=h2h,spreads,totals&oddsFormat=american",
 		bms.baseURL, bms.apiKey)
 
-	resp, err := bms.httpClient.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not build request: %w", err)
+	}
+
+	resp, err := bms.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -185,22 +422,12 @@ func (bms *BettingMarketService) fetchOddsFromAPI(homeTeam, awayTeam string, gam
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Parse response
 	var apiResponse []OddsAPIResponse
 	if err := json.Unmarshal(body, &apiResponse); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	// Convert to our format and filter for this game
-	var odds []*models.BettingOdds
-	for _, game := range apiResponse {
-		if bms.matchesTeams(game, homeTeam, awayTeam) {
-			converted := bms.convertAPIResponseToOdds(game, homeTeam, awayTeam)
-			odds = append(odds, converted)
-		}
-	}
-
-	return odds, nil
+	return apiResponse, nil
 }
 
 // OddsAPIResponse represents the API response format
@@ -226,36 +453,39 @@ type OddsAPIResponse struct {
 	} `json:"bookmakers"`
 }
 
-// convertAPIResponseToOdds converts API format to our models
-func (bms *BettingMarketService) convertAPIResponseToOdds(game OddsAPIResponse, homeTeam, awayTeam string) *models.BettingOdds {
-	odds := &models.BettingOdds{
-		HomeTeam:    homeTeam,
-		AwayTeam:    awayTeam,
-		GameDate:    game.CommenceTime,
-		LastUpdated: time.Now(),
-	}
-
-	// Extract odds from first bookmaker (simplified)
-	if len(game.Bookmakers) > 0 {
-		bookmaker := game.Bookmakers[0]
-		odds.Bookmaker = bookmaker.Title
+// convertAPIResponseToOdds converts API format to our models, one entry per
+// bookmaker quoting a moneyline
+func (bms *BettingMarketService) convertAPIResponseToOdds(game OddsAPIResponse, homeTeam, awayTeam string) []*models.BettingOdds {
+	var allOdds []*models.BettingOdds
+
+	for _, bookmaker := range game.Bookmakers {
+		odds := &models.BettingOdds{
+			HomeTeam:    homeTeam,
+			AwayTeam:    awayTeam,
+			GameDate:    game.CommenceTime,
+			Bookmaker:   bookmaker.Title,
+			LastUpdated: time.Now(),
+		}
 
+		// Outcomes name teams the way the feed does, not by our team codes
 		for _, market := range bookmaker.Markets {
 			switch market.Key {
 			case "h2h": // Moneyline
 				for _, outcome := range market.Outcomes {
-					if outcome.Name == homeTeam {
+					switch outcome.Name {
+					case game.HomeTeam:
 						odds.HomeMoneyline = int(outcome.Price)
-					} else {
+					case game.AwayTeam:
 						odds.AwayMoneyline = int(outcome.Price)
 					}
 				}
 			case "spreads": // Puck line
 				for _, outcome := range market.Outcomes {
-					if outcome.Name == homeTeam {
+					switch outcome.Name {
+					case game.HomeTeam:
 						odds.HomeSpread = outcome.Point
 						odds.HomeSpreadOdds = int(outcome.Price)
-					} else {
+					case game.AwayTeam:
 						odds.AwaySpread = outcome.Point
 						odds.AwaySpreadOdds = int(outcome.Price)
 					}
@@ -273,13 +503,19 @@ func (bms *BettingMarketService) convertAPIResponseToOdds(game OddsAPIResponse,
 				}
 			}
 		}
-	}
 
-	// Calculate implied probabilities
-	odds.ImpliedHomeWinPct = bms.americanOddsToImpliedProb(odds.HomeMoneyline)
-	odds.ImpliedAwayWinPct = bms.americanOddsToImpliedProb(odds.AwayMoneyline)
+		if odds.HomeMoneyline == 0 || odds.AwayMoneyline == 0 {
+			continue
+		}
+
+		// Calculate implied probabilities
+		odds.ImpliedHomeWinPct = bms.americanOddsToImpliedProb(odds.HomeMoneyline)
+		odds.ImpliedAwayWinPct = bms.americanOddsToImpliedProb(odds.AwayMoneyline)
+
+		allOdds = append(allOdds, odds)
+	}
 
-	return odds
+	return allOdds
 }
 
 // americanOddsToImpliedProb converts American odds to implied probability
@@ -288,13 +524,84 @@ func (bms *BettingMarketService) americanOddsToImpliedProb(odds int) float64 {
 		return 0.5 // Default 50% if no odds
 	}
 
+	return americanToImpliedProb(odds)
+}
+
+// removeVig converts a pair of American moneylines into vig-free implied probabilities
+func removeVig(homeML, awayML int) (float64, float64) {
+	if !isValidAmericanOdds(homeML) || !isValidAmericanOdds(awayML) {
+		return 0.5, 0.5
+	}
+
+	home := americanToImpliedProb(homeML)
+	away := americanToImpliedProb(awayML)
+	total := home + away
+	if total <= 0 {
+		return 0.5, 0.5
+	}
+
+	return home / total, away / total
+}
+
+// americanToImpliedProb converts American odds to a raw (vig-inclusive) implied probability
+func americanToImpliedProb(odds int) float64 {
 	if odds > 0 {
 		// Positive odds (underdog)
 		return 100.0 / (float64(odds) + 100.0)
-	} else {
-		// Negative odds (favorite)
-		return math.Abs(float64(odds)) / (math.Abs(float64(odds)) + 100.0)
 	}
+	// Negative odds (favorite)
+	return math.Abs(float64(odds)) / (math.Abs(float64(odds)) + 100.0)
+}
+
+// americanToDecimalOdds converts American odds to decimal odds (stake included)
+func americanToDecimalOdds(odds int) float64 {
+	if odds > 0 {
+		return 1.0 + float64(odds)/100.0
+	}
+	if odds < 0 {
+		return 1.0 + 100.0/math.Abs(float64(odds))
+	}
+	return 2.0
+}
+
+// decimalToAmericanOdds converts decimal odds back to the nearest American
+// price, or 0 for odds that can't win anything
+func decimalToAmericanOdds(decimal float64) int {
+	switch {
+	case decimal <= 1.0:
+		return 0
+	case decimal >= 2.0:
+		return int(math.Round((decimal - 1.0) * 100.0))
+	default:
+		return int(math.Round(-100.0 / (decimal - 1.0)))
+	}
+}
+
+// isValidAmericanOdds reports whether odds is a real price. American odds
+// skip from -100 to +100, so values in between (such as an arithmetic mean
+// of -105 and +102) aren't prices.
+func isValidAmericanOdds(odds int) bool {
+	return odds >= 100 || odds <= -100
+}
+
+// medianMoneyline returns the median of bookmaker prices, taken in decimal
+// odds so prices either side of even money combine correctly
+func medianMoneyline(lines []int) int {
+	decimals := make([]float64, 0, len(lines))
+	for _, line := range lines {
+		if isValidAmericanOdds(line) {
+			decimals = append(decimals, americanToDecimalOdds(line))
+		}
+	}
+	if len(decimals) == 0 {
+		return 0
+	}
+	sort.Float64s(decimals)
+	mid := len(decimals) / 2
+	if len(decimals)%2 == 1 {
+		return decimalToAmericanOdds(decimals[mid])
+	}
+	return decimalToAmericanOdds((decimals[mid-1] + decimals[mid]) / 2)
 }
 
 // calculateConsensus aggregates odds from multiple bookmakers
@@ -311,8 +618,10 @@ func (bms *BettingMarketService) calculateConsensus(allOdds []*models.BettingOdd
 		LastUpdated:   time.Now(),
 	}
 
-	// Calculate averages
-	var sumHomeML, sumAwayML, sumTotal, sumSpread float64
+	// Calculate averages; prices are averaged as decimal odds
+	var sumHomeDecimal, sumAwayDecimal, sumTotal, sumSpread float64
+	homeLines := make([]int, 0, len(allOdds))
+	awayLines := make([]int, 0, len(allOdds))
 	var sumHomeWinPct, sumAwayWinPct float64
 	bestHomeOdds := -10000
 	worstHomeOdds := 10000
@@ -320,8 +629,10 @@ func (bms *BettingMarketService) calculateConsensus(allOdds []*models.BettingOdd
 	worstAwayOdds := 10000
 
 	for _, odds := range allOdds {
-		sumHomeML += float64(odds.HomeMoneyline)
-		sumAwayML += float64(odds.AwayMoneyline)
+		sumHomeDecimal += americanToDecimalOdds(odds.HomeMoneyline)
+		sumAwayDecimal += americanToDecimalOdds(odds.AwayMoneyline)
+		homeLines = append(homeLines, odds.HomeMoneyline)
+		awayLines = append(awayLines, odds.AwayMoneyline)
 		sumTotal += odds.TotalLine
 		sumSpread += odds.HomeSpread
 		sumHomeWinPct += odds.ImpliedHomeWinPct
@@ -345,8 +656,10 @@ func (bms *BettingMarketService) calculateConsensus(allOdds []*models.BettingOdd
 	}
 
 	n := float64(len(allOdds))
-	consensus.AvgHomeMoneyline = sumHomeML / n
-	consensus.AvgAwayMoneyline = sumAwayML / n
+	consensus.AvgHomeMoneyline = float64(decimalToAmericanOdds(sumHomeDecimal / n))
+	consensus.AvgAwayMoneyline = float64(decimalToAmericanOdds(sumAwayDecimal / n))
+	consensus.MedianHomeMoneyline = medianMoneyline(homeLines)
+	consensus.MedianAwayMoneyline = medianMoneyline(awayLines)
 	consensus.AvgTotalLine = sumTotal / n
 	consensus.AvgHomeSpread = sumSpread / n
 	consensus.ConsensusHomeWinPct = sumHomeWinPct / n
@@ -358,7 +671,8 @@ func (bms *BettingMarketService) calculateConsensus(allOdds []*models.BettingOdd
 	consensus.WorstAwayOdds = worstAwayOdds
 
 	// Calculate market agreement (low variance = high agreement)
-	variance := float64(bestHomeOdds-worstHomeOdds) / math.Abs(consensus.AvgHomeMoneyline)
+	bestDecimal, worstDecimal := americanToDecimalOdds(bestHomeOdds), americanToDecimalOdds(worstHomeOdds)
+	variance := safeDiv(bestDecimal-worstDecimal, sumHomeDecimal/n-1.0, 0)
 	consensus.MarketAgreement = math.Max(0, 1.0-variance)
 	consensus.MarketConfidence = consensus.MarketAgreement // Simplified
 
@@ -375,7 +689,7 @@ func (bms *BettingMarketService) detectReverseLineMove(history *models.BettingMa
 	recent := history.DataPoints[len(history.DataPoints)-3:]
 
 	// Line moving toward home but public betting away (or vice versa)
-	lineMovingHome := recent[2].HomeMoneyline < recent[0].HomeMoneyline // Odds getting better for home
+	lineMovingHome := recent[2].ImpliedHomeWinPct > recent[0].ImpliedHomeWinPct // Market moving toward home
 	publicBettingHome := recent[2].HomeBetPct > 55.0
 
 	// Reverse line move: Line moving one way, public betting the other
@@ -393,11 +707,12 @@ func (bms *BettingMarketService) detectSteamMove(history *models.BettingMarketHi
 	latest := history.DataPoints[len(history.DataPoints)-1]
 	previous := history.DataPoints[len(history.DataPoints)-2]
 
-	// Calculate line movement
-	homeOddsChange := math.Abs(float64(latest.HomeMoneyline - previous.HomeMoneyline))
+	// Calculate line movement in implied probability; American odds jump
+	// from -100 to +100, so their difference overstates moves near even money
+	homeMove := math.Abs(latest.ImpliedHomeWinPct - previous.ImpliedHomeWinPct)
 
-	// Steam move: Significant change in short time (> 10 points in American odds)
-	return homeOddsChange > 10.0
+	// Steam move: Significant change in short time (about 10 cents on a -150 line)
+	return homeMove > 0.015
 }
 
 // calculateDataRecency calculates how fresh the data is
@@ -432,10 +747,61 @@ func (bms *BettingMarketService) calculateMarketWeight(consensus *models.MarketC
 	return math.Min(0.40, baseWeight) // Cap at 40%
 }
 
-// matchesTeams checks if API response matches our teams
+// matchesTeams checks if API response matches our teams. Both sides must map
+// to the requested team codes.
 func (bms *BettingMarketService) matchesTeams(game OddsAPIResponse, homeTeam, awayTeam string) bool {
-	// Simplified team name matching (would need proper mapping in production)
-	return true // TODO: Implement proper team name matching
+	return oddsTeamCode(game.HomeTeam) == homeTeam && oddsTeamCode(game.AwayTeam) == awayTeam
+}
+
+// oddsAPITeamCodes maps The Odds API's full team names (normalized by
+// normalizeOddsTeamName) to NHL team codes
+var oddsAPITeamCodes = map[string]string{
+	"anaheim ducks":         "ANA",
+	"arizona coyotes":       "ARI",
+	"boston bruins":         "BOS",
+	"buffalo sabres":        "BUF",
+	"calgary flames":        "CGY",
+	"carolina hurricanes":   "CAR",
+	"chicago blackhawks":    "CHI",
+	"colorado avalanche":    "COL",
+	"columbus blue jackets": "CBJ",
+	"dallas stars":          "DAL",
+	"detroit red wings":     "DET",
+	"edmonton oilers":       "EDM",
+	"florida panthers":      "FLA",
+	"los angeles kings":     "LAK",
+	"minnesota wild":        "MIN",
+	"montreal canadiens":    "MTL",
+	"nashville predators":   "NSH",
+	"new jersey devils":     "NJD",
+	"new york islanders":    "NYI",
+	"new york rangers":      "NYR",
+	"ottawa senators":       "OTT",
+	"philadelphia flyers":   "PHI",
+	"pittsburgh penguins":   "PIT",
+	"san jose sharks":       "SJS",
+	"seattle kraken":        "SEA",
+	"st louis blues":        "STL",
+	"tampa bay lightning":   "TBL",
+	"toronto maple leafs":   "TOR",
+	"utah hockey club":      "UTA",
+	"utah mammoth":          "UTA",
+	"vancouver canucks":     "VAN",
+	"vegas golden knights":  "VGK",
+	"washington capitals":   "WSH",
+	"winnipeg jets":         "WPG",
+}
+
+var oddsTeamNameReplacer = strings.NewReplacer("é", "e", "É", "e", ".", "", "-", " ")
+
+// normalizeOddsTeamName lowercases a feed team name and strips accents and punctuation
+func normalizeOddsTeamName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(oddsTeamNameReplacer.Replace(name))), " ")
+}
+
+// oddsTeamCode returns the NHL team code for an Odds API team name, or "" if unknown
+func oddsTeamCode(name string) string {
+	return oddsAPITeamCodes[normalizeOddsTeamName(name)]
 }
 
 // ============================================================================
@@ -0,0 +1,459 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// ClosingLineService compares our stored predictions against the betting market.
+// For every settled game with market history it records the opening and closing
+// implied probabilities, closing line value (CLV), model-vs-market log loss and
+// feeds a simulated fractional Kelly bankroll over the season.
+type ClosingLineService struct {
+	records map[int]*models.ClosingLineRecord
+	dataDir string
+	mutex   sync.RWMutex
+
+	// Kelly simulation settings
+	startingBankroll float64
+	kellyMultiplier  float64 // Fractional Kelly (0.25 = quarter Kelly)
+	maxStakePct      float64 // Cap on any single wager as a fraction of bankroll
+	minEdge          float64 // Minimum model edge over the opening line to bet
+}
+
+// NewClosingLineService creates a new closing line value tracker
+func NewClosingLineService() *ClosingLineService {
+	service := &ClosingLineService{
+		records:          make(map[int]*models.ClosingLineRecord),
+		dataDir:          "data/closing_line",
+		startingBankroll: 1000.0,
+		kellyMultiplier:  0.25,
+		maxStakePct:      0.05,
+		minEdge:          0.02,
+	}
+
+	os.MkdirAll(service.dataDir, 0755)
+
+	if err := service.loadRecords(); err != nil {
+		log.Printf("⚠️ Could not load closing line records: %v (starting fresh)", err)
+	}
+
+	return service
+}
+
+// RecordGame captures opening/closing lines and scores a settled prediction.
+// Returns an error when the game has no result or no stored market history.
+func (cls *ClosingLineService) RecordGame(stored *StoredPrediction) (*models.ClosingLineRecord, error) {
+	if stored == nil || stored.ActualResult == nil {
+		return nil, fmt.Errorf("prediction has no actual result")
+	}
+
+	marketService := GetBettingMarketService()
+	if marketService == nil {
+		return nil, fmt.Errorf("betting market service not initialized")
+	}
+
+	history := marketService.GetMarketHistory(stored.HomeTeam, stored.AwayTeam, stored.GameDate)
+	if history == nil {
+		return nil, fmt.Errorf("no market history for %s @ %s", stored.AwayTeam, stored.HomeTeam)
+	}
+
+	gameStart := stored.GameDate
+	if !history.CommenceTime.IsZero() {
+		gameStart = history.CommenceTime
+	}
+
+	opening, closing, ok := selectOpeningAndClosing(history.DataPoints, gameStart)
+	if !ok {
+		return nil, fmt.Errorf("no usable market data points for game %d", stored.GameID)
+	}
+
+	record := &models.ClosingLineRecord{
+		GameID:           stored.GameID,
+		GameDate:         stored.GameDate,
+		HomeTeam:         stored.HomeTeam,
+		AwayTeam:         stored.AwayTeam,
+		ModelHomeWinProb: stored.Prediction.HomeTeam.WinProbability,
+		PredictedAt:      stored.PredictedAt,
+		OpeningHomeML:    opening.HomeMoneyline,
+		OpeningAwayML:    opening.AwayMoneyline,
+		OpeningTimestamp: opening.Timestamp,
+		ClosingHomeML:    closing.HomeMoneyline,
+		ClosingAwayML:    closing.AwayMoneyline,
+		ClosingTimestamp: closing.Timestamp,
+		MarketDataPoints: len(history.DataPoints),
+		HomeWon:          stored.ActualResult.WinningTeam == stored.HomeTeam,
+		RecordedAt:       time.Now(),
+	}
+	record.OpeningHomeImplied, record.OpeningAwayImplied = dataPointImplied(opening)
+	record.ClosingHomeImplied, record.ClosingAwayImplied = dataPointImplied(closing)
+
+	// Closing line value for the side our model leaned toward at the open
+	homeEdge := record.ModelHomeWinProb - record.OpeningHomeImplied
+	switch {
+	case homeEdge > 0:
+		record.ModelSide = "home"
+		record.ModelEdge = homeEdge
+		record.CLV = record.ClosingHomeImplied - record.OpeningHomeImplied
+	case homeEdge < 0:
+		record.ModelSide = "away"
+		record.ModelEdge = -homeEdge
+		record.CLV = record.ClosingAwayImplied - record.OpeningAwayImplied
+	default:
+		record.ModelSide = "none"
+	}
+
+	outcome := 0.0
+	if record.HomeWon {
+		outcome = 1.0
+	}
+	record.ModelLogLoss = binaryLogLoss(record.ModelHomeWinProb, outcome)
+	record.MarketLogLoss = binaryLogLoss(record.ClosingHomeImplied, outcome)
+	record.ModelBrier = math.Pow(record.ModelHomeWinProb-outcome, 2)
+	record.MarketBrier = math.Pow(record.ClosingHomeImplied-outcome, 2)
+
+	cls.mutex.Lock()
+	cls.records[record.GameID] = record
+	err := cls.saveRecords()
+	cls.mutex.Unlock()
+	if err != nil {
+		log.Printf("⚠️ Failed to save closing line records: %v", err)
+	}
+
+	log.Printf("💹 CLV recorded for game %d (%s @ %s): side=%s, CLV=%+.2f%%",
+		record.GameID, record.AwayTeam, record.HomeTeam, record.ModelSide, record.CLV*100)
+
+	return record, nil
+}
+
+// SyncWithStoredPredictions records any settled predictions not yet tracked
+func (cls *ClosingLineService) SyncWithStoredPredictions() int {
+	storage := GetPredictionStorageService()
+	if storage == nil {
+		return 0
+	}
+
+	predictions, err := storage.GetAllPredictions()
+	if err != nil {
+		log.Printf("⚠️ Could not load stored predictions for CLV sync: %v", err)
+		return 0
+	}
+
+	recorded := 0
+	for _, stored := range predictions {
+		if stored.ActualResult == nil {
+			continue
+		}
+
+		cls.mutex.RLock()
+		_, exists := cls.records[stored.GameID]
+		cls.mutex.RUnlock()
+		if exists {
+			continue
+		}
+
+		if _, err := cls.RecordGame(stored); err == nil {
+			recorded++
+		}
+	}
+
+	return recorded
+}
+
+// GetReport builds the closing line value report and season Kelly simulation
+func (cls *ClosingLineService) GetReport() *models.ClosingLineReport {
+	cls.mutex.RLock()
+	records := make([]models.ClosingLineRecord, 0, len(cls.records))
+	for _, record := range cls.records {
+		records = append(records, *record)
+	}
+	cls.mutex.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].GameDate.Before(records[j].GameDate)
+	})
+
+	report := &models.ClosingLineReport{
+		GamesTracked: len(records),
+		GeneratedAt:  time.Now(),
+	}
+
+	var sumCLV, sumModelLL, sumMarketLL, sumModelBrier, sumMarketBrier float64
+	positiveCLV, modelCorrect, marketCorrect := 0, 0, 0
+
+	for _, record := range records {
+		sumModelLL += record.ModelLogLoss
+		sumMarketLL += record.MarketLogLoss
+		sumModelBrier += record.ModelBrier
+		sumMarketBrier += record.MarketBrier
+
+		if (record.ModelHomeWinProb >= 0.5) == record.HomeWon {
+			modelCorrect++
+		}
+		if (record.ClosingHomeImplied >= 0.5) == record.HomeWon {
+			marketCorrect++
+		}
+
+		if record.ModelSide != "none" {
+			report.GamesWithLean++
+			sumCLV += record.CLV
+			if record.CLV > 0 {
+				positiveCLV++
+			}
+		}
+	}
+
+	if n := float64(len(records)); n > 0 {
+		report.ModelLogLoss = sumModelLL / n
+		report.MarketLogLoss = sumMarketLL / n
+		report.ModelBrier = sumModelBrier / n
+		report.MarketBrier = sumMarketBrier / n
+		report.ModelAccuracy = float64(modelCorrect) / n
+		report.MarketAccuracy = float64(marketCorrect) / n
+		report.LogLossAdvantage = report.MarketLogLoss - report.ModelLogLoss
+		report.BeatsMarket = report.LogLossAdvantage > 0
+	}
+	if report.GamesWithLean > 0 {
+		report.AvgCLV = sumCLV / float64(report.GamesWithLean)
+		report.PositiveCLVRate = float64(positiveCLV) / float64(report.GamesWithLean)
+	}
+
+	report.Kelly = cls.simulateKelly(records)
+
+	// Most recent 20 games, newest first
+	for i := len(records) - 1; i >= 0 && len(report.RecentRecords) < 20; i-- {
+		report.RecentRecords = append(report.RecentRecords, records[i])
+	}
+
+	return report
+}
+
+// simulateKelly bets fractional Kelly at the opening line whenever the model's
+// edge clears minEdge. Records must be sorted by game date.
+func (cls *ClosingLineService) simulateKelly(records []models.ClosingLineRecord) models.KellySimulation {
+	sim := models.KellySimulation{
+		StartingBankroll: cls.startingBankroll,
+		FinalBankroll:    cls.startingBankroll,
+		KellyMultiplier:  cls.kellyMultiplier,
+		MaxStakePct:      cls.maxStakePct,
+		MinEdge:          cls.minEdge,
+		BankrollHistory:  []models.BankrollPoint{},
+		Bets:             []models.KellyBet{},
+	}
+
+	bankroll := cls.startingBankroll
+	peak := bankroll
+
+	for _, record := range records {
+		if record.ModelSide == "none" || record.ModelEdge < cls.minEdge {
+			continue
+		}
+
+		prob := record.ModelHomeWinProb
+		odds := record.OpeningHomeML
+		won := record.HomeWon
+		if record.ModelSide == "away" {
+			prob = 1.0 - prob
+			odds = record.OpeningAwayML
+			won = !record.HomeWon
+		}
+		// Records from before history stored median prices can hold
+		// averaged American odds that aren't a price
+		if !isValidAmericanOdds(odds) {
+			continue
+		}
+
+		// Kelly fraction: f* = (b*p - q) / b with b = net decimal odds
+		b := americanToDecimalOdds(odds) - 1.0
+		fullKelly := safeDiv(b*prob-(1.0-prob), b, 0)
+		if fullKelly <= 0 {
+			continue
+		}
+		fraction := math.Min(fullKelly*cls.kellyMultiplier, cls.maxStakePct)
+		stake := bankroll * fraction
+
+		profit := -stake
+		if won {
+			profit = stake * b
+		}
+		bankroll += profit
+
+		sim.BetsPlaced++
+		if won {
+			sim.BetsWon++
+		}
+		sim.TotalStaked += stake
+		sim.TotalProfit += profit
+
+		peak = math.Max(peak, bankroll)
+		if drawdown := safeDiv(peak-bankroll, peak, 0); drawdown > sim.MaxDrawdown {
+			sim.MaxDrawdown = drawdown
+		}
+
+		sim.Bets = append(sim.Bets, models.KellyBet{
+			GameID:        record.GameID,
+			GameDate:      record.GameDate,
+			Matchup:       fmt.Sprintf("%s @ %s", record.AwayTeam, record.HomeTeam),
+			Side:          record.ModelSide,
+			Odds:          odds,
+			KellyFraction: fraction,
+			Stake:         stake,
+			Profit:        profit,
+			Won:           won,
+			BankrollAfter: bankroll,
+		})
+		sim.BankrollHistory = append(sim.BankrollHistory, models.BankrollPoint{
+			Date:     record.GameDate,
+			Bankroll: bankroll,
+		})
+	}
+
+	sim.FinalBankroll = bankroll
+	sim.ROI = safeDiv(sim.TotalProfit, sim.TotalStaked, 0)
+
+	return sim
+}
+
+// selectOpeningAndClosing picks the first data point and the last one at or before
+// puck drop. If gameStart carries no time of day, the last data point is used.
+func selectOpeningAndClosing(points []models.MarketDataPoint, gameStart time.Time) (models.MarketDataPoint, models.MarketDataPoint, bool) {
+	var opening, closing models.MarketDataPoint
+	if len(points) == 0 {
+		return opening, closing, false
+	}
+
+	sorted := append([]models.MarketDataPoint(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	opening = sorted[0]
+	closing = sorted[len(sorted)-1]
+
+	hasStartTime := gameStart.Hour() != 0 || gameStart.Minute() != 0
+	if hasStartTime {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			if !sorted[i].Timestamp.After(gameStart) {
+				closing = sorted[i]
+				break
+			}
+		}
+	}
+
+	return opening, closing, true
+}
+
+// dataPointImplied returns vig-free implied probabilities for a data point,
+// preferring the stored bookmaker consensus over the averaged moneyline.
+func dataPointImplied(point models.MarketDataPoint) (float64, float64) {
+	if point.ImpliedHomeWinPct > 0 && point.ImpliedAwayWinPct > 0 {
+		total := point.ImpliedHomeWinPct + point.ImpliedAwayWinPct
+		return point.ImpliedHomeWinPct / total, point.ImpliedAwayWinPct / total
+	}
+	return removeVig(point.HomeMoneyline, point.AwayMoneyline)
+}
+
+// binaryLogLoss computes log loss for a single probability/outcome pair
+func binaryLogLoss(prob, outcome float64) float64 {
+	p := clampValue(prob, 1e-6, 1-1e-6)
+	return -(outcome*math.Log(p) + (1-outcome)*math.Log(1-p))
+}
+
+// ============================================================================
+// PERSISTENCE
+// ============================================================================
+
+// loadRecords loads closing line records from disk
+func (cls *ClosingLineService) loadRecords() error {
+	filePath := filepath.Join(cls.dataDir, "closing_line_records.json")
+
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil // No records yet
+	}
+
+	jsonData, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading closing line records: %w", err)
+	}
+
+	var data struct {
+		Records map[int]*models.ClosingLineRecord `json:"records"`
+	}
+	if err := json.Unmarshal(jsonData, &data); err != nil {
+		return fmt.Errorf("error unmarshaling closing line records: %w", err)
+	}
+
+	if data.Records != nil {
+		cls.records = data.Records
+	}
+
+	log.Printf("💹 Loaded %d closing line records", len(cls.records))
+	return nil
+}
+
+// saveRecords saves closing line records to disk. Caller must hold cls.mutex.
+func (cls *ClosingLineService) saveRecords() error {
+	filePath := filepath.Join(cls.dataDir, "closing_line_records.json")
+
+	data := struct {
+		Records     map[int]*models.ClosingLineRecord `json:"records"`
+		LastUpdated time.Time                         `json:"lastUpdated"`
+		Version     string                            `json:"version"`
+	}{
+		Records:     cls.records,
+		LastUpdated: time.Now(),
+		Version:     "1.0",
+	}
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling closing line records: %w", err)
+	}
+
+	if err := ioutil.WriteFile(filePath, jsonData, 0644); err != nil {
+		return fmt.Errorf("error writing closing line records: %w", err)
+	}
+
+	return nil
+}
+
+// ============================================================================
+// GLOBAL SERVICE
+// ============================================================================
+
+var (
+	globalClosingLineService *ClosingLineService
+	closingLineMutex         sync.Mutex
+)
+
+// InitializeClosingLineService initializes the global closing line value service
+func InitializeClosingLineService() error {
+	closingLineMutex.Lock()
+	defer closingLineMutex.Unlock()
+
+	if globalClosingLineService != nil {
+		return fmt.Errorf("closing line service already initialized")
+	}
+
+	globalClosingLineService = NewClosingLineService()
+	log.Printf("💹 Closing Line Value Service initialized")
+
+	return nil
+}
+
+// GetClosingLineService returns the global closing line value service
+func GetClosingLineService() *ClosingLineService {
+	closingLineMutex.Lock()
+	defer closingLineMutex.Unlock()
+	return globalClosingLineService
+}
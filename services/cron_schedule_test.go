@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // America/Denver for the DST cases
+)
+
+func TestParseJobScheduleErrors(t *testing.T) {
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"0 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"0 0 * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"0 0 31 2 *", // Never occurs
+		"0 0 30 2 *",
+		"@every 0s",
+		"@every 500ms",
+		"@every soon",
+		"@fortnightly",
+	}
+
+	for _, spec := range invalid {
+		if _, err := ParseJobSchedule(spec); err == nil {
+			t.Errorf("ParseJobSchedule(%q) succeeded, expected an error", spec)
+		}
+	}
+}
+
+func TestJobScheduleNext(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatalf("Failed to load America/Denver: %v", err)
+	}
+	local := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, denver)
+	}
+	utc := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		spec     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"daily before run", "0 6 * * *", local(2026, 3, 10, 5, 59), local(2026, 3, 10, 6, 0)},
+		{"daily strictly after", "0 6 * * *", local(2026, 3, 10, 6, 0), local(2026, 3, 11, 6, 0)},
+		{"seconds ignored", "0 6 * * *", local(2026, 3, 10, 5, 59).Add(30 * time.Second), local(2026, 3, 10, 6, 0)},
+		{"step", "*/15 * * * *", local(2026, 3, 10, 10, 7), local(2026, 3, 10, 10, 15)},
+		{"ranged step", "0-30/10 9 * * *", local(2026, 3, 10, 9, 25), local(2026, 3, 10, 9, 30)},
+		{"ranged step wraps to next day", "0-30/10 9 * * *", local(2026, 3, 10, 9, 31), local(2026, 3, 11, 9, 0)},
+		{"offset step", "5/20 * * * *", local(2026, 3, 10, 9, 26), local(2026, 3, 10, 9, 45)},
+		{"list", "0 0 1,15 * *", local(2026, 3, 2, 0, 0), local(2026, 3, 15, 0, 0)},
+		{"hour range", "0 9-17 * * *", local(2026, 3, 10, 17, 30), local(2026, 3, 11, 9, 0)},
+		{"month", "0 0 1 10 *", local(2026, 3, 10, 0, 0), local(2026, 10, 1, 0, 0)},
+		{"day of week only", "0 0 * * 5", local(2026, 3, 1, 0, 0), local(2026, 3, 6, 0, 0)},
+		{"sunday as 7", "0 0 * * 7", local(2026, 3, 2, 0, 0), local(2026, 3, 8, 0, 0)},
+		{"day of month or day of week, weekday first", "0 0 13 * 5", local(2026, 3, 1, 0, 0), local(2026, 3, 6, 0, 0)},
+		{"day of month or day of week, date first", "0 0 13 * 5", local(2026, 3, 7, 0, 0), local(2026, 3, 13, 0, 0)},
+		{"leap day", "0 0 29 2 *", local(2026, 3, 1, 0, 0), local(2028, 2, 29, 0, 0)},
+		{"shorthand", "@weekly", local(2026, 3, 10, 12, 0), local(2026, 3, 15, 0, 0)},
+		{"every", "@every 30m", local(2026, 3, 10, 12, 7), local(2026, 3, 10, 12, 37)},
+
+		// Spring forward: 02:00-02:59 doesn't exist on 2026-03-08 in Denver
+		{"spring forward skips missing time", "30 2 * * *", local(2026, 3, 8, 0, 0), local(2026, 3, 9, 2, 30)},
+		{"spring forward hourly", "0 * * * *", local(2026, 3, 8, 1, 30), utc(2026, 3, 8, 9, 0)}, // 03:00 MDT
+
+		// Fall back: 01:00-01:59 happens twice on 2026-11-01 in Denver
+		{"fall back first pass", "*/30 * * * *", utc(2026, 11, 1, 7, 45).In(denver), utc(2026, 11, 1, 8, 0)},              // 01:45 MDT -> 01:00 MST
+		{"fall back second pass", "*/30 * * * *", utc(2026, 11, 1, 8, 45).In(denver), utc(2026, 11, 1, 9, 0)},             // 01:45 MST -> 02:00 MST
+		{"fall back daily after repeated hour", "0 3 * * *", utc(2026, 11, 1, 8, 45).In(denver), utc(2026, 11, 1, 10, 0)}, // 03:00 MST
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseJobSchedule(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseJobSchedule(%q) failed: %v", tt.spec, err)
+			}
+			got := schedule.Next(tt.from)
+			if !got.Equal(tt.expected) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.expected.In(denver))
+			}
+			if !got.After(tt.from) {
+				t.Errorf("Next(%v) = %v is not after its input", tt.from, got)
+			}
+		})
+	}
+}
+
+func TestCronNextAlwaysAdvances(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatalf("Failed to load America/Denver: %v", err)
+	}
+	schedule, err := ParseJobSchedule("*/30 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Walk through both 2026 DST changes run by run
+	for _, start := range []time.Time{
+		time.Date(2026, 3, 7, 22, 0, 0, 0, denver),
+		time.Date(2026, 10, 31, 22, 0, 0, 0, denver),
+	} {
+		next := start
+		for i := 0; i < 20; i++ {
+			prev := next
+			next = schedule.Next(prev)
+			if gap := next.Sub(prev); gap <= 0 || gap > 30*time.Minute {
+				t.Fatalf("Next(%v) = %v: gap %v", prev, next, gap)
+			}
+		}
+	}
+}
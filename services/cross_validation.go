@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"math"
-	"math/rand"
 	"sort"
 	"time"
 
@@ -103,9 +102,11 @@ type ValidationResult struct {
 // ModelValidationResult tracks individual model performance in cross-validation
 type ModelValidationResult struct {
 	ModelName         string  `json:"modelName"`
+	Predictions       int     `json:"predictions"`
 	Accuracy          float64 `json:"accuracy"`
 	CalibrationError  float64 `json:"calibrationError"`
 	BrierScore        float64 `json:"brierScore"`
+	LogLoss           float64 `json:"logLoss"`
 	AverageConfidence float64 `json:"averageConfidence"`
 	OptimalWeight     float64 `json:"optimalWeight"`   // Suggested weight based on performance
 	PerformanceRank   int     `json:"performanceRank"` // 1 = best performing
@@ -140,28 +141,257 @@ type ConfidenceBin struct {
 
 // CrossValidationSettings configures the validation process
 type CrossValidationSettings struct {
-	KFolds             int           `json:"kFolds"`             // Number of folds (default: 5)
-	MinHistoricalData  int           `json:"minHistoricalData"`  // Min predictions needed (default: 50)
-	ValidationWindow   time.Duration `json:"validationWindow"`   // How far back to look (default: 1 year)
-	TemporalValidation bool          `json:"temporalValidation"` // Use time-based splits
-	BootstrapSamples   int           `json:"bootstrapSamples"`   // Bootstrap iterations (default: 1000)
-	ConfidenceLevel    float64       `json:"confidenceLevel"`    // CI level (default: 0.95)
-	CalibrationBins    int           `json:"calibrationBins"`    // Number of confidence bins (default: 10)
-	UpdateFrequency    time.Duration `json:"updateFrequency"`    // How often to revalidate
+	RollingOrigin     RollingOriginConfig `json:"rollingOrigin" yaml:"rollingOrigin"`         // Walk-forward split layout
+	MinHistoricalData int                 `json:"minHistoricalData" yaml:"minHistoricalData"` // Min predictions needed (default: 50)
+	ValidationWindow  time.Duration       `json:"validationWindow" yaml:"validationWindow"`   // How far back to look (default: 1 year)
+	BootstrapSamples  int                 `json:"bootstrapSamples" yaml:"bootstrapSamples"`   // Bootstrap iterations (default: 1000)
+	ConfidenceLevel   float64             `json:"confidenceLevel" yaml:"confidenceLevel"`     // CI level (default: 0.95)
+	CalibrationBins   int                 `json:"calibrationBins" yaml:"calibrationBins"`     // Number of confidence bins (default: 10)
+	UpdateFrequency   time.Duration       `json:"updateFrequency" yaml:"updateFrequency"`     // How often to revalidate
+}
+
+// RollingOriginConfig lays out walk-forward validation: each fold trains on
+// games before an origin and tests on the games that follow it, so no fold
+// ever learns from games played after the ones it is scored on. Sizes are in
+// games, counted in date order.
+type RollingOriginConfig struct {
+	TrainWindow int `json:"trainWindow" yaml:"trainWindow"` // Games in each training window (0 = expanding from the first game)
+	MinTrain    int `json:"minTrain" yaml:"minTrain"`       // Games required before the first origin
+	Gap         int `json:"gap" yaml:"gap"`                 // Games skipped between training and test windows
+	Horizon     int `json:"horizon" yaml:"horizon"`         // Games in each test window; the origin advances by this much
+	MaxFolds    int `json:"maxFolds" yaml:"maxFolds"`       // Keep only the most recent folds (0 = all)
+}
+
+// DefaultRollingOriginConfig returns the walk-forward layout used by validation and recalibration
+func DefaultRollingOriginConfig() RollingOriginConfig {
+	return RollingOriginConfig{
+		TrainWindow: 0,
+		MinTrain:    30,
+		Gap:         0,
+		Horizon:     10,
+		MaxFolds:    10,
+	}
+}
+
+// RollingOriginSplit is one walk-forward fold as index ranges into date-sorted data
+type RollingOriginSplit struct {
+	Fold       int `json:"fold"`
+	TrainStart int `json:"trainStart"`
+	TrainEnd   int `json:"trainEnd"` // Exclusive
+	TestStart  int `json:"testStart"`
+	TestEnd    int `json:"testEnd"` // Exclusive
+}
+
+// TrainSize returns the number of training games in the split
+func (s RollingOriginSplit) TrainSize() int { return s.TrainEnd - s.TrainStart }
+
+// TestSize returns the number of test games in the split
+func (s RollingOriginSplit) TestSize() int { return s.TestEnd - s.TestStart }
+
+// RollingOriginSplits lays out walk-forward folds over n date-sorted items
+func RollingOriginSplits(n int, cfg RollingOriginConfig) []RollingOriginSplit {
+	if cfg.Horizon < 1 {
+		cfg.Horizon = 1
+	}
+	if cfg.MinTrain < 1 {
+		cfg.MinTrain = 1
+	}
+	if cfg.Gap < 0 {
+		cfg.Gap = 0
+	}
+
+	splits := make([]RollingOriginSplit, 0)
+	for origin := cfg.MinTrain; origin+cfg.Gap < n; origin += cfg.Horizon {
+		trainStart := 0
+		if cfg.TrainWindow > 0 && origin > cfg.TrainWindow {
+			trainStart = origin - cfg.TrainWindow
+		}
+		testStart := origin + cfg.Gap
+		testEnd := testStart + cfg.Horizon
+		if testEnd > n {
+			testEnd = n
+		}
+		splits = append(splits, RollingOriginSplit{
+			TrainStart: trainStart,
+			TrainEnd:   origin,
+			TestStart:  testStart,
+			TestEnd:    testEnd,
+		})
+	}
+
+	if cfg.MaxFolds > 0 && len(splits) > cfg.MaxFolds {
+		splits = splits[len(splits)-cfg.MaxFolds:]
+	}
+	for i := range splits {
+		splits[i].Fold = i + 1
+	}
+	return splits
+}
+
+// scoreProbabilities computes accuracy, calibration, Brier and log loss for
+// home-win probabilities against outcomes (1 = home win)
+func scoreProbabilities(name string, probs, outcomes []float64) ModelValidationResult {
+	result := ModelValidationResult{ModelName: name, Predictions: len(probs)}
+	if len(probs) == 0 {
+		return result
+	}
+
+	correct := 0
+	for i, p := range probs {
+		y := outcomes[i]
+		if (p > 0.5) == (y > 0.5) {
+			correct++
+		}
+		result.CalibrationError += math.Abs(p - y)
+		result.BrierScore += (p - y) * (p - y)
+
+		clamped := math.Max(0.001, math.Min(0.999, p))
+		result.LogLoss += -(y*math.Log(clamped) + (1-y)*math.Log(1-clamped))
+
+		confidence := p
+		if p < 0.5 {
+			confidence = 1 - p
+		}
+		result.AverageConfidence += confidence
+	}
+
+	n := float64(len(probs))
+	result.Accuracy = float64(correct) / n
+	result.CalibrationError /= n
+	result.BrierScore /= n
+	result.LogLoss /= n
+	result.AverageConfidence /= n
+	return result
+}
+
+// inverseBrierWeights turns per-model Brier scores into normalized weights
+func inverseBrierWeights(scores map[string]ModelValidationResult) map[string]float64 {
+	weights := make(map[string]float64, len(scores))
+	total := 0.0
+	for name, score := range scores {
+		if score.Predictions == 0 {
+			continue
+		}
+		w := 1.0 / math.Max(score.BrierScore, 0.01)
+		weights[name] = w
+		total += w
+	}
+	for name := range weights {
+		weights[name] /= total
+	}
+	return weights
+}
+
+// WalkForwardRecord is one completed game with each model's home win probability
+type WalkForwardRecord struct {
+	GameID       int
+	GameDate     time.Time
+	HomeTeam     string
+	AwayTeam     string
+	HomeWon      bool
+	ModelProbs   map[string]float64
+	EnsembleProb float64
+}
+
+// LoadWalkForwardRecords reads stored predictions that have results, oldest first
+func LoadWalkForwardRecords() ([]WalkForwardRecord, error) {
+	storage := GetPredictionStorageService()
+	if storage == nil {
+		return nil, fmt.Errorf("prediction storage service not available")
+	}
+
+	predictions, err := storage.GetAllPredictions()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]WalkForwardRecord, 0, len(predictions))
+	for _, pred := range predictions {
+		if pred.ActualResult == nil {
+			continue
+		}
+
+		record := WalkForwardRecord{
+			GameID:       pred.GameID,
+			GameDate:     pred.GameDate,
+			HomeTeam:     pred.HomeTeam,
+			AwayTeam:     pred.AwayTeam,
+			HomeWon:      pred.ActualResult.WinningTeam == pred.HomeTeam,
+			ModelProbs:   make(map[string]float64),
+			EnsembleProb: pred.Prediction.HomeTeam.WinProbability,
+		}
+		if record.EnsembleProb == 0 {
+			record.EnsembleProb = pred.Prediction.Prediction.WinProbability
+			if pred.Prediction.Prediction.Winner == pred.AwayTeam {
+				record.EnsembleProb = 1 - record.EnsembleProb
+			}
+		}
+		for _, modelResult := range pred.Prediction.Prediction.ModelResults {
+			record.ModelProbs[modelResult.ModelName] = modelResult.WinProbability
+		}
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].GameDate.Before(records[j].GameDate)
+	})
+	return records, nil
+}
+
+// scoreWalkForwardModels computes per-model metrics over a window of records
+func scoreWalkForwardModels(records []WalkForwardRecord) map[string]ModelValidationResult {
+	probs := make(map[string][]float64)
+	outcomes := make(map[string][]float64)
+	for _, record := range records {
+		y := 0.0
+		if record.HomeWon {
+			y = 1.0
+		}
+		for name, p := range record.ModelProbs {
+			probs[name] = append(probs[name], p)
+			outcomes[name] = append(outcomes[name], y)
+		}
+	}
+
+	scores := make(map[string]ModelValidationResult, len(probs))
+	for name := range probs {
+		scores[name] = scoreProbabilities(name, probs[name], outcomes[name])
+	}
+	return scores
+}
+
+// blendWalkForward combines a record's model probabilities with the given
+// weights, renormalized over the models that actually predicted the game
+func blendWalkForward(record WalkForwardRecord, weights map[string]float64) float64 {
+	sum, total := 0.0, 0.0
+	for name, p := range record.ModelProbs {
+		if w := weights[name]; w > 0 {
+			sum += w * p
+			total += w
+		}
+	}
+	if total == 0 {
+		return 0.5
+	}
+	return sum / total
+}
+
+// DefaultCrossValidationSettings returns the built-in validation settings
+func DefaultCrossValidationSettings() CrossValidationSettings {
+	return CrossValidationSettings{
+		RollingOrigin:     DefaultRollingOriginConfig(),
+		MinHistoricalData: 50,
+		ValidationWindow:  365 * 24 * time.Hour, // 1 year
+		BootstrapSamples:  1000,
+		ConfidenceLevel:   0.95,
+		CalibrationBins:   10,
+		UpdateFrequency:   24 * time.Hour, // Daily updates
+	}
 }
 
 // NewCrossValidationService creates a new cross-validation service
 func NewCrossValidationService() *CrossValidationService {
-	settings := CrossValidationSettings{
-		KFolds:             5,
-		MinHistoricalData:  50,
-		ValidationWindow:   365 * 24 * time.Hour, // 1 year
-		TemporalValidation: true,
-		BootstrapSamples:   1000,
-		ConfidenceLevel:    0.95,
-		CalibrationBins:    10,
-		UpdateFrequency:    24 * time.Hour, // Daily updates
-	}
+	settings := GetAppConfig().CrossValidation
 
 	return &CrossValidationService{
 		historicalData:    make([]HistoricalPrediction, 0),
@@ -207,15 +437,21 @@ func (cvs *CrossValidationService) AddHistoricalPrediction(prediction Historical
 		prediction.HomeTeam, prediction.AwayTeam, prediction.IsCorrect)
 }
 
-// RunCrossValidation performs k-fold cross-validation on historical data
+// SetRollingOrigin changes the walk-forward layout used by RunCrossValidation
+func (cvs *CrossValidationService) SetRollingOrigin(cfg RollingOriginConfig) {
+	cvs.settings.RollingOrigin = cfg
+}
+
+// RunCrossValidation performs rolling-origin (walk-forward) validation on historical data
 func (cvs *CrossValidationService) RunCrossValidation() error {
 	if len(cvs.historicalData) < cvs.settings.MinHistoricalData {
 		return fmt.Errorf("insufficient historical data: have %d, need %d",
 			len(cvs.historicalData), cvs.settings.MinHistoricalData)
 	}
 
-	log.Printf("🔄 Starting %d-fold cross-validation with %d historical predictions...",
-		cvs.settings.KFolds, len(cvs.historicalData))
+	cfg := cvs.settings.RollingOrigin
+	log.Printf("🔄 Starting rolling-origin validation with %d historical predictions (window %d, gap %d, horizon %d)...",
+		len(cvs.historicalData), cfg.TrainWindow, cfg.Gap, cfg.Horizon)
 
 	// Prepare data for validation
 	completedPredictions := cvs.getCompletedPredictions()
@@ -226,15 +462,19 @@ func (cvs *CrossValidationService) RunCrossValidation() error {
 
 	// Create folds
 	folds := cvs.createFolds(completedPredictions)
+	if len(folds) == 0 {
+		return fmt.Errorf("not enough completed predictions for a walk-forward fold")
+	}
 
 	// Clear previous results
 	cvs.validationResults = make([]ValidationResult, 0)
 
 	// Run validation for each fold
-	for i, fold := range folds {
-		log.Printf("🔍 Validating fold %d/%d...", i+1, len(folds))
+	for _, fold := range folds {
+		log.Printf("🔍 Validating fold %d/%d (train %d, test %d)...",
+			fold.Fold, len(folds), fold.TrainSize(), fold.TestSize())
 
-		result := cvs.validateFold(i, fold, folds)
+		result := cvs.validateFold(fold, completedPredictions)
 		cvs.validationResults = append(cvs.validationResults, result)
 	}
 
@@ -338,48 +578,31 @@ func (cvs *CrossValidationService) getCompletedPredictions() []HistoricalPredict
 	return completed
 }
 
-// createFolds creates k-fold splits of the data
-func (cvs *CrossValidationService) createFolds(data []HistoricalPrediction) [][]HistoricalPrediction {
-	// Sort by date for temporal validation
-	if cvs.settings.TemporalValidation {
-		sort.Slice(data, func(i, j int) bool {
-			return data[i].GameDate.Before(data[j].GameDate)
-		})
-	} else {
-		// Shuffle for random validation
-		rand.Seed(time.Now().UnixNano())
-		rand.Shuffle(len(data), func(i, j int) {
-			data[i], data[j] = data[j], data[i]
-		})
-	}
-
-	folds := make([][]HistoricalPrediction, cvs.settings.KFolds)
-	foldSize := len(data) / cvs.settings.KFolds
+// createFolds sorts the data by date and lays out rolling-origin splits over it
+func (cvs *CrossValidationService) createFolds(data []HistoricalPrediction) []RollingOriginSplit {
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].GameDate.Before(data[j].GameDate)
+	})
+	return RollingOriginSplits(len(data), cvs.settings.RollingOrigin)
+}
 
-	for i := 0; i < cvs.settings.KFolds; i++ {
-		start := i * foldSize
-		end := start + foldSize
-		if i == cvs.settings.KFolds-1 {
-			end = len(data) // Include remainder in last fold
-		}
-		folds[i] = data[start:end]
+// homeWinProbability returns the ensemble's probability that the home team wins
+func (hp HistoricalPrediction) homeWinProbability() float64 {
+	if hp.PredictedWinner == hp.AwayTeam {
+		return 1 - hp.WinProbability
 	}
-
-	return folds
+	return hp.WinProbability
 }
 
-// validateFold performs validation on a single fold
-func (cvs *CrossValidationService) validateFold(foldIndex int, testFold []HistoricalPrediction, allFolds [][]HistoricalPrediction) ValidationResult {
-	// Create training set (all folds except test fold)
-	var trainingSet []HistoricalPrediction
-	for i, fold := range allFolds {
-		if i != foldIndex {
-			trainingSet = append(trainingSet, fold...)
-		}
-	}
+// validateFold scores one walk-forward fold. Per-model metrics come from each
+// model's stored probability on the test window; the suggested weight for each
+// model is fit on the training window only.
+func (cvs *CrossValidationService) validateFold(split RollingOriginSplit, data []HistoricalPrediction) ValidationResult {
+	trainingSet := data[split.TrainStart:split.TrainEnd]
+	testFold := data[split.TestStart:split.TestEnd]
 
 	result := ValidationResult{
-		FoldNumber:       foldIndex + 1,
+		FoldNumber:       split.Fold,
 		TrainingSize:     len(trainingSet),
 		TestingSize:      len(testFold),
 		ModelPerformance: make(map[string]ModelValidationResult),
@@ -422,12 +645,47 @@ func (cvs *CrossValidationService) validateFold(foldIndex int, testFold []Histor
 	result.LogLoss = logLossSum / float64(len(testFold))
 	result.MeanScoreError = totalScoreError / float64(len(testFold))
 
+	// Per-model and ensemble metrics on the test window
+	testScores := cvs.scoreModels(testFold)
+	trainScores := cvs.scoreModels(trainingSet)
+	delete(trainScores, "Ensemble")
+	trainWeights := inverseBrierWeights(trainScores)
+	for name, score := range testScores {
+		score.OptimalWeight = trainWeights[name]
+		result.ModelPerformance[name] = score
+	}
+
 	// Calculate confidence interval for this fold
 	result.ConfidenceInterval = cvs.calculateConfidenceInterval(result.Accuracy, len(testFold))
 
 	return result
 }
 
+// scoreModels computes home-win metrics for every model in the predictions, plus the ensemble
+func (cvs *CrossValidationService) scoreModels(predictions []HistoricalPrediction) map[string]ModelValidationResult {
+	probs := make(map[string][]float64)
+	outcomes := make(map[string][]float64)
+
+	for _, pred := range predictions {
+		y := 0.0
+		if pred.ActualWinner == pred.HomeTeam {
+			y = 1.0
+		}
+		for _, modelResult := range pred.ModelResults {
+			probs[modelResult.ModelName] = append(probs[modelResult.ModelName], modelResult.WinProbability)
+			outcomes[modelResult.ModelName] = append(outcomes[modelResult.ModelName], y)
+		}
+		probs["Ensemble"] = append(probs["Ensemble"], pred.homeWinProbability())
+		outcomes["Ensemble"] = append(outcomes["Ensemble"], y)
+	}
+
+	scores := make(map[string]ModelValidationResult, len(probs))
+	for name := range probs {
+		scores[name] = scoreProbabilities(name, probs[name], outcomes[name])
+	}
+	return scores
+}
+
 // calculateScoreError computes the error between predicted and actual scores
 func (cvs *CrossValidationService) calculateScoreError(predicted, actual string) float64 {
 	predHome, predAway := cvs.parseScore(predicted)
@@ -631,6 +889,8 @@ func (cvs *CrossValidationService) getModelRankings() []ModelValidationResult {
 				existing.Accuracy += perf.Accuracy
 				existing.CalibrationError += perf.CalibrationError
 				existing.BrierScore += perf.BrierScore
+				existing.LogLoss += perf.LogLoss
+				existing.Predictions += perf.Predictions
 			} else {
 				perfCopy := perf
 				modelMap[modelName] = &perfCopy
@@ -646,6 +906,7 @@ func (cvs *CrossValidationService) getModelRankings() []ModelValidationResult {
 		perf.Accuracy /= foldCount
 		perf.CalibrationError /= foldCount
 		perf.BrierScore /= foldCount
+		perf.LogLoss /= foldCount
 		rankings = append(rankings, *perf)
 	}
 
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRollingOriginSplits(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		cfg      RollingOriginConfig
+		expected []RollingOriginSplit
+	}{
+		{
+			name: "expanding window with short last fold",
+			n:    10,
+			cfg:  RollingOriginConfig{MinTrain: 4, Horizon: 3},
+			expected: []RollingOriginSplit{
+				{Fold: 1, TrainStart: 0, TrainEnd: 4, TestStart: 4, TestEnd: 7},
+				{Fold: 2, TrainStart: 0, TrainEnd: 7, TestStart: 7, TestEnd: 10},
+			},
+		},
+		{
+			name: "sliding window",
+			n:    10,
+			cfg:  RollingOriginConfig{TrainWindow: 4, MinTrain: 4, Horizon: 2},
+			expected: []RollingOriginSplit{
+				{Fold: 1, TrainStart: 0, TrainEnd: 4, TestStart: 4, TestEnd: 6},
+				{Fold: 2, TrainStart: 2, TrainEnd: 6, TestStart: 6, TestEnd: 8},
+				{Fold: 3, TrainStart: 4, TrainEnd: 8, TestStart: 8, TestEnd: 10},
+			},
+		},
+		{
+			name: "gap between train and test",
+			n:    10,
+			cfg:  RollingOriginConfig{MinTrain: 4, Gap: 2, Horizon: 3},
+			expected: []RollingOriginSplit{
+				{Fold: 1, TrainStart: 0, TrainEnd: 4, TestStart: 6, TestEnd: 9},
+				{Fold: 2, TrainStart: 0, TrainEnd: 7, TestStart: 9, TestEnd: 10},
+			},
+		},
+		{
+			name: "max folds keeps the most recent",
+			n:    10,
+			cfg:  RollingOriginConfig{MinTrain: 2, Horizon: 2, MaxFolds: 2},
+			expected: []RollingOriginSplit{
+				{Fold: 1, TrainStart: 0, TrainEnd: 6, TestStart: 6, TestEnd: 8},
+				{Fold: 2, TrainStart: 0, TrainEnd: 8, TestStart: 8, TestEnd: 10},
+			},
+		},
+		{
+			name: "invalid settings fall back to one game",
+			n:    3,
+			cfg:  RollingOriginConfig{MinTrain: 0, Gap: -1, Horizon: 0},
+			expected: []RollingOriginSplit{
+				{Fold: 1, TrainStart: 0, TrainEnd: 1, TestStart: 1, TestEnd: 2},
+				{Fold: 2, TrainStart: 0, TrainEnd: 2, TestStart: 2, TestEnd: 3},
+			},
+		},
+		{
+			name:     "not enough data",
+			n:        4,
+			cfg:      RollingOriginConfig{MinTrain: 4, Horizon: 2},
+			expected: []RollingOriginSplit{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RollingOriginSplits(tt.n, tt.cfg)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("RollingOriginSplits(%d, %+v) = %+v, want %+v", tt.n, tt.cfg, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRollingOriginSplitsNeverLeak(t *testing.T) {
+	cfg := DefaultRollingOriginConfig()
+	cfg.MaxFolds = 0
+	cfg.Gap = 3
+	for n := 0; n < 80; n++ {
+		for _, split := range RollingOriginSplits(n, cfg) {
+			if split.TrainEnd+cfg.Gap > split.TestStart {
+				t.Fatalf("n=%d fold %d: test starts at %d, inside the gap after training ends at %d",
+					n, split.Fold, split.TestStart, split.TrainEnd)
+			}
+			if split.TrainSize() < cfg.MinTrain || split.TestSize() < 1 || split.TestEnd > n {
+				t.Fatalf("n=%d fold %d: bad split %+v", n, split.Fold, split)
+			}
+		}
+	}
+}
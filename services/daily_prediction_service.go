@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -16,8 +17,6 @@ import (
 type DailyPredictionService struct {
 	predictionStorage *PredictionStorageService
 	ensemble          *EnsemblePredictionService
-	ticker            *time.Ticker
-	stopChan          chan bool
 	mutex             sync.Mutex
 	running           bool
 }
@@ -33,7 +32,6 @@ func InitDailyPredictionService(predictionStorage *PredictionStorageService, ens
 		dailyPredictionService = &DailyPredictionService{
 			predictionStorage: predictionStorage,
 			ensemble:          ensemble,
-			stopChan:          make(chan bool),
 		}
 
 		log.Println("🎯 Daily Prediction Service initialized")
@@ -46,33 +44,20 @@ func GetDailyPredictionService() *DailyPredictionService {
 	return dailyPredictionService
 }
 
-// Start begins the daily prediction generation
+// Start enables scheduled prediction runs. The job scheduler drives them
+// (daily at 6:00 AM and shortly after startup) through RunScheduled.
 func (dps *DailyPredictionService) Start() {
 	dps.mutex.Lock()
+	defer dps.mutex.Unlock()
+
 	if dps.running {
-		dps.mutex.Unlock()
 		return
 	}
 	dps.running = true
-	dps.mutex.Unlock()
-
-	log.Println("🚀 Starting Daily Prediction Service...")
-
-	// Run immediately on startup
-	go func() {
-		log.Println("⏳ Waiting 10 seconds for services to initialize before first prediction run...")
-		time.Sleep(10 * time.Second) // Wait for services to initialize
-		log.Println("🎯 Triggering initial prediction generation...")
-		dps.generateDailyPredictions()
-	}()
-
-	// Schedule daily predictions at 6 AM
-	go dps.scheduleDailyPredictions()
-
-	log.Println("✅ Daily Prediction Service started (runs daily at 6:00 AM)")
+	log.Println("✅ Daily Prediction Service started")
 }
 
-// Stop stops the daily prediction service
+// Stop disables scheduled prediction runs
 func (dps *DailyPredictionService) Stop() {
 	dps.mutex.Lock()
 	defer dps.mutex.Unlock()
@@ -80,47 +65,25 @@ func (dps *DailyPredictionService) Stop() {
 	if !dps.running {
 		return
 	}
-
-	log.Println("⏹️ Stopping Daily Prediction Service...")
-	dps.stopChan <- true
-	if dps.ticker != nil {
-		dps.ticker.Stop()
-	}
 	dps.running = false
 	log.Println("✅ Daily Prediction Service stopped")
 }
 
-// scheduleDailyPredictions runs predictions once per day at 6 AM
-func (dps *DailyPredictionService) scheduleDailyPredictions() {
-	// Calculate time until next 6 AM
-	now := time.Now()
-	next6AM := time.Date(now.Year(), now.Month(), now.Day(), 6, 0, 0, 0, now.Location())
-	if now.After(next6AM) {
-		next6AM = next6AM.Add(24 * time.Hour)
-	}
-
-	durationUntil6AM := next6AM.Sub(now)
-	log.Printf("⏰ Next daily prediction run scheduled for: %s (in %s)",
-		next6AM.Format("2006-01-02 15:04:05"), durationUntil6AM.Round(time.Minute))
-
-	// Wait until 6 AM
-	timer := time.NewTimer(durationUntil6AM)
-	<-timer.C
-
-	// Run predictions
-	dps.generateDailyPredictions()
-
-	// Set up daily ticker (every 24 hours)
-	dps.ticker = time.NewTicker(24 * time.Hour)
+// IsRunning reports whether scheduled runs are enabled
+func (dps *DailyPredictionService) IsRunning() bool {
+	dps.mutex.Lock()
+	defer dps.mutex.Unlock()
+	return dps.running
+}
 
-	for {
-		select {
-		case <-dps.ticker.C:
-			dps.generateDailyPredictions()
-		case <-dps.stopChan:
-			return
-		}
+// RunScheduled generates predictions for upcoming games if scheduled runs
+// are enabled. Called by the job scheduler.
+func (dps *DailyPredictionService) RunScheduled(ctx context.Context) error {
+	if !dps.IsRunning() {
+		return nil
 	}
+	dps.generateDailyPredictions()
+	return ctx.Err()
 }
 
 // TriggerNow manually triggers prediction generation (for testing/manual refresh)
@@ -165,6 +128,11 @@ func (dps *DailyPredictionService) generateDailyPredictions() {
 
 	log.Printf("📊 Found %d upcoming games to predict", len(games))
 
+	// Load announced officiating crews before predicting
+	if officiating := GetOfficiatingService(); officiating != nil {
+		officiating.PrefetchAssignments(BackgroundContext(), games)
+	}
+
 	// Generate predictions for each game
 	successCount := 0
 	errorCount := 0
@@ -213,6 +181,11 @@ func (dps *DailyPredictionService) generateDailyPredictions() {
 	log.Printf("⏰ Next prediction run: %s", nextRun.Format("2006-01-02 15:04:05"))
 }
 
+// UpcomingGames returns the league's unstarted games in the next 7 days
+func (dps *DailyPredictionService) UpcomingGames() ([]UpcomingGame, error) {
+	return dps.fetchUpcomingGames()
+}
+
 // fetchUpcomingGames retrieves all NHL games for the next 7 days
 func (dps *DailyPredictionService) fetchUpcomingGames() ([]UpcomingGame, error) {
 	// Use the NHL scoreboard to get upcoming games
@@ -339,6 +312,7 @@ func (dps *DailyPredictionService) generatePredictionForGame(game UpcomingGame)
 
 	// Set game ID for lineup data integration
 	dps.ensemble.SetGameID(game.GameID)
+	dps.ensemble.SetGameStart(game.GameDate)
 
 	// Use ensemble prediction service with real team stats
 	result, err := dps.ensemble.PredictGame(homeFactors, awayFactors)
@@ -378,6 +352,39 @@ func (dps *DailyPredictionService) generatePredictionForGame(game UpcomingGame)
 	return gamePrediction, nil
 }
 
+// PredictGame generates a prediction for a single game without storing it
+func (dps *DailyPredictionService) PredictGame(game UpcomingGame) (*models.GamePrediction, error) {
+	return dps.generatePredictionForGame(game)
+}
+
+// RePredictGame regenerates and overwrites the stored prediction for a single upcoming game
+func (dps *DailyPredictionService) RePredictGame(gameID int) error {
+	existing, err := dps.predictionStorage.LoadPrediction(gameID)
+	if err != nil {
+		return fmt.Errorf("failed to load prediction: %w", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("no stored prediction for game %d", gameID)
+	}
+	if existing.ActualResult != nil {
+		return fmt.Errorf("game %d has already been played", gameID)
+	}
+
+	game := UpcomingGame{
+		GameID:   gameID,
+		GameDate: existing.GameDate,
+		HomeTeam: existing.HomeTeam,
+		AwayTeam: existing.AwayTeam,
+	}
+
+	prediction, err := dps.generatePredictionForGame(game)
+	if err != nil {
+		return err
+	}
+
+	return dps.predictionStorage.StorePrediction(game.GameID, game.GameDate, game.HomeTeam, game.AwayTeam, prediction)
+}
+
 // GetStats returns statistics about daily predictions
 func (dps *DailyPredictionService) GetStats() map[string]interface{} {
 	// Get predictions from storage
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DataLockPath is the lock file guarding data/ against concurrent writers.
+// The server holds it while running; offline commands that rewrite files
+// the server also writes (uhc train, uhc ingest) hold it for their run, so
+// neither can silently overwrite the other's work.
+const DataLockPath = "data/uhc.lock"
+
+var (
+	dataLockFile *os.File // Kept open: the lock lasts as long as the descriptor
+	dataLockMu   sync.Mutex
+)
+
+// DataLockedError reports that another process holds the data lock
+type DataLockedError struct {
+	Holder string // "server (pid 123)" or similar, from the lock file
+}
+
+func (e *DataLockedError) Error() string {
+	return fmt.Sprintf("data directory is in use by %s", e.Holder)
+}
+
+// AcquireDataLock takes the data lock for the rest of the process's life,
+// recording owner (e.g. "server", "uhc train") for anyone who finds it held.
+// The operating system releases it when the process exits, however it exits.
+func AcquireDataLock(owner string) error {
+	dataLockMu.Lock()
+	defer dataLockMu.Unlock()
+
+	if dataLockFile != nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(DataLockPath), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(DataLockPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", DataLockPath, err)
+	}
+	locked, err := tryLockFile(file)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to lock %s: %w", DataLockPath, err)
+	}
+	if !locked {
+		holder, _ := ioutil.ReadAll(file)
+		file.Close()
+		name := strings.TrimSpace(string(holder))
+		if name == "" {
+			name = "another process"
+		}
+		return &DataLockedError{Holder: name}
+	}
+
+	file.Truncate(0)
+	file.WriteAt([]byte(fmt.Sprintf("%s (pid %d)\n", owner, os.Getpid())), 0)
+	dataLockFile = file
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package services
+
+import (
+	"log"
+	"os"
+)
+
+// tryLockFile can't lock on this platform; concurrent writers aren't detected
+func tryLockFile(file *os.File) (bool, error) {
+	log.Printf("⚠️ File locking isn't supported on this platform; make sure the server isn't running")
+	return true, nil
+}
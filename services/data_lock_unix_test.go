@@ -0,0 +1,37 @@
+//go:build unix
+
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTryLockFileExclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uhc.lock")
+	open := func() *os.File {
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+
+	first := open()
+	if locked, err := tryLockFile(first); !locked || err != nil {
+		t.Fatalf("Expected the first lock to succeed, got %v (%v)", locked, err)
+	}
+
+	second := open()
+	defer second.Close()
+	if locked, err := tryLockFile(second); locked || err != nil {
+		t.Fatalf("Expected a second holder to be refused, got %v (%v)", locked, err)
+	}
+
+	// Closing the holder releases the lock
+	first.Close()
+	if locked, err := tryLockFile(second); !locked || err != nil {
+		t.Errorf("Expected the lock to be free once its holder closed, got %v (%v)", locked, err)
+	}
+}
@@ -23,6 +23,9 @@ var (
 	globalDBOnce sync.Once
 )
 
+// DefaultDatabasePath is where the server keeps its SQLite database
+const DefaultDatabasePath = "data/hockey.db"
+
 // InitDatabase initializes the global database
 func InitDatabase(dbPath string) error {
 	var initErr error
@@ -69,129 +72,9 @@ func GetDatabase() *Database {
 	return globalDB
 }
 
-// initSchema creates the database tables
+// initSchema brings the database schema up to date
 func (db *Database) initSchema() error {
-	schema := `
-	-- Team Stats Table
-	CREATE TABLE IF NOT EXISTS team_stats (
-		team_code TEXT NOT NULL,
-		season INTEGER NOT NULL,
-		games_played INTEGER DEFAULT 0,
-		wins INTEGER DEFAULT 0,
-		losses INTEGER DEFAULT 0,
-		points INTEGER DEFAULT 0,
-		goals_for INTEGER DEFAULT 0,
-		goals_against INTEGER DEFAULT 0,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		PRIMARY KEY (team_code, season)
-	);
-	
-	-- Game Results Table
-	CREATE TABLE IF NOT EXISTS game_results (
-		game_id INTEGER PRIMARY KEY,
-		home_team TEXT NOT NULL,
-		away_team TEXT NOT NULL,
-		home_score INTEGER,
-		away_score INTEGER,
-		game_date DATE,
-		season INTEGER,
-		game_type TEXT,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	
-	-- Predictions Table
-	CREATE TABLE IF NOT EXISTS predictions (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		game_id INTEGER NOT NULL,
-		home_team TEXT NOT NULL,
-		away_team TEXT NOT NULL,
-		predicted_home_win_prob REAL,
-		predicted_away_win_prob REAL,
-		prediction_date TIMESTAMP,
-		actual_winner TEXT,
-		correct BOOLEAN,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		FOREIGN KEY (game_id) REFERENCES game_results(game_id)
-	);
-	
-	-- Model Performance Table
-	CREATE TABLE IF NOT EXISTS model_performance (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		model_name TEXT NOT NULL,
-		total_predictions INTEGER DEFAULT 0,
-		correct_predictions INTEGER DEFAULT 0,
-		accuracy REAL,
-		last_updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	
-	-- Player Stats Table
-	CREATE TABLE IF NOT EXISTS player_stats (
-		player_id INTEGER NOT NULL,
-		team_code TEXT NOT NULL,
-		season INTEGER NOT NULL,
-		games_played INTEGER DEFAULT 0,
-		goals INTEGER DEFAULT 0,
-		assists INTEGER DEFAULT 0,
-		points INTEGER DEFAULT 0,
-		plus_minus INTEGER DEFAULT 0,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		PRIMARY KEY (player_id, season)
-	);
-	
-	-- Goalie Stats Table
-	CREATE TABLE IF NOT EXISTS goalie_stats (
-		goalie_id INTEGER NOT NULL,
-		team_code TEXT NOT NULL,
-		season INTEGER NOT NULL,
-		games_played INTEGER DEFAULT 0,
-		wins INTEGER DEFAULT 0,
-		save_percentage REAL DEFAULT 0.0,
-		goals_against_avg REAL DEFAULT 0.0,
-		shutouts INTEGER DEFAULT 0,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		PRIMARY KEY (goalie_id, season)
-	);
-	
-	-- ML Model Weights Table (for persistence)
-	CREATE TABLE IF NOT EXISTS ml_model_weights (
-		model_name TEXT PRIMARY KEY,
-		weights_json TEXT,
-		training_count INTEGER DEFAULT 0,
-		accuracy REAL DEFAULT 0.0,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	
-	-- Cache Table (generic key-value cache)
-	CREATE TABLE IF NOT EXISTS cache (
-		cache_key TEXT PRIMARY KEY,
-		cache_value TEXT,
-		expires_at TIMESTAMP,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	
-	-- System Metrics Table
-	CREATE TABLE IF NOT EXISTS system_metrics (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		metric_name TEXT NOT NULL,
-		metric_value REAL,
-		metric_data TEXT,
-		timestamp TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	
-	-- Indexes for performance
-	CREATE INDEX IF NOT EXISTS idx_game_results_date ON game_results(game_date);
-	CREATE INDEX IF NOT EXISTS idx_game_results_teams ON game_results(home_team, away_team);
-	CREATE INDEX IF NOT EXISTS idx_predictions_game_id ON predictions(game_id);
-	CREATE INDEX IF NOT EXISTS idx_player_stats_team ON player_stats(team_code, season);
-	CREATE INDEX IF NOT EXISTS idx_goalie_stats_team ON goalie_stats(team_code, season);
-	CREATE INDEX IF NOT EXISTS idx_cache_expires ON cache(expires_at);
-	CREATE INDEX IF NOT EXISTS idx_system_metrics_name ON system_metrics(metric_name, timestamp);
-	`
-
-	db.mu.Lock()
-	defer db.mu.Unlock()
-
-	_, err := db.db.Exec(schema)
+	_, err := db.Migrate()
 	return err
 }
 
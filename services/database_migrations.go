@@ -0,0 +1,318 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// schemaMigration is one forward-only step of the database schema. Applied
+// versions are recorded in schema_migrations, so each step runs exactly once.
+type schemaMigration struct {
+	Version     int
+	Description string
+	SQL         string
+}
+
+// SchemaMigrationStatus reports whether a migration has been applied
+type SchemaMigrationStatus struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+}
+
+// schemaMigrations lists every migration in order. Never edit a released
+// migration; append a new one instead. The first two use IF NOT EXISTS so
+// databases created before versioning adopt them without changes.
+var schemaMigrations = []schemaMigration{
+	{
+		Version:     1,
+		Description: "core tables",
+		SQL: `
+	-- Team Stats Table
+	CREATE TABLE IF NOT EXISTS team_stats (
+		team_code TEXT NOT NULL,
+		season INTEGER NOT NULL,
+		games_played INTEGER DEFAULT 0,
+		wins INTEGER DEFAULT 0,
+		losses INTEGER DEFAULT 0,
+		points INTEGER DEFAULT 0,
+		goals_for INTEGER DEFAULT 0,
+		goals_against INTEGER DEFAULT 0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (team_code, season)
+	);
+	
+	-- Game Results Table
+	CREATE TABLE IF NOT EXISTS game_results (
+		game_id INTEGER PRIMARY KEY,
+		home_team TEXT NOT NULL,
+		away_team TEXT NOT NULL,
+		home_score INTEGER,
+		away_score INTEGER,
+		game_date DATE,
+		season INTEGER,
+		game_type TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	
+	-- Predictions Table
+	CREATE TABLE IF NOT EXISTS predictions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		game_id INTEGER NOT NULL,
+		home_team TEXT NOT NULL,
+		away_team TEXT NOT NULL,
+		predicted_home_win_prob REAL,
+		predicted_away_win_prob REAL,
+		prediction_date TIMESTAMP,
+		actual_winner TEXT,
+		correct BOOLEAN,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (game_id) REFERENCES game_results(game_id)
+	);
+	
+	-- Model Performance Table
+	CREATE TABLE IF NOT EXISTS model_performance (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		model_name TEXT NOT NULL,
+		total_predictions INTEGER DEFAULT 0,
+		correct_predictions INTEGER DEFAULT 0,
+		accuracy REAL,
+		last_updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	
+	-- Player Stats Table
+	CREATE TABLE IF NOT EXISTS player_stats (
+		player_id INTEGER NOT NULL,
+		team_code TEXT NOT NULL,
+		season INTEGER NOT NULL,
+		games_played INTEGER DEFAULT 0,
+		goals INTEGER DEFAULT 0,
+		assists INTEGER DEFAULT 0,
+		points INTEGER DEFAULT 0,
+		plus_minus INTEGER DEFAULT 0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (player_id, season)
+	);
+	
+	-- Goalie Stats Table
+	CREATE TABLE IF NOT EXISTS goalie_stats (
+		goalie_id INTEGER NOT NULL,
+		team_code TEXT NOT NULL,
+		season INTEGER NOT NULL,
+		games_played INTEGER DEFAULT 0,
+		wins INTEGER DEFAULT 0,
+		save_percentage REAL DEFAULT 0.0,
+		goals_against_avg REAL DEFAULT 0.0,
+		shutouts INTEGER DEFAULT 0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (goalie_id, season)
+	);
+	
+	-- ML Model Weights Table (for persistence)
+	CREATE TABLE IF NOT EXISTS ml_model_weights (
+		model_name TEXT PRIMARY KEY,
+		weights_json TEXT,
+		training_count INTEGER DEFAULT 0,
+		accuracy REAL DEFAULT 0.0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	
+	-- Cache Table (generic key-value cache)
+	CREATE TABLE IF NOT EXISTS cache (
+		cache_key TEXT PRIMARY KEY,
+		cache_value TEXT,
+		expires_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	
+	-- System Metrics Table
+	CREATE TABLE IF NOT EXISTS system_metrics (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		metric_name TEXT NOT NULL,
+		metric_value REAL,
+		metric_data TEXT,
+		timestamp TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	
+	-- Indexes for performance
+	CREATE INDEX IF NOT EXISTS idx_game_results_date ON game_results(game_date);
+	CREATE INDEX IF NOT EXISTS idx_game_results_teams ON game_results(home_team, away_team);
+	CREATE INDEX IF NOT EXISTS idx_predictions_game_id ON predictions(game_id);
+	CREATE INDEX IF NOT EXISTS idx_player_stats_team ON player_stats(team_code, season);
+	CREATE INDEX IF NOT EXISTS idx_goalie_stats_team ON goalie_stats(team_code, season);
+	CREATE INDEX IF NOT EXISTS idx_cache_expires ON cache(expires_at);
+	CREATE INDEX IF NOT EXISTS idx_system_metrics_name ON system_metrics(metric_name, timestamp);
+	`,
+	},
+	{
+		Version:     2,
+		Description: "backfill queue",
+		SQL: `
+	-- Backfill Queue: one job per requested backfill, one task per game
+	CREATE TABLE IF NOT EXISTS backfill_jobs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		kind TEXT NOT NULL,
+		params TEXT NOT NULL,
+		status TEXT NOT NULL,
+		planned BOOLEAN DEFAULT 0,
+		error TEXT DEFAULT '',
+		created_at TIMESTAMP,
+		started_at TIMESTAMP,
+		finished_at TIMESTAMP
+	);
+	
+	CREATE TABLE IF NOT EXISTS backfill_tasks (
+		job_id INTEGER NOT NULL,
+		game_id INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		attempts INTEGER DEFAULT 0,
+		last_error TEXT DEFAULT '',
+		duration_ms INTEGER DEFAULT 0,
+		updated_at TIMESTAMP,
+		PRIMARY KEY (job_id, game_id),
+		FOREIGN KEY (job_id) REFERENCES backfill_jobs(id)
+	);
+	
+	-- Indexes for performance
+	CREATE INDEX IF NOT EXISTS idx_backfill_jobs_status ON backfill_jobs(status, id);
+	CREATE INDEX IF NOT EXISTS idx_backfill_tasks_status ON backfill_tasks(job_id, status);
+	`,
+	},
+	{
+		Version:     3,
+		Description: "unique model_performance.model_name",
+		SQL: `
+	-- SaveModelPerformance upserts on model_name, which needs a unique index;
+	-- keep the newest row for any model saved more than once without it
+	DELETE FROM model_performance WHERE id NOT IN (
+		SELECT MAX(id) FROM model_performance GROUP BY model_name
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_model_performance_name ON model_performance(model_name);
+	`,
+	},
+	{
+		Version:     4,
+		Description: "backfill cancel requests",
+		SQL: `
+	-- Set by uhc backfill cancel for a job running in the server process;
+	-- the server's queue checks it between game attempts
+	ALTER TABLE backfill_jobs ADD COLUMN cancel_requested BOOLEAN DEFAULT 0;
+	`,
+	},
+}
+
+// Migrate applies every pending schema migration in order, each in its own
+// transaction, and returns the ones it applied
+func (db *Database) Migrate() ([]SchemaMigrationStatus, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, err := db.db.Exec(`
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	applied, err := appliedMigrations(db.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []SchemaMigrationStatus
+	for _, migration := range schemaMigrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		now := time.Now()
+		if err := applyMigration(db.db, migration, now); err != nil {
+			return ran, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		fmt.Printf("🗄️ Applied database migration %d: %s\n", migration.Version, migration.Description)
+
+		ran = append(ran, SchemaMigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Applied:     true,
+			AppliedAt:   &now,
+		})
+	}
+
+	return ran, nil
+}
+
+// MigrationStatus lists every known migration and when it was applied
+func (db *Database) MigrationStatus() ([]SchemaMigrationStatus, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	applied, err := appliedMigrations(db.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]SchemaMigrationStatus, 0, len(schemaMigrations))
+	for _, migration := range schemaMigrations {
+		status := SchemaMigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// appliedMigrations returns applied migration versions and their timestamps
+func appliedMigrations(db *sql.DB) (map[int]time.Time, error) {
+	rows, err := db.Query(`SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration runs one migration and records it atomically
+func applyMigration(db *sql.DB, migration schemaMigration, appliedAt time.Time) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(migration.SQL); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, ?)`,
+		migration.Version, migration.Description, appliedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
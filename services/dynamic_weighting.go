@@ -128,20 +128,20 @@ type DynamicWeightingService struct {
 
 // DynamicWeightingSettings configures the dynamic weighting behavior
 type DynamicWeightingSettings struct {
-	EnableDynamicWeights  bool          `json:"enableDynamicWeights"`
-	RecencyDecayRate      float64       `json:"recencyDecayRate"`      // How quickly old data loses importance
-	PerformanceThreshold  float64       `json:"performanceThreshold"`  // Min accuracy difference to trigger weight change
-	SmoothingStrength     float64       `json:"smoothingStrength"`     // How gradual weight transitions are
-	ContextualWeighting   bool          `json:"contextualWeighting"`   // Whether to use context-specific weights
-	AdaptationSpeed       string        `json:"adaptationSpeed"`       // "conservative", "moderate", "aggressive"
-	MinEvaluationPeriod   time.Duration `json:"minEvaluationPeriod"`   // Min time before first weight adjustment
-	WeightUpdateFrequency time.Duration `json:"weightUpdateFrequency"` // How often to recalculate weights
-}
-
-// NewDynamicWeightingService creates a new dynamic weighting service
-func NewDynamicWeightingService() *DynamicWeightingService {
-	// Default settings for conservative but effective weighting
-	settings := DynamicWeightingSettings{
+	EnableDynamicWeights  bool          `json:"enableDynamicWeights" yaml:"enableDynamicWeights"`
+	RecencyDecayRate      float64       `json:"recencyDecayRate" yaml:"recencyDecayRate"`           // How quickly old data loses importance
+	PerformanceThreshold  float64       `json:"performanceThreshold" yaml:"performanceThreshold"`   // Min accuracy difference to trigger weight change
+	SmoothingStrength     float64       `json:"smoothingStrength" yaml:"smoothingStrength"`         // How gradual weight transitions are
+	ContextualWeighting   bool          `json:"contextualWeighting" yaml:"contextualWeighting"`     // Whether to use context-specific weights
+	AdaptationSpeed       string        `json:"adaptationSpeed" yaml:"adaptationSpeed"`             // "conservative", "moderate", "aggressive"
+	MinEvaluationPeriod   time.Duration `json:"minEvaluationPeriod" yaml:"minEvaluationPeriod"`     // Min time before first weight adjustment
+	WeightUpdateFrequency time.Duration `json:"weightUpdateFrequency" yaml:"weightUpdateFrequency"` // How often to recalculate weights
+}
+
+// DefaultDynamicWeightingSettings returns conservative but effective
+// weighting settings
+func DefaultDynamicWeightingSettings() DynamicWeightingSettings {
+	return DynamicWeightingSettings{
 		EnableDynamicWeights:  true,
 		RecencyDecayRate:      0.95, // 5% decay per prediction
 		PerformanceThreshold:  0.05, // 5% accuracy difference triggers change
@@ -151,6 +151,11 @@ func NewDynamicWeightingService() *DynamicWeightingService {
 		MinEvaluationPeriod:   24 * time.Hour, // Wait 1 day before adjusting
 		WeightUpdateFrequency: time.Hour,      // Check hourly
 	}
+}
+
+// NewDynamicWeightingService creates a new dynamic weighting service
+func NewDynamicWeightingService() *DynamicWeightingService {
+	settings := GetAppConfig().DynamicWeighting
 
 	constraints := WeightConstraints{
 		MinWeight:         0.15, // No model below 15%
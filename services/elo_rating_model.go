@@ -29,6 +29,7 @@ type EloRatingModel struct {
 	seasonDecayRate   float64            // How much ratings decay over time
 	confidenceFactors map[string]float64 // Confidence in each team's rating
 	dataDir           string             // Directory for persistent storage
+	quiet             bool               // Suppresses per-game logs during tuning replays
 }
 
 // EloModelData represents the serializable state of the Elo model
@@ -74,6 +75,11 @@ func NewEloRatingModel() *EloRatingModel {
 		},
 	}
 
+	// Apply tuned hyperparameters if a config has been written
+	model.kFactor = tunedParam("elo", "kFactor", model.kFactor)
+	model.homeAdvantage = tunedParam("elo", "homeAdvantage", model.homeAdvantage)
+	model.seasonDecayRate = tunedParam("elo", "seasonDecayRate", model.seasonDecayRate)
+
 	// Create data directory if it doesn't exist
 	os.MkdirAll(model.dataDir, 0755)
 
@@ -147,7 +153,9 @@ func (elo *EloRatingModel) getTeamRating(teamCode string) float64 {
 	initialRating := elo.calculateInitialRating(teamCode)
 	elo.teamRatings[teamCode] = initialRating
 
-	log.Printf("🆕 Initialized Elo rating for %s: %.0f", teamCode, initialRating)
+	if !elo.quiet {
+		log.Printf("🆕 Initialized Elo rating for %s: %.0f", teamCode, initialRating)
+	}
 	return initialRating
 }
 
@@ -188,43 +196,105 @@ func (elo *EloRatingModel) calculateWinProbability(rating1, rating2 float64) flo
 // calculateSituationalAdjustment applies situational factors to Elo predictions
 func (elo *EloRatingModel) calculateSituationalAdjustment(homeFactors, awayFactors *models.PredictionFactors) float64 {
 	adjustment := 0.0
+	for _, part := range elo.situationalAdjustmentParts(homeFactors, awayFactors) {
+		adjustment += part
+	}
+	return capSituationalAdjustment(adjustment)
+}
+
+// eloSituationalLabels names the parts returned by situationalAdjustmentParts
+var eloSituationalLabels = []string{"Travel Fatigue", "Injuries", "Momentum", "Recent Form", "Team Rating"}
+
+// situationalAdjustmentParts returns the uncapped win probability shifts, in
+// eloSituationalLabels order
+func (elo *EloRatingModel) situationalAdjustmentParts(homeFactors, awayFactors *models.PredictionFactors) []float64 {
+	parts := make([]float64, len(eloSituationalLabels))
 
 	// Travel fatigue impact
 	if awayFactors.TravelFatigue.FatigueScore > 0.3 {
-		adjustment += 0.05 // Favor home team
+		parts[0] = 0.05 // Favor home team
 	}
 
 	// Injury impact
 	homeHealthPenalty := homeFactors.InjuryImpact.ImpactScore * 0.001
 	awayHealthPenalty := awayFactors.InjuryImpact.ImpactScore * 0.001
-	adjustment += awayHealthPenalty - homeHealthPenalty
+	parts[1] = awayHealthPenalty - homeHealthPenalty
 
 	// Momentum factors
 	homeMomentum := homeFactors.MomentumFactors.MomentumScore
 	awayMomentum := awayFactors.MomentumFactors.MomentumScore
-	momentumDiff := (homeMomentum - awayMomentum) * 0.02
-	adjustment += momentumDiff
+	parts[2] = (homeMomentum - awayMomentum) * 0.02
 
 	// Recent form impact
-	formDiff := (homeFactors.RecentForm - awayFactors.RecentForm) * 0.01
-	adjustment += formDiff
+	parts[3] = (homeFactors.RecentForm - awayFactors.RecentForm) * 0.01
 
 	// Advanced analytics impact
 	if homeFactors.AdvancedStats.OverallRating > 0 && awayFactors.AdvancedStats.OverallRating > 0 {
-		ratingDiff := (homeFactors.AdvancedStats.OverallRating - awayFactors.AdvancedStats.OverallRating) * 0.001
-		adjustment += ratingDiff
+		parts[4] = (homeFactors.AdvancedStats.OverallRating - awayFactors.AdvancedStats.OverallRating) * 0.001
 	}
 
-	// Cap adjustment to prevent extreme swings
+	return parts
+}
+
+// capSituationalAdjustment caps the adjustment to prevent extreme swings
+func capSituationalAdjustment(adjustment float64) float64 {
 	if adjustment > 0.15 {
-		adjustment = 0.15
+		return 0.15
 	} else if adjustment < -0.15 {
-		adjustment = -0.15
+		return -0.15
 	}
-
 	return adjustment
 }
 
+// Explain attributes the prediction to the two ratings, home ice and each
+// situational adjustment with exact Shapley values. The baseline is two
+// teams at the initial rating on neutral ice with no adjustments.
+func (elo *EloRatingModel) Explain(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionExplanation, error) {
+	elo.mutex.RLock()
+	homeRating, homeKnown := elo.teamRatings[homeFactors.TeamCode]
+	awayRating, awayKnown := elo.teamRatings[awayFactors.TeamCode]
+	elo.mutex.RUnlock()
+	if !homeKnown {
+		homeRating = elo.calculateInitialRating(homeFactors.TeamCode)
+	}
+	if !awayKnown {
+		awayRating = elo.calculateInitialRating(awayFactors.TeamCode)
+	}
+
+	names := append([]string{homeFactors.TeamCode + " Elo Rating", awayFactors.TeamCode + " Elo Rating", "Home Ice"}, eloSituationalLabels...)
+	values := append([]float64{homeRating, awayRating, elo.homeAdvantage}, elo.situationalAdjustmentParts(homeFactors, awayFactors)...)
+
+	value := func(mask int) float64 { return elo.termWinProbability(values, mask) }
+
+	phi := exactShapley(len(values), value)
+	all := 1<<len(values) - 1
+	return newPredictionExplanation("shapley", value(0), value(all), names, values, phi), nil
+}
+
+// termWinProbability is the home win probability from Explain's terms (home
+// rating, away rating, home ice, then the situational parts), keeping only
+// those in mask and putting the rest at their baseline
+func (elo *EloRatingModel) termWinProbability(values []float64, mask int) float64 {
+	home, away, homeIce := elo.initialRating, elo.initialRating, 0.0
+	if mask&1 != 0 {
+		home = values[0]
+	}
+	if mask&2 != 0 {
+		away = values[1]
+	}
+	if mask&4 != 0 {
+		homeIce = values[2]
+	}
+	adjustment := 0.0
+	for i := 3; i < len(values); i++ {
+		if mask&(1<<i) != 0 {
+			adjustment += values[i]
+		}
+	}
+	probability := elo.calculateWinProbability(home+homeIce, away)
+	return elo.applySituationalAdjustment(probability, capSituationalAdjustment(adjustment))
+}
+
 // applySituationalAdjustment applies the calculated adjustment to win probability
 func (elo *EloRatingModel) applySituationalAdjustment(baseProbability, adjustment float64) float64 {
 	adjusted := baseProbability + adjustment
@@ -606,9 +676,11 @@ func (elo *EloRatingModel) processGameResult(gameResult *models.GameResult) erro
 	// Mark this game as processed
 	elo.processedGames[gameResult.GameID] = true
 
-	log.Printf("🏆 Elo Update: %s %.0f→%.0f (%+.0f), %s %.0f→%.0f (%+.0f) [Game %d]",
-		homeTeam, homeRating, newHomeRating, homeChange,
-		awayTeam, awayRating, newAwayRating, awayChange, gameResult.GameID)
+	if !elo.quiet {
+		log.Printf("🏆 Elo Update: %s %.0f→%.0f (%+.0f), %s %.0f→%.0f (%+.0f) [Game %d]",
+			homeTeam, homeRating, newHomeRating, homeChange,
+			awayTeam, awayRating, newAwayRating, awayChange, gameResult.GameID)
+	}
 
 	return nil
 }
@@ -750,6 +822,30 @@ func (elo *EloRatingModel) applySeasonalDecay() {
 	}
 }
 
+// ApplySeasonRollover regresses every rating toward the mean by the given
+// carryover fraction, then shifts each team by its roster adjustment (in Elo
+// points). Confidence is reduced since last season's form is a weaker signal.
+func (elo *EloRatingModel) ApplySeasonRollover(carryover float64, adjustments map[string]float64) {
+	elo.mutex.Lock()
+	now := time.Now()
+	for teamCode, rating := range elo.teamRatings {
+		newRating := elo.initialRating + (rating-elo.initialRating)*carryover + adjustments[teamCode]
+		elo.teamRatings[teamCode] = newRating
+		elo.recordRatingChange(teamCode, rating, newRating, newRating-rating, "", "ROLLOVER", "", 0, false, now)
+
+		if confidence, exists := elo.confidenceFactors[teamCode]; exists {
+			elo.confidenceFactors[teamCode] = math.Max(0.3, confidence*0.8)
+		}
+	}
+	elo.lastUpdated = now
+	elo.mutex.Unlock()
+
+	if err := elo.saveRatings(); err != nil {
+		log.Printf("⚠️ Failed to save Elo ratings after season rollover: %v", err)
+	}
+	log.Printf("🔄 Elo ratings rolled over: %.0f%% carried over, %d teams", carryover*100, len(adjustments))
+}
+
 // updateConfidenceFactors updates confidence in team ratings based on rating stability
 func (elo *EloRatingModel) updateConfidenceFactors(teamCode string, ratingChange float64) {
 	currentConfidence := elo.confidenceFactors[teamCode]
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
@@ -22,7 +23,8 @@ type EnsemblePredictionService struct {
 	metaLearner     *MetaLearnerModel // Optional: learns optimal model combination
 	useMetaLearner  bool              // Flag to enable/disable meta-learner
 	teamCode        string
-	gameID          int // Optional: game ID for lineup data
+	gameID          int       // Optional: game ID for lineup data
+	gameStart       time.Time // Optional: scheduled puck drop, gates feature snapshots
 	accuracyTracker *AccuracyTrackingService
 	dataQuality     *DataQualityService
 	dynamicWeights  *DynamicWeightingService
@@ -37,7 +39,7 @@ func NewEnsemblePredictionService(teamCode string) *EnsemblePredictionService {
 	return &EnsemblePredictionService{
 		teamCode:        teamCode,
 		metaLearner:     metaLearner,
-		useMetaLearner:  metaLearner.trained, // Use if trained, otherwise fall back to weighted average
+		useMetaLearner:  true, // Combiner() falls back to dynamic weighting until stacking has run
 		accuracyTracker: NewAccuracyTrackingService(),
 		dataQuality:     NewDataQualityService(teamCode),
 		dynamicWeights:  NewDynamicWeightingService(),
@@ -52,6 +54,7 @@ func NewEnsemblePredictionService(teamCode string) *EnsemblePredictionService {
 			NewGradientBoostingModel(),  // 7%
 			NewLSTMModel(),              // 7%
 			NewRandomForestModel(),      // 7%
+			NewBayesianRatingModel(),    // 8%
 		},
 	}
 }
@@ -61,8 +64,22 @@ func (eps *EnsemblePredictionService) SetGameID(gameID int) {
 	eps.gameID = gameID
 }
 
+// SetGameStart sets the scheduled start of the game being predicted
+func (eps *EnsemblePredictionService) SetGameStart(start time.Time) {
+	eps.gameStart = start
+}
+
 // PredictGame runs all models and combines their predictions with dynamic weighting
 func (eps *EnsemblePredictionService) PredictGame(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionResult, error) {
+	return eps.PredictGameContext(context.Background(), homeFactors, awayFactors)
+}
+
+// PredictGameContext is PredictGame that stops between enrichment steps and
+// models once ctx is done, returning ctx.Err()
+func (eps *EnsemblePredictionService) PredictGameContext(ctx context.Context, homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	fmt.Printf("🤖 Running ensemble prediction with %d models...\n", len(eps.models))
 
@@ -150,6 +167,10 @@ func (eps *EnsemblePredictionService) PredictGame(homeFactors, awayFactors *mode
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// ============================================================================
 	// PHASE 2: ENHANCED DATA QUALITY ENRICHMENT
 	// ============================================================================
@@ -376,6 +397,26 @@ func (eps *EnsemblePredictionService) PredictGame(homeFactors, awayFactors *mode
 		}
 	}
 
+	// Officiating: assigned referees' penalty rate and home/away tendencies
+	officiatingService := GetOfficiatingService()
+	if officiatingService != nil && eps.gameID != 0 {
+		officiating := officiatingService.GetOfficiatingFactor(eps.gameID)
+		if officiating.CrewKnown {
+			homeFactors.RefereeHomeAdvantage = officiating.HomeBias
+			awayFactors.RefereeHomeAdvantage = -officiating.HomeBias
+			homeFactors.RefereePenaltyRate = officiating.PenaltyRateMultiplier
+			awayFactors.RefereePenaltyRate = officiating.PenaltyRateMultiplier
+			homeFactors.ExpectedPPOpportunities = officiating.ExpectedHomePPOpps
+			awayFactors.ExpectedPPOpportunities = officiating.ExpectedAwayPPOpps
+			homeFactors.RefereeGoalsDelta = officiating.ExpectedTotalGoalsDelta / 2.0
+			awayFactors.RefereeGoalsDelta = officiating.ExpectedTotalGoalsDelta / 2.0
+
+			fmt.Printf("🦓 Officials: %s | Penalty rate %.2fx | Expected PP %.1f-%.1f | Goals %+.2f\n",
+				strings.Join(officiating.Referees, ", "), officiating.PenaltyRateMultiplier,
+				officiating.ExpectedHomePPOpps, officiating.ExpectedAwayPPOpps, officiating.ExpectedTotalGoalsDelta)
+		}
+	}
+
 	// 2. Tactical Advantage Analysis
 	tacticalService := GetTacticalAdvantageService()
 	if tacticalService != nil {
@@ -395,6 +436,10 @@ func (eps *EnsemblePredictionService) PredictGame(homeFactors, awayFactors *mode
 		stImpact := tacticalService.GetSpecialTeamsAdvantage(
 			homeFactors.TeamCode, awayFactors.TeamCode,
 			homeStats, awayStats)
+		// Special teams matter more with a crew that calls more penalties
+		if homeFactors.RefereePenaltyRate > 0 {
+			stImpact *= homeFactors.RefereePenaltyRate
+		}
 		homeFactors.SpecialTeamsMatchup = stImpact
 		awayFactors.SpecialTeamsMatchup = -stImpact
 		
@@ -969,8 +1014,19 @@ func (eps *EnsemblePredictionService) PredictGame(homeFactors, awayFactors *mode
 		currentWeights["Poisson Regression"]*100,
 		currentWeights["Neural Network"]*100)
 
+	// Snapshot the final factors so tree models can train on what they predicted from
+	if snapshotService := GetFeatureSnapshotService(); snapshotService != nil && eps.gameID != 0 {
+		if err := snapshotService.RecordSnapshot(eps.gameID, eps.gameStart, homeFactors, awayFactors); err != nil {
+			fmt.Printf("⚠️ Failed to record feature snapshot for game %d: %v\n", eps.gameID, err)
+		}
+	}
+
 	// Run all models with dynamic weights
 	for _, model := range eps.models {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		result, err := model.Predict(homeFactors, awayFactors)
 		if err != nil {
 			fmt.Printf("⚠️ Model %s failed: %v\n", model.GetName(), err)
@@ -985,6 +1041,15 @@ func (eps *EnsemblePredictionService) PredictGame(homeFactors, awayFactors *mode
 			result.Weight = model.GetWeight()
 		}
 
+		// Attribute the prediction to its inputs when the model supports it
+		if explainer, ok := model.(ExplainableModel); ok {
+			if explanation, err := explainer.Explain(homeFactors, awayFactors); err == nil {
+				result.Explanation = explanation
+			} else if ensembleDEBUG {
+				fmt.Printf("⚠️ Could not explain %s: %v\n", model.GetName(), err)
+			}
+		}
+
 		modelResults = append(modelResults, *result)
 		totalWeight += result.Weight
 
@@ -1002,18 +1067,35 @@ func (eps *EnsemblePredictionService) PredictGame(homeFactors, awayFactors *mode
 	// Combine predictions - use meta-learner if trained, otherwise weighted average
 	var combinedResult *models.PredictionResult
 
-	if eps.useMetaLearner && eps.metaLearner.trained {
+	// The stacking pipeline picks whichever combiner scored best out of fold
+	combiner := CombinerDynamic
+	if eps.useMetaLearner {
+		combiner = eps.metaLearner.Combiner()
+	}
+
+	switch combiner {
+	case CombinerLogistic, CombinerGBM:
 		// Use meta-learner to optimally combine predictions
 		combinedResult = eps.combineWithMetaLearner(modelResults, homeFactors, awayFactors)
-		combinedResult.EnsembleMethod = "Meta-Learner (Stacking)"
-		fmt.Printf("🎯 Using Meta-Learner for optimal model combination\n")
-	} else {
+		combinedResult.EnsembleMethod = fmt.Sprintf("Meta-Learner (Stacking, %s)", combiner)
+		fmt.Printf("🎯 Using %s Meta-Learner for optimal model combination\n", combiner)
+	case CombinerFixed:
+		// Fixed weights beat dynamic weighting out of fold; undo the dynamic weights
+		totalWeight = 0
+		for i := range modelResults {
+			modelResults[i].Weight = fixedCombinerWeights[modelResults[i].ModelName]
+			totalWeight += modelResults[i].Weight
+		}
+		combinedResult = eps.combineWeightedPredictions(modelResults, totalWeight, homeFactors, awayFactors)
+		combinedResult.EnsembleMethod = "Weighted Average with Fixed Weights"
+	default:
 		// Fall back to weighted average
 		combinedResult = eps.combineWeightedPredictions(modelResults, totalWeight, homeFactors, awayFactors)
 		combinedResult.EnsembleMethod = "Weighted Average with Dynamic Weighting"
 	}
 
 	combinedResult.ModelResults = modelResults
+	combinedResult.Explanation = aggregateExplanations(modelResults)
 
 	// ============================================================================
 	// PHASE 3: FINAL CONFIDENCE CALIBRATION & QUALITY ASSESSMENT (in PredictGame)
@@ -1105,9 +1187,11 @@ func (eps *EnsemblePredictionService) combineWeightedPredictions(results []model
 		finalProb = weightedAwayProb / (weightedHomeProb + weightedAwayProb)
 	}
 
-	// Create final score prediction
+	// Create final score prediction, shifted by the officiating crew's scoring tendency
 	predictedScore := "3-2" // Default fallback
 	if validScores > 0 {
+		homeGoalsSum = math.Max(0, homeGoalsSum+homeFactors.RefereeGoalsDelta)
+		awayGoalsSum = math.Max(0, awayGoalsSum+awayFactors.RefereeGoalsDelta)
 		avgHomeGoals := int(math.Round(homeGoalsSum))
 		avgAwayGoals := int(math.Round(awayGoalsSum))
 		predictedScore = fmt.Sprintf("%d-%d", avgHomeGoals, avgAwayGoals)
@@ -1178,8 +1262,13 @@ func (eps *EnsemblePredictionService) combineWeightedPredictions(results []model
 
 // PredictGameWithRecovery wraps PredictGame with error recovery for graceful degradation
 func (eps *EnsemblePredictionService) PredictGameWithRecovery(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionResult, error) {
+	return eps.PredictGameWithRecoveryContext(context.Background(), homeFactors, awayFactors)
+}
+
+// PredictGameWithRecoveryContext is PredictGameWithRecovery bounded by ctx
+func (eps *EnsemblePredictionService) PredictGameWithRecoveryContext(ctx context.Context, homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionResult, error) {
 	// Try to run the full prediction
-	result, err := eps.PredictGame(homeFactors, awayFactors)
+	result, err := eps.PredictGameContext(ctx, homeFactors, awayFactors)
 	if err == nil {
 		// Success - cache the prediction
 		cache := GetPredictionCache()
@@ -1480,46 +1569,15 @@ func (eps *EnsemblePredictionService) GetAccuracyTracker() *AccuracyTrackingServ
 func (eps *EnsemblePredictionService) combineWithMetaLearner(results []models.ModelResult, homeFactors, awayFactors *models.PredictionFactors) *models.PredictionResult {
 	// Extract predictions from each model
 	predictions := &ModelPredictions{}
-
 	for _, result := range results {
-		switch result.ModelName {
-		case "Enhanced Statistical":
-			predictions.Statistical = result.WinProbability
-		case "Bayesian Inference":
-			predictions.Bayesian = result.WinProbability
-		case "Monte Carlo Simulation":
-			predictions.MonteCarlo = result.WinProbability
-		case "Elo Rating":
-			predictions.Elo = result.WinProbability
-		case "Poisson Regression":
-			predictions.Poisson = result.WinProbability
-		case "Neural Network":
-			predictions.NeuralNetwork = result.WinProbability
-		case "Gradient Boosting":
-			predictions.GradientBoosting = result.WinProbability
-		case "LSTM":
-			predictions.LSTM = result.WinProbability
-		case "Random Forest":
-			predictions.RandomForest = result.WinProbability
-		}
+		predictions.setModel(result.ModelName, result.WinProbability)
 	}
 
 	// Build game context
-	context := &MetaGameContext{
-		IsDivisionalGame: false, // TODO: Determine from team codes
-		IsPlayoffGame:    false, // TODO: Determine from game type
-		IsRivalryGame:    false, // TODO: Determine from matchup
-		HomeTeamHot:      homeFactors.IsHot,
-		AwayTeamHot:      awayFactors.IsHot,
-		HomeTeamCold:     homeFactors.IsCold,
-		AwayTeamCold:     awayFactors.IsCold,
-		RestAdvantage:    float64(homeFactors.RestDays - awayFactors.RestDays),
-		TravelDistance:   awayFactors.TravelFatigue.MilesTraveled,
-		BackToBack:       homeFactors.BackToBackPenalty > 0 || awayFactors.BackToBackPenalty > 0,
-	}
+	context := newMetaGameContext(homeFactors, awayFactors)
 
 	// Get meta-learner prediction
-	winProb := eps.metaLearner.PredictFromModels(predictions, context)
+	winProb := eps.metaLearner.PredictFromModels(predictions, &context)
 
 	// Calculate confidence (based on model agreement)
 	var sumSquaredDiff float64
@@ -1554,8 +1612,9 @@ func (eps *EnsemblePredictionService) combineWithMetaLearner(results []models.Mo
 
 	predictedScore := "3-2"
 	if validScores > 0 {
-		homeScore := int(math.Round(homeGoalsSum / float64(validScores)))
-		awayScore := int(math.Round(awayGoalsSum / float64(validScores)))
+		// Shift by the officiating crew's scoring tendency
+		homeScore := int(math.Round(math.Max(0, homeGoalsSum/float64(validScores)+homeFactors.RefereeGoalsDelta)))
+		awayScore := int(math.Round(math.Max(0, awayGoalsSum/float64(validScores)+awayFactors.RefereeGoalsDelta)))
 
 		// Adjust based on win probability
 		if winProb > 0.6 && homeScore <= awayScore {
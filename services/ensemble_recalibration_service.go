@@ -25,6 +25,7 @@ type EnsembleRecalibrationService struct {
 	config            models.RecalibrationConfig
 	dataDir           string
 	predictionsSinceLastRecal int
+	walkForward       RollingOriginConfig // Layout for validating new weights on stored predictions
 }
 
 // InitializeRecalibration initializes the singleton EnsembleRecalibrationService
@@ -59,8 +60,9 @@ func InitializeRecalibration() error {
 			recalibrationHistory: &models.RecalibrationHistory{
 				Events: []models.RecalibrationEvent{},
 			},
-			config:  config,
-			dataDir: dataDir,
+			config:      config,
+			dataDir:     dataDir,
+			walkForward: DefaultRollingOriginConfig(),
 		}
 
 		// Load existing data
@@ -162,7 +164,7 @@ func (ers *EnsembleRecalibrationService) RecordPredictionOutcome(
 
 	// Check if recalibration should be triggered
 	if ers.config.AutoRecalibrate && ers.predictionsSinceLastRecal >= ers.config.UpdateFrequency {
-		if err := ers.TriggerRecalibration("scheduled"); err != nil {
+		if err := ers.recalibrate("scheduled"); err != nil {
 			fmt.Printf("⚠️ Warning: Recalibration failed: %v\n", err)
 		}
 	}
@@ -175,11 +177,20 @@ func (ers *EnsembleRecalibrationService) TriggerRecalibration(trigger string) er
 	ers.mu.Lock()
 	defer ers.mu.Unlock()
 
+	return ers.recalibrate(trigger)
+}
+
+// recalibrate adjusts weights; callers hold ers.mu
+func (ers *EnsembleRecalibrationService) recalibrate(trigger string) error {
 	// Get current weights (from dynamic weighting service if available)
 	oldWeights := ers.getCurrentWeights()
 	
-	// Calculate new weights based on recent performance
-	newWeights := ers.calculateNewWeights(oldWeights)
+	// Prefer weights validated walk-forward on stored predictions; fall back to
+	// recent accuracy when there isn't enough history for a single fold
+	newWeights, validation, validated := ers.walkForwardWeights(oldWeights)
+	if validation == nil {
+		newWeights = ers.calculateNewWeights(oldWeights)
+	}
 	
 	// Calculate weight changes
 	weightChanges := make(map[string]float64)
@@ -203,6 +214,14 @@ func (ers *EnsembleRecalibrationService) TriggerRecalibration(trigger string) er
 		PerformanceSnapshot: performanceSnapshot,
 		Reasoning:           ers.generateRecalibrationReasoning(oldWeights, newWeights, performanceSnapshot),
 		PredictionCount:     ers.getTotalPredictions(),
+		Validation:          validation,
+	}
+	if validation != nil {
+		if validated {
+			event.Reasoning += fmt.Sprintf("Walk-forward validated over %d folds. ", len(validation))
+		} else {
+			event.Reasoning += fmt.Sprintf("Walk-forward candidates did not beat current weights over %d folds; weights kept. ", len(validation))
+		}
 	}
 	
 	ers.recalibrationHistory.Events = append(ers.recalibrationHistory.Events, event)
@@ -276,6 +295,7 @@ func (ers *EnsembleRecalibrationService) initializeModelTrackers() {
 		"Gradient Boosting",
 		"LSTM",
 		"Random Forest",
+		"Bayesian Ratings",
 	}
 
 	baseWeights := map[string]float64{
@@ -288,6 +308,7 @@ func (ers *EnsembleRecalibrationService) initializeModelTrackers() {
 		"Gradient Boosting":      0.07,
 		"LSTM":                   0.07,
 		"Random Forest":          0.07,
+		"Bayesian Ratings":       0.08,
 	}
 
 	for _, model := range modelNames {
@@ -373,6 +394,99 @@ func (ers *EnsembleRecalibrationService) calculateNewWeights(oldWeights map[stri
 	return newWeights
 }
 
+// walkForwardWeights fits candidate weights on each rolling-origin training
+// window and scores them on the games that follow. If they beat the current
+// weights on average, the weights fit on the most recent window are applied
+// within the usual shift and min/max constraints. Returns nil validation when
+// there aren't enough stored results for a fold.
+func (ers *EnsembleRecalibrationService) walkForwardWeights(oldWeights map[string]float64) (map[string]float64, []models.WeightValidationFold, bool) {
+	records, err := LoadWalkForwardRecords()
+	if err != nil {
+		return oldWeights, nil, false
+	}
+
+	splits := RollingOriginSplits(len(records), ers.walkForward)
+	if len(splits) == 0 {
+		return oldWeights, nil, false
+	}
+
+	folds := make([]models.WeightValidationFold, 0, len(splits))
+	improvement := 0.0
+	for _, split := range splits {
+		train := records[split.TrainStart:split.TrainEnd]
+		test := records[split.TestStart:split.TestEnd]
+
+		candidate := ers.constrainWeights(inverseBrierWeights(scoreWalkForwardModels(train)))
+
+		fold := models.WeightValidationFold{
+			Fold:       split.Fold,
+			TrainSize:  len(train),
+			TestSize:   len(test),
+			ModelBrier: make(map[string]float64),
+		}
+		for _, record := range test {
+			y := 0.0
+			if record.HomeWon {
+				y = 1.0
+			}
+			current := blendWalkForward(record, oldWeights)
+			proposed := blendWalkForward(record, candidate)
+			fold.CurrentBrier += (current - y) * (current - y)
+			fold.CandidateBrier += (proposed - y) * (proposed - y)
+		}
+		fold.CurrentBrier /= float64(len(test))
+		fold.CandidateBrier /= float64(len(test))
+		for name, score := range scoreWalkForwardModels(test) {
+			fold.ModelBrier[name] = score.BrierScore
+		}
+
+		improvement += fold.CurrentBrier - fold.CandidateBrier
+		folds = append(folds, fold)
+	}
+
+	if improvement/float64(len(folds)) <= 0 {
+		return oldWeights, folds, false
+	}
+
+	// Fit on the latest window, then move toward it within the shift limit
+	latest := records
+	if ers.walkForward.TrainWindow > 0 && len(latest) > ers.walkForward.TrainWindow {
+		latest = latest[len(latest)-ers.walkForward.TrainWindow:]
+	}
+	target := ers.constrainWeights(inverseBrierWeights(scoreWalkForwardModels(latest)))
+
+	maxShift := ers.config.Constraints.MaxShiftPerUpdate
+	stepped := make(map[string]float64, len(oldWeights))
+	for model, oldWeight := range oldWeights {
+		targetWeight, ok := target[model]
+		if !ok {
+			stepped[model] = oldWeight
+			continue
+		}
+		shift := math.Max(-maxShift, math.Min(maxShift, targetWeight-oldWeight))
+		stepped[model] = oldWeight + shift
+	}
+
+	return ers.constrainWeights(stepped), folds, true
+}
+
+// constrainWeights clamps weights to the configured min/max and normalizes them
+func (ers *EnsembleRecalibrationService) constrainWeights(weights map[string]float64) map[string]float64 {
+	constrained := make(map[string]float64, len(weights))
+	total := 0.0
+	for model, weight := range weights {
+		w := math.Max(ers.config.Constraints.MinWeight, math.Min(ers.config.Constraints.MaxWeight, weight))
+		constrained[model] = w
+		total += w
+	}
+	if total > 0 {
+		for model := range constrained {
+			constrained[model] /= total
+		}
+	}
+	return constrained
+}
+
 func (ers *EnsembleRecalibrationService) generateRecalibrationReasoning(
 	oldWeights, newWeights, performance map[string]float64) string {
 	
@@ -34,11 +34,14 @@ type FeatureImportanceReport struct {
 	PruningRecommendation PruningRecommendation `json:"pruningRecommendation"`
 }
 
-// PruningRecommendation suggests which features to keep/remove
+// PruningRecommendation suggests which features to keep/remove. Counts come
+// from held-out permutation importance once it has been run, otherwise from
+// the models' split importance.
 type PruningRecommendation struct {
-	KeepCount   int `json:"keepCount"`   // >0.1% importance
-	ReviewCount int `json:"reviewCount"` // 0.01-0.1% importance
-	PruneCount  int `json:"pruneCount"`  // <0.01% importance
+	KeepCount   int    `json:"keepCount"`   // >0.1% importance
+	ReviewCount int    `json:"reviewCount"` // 0.01-0.1% importance
+	PruneCount  int    `json:"pruneCount"`  // <0.01% importance
+	Source      string `json:"source"`      // "permutation" or "split_importance"
 }
 
 // FeatureImportanceService analyzes feature importance across models
@@ -176,14 +179,23 @@ func (fis *FeatureImportanceService) GetFeatureImportanceReport() (*FeatureImpor
 	})
 
 	// Pruning recommendation
-	pruning := PruningRecommendation{}
-	for _, feature := range features {
-		if feature.Importance > 0.001 {
-			pruning.KeepCount++
-		} else if feature.Importance > 0.0001 {
-			pruning.ReviewCount++
-		} else {
-			pruning.PruneCount++
+	pruning := PruningRecommendation{Source: "split_importance"}
+	if permutation := GetPermutationImportanceService().GetReport(); permutation != nil {
+		pruning = PruningRecommendation{
+			KeepCount:   permutation.KeepCount,
+			ReviewCount: permutation.ReviewCount,
+			PruneCount:  permutation.PruneCount,
+			Source:      "permutation",
+		}
+	} else {
+		for _, feature := range features {
+			if feature.Importance > 0.001 {
+				pruning.KeepCount++
+			} else if feature.Importance > 0.0001 {
+				pruning.ReviewCount++
+			} else {
+				pruning.PruneCount++
+			}
 		}
 	}
 
@@ -215,13 +227,46 @@ func getFeatureName(idx int) string {
 		28: "Home Rest Days", 29: "Away Rest Days",
 		30: "Home B2B Penalty", 31: "Away B2B Penalty",
 		32: "Home H2H Record", 33: "Away H2H Record",
+		34: "Home Goalie Save %", 35: "Away Goalie Save %",
+		36: "Home Saves Above Expected", 37: "Away Saves Above Expected",
+		38: "Home Leading Performance", 39: "Away Leading Performance",
+		40: "Home Trailing Performance", 41: "Away Trailing Performance",
+		42: "Home Offensive Zone %", 43: "Away Offensive Zone %",
+		44: "Home Controlled Entries", 45: "Away Controlled Entries",
+		46: "Home Overall Rating", 47: "Away Overall Rating",
+		48: "Home Ice", 49: "Away Ice",
 		50: "Home Goalie Advantage", 51: "Away Goalie Advantage",
 		52: "Goalie Save % Diff", 53: "Goalie Recent Form Diff",
 		54: "Home Market Consensus", 55: "Away Market Consensus",
 		56: "Sharp Money Indicator", 57: "Market Line Movement",
+		58: "Home Travel Distance", 59: "Away Travel Distance",
+		60: "Home Back-to-Back", 61: "Away Back-to-Back",
+		62: "Home Schedule Density", 63: "Away Schedule Density",
+		64: "Rest Advantage",
 		65: "Home Star Power", 66: "Away Star Power",
 		67: "Home Top Scorer PPG", 68: "Away Top Scorer PPG",
 		69: "Home Top 3 Combined PPG", 70: "Away Top 3 Combined PPG",
+		71: "Home Depth Scoring", 72: "Away Depth Scoring",
+		73: "Home Scoring Balance", 74: "Away Scoring Balance",
+		75: "Home xGF", 76: "Away xGF",
+		77: "Home xGA", 78: "Away xGA",
+		79: "Home xG Differential (PBP)", 80: "Away xG Differential (PBP)",
+		81: "Home xG per Shot", 82: "Away xG per Shot",
+		83: "Home Dangerous Shots", 84: "Away Dangerous Shots",
+		85: "Home High-Danger xG", 86: "Away High-Danger xG",
+		87: "Shot Quality Advantage",
+		88: "Home Corsi (PBP)", 89: "Away Corsi (PBP)",
+		90: "Home Fenwick For %", 91: "Away Fenwick For %",
+		92: "Home Faceoff %",
+		93: "Home Avg Shift Length", 94: "Away Avg Shift Length",
+		95: "Home Line Consistency", 96: "Away Line Consistency",
+		97: "Home Short Bench", 98: "Away Short Bench",
+		99: "Home Fatigue Indicator", 100: "Away Fatigue Indicator",
+		101: "Home Time on Attack", 102: "Away Time on Attack",
+		103: "Home Zone Control", 104: "Away Zone Control",
+		105: "Home Shot Quality Index", 106: "Away Shot Quality Index",
+		107: "Home PP Time", 108: "Away PP Time",
+		109: "Home Offensive Zone Time", 110: "Away Offensive Zone Time",
 		111: "Home IsHot", 112: "Away IsHot",
 		113: "Home IsCold", 114: "Away IsCold",
 		115: "Home IsStreaking", 116: "Away IsStreaking",
@@ -319,9 +364,16 @@ func (fis *FeatureImportanceService) GenerateMarkdownReport() (string, error) {
 	}
 
 	md += "\n## Pruning Recommendation\n\n"
-	md += fmt.Sprintf("- **Keep**: %d features (>0.1%% importance)\n", report.PruningRecommendation.KeepCount)
-	md += fmt.Sprintf("- **Review**: %d features (0.01-0.1%% importance)\n", report.PruningRecommendation.ReviewCount)
-	md += fmt.Sprintf("- **Prune**: %d features (<0.01%% importance)\n", report.PruningRecommendation.PruneCount)
+	if report.PruningRecommendation.Source == "permutation" {
+		md += "Based on held-out permutation and drop-column importance (see /api/feature-importance/permutation).\n\n"
+		md += fmt.Sprintf("- **Keep**: %d features (held-out loss increase significantly above zero)\n", report.PruningRecommendation.KeepCount)
+		md += fmt.Sprintf("- **Review**: %d features (inconclusive)\n", report.PruningRecommendation.ReviewCount)
+		md += fmt.Sprintf("- **Prune**: %d features (constant, or no material loss when shuffled or removed)\n", report.PruningRecommendation.PruneCount)
+	} else {
+		md += fmt.Sprintf("- **Keep**: %d features (>0.1%% importance)\n", report.PruningRecommendation.KeepCount)
+		md += fmt.Sprintf("- **Review**: %d features (0.01-0.1%% importance)\n", report.PruningRecommendation.ReviewCount)
+		md += fmt.Sprintf("- **Prune**: %d features (<0.01%% importance)\n", report.PruningRecommendation.PruneCount)
+	}
 
 	return md, nil
 }
@@ -84,8 +84,9 @@ func (fis *FeatureInteractionService) EnrichWithInteractions(factors *models.Pre
 	// Home advantage means more for good teams
 	factors.HomeFieldStrength = factors.HomeAdvantage * factors.WeightedWinPct
 
-	// RefereeHomeBias: Set to 0 (referee data removed)
-	factors.RefereeHomeBias = 0.0
+	// RefereeHomeBias: Crew's home power play edge compounded with venue advantage
+	// Zero when the officiating crew is unknown
+	factors.RefereeHomeBias = factors.RefereeHomeAdvantage * factors.HomeAdvantage
 
 	// ============================================================================
 	// ELITE PERFORMANCE INTERACTIONS
@@ -118,8 +119,12 @@ func (fis *FeatureInteractionService) EnrichWithInteractions(factors *models.Pre
 	pkAboveAvg := factors.PenaltyKillPct - 0.80
 	factors.SpecialTeamsDominance = ppAboveAvg * pkAboveAvg
 
-	// PowerPlayOpportunity: PP effectiveness
-	factors.PowerPlayOpportunity = factors.PowerPlayPct
+	// PowerPlayOpportunity: PP effectiveness scaled by how often the crew calls penalties
+	refereePenaltyRate := factors.RefereePenaltyRate
+	if refereePenaltyRate == 0 {
+		refereePenaltyRate = 1.0 // Unknown crew = league average
+	}
+	factors.PowerPlayOpportunity = factors.PowerPlayPct * refereePenaltyRate
 
 	// ============================================================================
 	// SITUATIONAL CONTEXT
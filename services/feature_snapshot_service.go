@@ -0,0 +1,364 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// FeatureSnapshotService persists the factors each game was predicted with and
+// joins them back to completed games, so batch-trained models learn from the
+// same feature space they predict from
+type FeatureSnapshotService struct {
+	dataDir    string
+	resultsDir string
+	mutex      sync.RWMutex
+}
+
+var (
+	featureSnapshotService     *FeatureSnapshotService
+	featureSnapshotServiceOnce sync.Once
+)
+
+// InitFeatureSnapshotService initializes the singleton
+func InitFeatureSnapshotService() *FeatureSnapshotService {
+	featureSnapshotServiceOnce.Do(func() {
+		dataDir := "data/feature_snapshots"
+		os.MkdirAll(dataDir, 0755)
+
+		featureSnapshotService = &FeatureSnapshotService{
+			dataDir:    dataDir,
+			resultsDir: "data/results",
+		}
+
+		log.Printf("📸 Feature Snapshot Service initialized (dir: %s)", dataDir)
+	})
+	return featureSnapshotService
+}
+
+// GetFeatureSnapshotService returns the singleton instance
+func GetFeatureSnapshotService() *FeatureSnapshotService {
+	return featureSnapshotService
+}
+
+// RecordSnapshot stores the enriched factors used for a pre-game prediction.
+// Later predictions replace earlier ones only before the scheduled puck drop,
+// so live and post-game re-predictions can't leak in-game state or the
+// result. When the start time is unknown an existing snapshot is never replaced.
+func (fss *FeatureSnapshotService) RecordSnapshot(gameID int, gameStart time.Time, homeFactors, awayFactors *models.PredictionFactors) error {
+	if gameID == 0 || homeFactors == nil || awayFactors == nil {
+		return nil
+	}
+	if !gameStart.IsZero() && !time.Now().Before(gameStart) {
+		return nil
+	}
+	if grs := GetGameResultsService(); grs != nil && grs.isProcessed(gameID) {
+		return nil
+	}
+
+	snapshot := models.FeatureSnapshot{
+		GameID:      gameID,
+		HomeTeam:    homeFactors.TeamCode,
+		AwayTeam:    awayFactors.TeamCode,
+		HomeFactors: *homeFactors,
+		AwayFactors: *awayFactors,
+		Source:      "prediction",
+		CapturedAt:  time.Now(),
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal feature snapshot: %w", err)
+	}
+
+	fss.mutex.Lock()
+	defer fss.mutex.Unlock()
+
+	filePath := filepath.Join(fss.dataDir, fmt.Sprintf("game_%d.json", gameID))
+	if gameStart.IsZero() {
+		if _, err := os.Stat(filePath); err == nil {
+			return nil
+		}
+	}
+	if err := ioutil.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write feature snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot returns the stored snapshot for a game, or nil if none exists
+func (fss *FeatureSnapshotService) LoadSnapshot(gameID int) (*models.FeatureSnapshot, error) {
+	fss.mutex.RLock()
+	defer fss.mutex.RUnlock()
+
+	filePath := filepath.Join(fss.dataDir, fmt.Sprintf("game_%d.json", gameID))
+	data, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature snapshot: %w", err)
+	}
+
+	var snapshot models.FeatureSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse feature snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// LoadCompletedGames reads every completed game from the monthly results files, oldest first
+func (fss *FeatureSnapshotService) LoadCompletedGames() ([]models.CompletedGame, error) {
+	return loadCompletedGamesFrom(fss.resultsDir)
+}
+
+// loadCompletedGamesFrom reads the monthly results files in dir, oldest first
+func loadCompletedGamesFrom(dir string) ([]models.CompletedGame, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]models.CompletedGame, 0)
+	for _, file := range files {
+		if strings.HasSuffix(file, "processed_games.json") {
+			continue
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Printf("⚠️ Failed to read %s: %v", file, err)
+			continue
+		}
+		var monthly []models.CompletedGame
+		if err := json.Unmarshal(data, &monthly); err != nil {
+			log.Printf("⚠️ Failed to parse %s: %v", file, err)
+			continue
+		}
+		games = append(games, monthly...)
+	}
+
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].GameDate.Before(games[j].GameDate)
+	})
+	return games, nil
+}
+
+// minSnapshotExamples is how many games with real prediction-time snapshots a
+// training set needs before reconstructed examples are left out of it
+const minSnapshotExamples = 200
+
+// BuildTrainingSet joins each game with the factors used to predict it. Games
+// predicted before this service existed get factors reconstructed from the
+// results that were known at the time, then run through the same feature
+// interaction step as live predictions. Reconstructed factors fill only a few
+// fields, so they don't match the distribution of real snapshots; they are
+// marked with Source "reconstructed" and only used until the set has
+// minSnapshotExamples real snapshots.
+func (fss *FeatureSnapshotService) BuildTrainingSet(games []models.CompletedGame) ([]models.TrainingExample, models.TrainingSetSummary) {
+	summary := models.TrainingSetSummary{}
+
+	history, err := fss.LoadCompletedGames()
+	if err != nil {
+		log.Printf("⚠️ Failed to load game history for reconstruction: %v", err)
+	}
+	history = mergeGameHistory(history, games)
+
+	interactionService := NewFeatureInteractionService()
+	examples := make([]models.TrainingExample, 0, len(games))
+
+	for _, game := range games {
+		snapshot, err := fss.LoadSnapshot(game.GameID)
+		if err != nil {
+			log.Printf("⚠️ Ignoring feature snapshot for game %d: %v", game.GameID, err)
+		}
+
+		if snapshot != nil {
+			home, away := snapshot.HomeFactors, snapshot.AwayFactors
+			examples = append(examples, models.TrainingExample{
+				Game:        game,
+				HomeFactors: &home,
+				AwayFactors: &away,
+				Source:      snapshot.Source,
+			})
+			summary.FromSnapshots++
+			continue
+		}
+
+		homeFactors, awayFactors := reconstructFactors(game, history)
+		if homeFactors == nil || awayFactors == nil {
+			summary.Skipped++
+			continue
+		}
+		interactionService.EnrichWithInteractions(homeFactors)
+		interactionService.EnrichWithInteractions(awayFactors)
+
+		examples = append(examples, models.TrainingExample{
+			Game:        game,
+			HomeFactors: homeFactors,
+			AwayFactors: awayFactors,
+			Source:      "reconstructed",
+		})
+		summary.Reconstructed++
+	}
+
+	if summary.FromSnapshots >= minSnapshotExamples && summary.Reconstructed > 0 {
+		kept := examples[:0]
+		for _, example := range examples {
+			if example.Source != "reconstructed" {
+				kept = append(kept, example)
+			}
+		}
+		examples = kept
+		summary.Excluded = summary.Reconstructed
+		summary.Reconstructed = 0
+	}
+
+	summary.Games = len(examples)
+	log.Printf("📸 Training set: %d games (%d snapshots, %d reconstructed, %d reconstructed excluded, %d skipped)",
+		summary.Games, summary.FromSnapshots, summary.Reconstructed, summary.Excluded, summary.Skipped)
+
+	return examples, summary
+}
+
+// mergeGameHistory adds games not already on disk and keeps the history sorted by date
+func mergeGameHistory(history, games []models.CompletedGame) []models.CompletedGame {
+	seen := make(map[int]bool, len(history))
+	for _, game := range history {
+		seen[game.GameID] = true
+	}
+	for _, game := range games {
+		if !seen[game.GameID] {
+			history = append(history, game)
+			seen[game.GameID] = true
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].GameDate.Before(history[j].GameDate)
+	})
+	return history
+}
+
+// reconstructFactors rebuilds point-in-time factors for both teams using only
+// games played before this one. Returns nil if either team has no prior games.
+func reconstructFactors(game models.CompletedGame, history []models.CompletedGame) (*models.PredictionFactors, *models.PredictionFactors) {
+	home := teamFactorsBefore(game.HomeTeam.TeamCode, game.AwayTeam.TeamCode, game, history, true)
+	away := teamFactorsBefore(game.AwayTeam.TeamCode, game.HomeTeam.TeamCode, game, history, false)
+	if home == nil || away == nil {
+		return nil, nil
+	}
+
+	home.RestAdvantage = float64(home.RestDays - away.RestDays)
+	away.RestAdvantage = float64(away.RestDays - home.RestDays)
+	return home, away
+}
+
+// teamFactorsBefore summarizes a team's season up to (not including) the given game
+func teamFactorsBefore(teamCode, opponentCode string, game models.CompletedGame, history []models.CompletedGame, isHome bool) *models.PredictionFactors {
+	var prior []models.CompletedGame
+	for _, g := range history {
+		if !g.GameDate.Before(game.GameDate) || g.GameID == game.GameID {
+			continue
+		}
+		if game.Season != 0 && g.Season != 0 && g.Season != game.Season {
+			continue
+		}
+		if g.HomeTeam.TeamCode == teamCode || g.AwayTeam.TeamCode == teamCode {
+			prior = append(prior, g)
+		}
+	}
+	if len(prior) == 0 {
+		return nil
+	}
+
+	wins, goalsFor, goalsAgainst := 0, 0, 0
+	ppGoals, ppOpps, pkSaves, pkOpps := 0, 0, 0, 0
+	h2hGames, h2hWins := 0, 0
+	gamesLastWeek := 0
+
+	for _, g := range prior {
+		team, opponent := g.HomeTeam, g.AwayTeam
+		if g.AwayTeam.TeamCode == teamCode {
+			team, opponent = g.AwayTeam, g.HomeTeam
+		}
+
+		won := team.Score > opponent.Score
+		if won {
+			wins++
+		}
+		goalsFor += team.Score
+		goalsAgainst += opponent.Score
+		ppGoals += team.PowerPlayGoals
+		ppOpps += team.PowerPlayOpps
+		pkSaves += team.PenaltyKillSaves
+		pkOpps += team.PenaltyKillOpps
+
+		if opponent.TeamCode == opponentCode {
+			h2hGames++
+			if won {
+				h2hWins++
+			}
+		}
+		if game.GameDate.Sub(g.GameDate) <= 7*24*time.Hour {
+			gamesLastWeek++
+		}
+	}
+
+	gamesPlayed := float64(len(prior))
+
+	// Last 10 games
+	recent := prior
+	if len(recent) > 10 {
+		recent = recent[len(recent)-10:]
+	}
+	recentWins := 0
+	for _, g := range recent {
+		if g.Winner == teamCode {
+			recentWins++
+		}
+	}
+
+	restDays := int(game.GameDate.Sub(prior[len(prior)-1].GameDate).Hours()/24) - 1
+	if restDays < 0 {
+		restDays = 0
+	}
+
+	factors := &models.PredictionFactors{
+		TeamCode:        teamCode,
+		WinPercentage:   float64(wins) / gamesPlayed,
+		GoalsFor:        float64(goalsFor) / gamesPlayed,
+		GoalsAgainst:    float64(goalsAgainst) / gamesPlayed,
+		PowerPlayPct:    0.20, // League average when special teams weren't recorded
+		PenaltyKillPct:  0.80,
+		RecentForm:      float64(recentWins) / float64(len(recent)),
+		HeadToHead:      0.5,
+		RestDays:        restDays,
+		ScheduleDensity: float64(gamesLastWeek),
+	}
+	if ppOpps > 0 {
+		factors.PowerPlayPct = float64(ppGoals) / float64(ppOpps)
+	}
+	if pkOpps > 0 {
+		factors.PenaltyKillPct = float64(pkSaves) / float64(pkOpps)
+	}
+	if h2hGames > 0 {
+		factors.HeadToHead = float64(h2hWins) / float64(h2hGames)
+	}
+	if isHome {
+		factors.HomeAdvantage = 0.12 // League average, as in PredictionService
+	}
+	if restDays == 0 {
+		factors.BackToBackPenalty = 0.15
+		factors.BackToBackIndicator = 1.0
+	}
+
+	return factors
+}
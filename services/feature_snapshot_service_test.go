@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+func TestRecordSnapshotOnlyBeforePuckDrop(t *testing.T) {
+	fss := &FeatureSnapshotService{dataDir: t.TempDir()}
+	home := &models.PredictionFactors{TeamCode: "UTA", WinPercentage: 0.55}
+	away := &models.PredictionFactors{TeamCode: "COL", WinPercentage: 0.60}
+
+	// Predictions after the scheduled start are live or post-game
+	if err := fss.RecordSnapshot(1, time.Now().Add(-time.Minute), home, away); err != nil {
+		t.Fatalf("RecordSnapshot failed: %v", err)
+	}
+	if snapshot, _ := fss.LoadSnapshot(1); snapshot != nil {
+		t.Error("Expected no snapshot for a prediction after puck drop")
+	}
+
+	start := time.Now().Add(2 * time.Hour)
+	if err := fss.RecordSnapshot(2, start, home, away); err != nil {
+		t.Fatalf("RecordSnapshot failed: %v", err)
+	}
+	later := &models.PredictionFactors{TeamCode: "UTA", WinPercentage: 0.70}
+	if err := fss.RecordSnapshot(2, start, later, away); err != nil {
+		t.Fatalf("RecordSnapshot failed: %v", err)
+	}
+	snapshot, err := fss.LoadSnapshot(2)
+	if err != nil || snapshot == nil {
+		t.Fatalf("Expected a snapshot for game 2, got %v (%v)", snapshot, err)
+	}
+	if snapshot.HomeFactors.WinPercentage != 0.70 {
+		t.Errorf("Expected the later pre-game prediction to replace the snapshot, got %.2f",
+			snapshot.HomeFactors.WinPercentage)
+	}
+
+	// Without a known start time the first snapshot is kept
+	if err := fss.RecordSnapshot(2, time.Time{}, home, away); err != nil {
+		t.Fatalf("RecordSnapshot failed: %v", err)
+	}
+	if snapshot, _ := fss.LoadSnapshot(2); snapshot.HomeFactors.WinPercentage != 0.70 {
+		t.Errorf("Expected a snapshot without a start time not to overwrite, got %.2f",
+			snapshot.HomeFactors.WinPercentage)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -35,7 +36,6 @@ type GameResultsService struct {
 	accuracyTracker *AccuracyTrackingService
 	evaluationSvc   *ModelEvaluationService // For batch training
 	mutex           sync.RWMutex
-	stopChan        chan bool
 	isRunning       bool
 	httpClient      *http.Client
 	lastPredictionCheck time.Time // Track last time we checked for unprocessed predictions
@@ -60,7 +60,6 @@ func NewGameResultsService(
 		neuralNet:       neuralNet,
 		rollingStats:    rollingStats,
 		accuracyTracker: accuracyTracker,
-		stopChan:        make(chan bool),
 		isRunning:       false,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
@@ -78,7 +77,8 @@ func NewGameResultsService(
 	return service
 }
 
-// Start begins the game monitoring process
+// Start enables game monitoring. The job scheduler runs the checks through
+// CheckForCompletedGames and CheckUnprocessedPredictions.
 func (grs *GameResultsService) Start() {
 	grs.mutex.Lock()
 	if grs.isRunning {
@@ -93,12 +93,9 @@ func (grs *GameResultsService) Start() {
 	log.Printf("📊 Loaded %d processed games from index", len(grs.processedGames))
 	log.Printf("📊 Checking for completed NHL games every %v", grs.checkInterval)
 	log.Printf("🌍 Processing ALL NHL games for maximum ML training data")
-
-	// Start background monitoring goroutine
-	go grs.monitorGames()
 }
 
-// Stop halts the game monitoring process
+// Stop disables game monitoring
 func (grs *GameResultsService) Stop() {
 	grs.mutex.Lock()
 	defer grs.mutex.Unlock()
@@ -108,37 +105,30 @@ func (grs *GameResultsService) Stop() {
 	}
 
 	log.Printf("⏹️ Stopping Game Results Service...")
-	grs.stopChan <- true
 	grs.isRunning = false
 	log.Printf("✅ Game Results Service stopped")
 }
 
-// monitorGames runs the continuous monitoring loop
-func (grs *GameResultsService) monitorGames() {
-	ticker := time.NewTicker(grs.checkInterval)
-	defer ticker.Stop()
-
-	// Daily ticker for checking predictions without results
-	dailyTicker := time.NewTicker(24 * time.Hour)
-	defer dailyTicker.Stop()
+// IsRunning reports whether game monitoring is enabled
+func (grs *GameResultsService) IsRunning() bool {
+	grs.mutex.Lock()
+	defer grs.mutex.Unlock()
+	return grs.isRunning
+}
 
-	// Do an immediate check on startup
-	grs.checkForCompletedGames()
-	
-	// Also check for unprocessed predictions on startup (in case server was down)
-	grs.checkUnprocessedPredictions()
+// CheckInterval is how often completed games should be checked for
+func (grs *GameResultsService) CheckInterval() time.Duration {
+	return grs.checkInterval
+}
 
-	for {
-		select {
-		case <-ticker.C:
-			grs.checkForCompletedGames()
-		case <-dailyTicker.C:
-			// Daily check for predictions without results
-			grs.checkUnprocessedPredictions()
-		case <-grs.stopChan:
-			return
-		}
+// CheckForCompletedGames processes newly completed games if monitoring is
+// enabled. Called by the job scheduler every CheckInterval.
+func (grs *GameResultsService) CheckForCompletedGames(ctx context.Context) error {
+	if !grs.IsRunning() {
+		return nil
 	}
+	grs.checkForCompletedGames()
+	return ctx.Err()
 }
 
 // checkForCompletedGames checks for new completed games (league-wide)
@@ -176,7 +166,7 @@ func (grs *GameResultsService) checkForCompletedGames() {
 	// Process each new game
 	for _, game := range newGames {
 		log.Printf("📥 Processing game %d (%s @ %s)...", game.GameID, game.AwayTeam.Abbrev, game.HomeTeam.Abbrev)
-		if err := grs.processGame(game.GameID); err != nil {
+		if err := grs.processGame(context.Background(), game.GameID); err != nil {
 			log.Printf("❌ Failed to process game %d: %v", game.GameID, err)
 			log.Printf("⚠️ Game %d will be retried on next check cycle", game.GameID)
 			// Don't mark as processed if it failed - allow retry
@@ -211,19 +201,12 @@ func (grs *GameResultsService) CheckForMissedGames() {
 		log.Printf("📅 Checking date %s for missed games...", dateStr)
 		
 		// Try to fetch scoreboard for that specific date
-		url := fmt.Sprintf("https://api-web.nhle.com/v1/scoreboard/%s", dateStr)
-		body, err := MakeAPICall(url)
+		scheduleData, err := GetNHLAPIClient().LeagueScoreboardForDate(context.Background(), checkDate)
 		if err != nil {
 			log.Printf("⚠️ Could not check date %s: %v", dateStr, err)
 			continue
 		}
 		
-		var scheduleData models.ScoreboardResponse
-		if err := json.Unmarshal(body, &scheduleData); err != nil {
-			log.Printf("⚠️ Failed to decode scoreboard for %s: %v", dateStr, err)
-			continue
-		}
-		
 		// Find completed games from this date that haven't been processed
 		foundMissed := false
 		for _, gamesByDate := range scheduleData.GamesByDate {
@@ -232,7 +215,7 @@ func (grs *GameResultsService) CheckForMissedGames() {
 					log.Printf("🔍 Found missed game %d from %s (%s @ %s)", 
 						game.GameID, dateStr, game.AwayTeam.Abbrev, game.HomeTeam.Abbrev)
 					
-					if err := grs.processGame(game.GameID); err != nil {
+					if err := grs.processGame(context.Background(), game.GameID); err != nil {
 						log.Printf("❌ Failed to process missed game %d: %v", game.GameID, err)
 					} else {
 						grs.markProcessed(game.GameID)
@@ -252,37 +235,31 @@ func (grs *GameResultsService) CheckForMissedGames() {
 	}
 }
 
-// BackfillGamesForDate processes all completed games from a specific date
-func (grs *GameResultsService) BackfillGamesForDate(targetDate time.Time) {
+// BackfillGamesForDate processes all completed games from a specific date,
+// stopping between games once ctx is cancelled
+func (grs *GameResultsService) BackfillGamesForDate(ctx context.Context, targetDate time.Time) {
 	dateStr := targetDate.Format("2006-01-02")
 	log.Printf("📅 Backfilling games from %s...", dateStr)
 	
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/scoreboard/%s", dateStr)
-	body, err := MakeAPICall(url)
+	games, err := grs.unprocessedGamesForDate(ctx, targetDate)
 	if err != nil {
-		log.Printf("❌ Failed to fetch scoreboard for %s: %v", dateStr, err)
-		return
-	}
-	
-	var scheduleData models.ScoreboardResponse
-	if err := json.Unmarshal(body, &scheduleData); err != nil {
-		log.Printf("❌ Failed to decode scoreboard for %s: %v", dateStr, err)
+		log.Printf("❌ %v", err)
 		return
 	}
 	
 	processedCount := 0
-	for _, gamesByDate := range scheduleData.GamesByDate {
-		for _, game := range gamesByDate.Games {
-			if grs.isGameCompleted(game) && !grs.isProcessed(game.GameID) {
-				log.Printf("📥 Processing game %d (%s @ %s)...", game.GameID, game.AwayTeam.Abbrev, game.HomeTeam.Abbrev)
-				if err := grs.processGame(game.GameID); err != nil {
-					log.Printf("❌ Failed to process game %d: %v", game.GameID, err)
-				} else {
-					grs.markProcessed(game.GameID)
-					processedCount++
-					log.Printf("✅ Processed game %d", game.GameID)
-				}
-			}
+	for _, game := range games {
+		if ctx.Err() != nil {
+			log.Printf("🛑 Backfill for %s cancelled", dateStr)
+			break
+		}
+		log.Printf("📥 Processing game %d (%s @ %s)...", game.GameID, game.AwayTeam.Abbrev, game.HomeTeam.Abbrev)
+		if err := grs.processGame(ctx, game.GameID); err != nil {
+			log.Printf("❌ Failed to process game %d: %v", game.GameID, err)
+		} else {
+			grs.markProcessed(game.GameID)
+			processedCount++
+			log.Printf("✅ Processed game %d", game.GameID)
 		}
 	}
 	
@@ -296,13 +273,51 @@ func (grs *GameResultsService) BackfillGamesForDate(targetDate time.Time) {
 	}
 }
 
+// unprocessedGamesForDate returns the completed games on a date's scoreboard
+// that haven't been processed yet
+func (grs *GameResultsService) unprocessedGamesForDate(ctx context.Context, date time.Time) ([]models.ScoreboardGame, error) {
+	dateStr := date.Format("2006-01-02")
+	scheduleData, err := GetNHLAPIClient().LeagueScoreboardForDate(ctx, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scoreboard for %s: %w", dateStr, err)
+	}
+	
+	var games []models.ScoreboardGame
+	for _, gamesByDate := range scheduleData.GamesByDate {
+		for _, game := range gamesByDate.Games {
+			if grs.isGameCompleted(game) && !grs.isProcessed(game.GameID) {
+				games = append(games, game)
+			}
+		}
+	}
+	return games, nil
+}
+
+// BackfillGame processes one completed game and checkpoints the processed
+// games index. Games that were already processed are skipped. Cancelling ctx
+// aborts the game's API calls.
+func (grs *GameResultsService) BackfillGame(ctx context.Context, gameID int) error {
+	if grs.isProcessed(gameID) {
+		return nil
+	}
+	if err := grs.processGame(ctx, gameID); err != nil {
+		return err
+	}
+	grs.markProcessed(gameID)
+	return grs.saveProcessedGames()
+}
+
 // BackfillGamesForDays processes games from the last N days
-func (grs *GameResultsService) BackfillGamesForDays(days int) {
+func (grs *GameResultsService) BackfillGamesForDays(ctx context.Context, days int) {
 	log.Printf("📅 Backfilling games from last %d days...", days)
 	
 	for i := 1; i <= days; i++ {
+		if ctx.Err() != nil {
+			log.Printf("🛑 Backfill cancelled after %d of %d days", i-1, days)
+			return
+		}
 		checkDate := time.Now().AddDate(0, 0, -i)
-		grs.BackfillGamesForDate(checkDate)
+		grs.BackfillGamesForDate(ctx, checkDate)
 	}
 	
 	log.Printf("✅ Backfill complete for last %d days", days)
@@ -362,7 +377,7 @@ func (grs *GameResultsService) checkUnprocessedPredictions() {
 			pred.GameID, pred.AwayTeam, pred.HomeTeam, pred.GameDate.Format("2006-01-02"))
 		
 		// Try to process this game
-		if err := grs.processGame(pred.GameID); err != nil {
+		if err := grs.processGame(context.Background(), pred.GameID); err != nil {
 			log.Printf("❌ Failed to process game %d from prediction check: %v", pred.GameID, err)
 		} else {
 			grs.markProcessed(pred.GameID)
@@ -423,22 +438,13 @@ func (grs *GameResultsService) CheckUnprocessedPredictions() {
 func (grs *GameResultsService) fetchWeekSchedule() (*models.ScoreboardResponse, error) {
 	// Use the league-wide scoreboard endpoint to get all games
 	// This fetches all NHL games, not just one team
-	url := "https://api-web.nhle.com/v1/scoreboard/now"
-
-	// Use MakeAPICall for caching and rate limiting
-	body, err := MakeAPICall(url)
+	scheduleData, err := GetNHLAPIClient().LeagueScoreboard(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch league scoreboard: %w", err)
 	}
 
-	// Parse the scoreboard response which includes all NHL games
-	var scheduleData models.ScoreboardResponse
-	if err := json.Unmarshal(body, &scheduleData); err != nil {
-		return nil, fmt.Errorf("failed to decode league scoreboard: %w", err)
-	}
-
 	// Return the full scoreboard with all NHL games
-	return &scheduleData, nil
+	return scheduleData, nil
 }
 
 // isGameCompleted checks if a game is completed
@@ -461,11 +467,11 @@ func (grs *GameResultsService) markProcessed(gameID int) {
 }
 
 // processGame fetches and processes a completed game
-func (grs *GameResultsService) processGame(gameID int) error {
+func (grs *GameResultsService) processGame(ctx context.Context, gameID int) error {
 	log.Printf("📥 Fetching data for game %d...", gameID)
 
 	// Fetch boxscore data
-	boxscore, err := grs.fetchBoxscore(gameID)
+	boxscore, err := grs.fetchBoxscore(ctx, gameID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch boxscore: %w", err)
 	}
@@ -490,22 +496,14 @@ func (grs *GameResultsService) processGame(gameID int) error {
 }
 
 // fetchBoxscore fetches boxscore data from NHL API
-// Uses MakeAPICall for rate limiting, retry logic, and error handling
-func (grs *GameResultsService) fetchBoxscore(gameID int) (*models.BoxscoreResponse, error) {
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/gamecenter/%d/boxscore", gameID)
-
-	// Use MakeAPICall for caching, rate limiting, and retry logic
-	body, err := MakeAPICall(url)
+// Goes through the typed client for rate limiting, retry logic, and error handling
+func (grs *GameResultsService) fetchBoxscore(ctx context.Context, gameID int) (*models.BoxscoreResponse, error) {
+	boxscore, err := GetNHLAPIClient().Boxscore(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch boxscore: %w", err)
 	}
 
-	var boxscore models.BoxscoreResponse
-	if err := json.Unmarshal(body, &boxscore); err != nil {
-		return nil, fmt.Errorf("failed to decode boxscore: %w", err)
-	}
-
-	return &boxscore, nil
+	return boxscore, nil
 }
 
 // transformBoxscore converts NHL API boxscore to our CompletedGame format
@@ -677,6 +675,13 @@ func (grs *GameResultsService) feedToModels(game *models.CompletedGame) {
 		}
 	}
 
+	// Update Bayesian rating posteriors (real-time, not batched)
+	if bayesianRatings := GetBayesianRatingModel(); bayesianRatings != nil {
+		if err := bayesianRatings.processGameResult(gameResult); err != nil {
+			log.Printf("⚠️ Failed to update Bayesian ratings: %v", err)
+		}
+	}
+
 	// Add game to batch training queue for Neural Network
 	if grs.evaluationSvc != nil {
 		if err := grs.evaluationSvc.AddGameToBatch(*game); err != nil {
@@ -723,9 +728,27 @@ func (grs *GameResultsService) feedToModels(game *models.CompletedGame) {
 			log.Printf("⚠️ Failed to update prediction with result: %v", err)
 		} else {
 			log.Printf("✅ Prediction updated with actual result for game %d", game.GameID)
+
+			// Record opening/closing lines for closing line value tracking
+			if clvService := GetClosingLineService(); clvService != nil {
+				if stored, err := predictionStorage.LoadPrediction(game.GameID); err == nil && stored != nil {
+					if _, err := clvService.RecordGame(stored); err != nil {
+						log.Printf("💹 No closing line value for game %d: %v", game.GameID, err)
+					}
+				}
+			}
 		}
 	}
 
+	// Record officials and penalties for referee tendencies
+	if officiatingService := GetOfficiatingService(); officiatingService != nil {
+		go func(gameID int) {
+			if _, err := officiatingService.RecordGame(gameID); err != nil {
+				log.Printf("🦓 No officiating record for game %d: %v", gameID, err)
+			}
+		}(game.GameID)
+	}
+
 	// Auto-train Meta-Learner if conditions are met
 	metaLearner := GetMetaLearnerModel()
 	modelAccuracyImprovement := 0.0
@@ -956,14 +979,14 @@ func (grs *GameResultsService) GetMonthlyGames(year int, month int) ([]models.Co
 	return games, nil
 }
 
-// BackfillGames attempts to fetch and process historical games
+// BackfillGames queues a backfill of the last daysBack days on the backfill
+// queue; progress is at /api/backfill/jobs
 func (grs *GameResultsService) BackfillGames(daysBack int) error {
-	log.Printf("📊 Starting backfill for last %d days...", daysBack)
-
-	// This would fetch the schedule for the last N days and process any completed games
-	// Implementation would be similar to checkForCompletedGames but for a date range
-
-	log.Printf("✅ Backfill completed")
+	job, err := GetBackfillQueue().Enqueue(BackfillKindGameResults, BackfillParams{Days: daysBack})
+	if err != nil {
+		return fmt.Errorf("failed to queue backfill: %w", err)
+	}
+	log.Printf("📊 Queued backfill job %d for last %d days", job.ID, daysBack)
 	return nil
 }
 
@@ -999,7 +1022,8 @@ func parseFloat(s string) float64 {
 // GLOBAL SERVICE INITIALIZATION (for use in main.go)
 // ============================================================================
 
-// InitializeGameResultsService initializes the global game results service
+// InitializeGameResultsService initializes the global game results service.
+// Monitoring begins when Start is called.
 func InitializeGameResultsService(teamCode string) error {
 	gameResultsMutex.Lock()
 	defer gameResultsMutex.Unlock()
@@ -1053,9 +1077,6 @@ func InitializeGameResultsService(teamCode string) error {
 		log.Printf("✅ Game Results Service linked to Evaluation Service (batch training enabled)")
 	}
 
-	// Start the service
-	globalGameResultsService.Start()
-
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -85,10 +86,7 @@ func (gss *GameSummaryService) FetchGameSummaryData(gameID int) (*models.GameSum
 
 	startTime := time.Now()
 
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/gamecenter/%d/summary", gameID)
-
-	// Use MakeAPICall for caching and rate limiting
-	body, err := MakeAPICall(url)
+	apiResp, err := GetNHLAPIClient().Summary(context.Background(), gameID)
 	if err != nil {
 		// Record failure
 		if gss.systemStatsServ != nil {
@@ -97,17 +95,8 @@ func (gss *GameSummaryService) FetchGameSummaryData(gameID int) (*models.GameSum
 		return nil, fmt.Errorf("failed to fetch game summary data: %w", err)
 	}
 
-	var apiResp models.GameSummaryResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		// Record failure
-		if gss.systemStatsServ != nil {
-			gss.systemStatsServ.RecordBackfillFailure()
-		}
-		return nil, fmt.Errorf("failed to decode game summary data: %w", err)
-	}
-
 	// Analyze the game summary data
-	analytics := gss.analyzeGameSummary(&apiResp)
+	analytics := gss.analyzeGameSummary(apiResp)
 	if analytics == nil {
 		return nil, fmt.Errorf("failed to analyze game summary data")
 	}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,10 +9,12 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/jaredshillingburg/go_uhc/models"
+	"github.com/jaredshillingburg/go_uhc/nhlapi"
 )
 
 // GoalieIntelligenceService tracks and analyzes goalie performance
@@ -57,12 +60,16 @@ func (gis *GoalieIntelligenceService) GetGoalieComparison(homeTeam, awayTeam str
 }
 
 // GetGoalieComparisonWithConfirmed analyzes goalie matchup with optional confirmed lineup data
-// Falls back to determineStarter() if confirmed goalies are nil or not found
+// Unconfirmed sides are blended across likely starters using EstimateStarterProbabilities
 func (gis *GoalieIntelligenceService) GetGoalieComparisonWithConfirmed(
 	homeTeam, awayTeam string,
 	gameDate time.Time,
 	confirmedHomeGoalie, confirmedAwayGoalie *models.LineupGoalie,
 ) (*models.GoalieComparison, error) {
+	// Opponent strength feeds the starter model (fetched before locking)
+	homeOpponentPct := teamPointPercentage(awayTeam)
+	awayOpponentPct := teamPointPercentage(homeTeam)
+
 	gis.mutex.RLock()
 	defer gis.mutex.RUnlock()
 
@@ -73,60 +80,43 @@ func (gis *GoalieIntelligenceService) GetGoalieComparisonWithConfirmed(
 		return nil, fmt.Errorf("goalie depth not available for teams")
 	}
 
-	// Try to use confirmed lineup goalies, fall back to determination
-	var homeGoalie, awayGoalie *models.GoalieInfo
-
-	// Home goalie
-	if confirmedHomeGoalie != nil {
-		homeGoalie = gis.findGoalieByID(confirmedHomeGoalie.PlayerID)
-		if homeGoalie != nil {
-			log.Printf("🥅 Using CONFIRMED home starter: %s (#%d)",
-				confirmedHomeGoalie.PlayerName, confirmedHomeGoalie.SweaterNumber)
-		}
-	}
-	if homeGoalie == nil {
-		homeGoalie = gis.determineStarter(homeDepth, gameDate)
-		log.Printf("🎲 Using PREDICTED home starter (lineup not available)")
-	}
-
-	// Away goalie
-	if confirmedAwayGoalie != nil {
-		awayGoalie = gis.findGoalieByID(confirmedAwayGoalie.PlayerID)
-		if awayGoalie != nil {
-			log.Printf("🥅 Using CONFIRMED away starter: %s (#%d)",
-				confirmedAwayGoalie.PlayerName, confirmedAwayGoalie.SweaterNumber)
-		}
-	}
-	if awayGoalie == nil {
-		awayGoalie = gis.determineStarter(awayDepth, gameDate)
-		log.Printf("🎲 Using PREDICTED away starter (lineup not available)")
-	}
+	// Starter distributions (confirmed lineup -> team news -> starter model)
+	homeStarter := gis.estimateStarters(homeDepth, homeTeam, awayTeam, gameDate, confirmedHomeGoalie, homeOpponentPct)
+	awayStarter := gis.estimateStarters(awayDepth, awayTeam, homeTeam, gameDate, confirmedAwayGoalie, awayOpponentPct)
 
-	if homeGoalie == nil || awayGoalie == nil {
+	if len(homeStarter.Candidates) == 0 || len(awayStarter.Candidates) == 0 {
 		return nil, fmt.Errorf("could not determine starting goalies")
 	}
 
-	// Calculate comparison
+	logStarterEstimate("home", homeStarter)
+	logStarterEstimate("away", awayStarter)
+
+	// Blend factor comparisons across every starter pairing, weighted by probability
 	comparison := &models.GoalieComparison{
-		HomeGoalie: homeGoalie,
-		AwayGoalie: awayGoalie,
+		HomeGoalie:  gis.findGoalieByID(homeStarter.MostLikely().PlayerID),
+		AwayGoalie:  gis.findGoalieByID(awayStarter.MostLikely().PlayerID),
+		HomeStarter: homeStarter,
+		AwayStarter: awayStarter,
+	}
+
+	for _, homeCandidate := range homeStarter.Candidates {
+		homeGoalie := gis.findGoalieByID(homeCandidate.PlayerID)
+		for _, awayCandidate := range awayStarter.Candidates {
+			awayGoalie := gis.findGoalieByID(awayCandidate.PlayerID)
+			if homeGoalie == nil || awayGoalie == nil {
+				continue
+			}
+			weight := homeCandidate.Probability * awayCandidate.Probability
+
+			comparison.SeasonPerformance += weight * gis.compareSeasonPerformance(homeGoalie, awayGoalie)
+			comparison.RecentForm += weight * gis.compareRecentForm(homeGoalie, awayGoalie)
+			comparison.WorkloadFatigue += weight * gis.compareWorkload(homeGoalie, awayGoalie)
+			comparison.MatchupHistory += weight * gis.compareMatchupHistory(homeGoalie, awayGoalie, homeTeam, awayTeam)
+			comparison.HomeAwayFactor += weight * gis.compareHomeAway(homeGoalie, awayGoalie)
+			comparison.Confidence += weight * gis.calculateConfidence(homeGoalie, awayGoalie)
+		}
 	}
 
-	// Compare season performance
-	comparison.SeasonPerformance = gis.compareSeasonPerformance(homeGoalie, awayGoalie)
-
-	// Compare recent form
-	comparison.RecentForm = gis.compareRecentForm(homeGoalie, awayGoalie)
-
-	// Compare workload/fatigue
-	comparison.WorkloadFatigue = gis.compareWorkload(homeGoalie, awayGoalie)
-
-	// Compare matchup history
-	comparison.MatchupHistory = gis.compareMatchupHistory(homeGoalie, awayGoalie, homeTeam, awayTeam)
-
-	// Compare home/away performance
-	comparison.HomeAwayFactor = gis.compareHomeAway(homeGoalie, awayGoalie)
-
 	// Calculate overall advantage
 	comparison.AdvantageScore = gis.calculateOverallAdvantage(comparison)
 
@@ -140,24 +130,18 @@ func (gis *GoalieIntelligenceService) GetGoalieComparisonWithConfirmed(
 
 	// Calculate impact on win probability
 	comparison.WinProbabilityImpact = comparison.AdvantageScore * 0.15 // Goalie worth up to 15% swing
-	comparison.Confidence = gis.calculateConfidence(homeGoalie, awayGoalie)
 
 	return comparison, nil
 }
 
-// determineStarter determines which goalie is starting
-func (gis *GoalieIntelligenceService) determineStarter(depth *models.GoalieDepth, gameDate time.Time) *models.GoalieInfo {
-	// If we have confirmed starter for tonight, use it
-	if depth.StartingTonight != nil && depth.StartingTonight.LastUpdated.After(gameDate.Add(-6*time.Hour)) {
-		return depth.StartingTonight
+// logStarterEstimate logs which goalie is expected in net
+func logStarterEstimate(side string, estimate *models.GoalieStarterEstimate) {
+	likely := estimate.MostLikely()
+	if estimate.Source == "model" {
+		log.Printf("🎲 PREDICTED %s starter: %s (%.0f%%, lineup not available)", side, likely.Name, likely.Probability*100)
+		return
 	}
-
-	// Otherwise, use the designated starter
-	if depth.Starter != nil {
-		return depth.Starter
-	}
-
-	return nil
+	log.Printf("🥅 Using %s %s starter: %s", strings.ToUpper(strings.Replace(estimate.Source, "_", " ", -1)), side, likely.Name)
 }
 
 // compareSeasonPerformance compares season-long performance
@@ -285,32 +269,21 @@ func (gis *GoalieIntelligenceService) FetchGoalieStats(teamCode string, season i
 	log.Printf("🥅 Fetching goalie stats for %s (season %d)...", teamCode, season)
 
 	// Try current season first (using /now endpoint)
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/club-stats/%s/now", teamCode)
-
-	body, err := MakeAPICall(url)
+	clubStats, err := GetNHLAPIClient().ClubStats(context.Background(), teamCode)
 	if err != nil {
 		return fmt.Errorf("failed to fetch club stats: %w", err)
 	}
 
-	var clubStats models.ClubStatsResponse
-	if err := json.Unmarshal(body, &clubStats); err != nil {
-		return fmt.Errorf("failed to unmarshal club stats: %w", err)
-	}
-
 	// Check if we have goalie data (current season has started)
 	if len(clubStats.Goalies) == 0 {
 		log.Printf("⚠️ No current season goalie data for %s, trying previous season...", teamCode)
 
 		// Try previous season for seed data
 		previousSeason := season - 10001 // e.g., 20252026 -> 20242025 (or use utils.GetPreviousSeason())
-		prevSeasonStr := fmt.Sprintf("%d%d", previousSeason/10000, previousSeason%10000)
-		url = fmt.Sprintf("https://api-web.nhle.com/v1/club-stats/%s/%s/2", teamCode, prevSeasonStr)
-
-		body, err = MakeAPICall(url)
-		if err == nil {
-			if err := json.Unmarshal(body, &clubStats); err == nil && len(clubStats.Goalies) > 0 {
-				log.Printf("✅ Using previous season (%d) goalie data as seed for %s", previousSeason, teamCode)
-			}
+		prevStats, err := GetNHLAPIClient().ClubStatsForSeason(context.Background(), teamCode, previousSeason, nhlapi.GameTypeRegularSeason)
+		if err == nil && len(prevStats.Goalies) > 0 {
+			clubStats = prevStats
+			log.Printf("✅ Using previous season (%d) goalie data as seed for %s", previousSeason, teamCode)
 		}
 	}
 
@@ -342,6 +315,17 @@ func (gis *GoalieIntelligenceService) FetchGoalieStats(teamCode string, season i
 		}
 	}
 
+	// Fetch recent starts for workload and starter probabilities (before locking)
+	recentStarts := make(map[int][]models.GoalieStart)
+	for _, goalie := range clubStats.Goalies {
+		starts, err := fetchGoalieRecentStarts(goalie.PlayerID)
+		if err != nil {
+			log.Printf("⚠️ Could not fetch game log for goalie %d: %v", goalie.PlayerID, err)
+			continue
+		}
+		recentStarts[goalie.PlayerID] = starts
+	}
+
 	// Update goalie data
 	gis.mutex.Lock()
 	defer gis.mutex.Unlock()
@@ -365,16 +349,19 @@ func (gis *GoalieIntelligenceService) FetchGoalieStats(teamCode string, season i
 			Name:                 fmt.Sprintf("%s %s", starter.FirstName.Default, starter.LastName.Default),
 			TeamCode:             teamCode,
 			SeasonGamesPlayed:    starter.GamesPlayed,
+			SeasonGamesStarted:   starter.GamesStarted,
 			SeasonWins:           starter.Wins,
 			SeasonLosses:         starter.Losses,
 			SeasonOTLosses:       starter.OvertimeLosses,
 			SeasonSavePercentage: starter.SavePct,
 			SeasonGAA:            starter.GoalsAgainstAvg,
 			SeasonShutouts:       starter.Shutouts,
-			RecentStarts:         []models.GoalieStart{}, // Would be populated from game logs
-			RecentSavePct:        starter.SavePct,        // Use season avg for now
-			WorkloadFatigueScore: 0.0,                    // Would be calculated based on recent starts
+			RecentStarts:         []models.GoalieStart{},
+			RecentSavePct:        starter.SavePct, // Season avg until game log is applied
+			WorkloadFatigueScore: 0.0,
+			LastUpdated:          time.Now(),
 		}
+		applyRecentStarts(starterInfo, recentStarts[starter.PlayerID], time.Now())
 		gis.goalies[starter.PlayerID] = starterInfo
 		log.Printf("🥅 Starter: %s - %d GP, %.3f SV%%, %.2f GAA",
 			starterInfo.Name, starter.GamesPlayed, starter.SavePct, starter.GoalsAgainstAvg)
@@ -388,6 +375,7 @@ func (gis *GoalieIntelligenceService) FetchGoalieStats(teamCode string, season i
 			Name:                 fmt.Sprintf("%s %s", backup.FirstName.Default, backup.LastName.Default),
 			TeamCode:             teamCode,
 			SeasonGamesPlayed:    backup.GamesPlayed,
+			SeasonGamesStarted:   backup.GamesStarted,
 			SeasonWins:           backup.Wins,
 			SeasonLosses:         backup.Losses,
 			SeasonOTLosses:       backup.OvertimeLosses,
@@ -397,7 +385,9 @@ func (gis *GoalieIntelligenceService) FetchGoalieStats(teamCode string, season i
 			RecentStarts:         []models.GoalieStart{},
 			RecentSavePct:        backup.SavePct,
 			WorkloadFatigueScore: 0.0,
+			LastUpdated:          time.Now(),
 		}
+		applyRecentStarts(backupInfo, recentStarts[backup.PlayerID], time.Now())
 		gis.goalies[backup.PlayerID] = backupInfo
 		log.Printf("🥅 Backup: %s - %d GP, %.3f SV%%, %.2f GAA",
 			backupInfo.Name, backup.GamesPlayed, backup.SavePct, backup.GoalsAgainstAvg)
@@ -0,0 +1,413 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// ============================================================================
+// GOALIE STARTER PROBABILITY MODEL
+// ============================================================================
+//
+// Before a lineup is confirmed we don't know who is in net, so instead of
+// assuming the No. 1 goalie always starts we estimate a starting probability
+// for each goalie in the tandem and blend goalie impact by those probabilities.
+// The model is a hand-tuned logistic adjustment on top of the season start split.
+
+const (
+	starterPriorShare          = 0.62 // League-typical No. 1 share of starts
+	starterPriorGames          = 10.0 // Pseudo-starts used to shrink early-season splits
+	starterBackToBackPenalty   = 2.2  // Log-odds: No. 1 started the first half of a back-to-back
+	starterBackToBackBonus     = 1.5  // Log-odds: backup started the first half of a back-to-back
+	starterHeavyWorkloadStarts = 3    // Starts in the last 7 days before fatigue kicks in
+	starterHeavyWorkloadLogit  = 0.5  // Log-odds per start beyond the workload threshold
+	starterLongRestDays        = 3
+	starterLongRestLogit       = 0.3
+	starterWeakOpponentPct     = 0.45 // Opponent point % below which backups get more starts
+	starterWeakOpponentLogit   = 0.4
+	starterStrongOpponentPct   = 0.60
+	starterStrongOpponentLogit = 0.3
+	starterHotBackupSavePct    = 0.020 // Backup recent SV% edge that earns extra starts
+	starterHotBackupLogit      = 0.3
+	starterMinProbability      = 0.02
+	starterMaxProbability      = 0.98
+	recentStartsTracked        = 10
+)
+
+// EstimateStarterProbabilities estimates each goalie's chance of starting for teamCode
+func (gis *GoalieIntelligenceService) EstimateStarterProbabilities(teamCode, opponent string, gameDate time.Time) (*models.GoalieStarterEstimate, error) {
+	opponentPointPct := teamPointPercentage(opponent)
+
+	gis.mutex.RLock()
+	defer gis.mutex.RUnlock()
+
+	depth, exists := gis.teamDepth[teamCode]
+	if !exists || depth == nil {
+		return nil, fmt.Errorf("goalie depth not available for %s", teamCode)
+	}
+
+	estimate := gis.estimateStarters(depth, teamCode, opponent, gameDate, nil, opponentPointPct)
+	if len(estimate.Candidates) == 0 {
+		return nil, fmt.Errorf("no goalies available for %s", teamCode)
+	}
+	return estimate, nil
+}
+
+// estimateStarters builds the starter distribution for a team (caller holds the read lock)
+func (gis *GoalieIntelligenceService) estimateStarters(
+	depth *models.GoalieDepth,
+	teamCode, opponent string,
+	gameDate time.Time,
+	confirmed *models.LineupGoalie,
+	opponentPointPct float64,
+) *models.GoalieStarterEstimate {
+	estimate := &models.GoalieStarterEstimate{
+		TeamCode:   teamCode,
+		Opponent:   opponent,
+		GameDate:   gameDate,
+		Source:     "model",
+		Candidates: []models.GoalieStarterCandidate{},
+	}
+
+	// Confirmed lineup wins outright
+	if confirmed != nil {
+		if goalie := gis.findGoalieByID(confirmed.PlayerID); goalie != nil {
+			estimate.Source = "confirmed"
+			estimate.Confirmed = true
+			estimate.Candidates = append(estimate.Candidates, starterCandidate(goalie, depth, gameDate, 1.0, "Confirmed in pre-game lineup"))
+			return estimate
+		}
+	}
+
+	// Team news (e.g. morning skate) is treated as near-certain
+	if depth.StartingTonight != nil && depth.StartingTonight.LastUpdated.After(gameDate.Add(-6*time.Hour)) {
+		estimate.Source = "team_news"
+		estimate.Candidates = append(estimate.Candidates, starterCandidate(depth.StartingTonight, depth, gameDate, 1.0, "Announced starter"))
+		return estimate
+	}
+
+	starter, backup := depth.Starter, depth.Backup
+	if starter == nil && backup == nil {
+		return estimate
+	}
+	if starter == nil || backup == nil {
+		only := starter
+		if only == nil {
+			only = backup
+		}
+		estimate.Candidates = append(estimate.Candidates, starterCandidate(only, depth, gameDate, 1.0, "Only goalie on depth chart"))
+		return estimate
+	}
+
+	factors := []string{}
+
+	// Season start split, shrunk towards a typical No. 1 share
+	starterStarts := float64(goalieSeasonStarts(starter))
+	backupStarts := float64(goalieSeasonStarts(backup))
+	share := (starterStarts + starterPriorShare*starterPriorGames) / (starterStarts + backupStarts + starterPriorGames)
+	logit := math.Log(share / (1 - share))
+	factors = append(factors, fmt.Sprintf("Season split %.0f%%", share*100))
+
+	// Back-to-backs: whoever played last night usually sits
+	teamLastGame := latestStartBefore(gameDate, starter, backup)
+	if !teamLastGame.IsZero() && daysBetween(teamLastGame, gameDate) == 1 {
+		estimate.IsBackToBack = true
+		if sameDay(latestStartBefore(gameDate, starter), teamLastGame) {
+			logit -= starterBackToBackPenalty
+			factors = append(factors, "Back-to-back, No. 1 played yesterday")
+		} else if sameDay(latestStartBefore(gameDate, backup), teamLastGame) {
+			logit += starterBackToBackBonus
+			factors = append(factors, "Back-to-back, backup played yesterday")
+		}
+	}
+
+	// Recent workload
+	if starts := startsInWindow(starter, gameDate, 7); starts > starterHeavyWorkloadStarts {
+		logit -= starterHeavyWorkloadLogit * float64(starts-starterHeavyWorkloadStarts)
+		factors = append(factors, fmt.Sprintf("Heavy workload (%d starts in 7 days)", starts))
+	}
+	if rest := restDays(starter, gameDate); rest >= starterLongRestDays {
+		logit += starterLongRestLogit
+		factors = append(factors, fmt.Sprintf("No. 1 rested %d days", rest))
+	}
+
+	// Opponent: backups tend to draw weaker opponents
+	if opponentPointPct > 0 {
+		if opponentPointPct < starterWeakOpponentPct {
+			logit -= starterWeakOpponentLogit
+			factors = append(factors, fmt.Sprintf("Weak opponent (%.3f pts%%)", opponentPointPct))
+		} else if opponentPointPct > starterStrongOpponentPct {
+			logit += starterStrongOpponentLogit
+			factors = append(factors, fmt.Sprintf("Strong opponent (%.3f pts%%)", opponentPointPct))
+		}
+	}
+
+	// A hot backup earns more starts
+	if len(backup.RecentStarts) >= 2 && starter.RecentSavePct > 0 &&
+		backup.RecentSavePct-starter.RecentSavePct > starterHotBackupSavePct {
+		logit -= starterHotBackupLogit
+		factors = append(factors, "Backup outplaying No. 1 recently")
+	}
+
+	starterProb := 1.0 / (1.0 + math.Exp(-logit))
+	starterProb = math.Max(starterMinProbability, math.Min(starterMaxProbability, starterProb))
+
+	starterEntry := starterCandidate(starter, depth, gameDate, starterProb, factors...)
+	backupEntry := starterCandidate(backup, depth, gameDate, 1-starterProb)
+	starterEntry.SeasonStartShare = share
+	backupEntry.SeasonStartShare = 1 - share
+	estimate.Candidates = append(estimate.Candidates, starterEntry, backupEntry)
+
+	return estimate
+}
+
+// starterCandidate builds a candidate entry for a goalie
+func starterCandidate(goalie *models.GoalieInfo, depth *models.GoalieDepth, gameDate time.Time, probability float64, factors ...string) models.GoalieStarterCandidate {
+	return models.GoalieStarterCandidate{
+		PlayerID:        goalie.PlayerID,
+		Name:            goalie.Name,
+		Probability:     probability,
+		IsDesignated:    depth.Starter != nil && depth.Starter.PlayerID == goalie.PlayerID,
+		RestDays:        restDays(goalie, gameDate),
+		StartsLast7Days: startsInWindow(goalie, gameDate, 7),
+		Factors:         factors,
+	}
+}
+
+// goalieSeasonStarts returns season starts, falling back to games played
+func goalieSeasonStarts(goalie *models.GoalieInfo) int {
+	if goalie.SeasonGamesStarted > 0 {
+		return goalie.SeasonGamesStarted
+	}
+	return goalie.SeasonGamesPlayed
+}
+
+// latestStartBefore returns the most recent start by any of the goalies before gameDate
+func latestStartBefore(gameDate time.Time, goalies ...*models.GoalieInfo) time.Time {
+	var latest time.Time
+	for _, goalie := range goalies {
+		if goalie == nil {
+			continue
+		}
+		for _, start := range goalie.RecentStarts {
+			if daysBetween(start.GameDate, gameDate) >= 1 && start.GameDate.After(latest) {
+				latest = start.GameDate
+			}
+		}
+	}
+	return latest
+}
+
+// restDays returns days since the goalie's last start (-1 if unknown)
+func restDays(goalie *models.GoalieInfo, gameDate time.Time) int {
+	last := latestStartBefore(gameDate, goalie)
+	if last.IsZero() {
+		return -1
+	}
+	return daysBetween(last, gameDate)
+}
+
+// startsInWindow counts starts in the days before gameDate
+func startsInWindow(goalie *models.GoalieInfo, gameDate time.Time, days int) int {
+	count := 0
+	for _, start := range goalie.RecentStarts {
+		if d := daysBetween(start.GameDate, gameDate); d >= 1 && d <= days {
+			count++
+		}
+	}
+	return count
+}
+
+// daysBetween returns whole calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(math.Round(dayB.Sub(dayA).Hours() / 24))
+}
+
+// sameDay reports whether two non-zero times fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	return !a.IsZero() && !b.IsZero() && daysBetween(a, b) == 0
+}
+
+// teamPointPercentage returns a team's standings point percentage (0 if unavailable)
+func teamPointPercentage(teamCode string) float64 {
+	if teamCode == "" {
+		return 0
+	}
+	standings, err := GetStandings()
+	if err != nil {
+		return 0
+	}
+	for _, team := range standings.Standings {
+		if team.TeamAbbrev.Default == teamCode {
+			return team.PointPctg
+		}
+	}
+	return 0
+}
+
+// ============================================================================
+// GAME LOGS (WORKLOAD INPUTS)
+// ============================================================================
+
+// fetchGoalieRecentStarts fetches a goalie's most recent starts from the NHL game log
+func fetchGoalieRecentStarts(playerID int) ([]models.GoalieStart, error) {
+	gameLog, err := GetNHLAPIClient().GoalieGameLog(context.Background(), playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch goalie game log: %w", err)
+	}
+
+	starts := []models.GoalieStart{}
+	for _, entry := range gameLog.GameLog {
+		if entry.GamesStarted == 0 {
+			continue
+		}
+		gameDate, err := time.Parse("2006-01-02", entry.GameDate)
+		if err != nil {
+			continue
+		}
+
+		result := entry.Decision
+		if result == "O" {
+			result = "OTL"
+		}
+		minutes := parseTOIMinutes(entry.TOI)
+
+		starts = append(starts, models.GoalieStart{
+			GameID:         entry.GameID,
+			GameDate:       gameDate,
+			Opponent:       entry.OpponentAbbrev,
+			IsHome:         entry.HomeRoadFlag == "H",
+			Result:         result,
+			ShotsAgainst:   entry.ShotsAgainst,
+			Saves:          entry.ShotsAgainst - entry.GoalsAgainst,
+			GoalsAgainst:   entry.GoalsAgainst,
+			SavePct:        entry.SavePctg,
+			IsQualityStart: entry.SavePctg > 0.913 || (entry.GoalsAgainst < 3 && minutes >= 60),
+			MinutesPlayed:  minutes,
+		})
+	}
+
+	// Most recent first
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].GameDate.After(starts[j].GameDate)
+	})
+	if len(starts) > recentStartsTracked {
+		starts = starts[:recentStartsTracked]
+	}
+
+	return starts, nil
+}
+
+// applyRecentStarts updates recent form and workload from a goalie's recent starts
+func applyRecentStarts(goalie *models.GoalieInfo, starts []models.GoalieStart, now time.Time) {
+	goalie.RecentStarts = starts
+	if len(starts) == 0 {
+		return
+	}
+
+	// Recent form from the last 5 starts
+	saves, shots, goalsAgainst, wins, minutes := 0, 0, 0, 0, 0
+	for i, start := range starts {
+		if i >= 5 {
+			break
+		}
+		saves += start.Saves
+		shots += start.ShotsAgainst
+		goalsAgainst += start.GoalsAgainst
+		minutes += start.MinutesPlayed
+		if start.Result == "W" {
+			wins++
+		}
+	}
+	recentCount := int(math.Min(5, float64(len(starts))))
+	if shots > 0 {
+		goalie.RecentSavePct = float64(saves) / float64(shots)
+	}
+	if minutes > 0 {
+		goalie.RecentGAA = float64(goalsAgainst) * 60.0 / float64(minutes)
+	}
+	goalie.RecentWinPct = float64(wins) / float64(recentCount)
+
+	// Workload: 4 starts in 7 days is a heavy week
+	goalie.GamesInLast7Days = startsInWindow(goalie, now, 7)
+	goalie.WorkloadFatigueScore = math.Min(1.0, float64(goalie.GamesInLast7Days)/4.0)
+}
+
+// parseTOIMinutes converts "MM:SS" time on ice into whole minutes
+func parseTOIMinutes(toi string) int {
+	parts := strings.Split(toi, ":")
+	if len(parts) == 0 {
+		return 0
+	}
+	minutes, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0
+	}
+	return minutes
+}
+
+// ============================================================================
+// CONFIRMATION CHECK
+// ============================================================================
+
+// CheckConfirmedStarters compares confirmed starters against the model's most
+// likely starter and triggers a re-prediction when they differ
+func (gis *GoalieIntelligenceService) CheckConfirmedStarters(lineup *models.PreGameLineup) {
+	if lineup == nil || !lineup.IsAvailable {
+		return
+	}
+
+	teams := []struct {
+		teamCode string
+		opponent string
+		lineup   *models.TeamLineup
+	}{
+		{lineup.HomeTeam, lineup.AwayTeam, lineup.HomeLineup},
+		{lineup.AwayTeam, lineup.HomeTeam, lineup.AwayLineup},
+	}
+
+	surprises := []string{}
+	for _, team := range teams {
+		if team.lineup == nil || team.lineup.StartingGoalie == nil {
+			continue
+		}
+
+		estimate, err := gis.EstimateStarterProbabilities(team.teamCode, team.opponent, lineup.GameDate)
+		if err != nil {
+			continue
+		}
+		expected := estimate.MostLikely()
+		if expected == nil || expected.PlayerID == team.lineup.StartingGoalie.PlayerID {
+			continue
+		}
+
+		log.Printf("🥅 Starter surprise for %s: %s confirmed, model expected %s (%.0f%%)",
+			team.teamCode, team.lineup.StartingGoalie.PlayerName, expected.Name, expected.Probability*100)
+		surprises = append(surprises, team.teamCode)
+	}
+
+	if len(surprises) == 0 {
+		return
+	}
+
+	decision := &RePredictionDecision{
+		ShouldRePredict: true,
+		Scope:           "direct_impact",
+		Reason:          fmt.Sprintf("confirmed starting goalie differs from expected for %s", strings.Join(surprises, ", ")),
+		GameIDs:         []int{lineup.GameID},
+		TeamCodes:       surprises,
+	}
+	if err := GetSmartRePredictionService().ExecuteRePrediction(decision); err != nil {
+		log.Printf("⚠️ Failed to re-predict game %d after goalie confirmation: %v", lineup.GameID, err)
+	}
+}
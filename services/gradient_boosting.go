@@ -27,6 +27,7 @@ type GradientBoostingModel struct {
 	mutex             sync.RWMutex
 	featureNames      []string
 	featureImportance map[string]float64
+	quiet             bool // Suppresses progress logs for tuning fits
 }
 
 // GBTree represents a single decision tree in the gradient boosting ensemble
@@ -85,6 +86,9 @@ func NewGradientBoostingModel() *GradientBoostingModel {
 			gradientBoostingModel.featureNames[i] = fmt.Sprintf("feature_%d", i)
 		}
 
+		// Tuned hyperparameters replace the defaults; a saved model keeps its own
+		gradientBoostingModel.applyTunedHyperparameters()
+
 		// Try to load existing model
 		gradientBoostingModel.loadModel()
 	})
@@ -150,6 +154,42 @@ func (gbm *GradientBoostingModel) Predict(homeFactors, awayFactors *models.Predi
 	return result, nil
 }
 
+// Explain attributes the prediction to input features with exact TreeSHAP.
+// Attributions are exact in log-odds and scaled onto the bounded probability.
+func (gbm *GradientBoostingModel) Explain(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionExplanation, error) {
+	gbm.mutex.RLock()
+	defer gbm.mutex.RUnlock()
+
+	if !gbm.trained || len(gbm.trees) == 0 {
+		return nil, fmt.Errorf("gradient boosting model not trained")
+	}
+
+	features := gbm.extractFeatures(homeFactors, awayFactors)
+	phi := make([]float64, len(features))
+	baseRaw := 0.0
+	for _, tree := range gbm.trees {
+		root := gbExplainTree(tree.Root)
+		baseRaw += gbm.learningRate * root.expectedValue()
+		treeSHAP(root, features, phi)
+	}
+	for i := range phi {
+		phi[i] *= gbm.learningRate
+	}
+
+	bounded := func(raw float64) float64 {
+		return math.Max(0.35, math.Min(0.85, 1.0/(1.0+math.Exp(-raw))))
+	}
+	base := bounded(baseRaw)
+	prediction := bounded(gbm.predictProbability(features))
+	phi = rescaleContributions(phi, prediction-base)
+
+	names := make([]string, len(features))
+	for i := range names {
+		names[i] = gbmFeatureLabel(i)
+	}
+	return newPredictionExplanation("tree_shap", base, prediction, names, features, phi), nil
+}
+
 // Train trains the gradient boosting model on game results
 func (gbm *GradientBoostingModel) Train(games []models.CompletedGame) error {
 	gbm.mutex.Lock()
@@ -164,7 +204,40 @@ func (gbm *GradientBoostingModel) Train(games []models.CompletedGame) error {
 
 	// Prepare training data
 	features, labels := gbm.prepareTrainingData(games)
+	if len(labels) < 10 {
+		return fmt.Errorf("insufficient training data: only %d games have prediction-time features", len(labels))
+	}
+
+	gbm.applyTunedHyperparameters()
+	finalAccuracy := gbm.fit(features, labels)
+
+	// Calculate feature importance
+	gbm.calculateFeatureImportance()
+
+	gbm.trained = true
+
+	trainingTime := time.Since(start)
+	log.Printf("✅ Gradient Boosting training complete!")
+	log.Printf("   Trees: %d | Accuracy: %.2f%% | Time: %.1fs",
+		len(gbm.trees), finalAccuracy*100, trainingTime.Seconds())
+
+	// Save model
+	gbm.saveModel()
 
+	return nil
+}
+
+// applyTunedHyperparameters overrides training settings from the tuned config, if any
+func (gbm *GradientBoostingModel) applyTunedHyperparameters() {
+	gbm.learningRate = tunedParam("gradient_boosting", "learningRate", gbm.learningRate)
+	gbm.numTrees = int(tunedParam("gradient_boosting", "numTrees", float64(gbm.numTrees)))
+	gbm.maxDepth = int(tunedParam("gradient_boosting", "maxDepth", float64(gbm.maxDepth)))
+	gbm.minSamplesLeaf = int(tunedParam("gradient_boosting", "minSamplesLeaf", float64(gbm.minSamplesLeaf)))
+}
+
+// fit builds the boosted trees on a prepared feature matrix and returns the
+// training accuracy. Callers hold the lock; nothing is persisted.
+func (gbm *GradientBoostingModel) fit(features [][]float64, labels []float64) float64 {
 	// Initialize predictions with 0 (neutral)
 	predictions := make([]float64, len(labels))
 
@@ -202,29 +275,14 @@ func (gbm *GradientBoostingModel) Train(games []models.CompletedGame) error {
 		}
 
 		// Log progress every 20 trees
-		if (t+1)%20 == 0 {
+		if (t+1)%20 == 0 && !gbm.quiet {
 			accuracy := gbm.calculateAccuracy(predictions, labels)
 			log.Printf("   Tree %d/%d: Accuracy %.2f%%", t+1, gbm.numTrees, accuracy*100)
 		}
 	}
 
 	// Calculate final training accuracy
-	finalAccuracy := gbm.calculateAccuracy(predictions, labels)
-
-	// Calculate feature importance
-	gbm.calculateFeatureImportance()
-
-	gbm.trained = true
-
-	trainingTime := time.Since(start)
-	log.Printf("✅ Gradient Boosting training complete!")
-	log.Printf("   Trees: %d | Accuracy: %.2f%% | Time: %.1fs",
-		len(gbm.trees), finalAccuracy*100, trainingTime.Seconds())
-
-	// Save model
-	gbm.saveModel()
-
-	return nil
+	return gbm.calculateAccuracy(predictions, labels)
 }
 
 // buildTree recursively builds a decision tree
@@ -359,6 +417,20 @@ func (gbm *GradientBoostingModel) traverseTree(node *GBTreeNode, features []floa
 	return gbm.traverseTree(node.Right, features)
 }
 
+// gbExplainTree converts a tree for TreeSHAP
+func gbExplainTree(node *GBTreeNode) *explainNode {
+	if node.IsLeaf {
+		return &explainNode{leaf: true, value: node.Prediction, cover: float64(node.SamplesCount)}
+	}
+	return &explainNode{
+		feature:   node.FeatureIndex,
+		threshold: node.Threshold,
+		cover:     float64(node.SamplesCount),
+		left:      gbExplainTree(node.Left),
+		right:     gbExplainTree(node.Right),
+	}
+}
+
 // predictProbability gets probability from all trees
 func (gbm *GradientBoostingModel) predictProbability(features []float64) float64 {
 	prediction := 0.0
@@ -437,20 +509,19 @@ func (gbm *GradientBoostingModel) calculateAccuracy(predictions, labels []float6
 	return float64(correct) / float64(len(labels))
 }
 
-// prepareTrainingData converts games to feature matrix and labels
+// prepareTrainingData converts games to feature matrix and labels using the
+// factors each game was predicted with, so training and inference share extractFeatures
 func (gbm *GradientBoostingModel) prepareTrainingData(games []models.CompletedGame) ([][]float64, []float64) {
-	features := make([][]float64, len(games))
-	labels := make([]float64, len(games))
+	examples := buildTreeTrainingExamples(games)
 
-	for i, game := range games {
-		// Extract features (same as Neural Network)
-		homeFactors := &models.PredictionFactors{TeamCode: game.HomeTeam.TeamCode}
-		awayFactors := &models.PredictionFactors{TeamCode: game.AwayTeam.TeamCode}
+	features := make([][]float64, len(examples))
+	labels := make([]float64, len(examples))
 
-		features[i] = gbm.extractFeatures(homeFactors, awayFactors)
+	for i, example := range examples {
+		features[i] = gbm.extractFeatures(example.HomeFactors, example.AwayFactors)
 
 		// Label: 1.0 if home won, 0.0 if away won
-		if game.HomeTeam.Score > game.AwayTeam.Score {
+		if example.Game.HomeTeam.Score > example.Game.AwayTeam.Score {
 			labels[i] = 1.0
 		} else {
 			labels[i] = 0.0
@@ -460,6 +531,16 @@ func (gbm *GradientBoostingModel) prepareTrainingData(games []models.CompletedGa
 	return features, labels
 }
 
+// buildTreeTrainingExamples joins games with their prediction-time factors
+func buildTreeTrainingExamples(games []models.CompletedGame) []models.TrainingExample {
+	snapshotService := GetFeatureSnapshotService()
+	if snapshotService == nil {
+		snapshotService = InitFeatureSnapshotService()
+	}
+	examples, _ := snapshotService.BuildTrainingSet(games)
+	return examples
+}
+
 // extractFeatures extracts 156 features from prediction factors
 func (gbm *GradientBoostingModel) extractFeatures(home, away *models.PredictionFactors) []float64 {
 	features := make([]float64, 156) // Expanded to 156 to include Phase 2 features
@@ -651,6 +732,45 @@ func (gbm *GradientBoostingModel) extractFeatures(home, away *models.PredictionF
 	return features
 }
 
+// gbmFeatureLabel names a feature in the gradient boosting layout, which
+// differs from the shared layout below index 111
+func gbmFeatureLabel(idx int) string {
+	names := map[int]string{
+		0: "Home Win %", 1: "Away Win %",
+		2: "Home Goals For", 3: "Away Goals For",
+		4: "Home Goals Against", 5: "Away Goals Against",
+		6: "Home PP%", 7: "Away PP%",
+		8: "Home PK%", 9: "Away PK%",
+		50: "Home Goalie Advantage", 51: "Away Goalie Advantage",
+		52: "Home Market Consensus", 53: "Away Market Consensus",
+		54: "Home Travel Distance", 55: "Away Travel Distance",
+		56: "Home Back-to-Back", 57: "Away Back-to-Back",
+		58: "Home Schedule Density", 59: "Away Schedule Density",
+		60: "Home Rest Advantage", 61: "Away Rest Advantage",
+		62: "Home Rest Days", 63: "Away Rest Days",
+		64: "Home Ice",
+		65: "Home Star Power", 66: "Away Star Power",
+		67: "Home Top 3 Combined PPG", 68: "Away Top 3 Combined PPG",
+		69: "Home Top Scorer Form", 70: "Away Top Scorer Form",
+		71: "Home Depth Form", 72: "Away Depth Form",
+		73: "Star Power Edge", 74: "Depth Edge",
+		75: "Home xGF", 76: "Away xGF",
+		77: "Home xGA", 78: "Away xGA",
+		79: "Home xG Differential (PBP)", 80: "Away xG Differential (PBP)",
+		81: "Home xG per Shot", 82: "Away xG per Shot",
+		83: "Home Dangerous Shots", 84: "Away Dangerous Shots",
+		85: "Home High-Danger xG", 86: "Away High-Danger xG",
+		87: "Shot Quality Advantage",
+		88: "Home Corsi (PBP)", 89: "Away Corsi (PBP)",
+		90: "Home Fenwick For %", 91: "Away Fenwick For %",
+		92: "Home Faceoff %",
+	}
+	if name, ok := names[idx]; ok {
+		return name
+	}
+	return getFeatureName(idx)
+}
+
 // predictScore predicts the final score
 func (gbm *GradientBoostingModel) predictScore(winProb float64, home, away *models.PredictionFactors) string {
 	// Simple score prediction based on probability
@@ -57,11 +57,32 @@ func (hcs *HealthCheckService) RunHealthChecks() *models.HealthStatus {
 	// 6. API Cache Health
 	checks["api_cache"] = hcs.checkAPICache()
 
-	// Determine overall status
-	overallStatus := hcs.determineOverallStatus(checks)
+	// 7. Background services
+	checks["background_services"] = hcs.checkBackgroundServices()
 
+	// 8. Database
+	checks["database"] = hcs.checkDatabase()
+
+	return hcs.healthStatus(checks)
+}
+
+// RunOfflineHealthChecks runs only the checks that don't depend on a running
+// server's in-memory state: NHL API reachability, data directories, the
+// database and memory. The CLI uses it from cron.
+func (hcs *HealthCheckService) RunOfflineHealthChecks() *models.HealthStatus {
+	checks := map[string]models.HealthCheck{
+		"nhl_api":          hcs.checkNHLAPI(),
+		"data_persistence": hcs.checkDataPersistence(),
+		"database":         hcs.checkDatabase(),
+		"memory":           hcs.checkMemoryUsage(),
+	}
+	return hcs.healthStatus(checks)
+}
+
+// healthStatus wraps individual checks with the overall status
+func (hcs *HealthCheckService) healthStatus(checks map[string]models.HealthCheck) *models.HealthStatus {
 	return &models.HealthStatus{
-		Status:    overallStatus,
+		Status:    hcs.determineOverallStatus(checks),
 		Timestamp: time.Now(),
 		Checks:    checks,
 		Uptime:    hcs.getUptime(),
@@ -337,6 +358,56 @@ func (hcs *HealthCheckService) checkCacheHealth() models.HealthCheck {
 	}
 }
 
+// checkDatabase verifies the SQLite database is open and answering queries
+func (hcs *HealthCheckService) checkDatabase() models.HealthCheck {
+	db := GetDatabase()
+	if db == nil {
+		return models.HealthCheck{
+			Name:        "Database",
+			Status:      "unhealthy",
+			Message:     "Database not initialized",
+			LastChecked: time.Now(),
+		}
+	}
+
+	start := time.Now()
+	migrations, err := db.MigrationStatus()
+	responseTime := time.Since(start)
+	if err != nil {
+		return models.HealthCheck{
+			Name:         "Database",
+			Status:       "unhealthy",
+			Message:      fmt.Sprintf("Query failed: %v", err),
+			ResponseTime: responseTime,
+			LastChecked:  time.Now(),
+		}
+	}
+
+	pending := 0
+	for _, migration := range migrations {
+		if !migration.Applied {
+			pending++
+		}
+	}
+
+	check := models.HealthCheck{
+		Name:         "Database",
+		Status:       "healthy",
+		Message:      fmt.Sprintf("Schema at version %d", len(migrations)-pending),
+		ResponseTime: responseTime,
+		LastChecked:  time.Now(),
+		Details: map[string]interface{}{
+			"path":               db.path,
+			"pending_migrations": pending,
+		},
+	}
+	if pending > 0 {
+		check.Status = "degraded"
+		check.Message = fmt.Sprintf("%d pending migrations", pending)
+	}
+	return check
+}
+
 // determineOverallStatus calculates overall system status from individual checks
 func (hcs *HealthCheckService) determineOverallStatus(checks map[string]models.HealthCheck) string {
 	unhealthyCount := 0
@@ -412,6 +483,34 @@ func (hcs *HealthCheckService) checkAPICache() models.HealthCheck {
 	}
 }
 
+// checkBackgroundServices reports the lifecycle manager's view of the
+// background services. It's unhealthy once shutdown begins.
+func (hcs *HealthCheckService) checkBackgroundServices() models.HealthCheck {
+	status := GetLifecycleManager().Status()
+
+	check := models.HealthCheck{
+		Name:        "Background Services",
+		Status:      "healthy",
+		Message:     fmt.Sprintf("%d services running", status.Healthy),
+		LastChecked: time.Now(),
+		Details:     status,
+	}
+
+	switch {
+	case status.State == LifecycleStopping || status.State == LifecycleStopped:
+		check.Status = "unhealthy"
+		check.Message = "Server is shutting down"
+	case status.State == LifecycleFailed:
+		check.Status = "unhealthy"
+		check.Message = "Background services failed to start"
+	case status.Unhealthy > 0:
+		check.Status = "degraded"
+		check.Message = fmt.Sprintf("%d of %d services unhealthy", status.Unhealthy, len(status.Components))
+	}
+
+	return check
+}
+
 // getUptime returns formatted uptime string
 func (hcs *HealthCheckService) getUptime() string {
 	uptime := time.Since(hcs.startTime)
@@ -0,0 +1,609 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+	"github.com/jaredshillingburg/go_uhc/nhlapi"
+)
+
+// Historical datasets
+const (
+	HistoryDatasetSchedule   = "schedule"     // League schedule per season
+	HistoryDatasetResults    = "results"      // CompletedGames in the monthly results files
+	HistoryDatasetBoxscores  = "boxscores"    // Raw boxscore per game
+	HistoryDatasetPlayByPlay = "play-by-play" // Play events per game
+	HistoryDatasetShifts     = "shifts"       // Player shifts per game
+	HistoryDatasetRosters    = "rosters"      // Roster per team
+	HistoryDatasetGoalies    = "goalies"      // Goalie stats per team
+)
+
+// AllHistoryDatasets lists every dataset, in ingestion order
+var AllHistoryDatasets = []string{
+	HistoryDatasetSchedule,
+	HistoryDatasetResults,
+	HistoryDatasetBoxscores,
+	HistoryDatasetPlayByPlay,
+	HistoryDatasetShifts,
+	HistoryDatasetRosters,
+	HistoryDatasetGoalies,
+}
+
+// resultsFlushEvery is how many new results are buffered before they're
+// merged into the monthly files
+const resultsFlushEvery = 50
+
+// HistoricalIngestOptions configure an ingestion run
+type HistoricalIngestOptions struct {
+	Seasons         []int    // Seasons to ingest, e.g. 20232024
+	Teams           []string // Only games involving these teams (default every team in each season)
+	Datasets        []string // Datasets to ingest (default AllHistoryDatasets)
+	IncludePlayoffs bool     // Ingest playoff games as well as the regular season
+	DataDir         string   // Root data directory (default "data")
+	Force           bool     // Refetch data that is already stored
+}
+
+// HistoricalGame is a game on an ingested season schedule
+type HistoricalGame struct {
+	GameID    int    `json:"gameId"`
+	Season    int    `json:"season"`
+	GameType  int    `json:"gameType"`
+	GameDate  string `json:"gameDate"`
+	StartTime string `json:"startTimeUTC"`
+	HomeTeam  string `json:"homeTeam"`
+	AwayTeam  string `json:"awayTeam"`
+	Venue     string `json:"venue"`
+}
+
+// HistoricalGoalieStats are a team's goalie stats for a season
+type HistoricalGoalieStats struct {
+	Team          string                   `json:"team"`
+	Season        int                      `json:"season"`
+	RegularSeason []models.ClubGoalieStats `json:"regularSeason"`
+	Playoffs      []models.ClubGoalieStats `json:"playoffs,omitempty"`
+}
+
+// SeasonIngestReport summarizes one season of an ingestion run. It is also
+// saved as history/<season>/manifest.json.
+type SeasonIngestReport struct {
+	Season    int                    `json:"season"`
+	Games     int                    `json:"games"`   // Completed games in scope
+	Fetched   map[string]int         `json:"fetched"` // Newly stored, per dataset
+	Skipped   map[string]int         `json:"skipped"` // Already stored, per dataset
+	Failures  []HistoryIngestFailure `json:"failures,omitempty"`
+	StartedAt time.Time              `json:"startedAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+// HistoryIngestFailure is one item that couldn't be ingested; rerunning
+// retries it
+type HistoryIngestFailure struct {
+	Dataset string `json:"dataset"`
+	Key     string `json:"key"` // Game ID or team code
+	Error   string `json:"error"`
+}
+
+// HistoricalIngester pulls full past seasons from the NHL API into local
+// storage for offline training. Raw data goes under <DataDir>/history/<season>
+// and each completed game is added to the monthly results files in
+// <DataDir>/results that the models train from. Everything already stored is
+// skipped, so a run can be interrupted and rerun safely.
+type HistoricalIngester struct {
+	client     *nhlapi.Client
+	opts       HistoricalIngestOptions
+	datasets   map[string]bool
+	historyDir string
+	resultsDir string
+
+	knownResults     map[int]bool
+	newResults       []models.CompletedGame
+	standingsSeasons map[int]models.StandingsSeason // Fetched once per run
+}
+
+// NewHistoricalIngester validates opts and fills in defaults
+func NewHistoricalIngester(opts HistoricalIngestOptions) (*HistoricalIngester, error) {
+	if len(opts.Seasons) == 0 {
+		return nil, fmt.Errorf("at least one season is required")
+	}
+	for _, season := range opts.Seasons {
+		if err := ValidateSeason(season); err != nil {
+			return nil, err
+		}
+	}
+	for _, team := range opts.Teams {
+		if !models.IsValidTeamCode(team) && !models.IsHistoricalTeamCode(team) {
+			return nil, fmt.Errorf("unknown team code %q", team)
+		}
+	}
+	if len(opts.Datasets) == 0 {
+		opts.Datasets = AllHistoryDatasets
+	}
+	datasets := make(map[string]bool)
+	for _, dataset := range opts.Datasets {
+		known := false
+		for _, d := range AllHistoryDatasets {
+			known = known || d == dataset
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown dataset %q", dataset)
+		}
+		datasets[dataset] = true
+	}
+	if opts.DataDir == "" {
+		opts.DataDir = "data"
+	}
+
+	return &HistoricalIngester{
+		client:     GetNHLAPIClient(),
+		opts:       opts,
+		datasets:   datasets,
+		historyDir: filepath.Join(opts.DataDir, "history"),
+		resultsDir: filepath.Join(opts.DataDir, "results"),
+	}, nil
+}
+
+// ValidateSeason checks a season is in 20232024 form
+func ValidateSeason(season int) error {
+	start, end := season/10000, season%10000
+	if start < 1917 || end != start+1 {
+		return fmt.Errorf("invalid season %d (expected e.g. 20232024)", season)
+	}
+	return nil
+}
+
+// Run ingests each season in turn. It stops between items when ctx is
+// cancelled, returning the reports so far along with ctx.Err().
+func (hi *HistoricalIngester) Run(ctx context.Context) ([]SeasonIngestReport, error) {
+	if hi.datasets[HistoryDatasetResults] {
+		existing, err := loadCompletedGamesFrom(hi.resultsDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read existing results: %w", err)
+		}
+		hi.knownResults = make(map[int]bool, len(existing))
+		for _, game := range existing {
+			hi.knownResults[game.GameID] = true
+		}
+	}
+
+	var reports []SeasonIngestReport
+	for _, season := range hi.opts.Seasons {
+		report, err := hi.ingestSeason(ctx, season)
+		reports = append(reports, report)
+		if err != nil {
+			return reports, err
+		}
+	}
+	return reports, nil
+}
+
+// ingestSeason ingests the schedule, then per-team data, then per-game data
+func (hi *HistoricalIngester) ingestSeason(ctx context.Context, season int) (SeasonIngestReport, error) {
+	report := SeasonIngestReport{
+		Season:    season,
+		Fetched:   make(map[string]int),
+		Skipped:   make(map[string]int),
+		StartedAt: time.Now(),
+	}
+	seasonDir := filepath.Join(hi.historyDir, fmt.Sprint(season))
+	defer func() {
+		report.UpdatedAt = time.Now()
+		if err := writeJSONFile(filepath.Join(seasonDir, "manifest.json"), report); err != nil {
+			log.Printf("⚠️ Failed to save ingestion manifest for %d: %v", season, err)
+		}
+	}()
+
+	teams, err := hi.seasonTeams(ctx, season, seasonDir)
+	if err != nil {
+		return report, err
+	}
+	if len(teams) == 0 {
+		log.Printf("⏭️ None of the selected teams played in season %d", season)
+		return report, nil
+	}
+	log.Printf("📚 Ingesting season %d (%d teams)...", season, len(teams))
+
+	games, err := hi.seasonSchedule(ctx, season, seasonDir, teams, &report)
+	if err != nil {
+		return report, err
+	}
+	report.Games = len(games)
+	log.Printf("📅 Season %d: %d completed games in scope", season, len(games))
+
+	for _, team := range teams {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if hi.datasets[HistoryDatasetRosters] {
+			hi.fetchToFile(ctx, &report, HistoryDatasetRosters, team,
+				filepath.Join(seasonDir, "rosters", team+".json"),
+				func() (interface{}, error) { return hi.client.RosterForSeason(ctx, team, season) })
+		}
+		if hi.datasets[HistoryDatasetGoalies] {
+			hi.fetchToFile(ctx, &report, HistoryDatasetGoalies, team,
+				filepath.Join(seasonDir, "goalies", team+".json"),
+				func() (interface{}, error) { return hi.goalieStats(ctx, team, season) })
+		}
+	}
+
+	for i, game := range games {
+		if err := ctx.Err(); err != nil {
+			hi.flushResults(&report)
+			return report, err
+		}
+		if i%25 == 0 {
+			log.Printf("🏒 Season %d: game %d/%d", season, i+1, len(games))
+		}
+		hi.ingestGame(ctx, seasonDir, game, &report)
+	}
+	hi.flushResults(&report)
+
+	log.Printf("✅ Season %d ingested: fetched %v, already stored %v, %d failures",
+		season, report.Fetched, report.Skipped, len(report.Failures))
+	return report, nil
+}
+
+// seasonTeams returns the teams to ingest for a season. Franchises move
+// and get renamed (ARI became UTA in 2024), so the league's teams come from
+// that season's final standings rather than today's list; selected teams
+// that didn't play that season are skipped. A finished season's saved team
+// list is reused.
+func (hi *HistoricalIngester) seasonTeams(ctx context.Context, season int, seasonDir string) ([]string, error) {
+	teamsPath := filepath.Join(seasonDir, "teams.json")
+	var league []string
+	if !hi.opts.Force && season < getCurrentSeasonInt() && readJSONFile(teamsPath, &league) == nil && len(league) > 0 {
+		return hi.selectTeams(season, league), nil
+	}
+
+	league, err := hi.fetchSeasonTeams(ctx, season)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if len(hi.opts.Teams) > 0 {
+			log.Printf("⚠️ Couldn't look up the teams in season %d, using the selected teams as given: %v", season, err)
+			return hi.opts.Teams, nil
+		}
+		return nil, fmt.Errorf("failed to look up the teams in season %d: %w", season, err)
+	}
+	if err := writeJSONFile(teamsPath, league); err != nil {
+		log.Printf("⚠️ Failed to save the team list for %d: %v", season, err)
+	}
+	return hi.selectTeams(season, league), nil
+}
+
+// fetchSeasonTeams lists the teams in a season's standings at the end of
+// its regular season (or today, for the season in progress)
+func (hi *HistoricalIngester) fetchSeasonTeams(ctx context.Context, season int) ([]string, error) {
+	if hi.standingsSeasons == nil {
+		resp, err := hi.client.StandingsSeasons(ctx)
+		if err != nil {
+			return nil, err
+		}
+		hi.standingsSeasons = make(map[int]models.StandingsSeason, len(resp.Seasons))
+		for _, s := range resp.Seasons {
+			hi.standingsSeasons[s.ID] = s
+		}
+	}
+	info, ok := hi.standingsSeasons[season]
+	if !ok {
+		return nil, fmt.Errorf("no standings for season %d", season)
+	}
+	date, err := time.Parse("2006-01-02", info.StandingsEnd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid standings end %q for season %d", info.StandingsEnd, season)
+	}
+	if date.After(time.Now()) {
+		date = time.Now()
+	}
+
+	standings, err := hi.client.StandingsForDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	var teams []string
+	for _, standing := range standings.Standings {
+		if code := standing.TeamAbbrev.Default; code != "" {
+			teams = append(teams, code)
+		}
+	}
+	if len(teams) == 0 {
+		return nil, fmt.Errorf("standings for %s list no teams", date.Format("2006-01-02"))
+	}
+	sort.Strings(teams)
+	return teams, nil
+}
+
+// selectTeams narrows a season's teams to the selected ones, if any
+func (hi *HistoricalIngester) selectTeams(season int, league []string) []string {
+	if len(hi.opts.Teams) == 0 {
+		return league
+	}
+	inSeason := make(map[string]bool, len(league))
+	for _, team := range league {
+		inSeason[team] = true
+	}
+	var teams []string
+	for _, team := range hi.opts.Teams {
+		if inSeason[team] {
+			teams = append(teams, team)
+		} else {
+			log.Printf("⏭️ %s didn't play in season %d, skipping it", team, season)
+		}
+	}
+	return teams
+}
+
+// seasonSchedule returns the season's completed games involving the given
+// teams, oldest first. A finished season's saved schedule is reused; the
+// current season is always refetched.
+func (hi *HistoricalIngester) seasonSchedule(ctx context.Context, season int, seasonDir string, teams []string, report *SeasonIngestReport) ([]HistoricalGame, error) {
+	schedulePath := filepath.Join(seasonDir, "schedule.json")
+	var games []HistoricalGame
+
+	reuse := !hi.opts.Force && season < getCurrentSeasonInt() && fileExists(schedulePath)
+	if reuse || !hi.datasets[HistoryDatasetSchedule] {
+		if err := readJSONFile(schedulePath, &games); err == nil {
+			report.Skipped[HistoryDatasetSchedule]++
+		} else if !hi.datasets[HistoryDatasetSchedule] {
+			return nil, fmt.Errorf("no saved schedule for %d; include the %s dataset", season, HistoryDatasetSchedule)
+		} else {
+			reuse = false
+		}
+	}
+
+	if !reuse && hi.datasets[HistoryDatasetSchedule] {
+		seen := make(map[int]bool)
+		games = nil
+		for _, team := range teams {
+			resp, err := hi.client.SeasonSchedule(ctx, team, season)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				report.addFailure(HistoryDatasetSchedule, team, err)
+				continue
+			}
+			for _, g := range resp.Games {
+				if seen[g.ID] {
+					continue
+				}
+				seen[g.ID] = true
+				games = append(games, HistoricalGame{
+					GameID:    g.ID,
+					Season:    season,
+					GameType:  (g.ID / 10000) % 100,
+					GameDate:  g.GameDate,
+					StartTime: g.StartTime,
+					HomeTeam:  g.HomeTeam.Abbrev,
+					AwayTeam:  g.AwayTeam.Abbrev,
+					Venue:     g.Venue.Default,
+				})
+			}
+		}
+		sort.Slice(games, func(i, j int) bool { return games[i].GameID < games[j].GameID })
+		if len(games) == 0 {
+			return nil, fmt.Errorf("no schedule found for season %d", season)
+		}
+		if err := writeJSONFile(schedulePath, games); err != nil {
+			return nil, fmt.Errorf("failed to save schedule: %w", err)
+		}
+		report.Fetched[HistoryDatasetSchedule]++
+	}
+
+	// Keep completed regular season (and playoff) games of the selected teams
+	selected := make(map[string]bool)
+	for _, team := range teams {
+		selected[team] = true
+	}
+	cutoff := time.Now().Add(-3 * time.Hour)
+	var inScope []HistoricalGame
+	for _, g := range games {
+		if g.GameType != nhlapi.GameTypeRegularSeason && !(hi.opts.IncludePlayoffs && g.GameType == nhlapi.GameTypePlayoffs) {
+			continue
+		}
+		if !selected[g.HomeTeam] && !selected[g.AwayTeam] {
+			continue
+		}
+		if start, err := time.Parse(time.RFC3339, g.StartTime); err != nil || start.After(cutoff) {
+			continue
+		}
+		inScope = append(inScope, g)
+	}
+	return inScope, nil
+}
+
+// ingestGame stores one game's boxscore, result, play-by-play and shifts
+func (hi *HistoricalIngester) ingestGame(ctx context.Context, seasonDir string, game HistoricalGame, report *SeasonIngestReport) {
+	key := fmt.Sprint(game.GameID)
+	file := key + ".json"
+
+	needResult := hi.datasets[HistoryDatasetResults] && (hi.opts.Force || !hi.knownResults[game.GameID])
+	if hi.datasets[HistoryDatasetResults] && !needResult {
+		report.Skipped[HistoryDatasetResults]++
+	}
+
+	if hi.datasets[HistoryDatasetBoxscores] || needResult {
+		boxscorePath := filepath.Join(seasonDir, "boxscores", file)
+		var boxscore *models.BoxscoreResponse
+		if !hi.opts.Force && fileExists(boxscorePath) {
+			var stored models.BoxscoreResponse
+			if err := readJSONFile(boxscorePath, &stored); err == nil {
+				boxscore = &stored
+				if hi.datasets[HistoryDatasetBoxscores] {
+					report.Skipped[HistoryDatasetBoxscores]++
+				}
+			}
+		}
+		if boxscore == nil {
+			fetched, err := hi.client.Boxscore(ctx, game.GameID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				report.addFailure(HistoryDatasetBoxscores, key, err)
+				return
+			}
+			if fetched.GameState != "FINAL" && fetched.GameState != "OFF" {
+				log.Printf("⏭️ Game %d is %s, skipping until it's final", game.GameID, fetched.GameState)
+				return
+			}
+			boxscore = fetched
+			if hi.datasets[HistoryDatasetBoxscores] {
+				if err := writeJSONFile(boxscorePath, boxscore); err != nil {
+					report.addFailure(HistoryDatasetBoxscores, key, err)
+				} else {
+					report.Fetched[HistoryDatasetBoxscores]++
+				}
+			}
+		}
+
+		if needResult {
+			// transformBoxscore doesn't use any service state
+			var results GameResultsService
+			hi.newResults = append(hi.newResults, *results.transformBoxscore(boxscore))
+			hi.knownResults[game.GameID] = true
+			report.Fetched[HistoryDatasetResults]++
+			if len(hi.newResults) >= resultsFlushEvery {
+				hi.flushResults(report)
+			}
+		}
+	}
+
+	if hi.datasets[HistoryDatasetPlayByPlay] {
+		hi.fetchToFile(ctx, report, HistoryDatasetPlayByPlay, key, filepath.Join(seasonDir, "play_by_play", file),
+			func() (interface{}, error) { return hi.client.PlayByPlay(ctx, game.GameID) })
+	}
+	if hi.datasets[HistoryDatasetShifts] {
+		hi.fetchToFile(ctx, report, HistoryDatasetShifts, key, filepath.Join(seasonDir, "shifts", file),
+			func() (interface{}, error) { return hi.client.Shifts(ctx, game.GameID) })
+	}
+}
+
+// goalieStats fetches a team's regular season and playoff goalie stats
+func (hi *HistoricalIngester) goalieStats(ctx context.Context, team string, season int) (*HistoricalGoalieStats, error) {
+	regular, err := hi.client.ClubStatsForSeason(ctx, team, season, nhlapi.GameTypeRegularSeason)
+	if err != nil {
+		return nil, err
+	}
+	stats := &HistoricalGoalieStats{Team: team, Season: season, RegularSeason: regular.Goalies}
+
+	if hi.opts.IncludePlayoffs {
+		// Teams that missed the playoffs have no playoff stats
+		if playoffs, err := hi.client.ClubStatsForSeason(ctx, team, season, nhlapi.GameTypePlayoffs); err == nil {
+			stats.Playoffs = playoffs.Goalies
+		}
+	}
+	return stats, nil
+}
+
+// fetchToFile stores fetch's result at path unless it's already there
+func (hi *HistoricalIngester) fetchToFile(ctx context.Context, report *SeasonIngestReport, dataset, key, path string, fetch func() (interface{}, error)) {
+	if !hi.opts.Force && fileExists(path) {
+		report.Skipped[dataset]++
+		return
+	}
+	data, err := fetch()
+	if err != nil {
+		if ctx.Err() == nil {
+			report.addFailure(dataset, key, err)
+		}
+		return
+	}
+	if err := writeJSONFile(path, data); err != nil {
+		report.addFailure(dataset, key, err)
+		return
+	}
+	report.Fetched[dataset]++
+}
+
+// flushResults merges buffered results into the monthly results files,
+// replacing any existing record of the same game
+func (hi *HistoricalIngester) flushResults(report *SeasonIngestReport) {
+	if len(hi.newResults) == 0 {
+		return
+	}
+
+	byMonth := make(map[string][]models.CompletedGame)
+	for _, game := range hi.newResults {
+		month := game.GameDate.Format("2006-01")
+		byMonth[month] = append(byMonth[month], game)
+	}
+
+	for month, games := range byMonth {
+		path := filepath.Join(hi.resultsDir, month+".json")
+		var existing []models.CompletedGame
+		if fileExists(path) {
+			if err := readJSONFile(path, &existing); err != nil {
+				// Don't overwrite a file we couldn't read
+				for _, game := range games {
+					report.addFailure(HistoryDatasetResults, fmt.Sprint(game.GameID), err)
+				}
+				continue
+			}
+		}
+
+		index := make(map[int]int, len(existing))
+		for i, game := range existing {
+			index[game.GameID] = i
+		}
+		for _, game := range games {
+			if i, ok := index[game.GameID]; ok {
+				existing[i] = game
+			} else {
+				index[game.GameID] = len(existing)
+				existing = append(existing, game)
+			}
+		}
+		sort.Slice(existing, func(i, j int) bool { return existing[i].GameDate.Before(existing[j].GameDate) })
+
+		if err := writeJSONFile(path, existing); err != nil {
+			for _, game := range games {
+				report.addFailure(HistoryDatasetResults, fmt.Sprint(game.GameID), err)
+			}
+		}
+	}
+	hi.newResults = nil
+}
+
+func (r *SeasonIngestReport) addFailure(dataset, key string, err error) {
+	log.Printf("⚠️ Failed to ingest %s for %s: %v", dataset, key, err)
+	r.Failures = append(r.Failures, HistoryIngestFailure{Dataset: dataset, Key: key, Error: err.Error()})
+}
+
+// writeJSONFile writes v as indented JSON via a temp file, so an interrupted
+// run never leaves a partial file that later runs would skip
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func readJSONFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,885 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// ============================================================================
+// TUNED HYPERPARAMETER CONFIG
+// ============================================================================
+
+const hyperparameterConfigPath = "data/config/hyperparameters.json"
+
+// TunedModelConfig is the chosen hyperparameter set for one model
+type TunedModelConfig struct {
+	Params         map[string]float64 `json:"params"`
+	LogLoss        float64            `json:"logLoss"`
+	BaselineLoss   float64            `json:"baselineLoss"`
+	Strategy       string             `json:"strategy"`
+	Trials         int                `json:"trials"`
+	GamesEvaluated int                `json:"gamesEvaluated"`
+	TunedAt        time.Time          `json:"tunedAt"`
+}
+
+// HyperparameterConfig is the on-disk file of tuned settings, read once at startup
+type HyperparameterConfig struct {
+	Models      map[string]TunedModelConfig `json:"models"`
+	LastUpdated time.Time                   `json:"lastUpdated"`
+}
+
+var (
+	hyperparameterConfig     *HyperparameterConfig
+	hyperparameterConfigOnce sync.Once
+	hyperparameterConfigMu   sync.RWMutex
+)
+
+// LoadHyperparameterConfig reads the tuned config file. Models constructed
+// afterwards use its values in place of their hard-coded defaults.
+func LoadHyperparameterConfig() *HyperparameterConfig {
+	ensureHyperparameterConfig()
+	return GetHyperparameterConfig()
+}
+
+// ensureHyperparameterConfig reads the config file once
+func ensureHyperparameterConfig() {
+	hyperparameterConfigOnce.Do(func() {
+		config := &HyperparameterConfig{Models: make(map[string]TunedModelConfig)}
+
+		data, err := ioutil.ReadFile(hyperparameterConfigPath)
+		if err == nil {
+			if err := json.Unmarshal(data, config); err != nil {
+				log.Printf("⚠️ Failed to parse %s: %v (using defaults)", hyperparameterConfigPath, err)
+				config = &HyperparameterConfig{Models: make(map[string]TunedModelConfig)}
+			} else {
+				log.Printf("🎛️ Loaded tuned hyperparameters for %d models", len(config.Models))
+			}
+		}
+		if config.Models == nil {
+			config.Models = make(map[string]TunedModelConfig)
+		}
+
+		hyperparameterConfigMu.Lock()
+		hyperparameterConfig = config
+		hyperparameterConfigMu.Unlock()
+	})
+}
+
+// GetHyperparameterConfig returns a copy of the current tuned config
+func GetHyperparameterConfig() *HyperparameterConfig {
+	ensureHyperparameterConfig()
+
+	hyperparameterConfigMu.RLock()
+	defer hyperparameterConfigMu.RUnlock()
+
+	config := &HyperparameterConfig{
+		Models:      make(map[string]TunedModelConfig, len(hyperparameterConfig.Models)),
+		LastUpdated: hyperparameterConfig.LastUpdated,
+	}
+	for name, model := range hyperparameterConfig.Models {
+		config.Models[name] = model
+	}
+	return config
+}
+
+// tunedParam returns the tuned value for a model parameter, or def if none was chosen
+func tunedParam(model, param string, def float64) float64 {
+	ensureHyperparameterConfig()
+
+	hyperparameterConfigMu.RLock()
+	defer hyperparameterConfigMu.RUnlock()
+
+	if tuned, ok := hyperparameterConfig.Models[model]; ok {
+		if value, ok := tuned.Params[param]; ok {
+			return value
+		}
+	}
+	return def
+}
+
+// saveTunedModelConfig records the chosen config for a model and rewrites the file
+func saveTunedModelConfig(model string, tuned TunedModelConfig) error {
+	ensureHyperparameterConfig()
+
+	hyperparameterConfigMu.Lock()
+	defer hyperparameterConfigMu.Unlock()
+
+	hyperparameterConfig.Models[model] = tuned
+	hyperparameterConfig.LastUpdated = time.Now()
+
+	data, err := json.MarshalIndent(hyperparameterConfig, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal hyperparameter config: %w", err)
+	}
+	os.MkdirAll(filepath.Dir(hyperparameterConfigPath), 0755)
+	if err := ioutil.WriteFile(hyperparameterConfigPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write hyperparameter config: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// SEARCH SPACES
+// ============================================================================
+
+// HyperparameterSpec describes the range searched for one parameter
+type HyperparameterSpec struct {
+	Name     string  `json:"name"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Integer  bool    `json:"integer"`
+	LogScale bool    `json:"logScale"`
+}
+
+// hyperparameterSearchSpaces covers the hard-coded settings of each tunable model
+var hyperparameterSearchSpaces = map[string][]HyperparameterSpec{
+	"gradient_boosting": {
+		{Name: "learningRate", Min: 0.01, Max: 0.3, LogScale: true},
+		{Name: "numTrees", Min: 20, Max: 200, Integer: true},
+		{Name: "maxDepth", Min: 2, Max: 6, Integer: true},
+		{Name: "minSamplesLeaf", Min: 2, Max: 20, Integer: true},
+	},
+	"random_forest": {
+		{Name: "numTrees", Min: 20, Max: 200, Integer: true},
+		{Name: "maxDepth", Min: 3, Max: 12, Integer: true},
+		{Name: "minSamplesLeaf", Min: 1, Max: 10, Integer: true},
+		{Name: "maxFeatures", Min: 6, Max: 40, Integer: true},
+	},
+	"elo": {
+		{Name: "kFactor", Min: 8, Max: 48},
+		{Name: "homeAdvantage", Min: 0, Max: 150},
+		{Name: "seasonDecayRate", Min: 0.5, Max: 1.0},
+	},
+	"poisson": {
+		{Name: "learningRate", Min: 0.05, Max: 0.2}, // updateAdaptiveLearningRate clamps to this range
+		{Name: "homeAdvantage", Min: 1.0, Max: 1.15},
+		{Name: "seasonDecayRate", Min: 0.5, Max: 1.0},
+	},
+}
+
+// TunableModels lists the models that have a search space
+func TunableModels() []string {
+	names := make([]string, 0, len(hyperparameterSearchSpaces))
+	for name := range hyperparameterSearchSpaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// decodeHyperparameters maps a point in the unit cube to parameter values
+func decodeHyperparameters(space []HyperparameterSpec, point []float64) map[string]float64 {
+	params := make(map[string]float64, len(space))
+	for i, spec := range space {
+		u := math.Max(0, math.Min(1, point[i]))
+		var value float64
+		if spec.LogScale {
+			value = math.Exp(math.Log(spec.Min) + u*(math.Log(spec.Max)-math.Log(spec.Min)))
+		} else {
+			value = spec.Min + u*(spec.Max-spec.Min)
+		}
+		if spec.Integer {
+			value = math.Round(value)
+		}
+		params[spec.Name] = value
+	}
+	return params
+}
+
+// encodeHyperparameters maps parameter values back into the unit cube
+func encodeHyperparameters(space []HyperparameterSpec, params map[string]float64) []float64 {
+	point := make([]float64, len(space))
+	for i, spec := range space {
+		value := math.Max(spec.Min, math.Min(spec.Max, params[spec.Name]))
+		if spec.LogScale {
+			point[i] = (math.Log(value) - math.Log(spec.Min)) / (math.Log(spec.Max) - math.Log(spec.Min))
+		} else {
+			point[i] = (value - spec.Min) / (spec.Max - spec.Min)
+		}
+	}
+	return point
+}
+
+// ============================================================================
+// TUNING SERVICE
+// ============================================================================
+
+// TuningOptions controls a tuning run
+type TuningOptions struct {
+	Model      string        `json:"model"`
+	Strategy   string        `json:"strategy"` // "random" or "bayesian"
+	Trials     int           `json:"trials"`
+	Folds      int           `json:"folds"`
+	TimeBudget time.Duration `json:"timeBudget"`
+	Seed       int64         `json:"seed"`
+}
+
+// DefaultTuningOptions returns the options used when a request doesn't override them
+func DefaultTuningOptions(model string) TuningOptions {
+	return TuningOptions{
+		Model:      model,
+		Strategy:   "bayesian",
+		Trials:     30,
+		Folds:      4,
+		TimeBudget: 20 * time.Minute,
+		Seed:       time.Now().UnixNano(),
+	}
+}
+
+// ValidateTuningOptions checks the model and strategy before a run is started
+func ValidateTuningOptions(opts TuningOptions) error {
+	if _, ok := hyperparameterSearchSpaces[opts.Model]; !ok {
+		return fmt.Errorf("no search space for model %q", opts.Model)
+	}
+	if opts.Strategy != "random" && opts.Strategy != "bayesian" {
+		return fmt.Errorf("unknown strategy %q (use random or bayesian)", opts.Strategy)
+	}
+	return nil
+}
+
+// TuningTrial is one evaluated hyperparameter set
+type TuningTrial struct {
+	Number     int                `json:"number"`
+	Params     map[string]float64 `json:"params"`
+	LogLoss    float64            `json:"logLoss"`
+	Brier      float64            `json:"brier"`
+	Accuracy   float64            `json:"accuracy"`
+	FoldLosses []float64          `json:"foldLosses"`
+	Baseline   bool               `json:"baseline,omitempty"`
+	DurationMs int64              `json:"durationMs"`
+}
+
+// TuningRun is the record of one search over a model's space
+type TuningRun struct {
+	ID          string        `json:"id"`
+	Model       string        `json:"model"`
+	Strategy    string        `json:"strategy"`
+	Games       int           `json:"games"`
+	Folds       int           `json:"folds"`
+	StartedAt   time.Time     `json:"startedAt"`
+	CompletedAt time.Time     `json:"completedAt"`
+	Trials      []TuningTrial `json:"trials"`
+	Best        *TuningTrial  `json:"best"`
+	Baseline    *TuningTrial  `json:"baseline"`
+	Applied     bool          `json:"applied"` // Best beat the current settings and was written to the config
+	Error       string        `json:"error,omitempty"`
+}
+
+// HyperparameterTuningService searches model hyperparameters with time-ordered
+// cross-validation over stored completed games
+type HyperparameterTuningService struct {
+	dataDir    string
+	resultsDir string
+	runs       []TuningRun
+	running    map[string]bool
+	mutex      sync.RWMutex
+}
+
+var (
+	hyperparameterTuningService     *HyperparameterTuningService
+	hyperparameterTuningServiceOnce sync.Once
+)
+
+// GetHyperparameterTuningService returns the singleton, creating it on first use
+func GetHyperparameterTuningService() *HyperparameterTuningService {
+	hyperparameterTuningServiceOnce.Do(func() {
+		dataDir := "data/tuning"
+		os.MkdirAll(dataDir, 0755)
+
+		hyperparameterTuningService = &HyperparameterTuningService{
+			dataDir:    dataDir,
+			resultsDir: "data/results",
+			runs:       make([]TuningRun, 0),
+			running:    make(map[string]bool),
+		}
+		hyperparameterTuningService.loadRuns()
+	})
+	return hyperparameterTuningService
+}
+
+// IsRunning reports whether a model is currently being tuned
+func (hts *HyperparameterTuningService) IsRunning(model string) bool {
+	hts.mutex.RLock()
+	defer hts.mutex.RUnlock()
+	return hts.running[model]
+}
+
+// GetRecentRuns returns the most recent tuning runs, newest first
+func (hts *HyperparameterTuningService) GetRecentRuns(limit int) []TuningRun {
+	hts.mutex.RLock()
+	defer hts.mutex.RUnlock()
+
+	runs := make([]TuningRun, 0, limit)
+	for i := len(hts.runs) - 1; i >= 0 && len(runs) < limit; i-- {
+		runs = append(runs, hts.runs[i])
+	}
+	return runs
+}
+
+// Tune runs a hyperparameter search for one model. The current settings are
+// evaluated first as the baseline; the best trial is written to the config
+// only if it beats them.
+func (hts *HyperparameterTuningService) Tune(opts TuningOptions) (*TuningRun, error) {
+	if err := ValidateTuningOptions(opts); err != nil {
+		return nil, err
+	}
+	space := hyperparameterSearchSpaces[opts.Model]
+	if opts.Trials < 1 {
+		opts.Trials = 1
+	}
+	if opts.Folds < 2 {
+		opts.Folds = 2
+	}
+
+	hts.mutex.Lock()
+	if hts.running[opts.Model] {
+		hts.mutex.Unlock()
+		return nil, fmt.Errorf("tuning already running for %s", opts.Model)
+	}
+	hts.running[opts.Model] = true
+	hts.mutex.Unlock()
+
+	defer func() {
+		hts.mutex.Lock()
+		delete(hts.running, opts.Model)
+		hts.mutex.Unlock()
+	}()
+
+	games, err := loadCompletedGamesFrom(hts.resultsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load completed games: %w", err)
+	}
+
+	evaluator, err := newTuningEvaluator(opts.Model, games, opts.Folds)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &TuningRun{
+		ID:        fmt.Sprintf("%s_%d", opts.Model, time.Now().Unix()),
+		Model:     opts.Model,
+		Strategy:  opts.Strategy,
+		Games:     len(games),
+		Folds:     len(evaluator.folds),
+		StartedAt: time.Now(),
+		Trials:    make([]TuningTrial, 0, opts.Trials+1),
+	}
+
+	log.Printf("🎛️ Tuning %s: %s search, %d trials, %d time-ordered folds over %d games",
+		opts.Model, opts.Strategy, opts.Trials, len(evaluator.folds), len(games))
+
+	deadline := time.Now().Add(opts.TimeBudget)
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	// Baseline: whatever the model would use today
+	baseline := evaluator.evaluate(currentHyperparameters(opts.Model))
+	baseline.Number = 0
+	baseline.Baseline = true
+	run.Trials = append(run.Trials, baseline)
+	log.Printf("🎛️ Baseline %s: log loss %.4f, Brier %.4f, accuracy %.1f%%",
+		opts.Model, baseline.LogLoss, baseline.Brier, baseline.Accuracy*100)
+
+	for n := 1; n <= opts.Trials; n++ {
+		if opts.TimeBudget > 0 && time.Now().After(deadline) {
+			log.Printf("⏱️ Tuning time budget reached after %d trials", n-1)
+			break
+		}
+
+		var point []float64
+		if opts.Strategy == "bayesian" {
+			point = suggestTPE(space, run.Trials, rng)
+		} else {
+			point = randomPoint(len(space), rng)
+		}
+
+		trial := evaluator.evaluate(decodeHyperparameters(space, point))
+		trial.Number = n
+		run.Trials = append(run.Trials, trial)
+
+		log.Printf("   Trial %d/%d: log loss %.4f, Brier %.4f, accuracy %.1f%% %v",
+			n, opts.Trials, trial.LogLoss, trial.Brier, trial.Accuracy*100, trial.Params)
+	}
+
+	best := run.Trials[0]
+	for _, trial := range run.Trials[1:] {
+		if trial.LogLoss < best.LogLoss {
+			best = trial
+		}
+	}
+	run.Best = &best
+	run.Baseline = &run.Trials[0]
+	run.CompletedAt = time.Now()
+
+	if !best.Baseline && best.LogLoss < baseline.LogLoss {
+		err := saveTunedModelConfig(opts.Model, TunedModelConfig{
+			Params:         best.Params,
+			LogLoss:        best.LogLoss,
+			BaselineLoss:   baseline.LogLoss,
+			Strategy:       opts.Strategy,
+			Trials:         len(run.Trials) - 1,
+			GamesEvaluated: evaluator.scoredGames(),
+			TunedAt:        run.CompletedAt,
+		})
+		if err != nil {
+			run.Error = err.Error()
+			log.Printf("⚠️ Failed to save tuned config: %v", err)
+		} else {
+			run.Applied = true
+			log.Printf("✅ Tuned %s: log loss %.4f → %.4f %v (used from next training run / restart)",
+				opts.Model, baseline.LogLoss, best.LogLoss, best.Params)
+		}
+	} else {
+		log.Printf("🎛️ Tuning %s found nothing better than the current settings (%.4f)", opts.Model, baseline.LogLoss)
+	}
+
+	hts.recordRun(*run)
+	return run, nil
+}
+
+// currentHyperparameters returns the values the model uses now, tuned or default
+func currentHyperparameters(model string) map[string]float64 {
+	defaults := map[string]map[string]float64{
+		"gradient_boosting": {"learningRate": 0.1, "numTrees": 100, "maxDepth": 3, "minSamplesLeaf": 5},
+		"random_forest":     {"numTrees": 100, "maxDepth": 6, "minSamplesLeaf": 3, "maxFeatures": 12},
+		"elo":               {"kFactor": 32, "homeAdvantage": 100, "seasonDecayRate": 0.95},
+		"poisson":           {"learningRate": 0.1, "homeAdvantage": 1.08, "seasonDecayRate": 0.98},
+	}
+
+	params := make(map[string]float64)
+	for name, value := range defaults[model] {
+		params[name] = tunedParam(model, name, value)
+	}
+	return params
+}
+
+// randomPoint draws a uniform point in the unit cube
+func randomPoint(dims int, rng *rand.Rand) []float64 {
+	point := make([]float64, dims)
+	for i := range point {
+		point[i] = rng.Float64()
+	}
+	return point
+}
+
+// suggestTPE proposes the next point with a small tree-structured Parzen
+// estimator: completed trials are split into the best quarter and the rest,
+// candidates are drawn around the good ones, and the candidate with the highest
+// good/bad density ratio wins. Falls back to random sampling until there are
+// enough trials to model.
+func suggestTPE(space []HyperparameterSpec, trials []TuningTrial, rng *rand.Rand) []float64 {
+	const (
+		startupTrials = 6
+		gamma         = 0.25
+		bandwidth     = 0.15
+		candidates    = 24
+	)
+
+	if len(trials) < startupTrials {
+		return randomPoint(len(space), rng)
+	}
+
+	sorted := make([]TuningTrial, len(trials))
+	copy(sorted, trials)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LogLoss < sorted[j].LogLoss })
+
+	numGood := int(math.Ceil(gamma * float64(len(sorted))))
+	good := make([][]float64, 0, numGood)
+	bad := make([][]float64, 0, len(sorted)-numGood)
+	for i, trial := range sorted {
+		point := encodeHyperparameters(space, trial.Params)
+		if i < numGood {
+			good = append(good, point)
+		} else {
+			bad = append(bad, point)
+		}
+	}
+
+	density := func(x []float64, points [][]float64) float64 {
+		// Mixture of Gaussians plus a uniform floor so unexplored regions aren't zero
+		total := 0.0
+		for _, p := range points {
+			sq := 0.0
+			for d := range x {
+				diff := (x[d] - p[d]) / bandwidth
+				sq += diff * diff
+			}
+			total += math.Exp(-0.5 * sq)
+		}
+		return total/float64(len(points)+1) + 1e-3
+	}
+
+	var best []float64
+	bestScore := math.Inf(-1)
+	for c := 0; c < candidates; c++ {
+		center := good[rng.Intn(len(good))]
+		candidate := make([]float64, len(center))
+		for d := range center {
+			candidate[d] = math.Max(0, math.Min(1, center[d]+rng.NormFloat64()*bandwidth))
+		}
+
+		score := math.Log(density(candidate, good)) - math.Log(density(candidate, bad))
+		if score > bestScore {
+			bestScore = score
+			best = candidate
+		}
+	}
+	return best
+}
+
+// recordRun appends a run to history and persists it
+func (hts *HyperparameterTuningService) recordRun(run TuningRun) {
+	hts.mutex.Lock()
+	defer hts.mutex.Unlock()
+
+	hts.runs = append(hts.runs, run)
+	if len(hts.runs) > 50 {
+		hts.runs = hts.runs[len(hts.runs)-50:]
+	}
+
+	data, err := json.MarshalIndent(hts.runs, "", "  ")
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal tuning trials: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(hts.dataDir, "trials.json"), data, 0644); err != nil {
+		log.Printf("⚠️ Failed to save tuning trials: %v", err)
+	}
+}
+
+// loadRuns restores tuning history from disk
+func (hts *HyperparameterTuningService) loadRuns() {
+	data, err := ioutil.ReadFile(filepath.Join(hts.dataDir, "trials.json"))
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &hts.runs); err != nil {
+		log.Printf("⚠️ Failed to parse tuning trials: %v", err)
+		hts.runs = make([]TuningRun, 0)
+	}
+}
+
+// ============================================================================
+// TIME-ORDERED EVALUATION
+// ============================================================================
+
+// tuningSplits lays out k rolling-origin folds over n date-sorted games: the
+// first half is always training data and the second half is cut into k test windows
+func tuningSplits(n, k int) []RollingOriginSplit {
+	minTrain := n / 2
+	horizon := int(math.Ceil(float64(n-minTrain) / float64(k)))
+	if horizon < 1 {
+		return nil
+	}
+	return RollingOriginSplits(n, RollingOriginConfig{MinTrain: minTrain, Horizon: horizon})
+}
+
+// tuningEvaluator scores one model's hyperparameters on a fixed set of folds
+type tuningEvaluator struct {
+	model    string
+	games    []models.CompletedGame
+	folds    []RollingOriginSplit
+	features [][]float64 // Tree models only, aligned with games
+}
+
+// newTuningEvaluator prepares the folds (and features for tree models)
+func newTuningEvaluator(model string, games []models.CompletedGame, k int) (*tuningEvaluator, error) {
+	var evaluator *tuningEvaluator
+
+	switch model {
+	case "gradient_boosting", "random_forest":
+		// Tree models train on prediction-time features; games without them can't be used
+		evaluator = newTreeEvaluator(model, buildTreeTrainingExamples(games))
+	default:
+		evaluator = &tuningEvaluator{model: model, games: games}
+	}
+
+	evaluator.folds = tuningSplits(len(evaluator.games), k)
+	if len(evaluator.games) < 40 || len(evaluator.folds) == 0 {
+		return nil, fmt.Errorf("insufficient data to tune %s: %d usable games (need 40)", model, len(evaluator.games))
+	}
+	return evaluator, nil
+}
+
+// newTreeEvaluator extracts a tree model's features from prediction-time
+// examples; callers lay out the folds
+func newTreeEvaluator(model string, examples []models.TrainingExample) *tuningEvaluator {
+	evaluator := &tuningEvaluator{
+		model:    model,
+		games:    make([]models.CompletedGame, 0, len(examples)),
+		features: make([][]float64, 0, len(examples)),
+	}
+	for _, example := range examples {
+		var features []float64
+		if model == "gradient_boosting" {
+			features = (&GradientBoostingModel{}).extractFeatures(example.HomeFactors, example.AwayFactors)
+		} else {
+			features = (&RandomForestModel{}).extractFeatures(example.HomeFactors, example.AwayFactors)
+		}
+		evaluator.games = append(evaluator.games, example.Game)
+		evaluator.features = append(evaluator.features, features)
+	}
+	return evaluator
+}
+
+// scoredGames returns how many games fall in a test block
+func (te *tuningEvaluator) scoredGames() int {
+	return te.folds[len(te.folds)-1].TestEnd - te.folds[0].TestStart
+}
+
+// evaluate computes mean out-of-sample metrics for one parameter set
+func (te *tuningEvaluator) evaluate(params map[string]float64) TuningTrial {
+	start := time.Now()
+
+	var probs []float64
+	switch te.model {
+	case "gradient_boosting":
+		probs = te.gradientBoostingProbabilities(params)
+	case "random_forest":
+		probs = te.randomForestProbabilities(params)
+	case "elo":
+		probs = te.eloProbabilities(params)
+	case "poisson":
+		probs = te.poissonProbabilities(params)
+	}
+
+	trial := TuningTrial{
+		Params:     params,
+		FoldLosses: make([]float64, len(te.folds)),
+	}
+
+	scored := 0
+	for f, fold := range te.folds {
+		foldLoss := 0.0
+		for i := fold.TestStart; i < fold.TestEnd; i++ {
+			p := math.Max(0.001, math.Min(0.999, probs[i]))
+			y := 0.0
+			if te.games[i].HomeTeam.Score > te.games[i].AwayTeam.Score {
+				y = 1.0
+			}
+
+			loss := -(y*math.Log(p) + (1-y)*math.Log(1-p))
+			foldLoss += loss
+			trial.LogLoss += loss
+			trial.Brier += (p - y) * (p - y)
+			if (p > 0.5) == (y == 1.0) {
+				trial.Accuracy++
+			}
+			scored++
+		}
+		trial.FoldLosses[f] = foldLoss / float64(fold.TestSize())
+	}
+
+	if scored > 0 {
+		trial.LogLoss /= float64(scored)
+		trial.Brier /= float64(scored)
+		trial.Accuracy /= float64(scored)
+	}
+	trial.DurationMs = time.Since(start).Milliseconds()
+	return trial
+}
+
+// gradientBoostingProbabilities refits a throwaway model per fold
+func (te *tuningEvaluator) gradientBoostingProbabilities(params map[string]float64) []float64 {
+	probs := make([]float64, len(te.games))
+	labels := make([]float64, len(te.games))
+	for i, game := range te.games {
+		if game.HomeTeam.Score > game.AwayTeam.Score {
+			labels[i] = 1.0
+		}
+	}
+
+	for _, fold := range te.folds {
+		model := &GradientBoostingModel{
+			learningRate:   params["learningRate"],
+			numTrees:       int(params["numTrees"]),
+			maxDepth:       int(params["maxDepth"]),
+			minSamplesLeaf: int(params["minSamplesLeaf"]),
+			quiet:          true,
+		}
+		model.fit(te.features[fold.TrainStart:fold.TrainEnd], labels[fold.TrainStart:fold.TrainEnd])
+
+		for i := fold.TestStart; i < fold.TestEnd; i++ {
+			raw := model.predictProbability(te.features[i])
+			prob := 1.0 / (1.0 + math.Exp(-raw))
+			probs[i] = math.Max(0.35, math.Min(0.85, prob)) // Same bounds as Predict
+		}
+	}
+	return probs
+}
+
+// randomForestProbabilities refits a throwaway forest per fold
+func (te *tuningEvaluator) randomForestProbabilities(params map[string]float64) []float64 {
+	probs := make([]float64, len(te.games))
+	labels := make([]float64, len(te.games))
+	for i, game := range te.games {
+		// Same encoding as prepareTrainingData: 1 = win, 2 = OT loss, 0 = loss
+		if game.HomeTeam.Score > game.AwayTeam.Score {
+			labels[i] = 1.0
+		} else if game.WinType == "OT" || game.WinType == "SO" {
+			labels[i] = 2.0
+		}
+	}
+
+	for _, fold := range te.folds {
+		model := &RandomForestModel{
+			numTrees:       int(params["numTrees"]),
+			maxDepth:       int(params["maxDepth"]),
+			minSamplesLeaf: int(params["minSamplesLeaf"]),
+			maxFeatures:    int(params["maxFeatures"]),
+			quiet:          true,
+		}
+		model.fit(te.features[fold.TrainStart:fold.TrainEnd], labels[fold.TrainStart:fold.TrainEnd])
+
+		for i := fold.TestStart; i < fold.TestEnd; i++ {
+			_, probs[i] = model.vote(te.features[i])
+		}
+	}
+	return probs
+}
+
+// eloProbabilities replays every game through a fresh Elo model, predicting
+// each game before its result is applied. Because the model only ever sees
+// earlier games, one pass gives the same scores as refitting per fold.
+func (te *tuningEvaluator) eloProbabilities(params map[string]float64) []float64 {
+	probs := make([]float64, len(te.games))
+	te.replayElo(params, func(i int, elo *EloRatingModel) {
+		home := elo.getTeamRating(te.games[i].HomeTeam.TeamCode)
+		away := elo.getTeamRating(te.games[i].AwayTeam.TeamCode)
+		probs[i] = elo.calculateWinProbability(home+elo.homeAdvantage, away)
+	})
+	return probs
+}
+
+// replayElo runs every game through a fresh Elo model, calling visit with the
+// model as it stood before each game's result is applied
+func (te *tuningEvaluator) replayElo(params map[string]float64, visit func(i int, elo *EloRatingModel)) {
+	elo := &EloRatingModel{
+		teamRatings:       make(map[string]float64),
+		ratingHistory:     make(map[string][]RatingRecord),
+		processedGames:    make(map[int]bool),
+		confidenceFactors: make(map[string]float64),
+		initialRating:     1500.0,
+		kFactor:           params["kFactor"],
+		homeAdvantage:     params["homeAdvantage"],
+		seasonDecayRate:   params["seasonDecayRate"],
+		quiet:             true,
+	}
+
+	season := 0
+	for i, game := range te.games {
+		// Regress ratings toward the mean between seasons
+		if game.Season != 0 && season != 0 && game.Season != season {
+			for team, rating := range elo.teamRatings {
+				elo.teamRatings[team] = elo.initialRating + (rating-elo.initialRating)*elo.seasonDecayRate
+			}
+		}
+		if game.Season != 0 {
+			season = game.Season
+		}
+
+		visit(i, elo)
+		elo.processGameResult(tuningGameResult(game))
+	}
+}
+
+// poissonProbabilities replays every game through a fresh Poisson model the same way
+func (te *tuningEvaluator) poissonProbabilities(params map[string]float64) []float64 {
+	probs := make([]float64, len(te.games))
+	te.replayPoisson(params, func(i int, pr *PoissonRegressionModel) {
+		homeCode, awayCode := te.games[i].HomeTeam.TeamCode, te.games[i].AwayTeam.TeamCode
+		homeExpected := pr.getOffensiveRate(homeCode) * pr.getDefensiveRate(awayCode) * pr.leagueAvgGoalsPerGame * pr.homeAdvantage
+		awayExpected := pr.getOffensiveRate(awayCode) * pr.getDefensiveRate(homeCode) * pr.leagueAvgGoalsPerGame
+		probs[i] = poissonHomeWinProbability(homeExpected, awayExpected)
+	})
+	return probs
+}
+
+// replayPoisson runs every game through a fresh Poisson model, calling visit
+// with the model as it stood before each game's result is applied
+func (te *tuningEvaluator) replayPoisson(params map[string]float64, visit func(i int, pr *PoissonRegressionModel)) {
+	pr := &PoissonRegressionModel{
+		leagueAvgGoalsPerGame: 3.1,
+		teamOffensiveRates:    make(map[string]float64),
+		teamDefensiveRates:    make(map[string]float64),
+		rateHistory:           make(map[string][]RateRecord),
+		confidenceTracking:    make(map[string]float64),
+		homeAdvantage:         params["homeAdvantage"],
+		learningRate:          params["learningRate"],
+		seasonDecayRate:       params["seasonDecayRate"],
+		quiet:                 true,
+	}
+
+	season := 0
+	for i, game := range te.games {
+		// Regress rates toward league average between seasons
+		if game.Season != 0 && season != 0 && game.Season != season {
+			for team, rate := range pr.teamOffensiveRates {
+				pr.teamOffensiveRates[team] = 1.0 + (rate-1.0)*pr.seasonDecayRate
+			}
+			for team, rate := range pr.teamDefensiveRates {
+				pr.teamDefensiveRates[team] = 1.0 + (rate-1.0)*pr.seasonDecayRate
+			}
+		}
+		if game.Season != 0 {
+			season = game.Season
+		}
+
+		visit(i, pr)
+		pr.processGameResult(tuningGameResult(game))
+	}
+}
+
+// poissonHomeWinProbability is the exact P(home wins) for independent Poisson
+// scores, with regulation ties split evenly as a stand-in for OT/shootouts.
+// Used instead of the Monte Carlo estimate so replays stay fast and deterministic.
+func poissonHomeWinProbability(homeExpected, awayExpected float64) float64 {
+	const maxGoals = 15
+	pmf := func(lambda float64) []float64 {
+		p := make([]float64, maxGoals+1)
+		p[0] = math.Exp(-lambda)
+		for k := 1; k <= maxGoals; k++ {
+			p[k] = p[k-1] * lambda / float64(k)
+		}
+		return p
+	}
+
+	home, away := pmf(homeExpected), pmf(awayExpected)
+	win, tie := 0.0, 0.0
+	for h := 0; h <= maxGoals; h++ {
+		for a := 0; a <= maxGoals; a++ {
+			if h > a {
+				win += home[h] * away[a]
+			} else if h == a {
+				tie += home[h] * away[a]
+			}
+		}
+	}
+	return win + 0.5*tie
+}
+
+// tuningGameResult converts a stored completed game into the live-update format
+func tuningGameResult(game models.CompletedGame) *models.GameResult {
+	return &models.GameResult{
+		GameID:      game.GameID,
+		HomeTeam:    game.HomeTeam.TeamCode,
+		AwayTeam:    game.AwayTeam.TeamCode,
+		HomeScore:   game.HomeTeam.Score,
+		AwayScore:   game.AwayTeam.Score,
+		GameState:   "FINAL",
+		GameDate:    game.GameDate,
+		IsOvertime:  game.WinType == "OT",
+		IsShootout:  game.WinType == "SO",
+		WinningTeam: game.Winner,
+	}
+}
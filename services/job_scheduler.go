@@ -0,0 +1,498 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// maxJobHistory is how many runs are kept per job
+const maxJobHistory = 50
+
+// Job run triggers and outcomes
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerStartup  = "startup"
+	JobTriggerCatchUp  = "catch-up"
+	JobTriggerManual   = "manual"
+
+	JobRunSuccess = "success"
+	JobRunFailed  = "failed"
+	JobRunSkipped = "skipped"
+)
+
+var (
+	// ErrJobNotFound is returned for an unregistered job name
+	ErrJobNotFound = errors.New("job not found")
+
+	// ErrJobRunning is returned when triggering a job that is already running
+	ErrJobRunning = errors.New("job already running")
+)
+
+// ScheduledJob describes a recurring background job run by the JobScheduler
+type ScheduledJob struct {
+	Name        string
+	Description string
+	Schedule    string        // Cron expression, @daily style shorthand or "@every 30m" (see ParseJobSchedule)
+	Jitter      time.Duration // Random delay up to this long added to each scheduled run
+	Timeout     time.Duration // Bounds a single run; zero means only shutdown stops it
+	RunOnStart  bool          // Run once when the scheduler starts, after StartDelay
+	StartDelay  time.Duration
+	CatchUp     bool // Run once at start if a scheduled run was missed while the server was down
+	Run         func(ctx context.Context) error
+}
+
+// JobRun records one execution of a job
+type JobRun struct {
+	Job        string    `json:"job"`
+	Trigger    string    `json:"trigger"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	DurationMs int64     `json:"durationMs"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// JobStatus reports a job's definition, state and recent runs
+type JobStatus struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Schedule    string     `json:"schedule"`
+	Paused      bool       `json:"paused"`
+	Running     bool       `json:"running"`
+	NextRun     *time.Time `json:"nextRun,omitempty"`
+	LastRun     *JobRun    `json:"lastRun,omitempty"`
+	TotalRuns   int        `json:"totalRuns"`
+	Failures    int        `json:"failures"`
+	RecentRuns  []JobRun   `json:"recentRuns"`
+}
+
+// jobState is the persisted part of a job's state
+type jobState struct {
+	Paused    bool      `json:"paused"`
+	LastRunAt time.Time `json:"lastRunAt"`
+	TotalRuns int       `json:"totalRuns"`
+	Failures  int       `json:"failures"`
+	History   []JobRun  `json:"history"`
+}
+
+type managedJob struct {
+	ScheduledJob
+	schedule JobSchedule
+	state    *jobState
+	running  bool
+	nextRun  time.Time
+}
+
+// JobScheduler runs registered jobs on their schedules. Each job runs at most
+// once at a time; a scheduled run that comes due while the previous one is
+// still going is skipped and recorded. Run history and pause state are
+// persisted so missed runs can be caught up after a restart.
+type JobScheduler struct {
+	jobs    []*managedJob
+	byName  map[string]*managedJob
+	states  map[string]*jobState
+	dataDir string
+	started bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mutex   sync.RWMutex
+}
+
+var (
+	jobScheduler     *JobScheduler
+	jobSchedulerOnce sync.Once
+)
+
+// GetJobScheduler returns the job scheduler singleton
+func GetJobScheduler() *JobScheduler {
+	jobSchedulerOnce.Do(func() {
+		dataDir := "data/scheduler"
+		os.MkdirAll(dataDir, 0755)
+
+		jobScheduler = &JobScheduler{
+			byName:  make(map[string]*managedJob),
+			states:  make(map[string]*jobState),
+			dataDir: dataDir,
+		}
+		jobScheduler.loadState()
+	})
+	return jobScheduler
+}
+
+// Register adds a job. Jobs registered after Start begin running immediately.
+func (js *JobScheduler) Register(job ScheduledJob) error {
+	if job.Name == "" {
+		return fmt.Errorf("scheduled job must have a name")
+	}
+	if job.Run == nil {
+		return fmt.Errorf("scheduled job %s has no Run function", job.Name)
+	}
+	schedule, err := ParseJobSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("scheduled job %s: %w", job.Name, err)
+	}
+
+	js.mutex.Lock()
+	defer js.mutex.Unlock()
+
+	if _, exists := js.byName[job.Name]; exists {
+		return fmt.Errorf("scheduled job %s already registered", job.Name)
+	}
+	state, ok := js.states[job.Name]
+	if !ok {
+		state = &jobState{}
+		js.states[job.Name] = state
+	}
+
+	mj := &managedJob{ScheduledJob: job, schedule: schedule, state: state}
+	js.jobs = append(js.jobs, mj)
+	js.byName[job.Name] = mj
+
+	if js.started {
+		js.startJobLocked(mj)
+	}
+	return nil
+}
+
+// MustRegister registers a job and panics on error
+func (js *JobScheduler) MustRegister(job ScheduledJob) {
+	if err := js.Register(job); err != nil {
+		panic(err)
+	}
+}
+
+// Start begins running every registered job on its schedule. Jobs stop when
+// Stop is called or the background context is cancelled.
+func (js *JobScheduler) Start() {
+	js.mutex.Lock()
+	defer js.mutex.Unlock()
+
+	if js.started {
+		return
+	}
+	js.started = true
+	js.ctx, js.cancel = context.WithCancel(BackgroundContext())
+
+	for _, mj := range js.jobs {
+		js.startJobLocked(mj)
+	}
+	log.Printf("⏰ Job scheduler started with %d jobs", len(js.jobs))
+}
+
+// Stop cancels all jobs and waits for running ones to return, or until ctx
+// is done
+func (js *JobScheduler) Stop(ctx context.Context) error {
+	js.mutex.Lock()
+	if !js.started {
+		js.mutex.Unlock()
+		return nil
+	}
+	js.started = false
+	js.cancel()
+	js.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		js.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Printf("⏹️ Job scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("jobs still running at shutdown: %w", ctx.Err())
+	}
+}
+
+// IsRunning reports whether the scheduler has been started and not stopped
+func (js *JobScheduler) IsRunning() bool {
+	js.mutex.RLock()
+	defer js.mutex.RUnlock()
+	return js.started
+}
+
+// Trigger runs a job now in the background, even if it is paused
+func (js *JobScheduler) Trigger(name string) error {
+	js.mutex.Lock()
+	mj, ok := js.byName[name]
+	if !ok {
+		js.mutex.Unlock()
+		return ErrJobNotFound
+	}
+	if mj.running {
+		js.mutex.Unlock()
+		return ErrJobRunning
+	}
+	ctx := js.ctx
+	if ctx == nil {
+		ctx = BackgroundContext()
+	}
+	js.wg.Add(1)
+	js.mutex.Unlock()
+
+	go func() {
+		defer js.wg.Done()
+		js.runJob(ctx, mj, JobTriggerManual)
+	}()
+	return nil
+}
+
+// Pause stops a job's scheduled runs until Resume. The pause is persisted.
+func (js *JobScheduler) Pause(name string) error {
+	return js.setPaused(name, true)
+}
+
+// Resume re-enables a paused job's scheduled runs
+func (js *JobScheduler) Resume(name string) error {
+	return js.setPaused(name, false)
+}
+
+func (js *JobScheduler) setPaused(name string, paused bool) error {
+	js.mutex.Lock()
+	defer js.mutex.Unlock()
+
+	mj, ok := js.byName[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+	mj.state.Paused = paused
+	js.saveStateLocked()
+
+	if paused {
+		log.Printf("⏸️ Paused job %s", name)
+	} else {
+		log.Printf("▶️ Resumed job %s", name)
+	}
+	return nil
+}
+
+// Status returns every job's status in registration order
+func (js *JobScheduler) Status() []JobStatus {
+	js.mutex.RLock()
+	defer js.mutex.RUnlock()
+
+	statuses := make([]JobStatus, 0, len(js.jobs))
+	for _, mj := range js.jobs {
+		statuses = append(statuses, js.jobStatusLocked(mj))
+	}
+	return statuses
+}
+
+// JobStatus returns one job's status
+func (js *JobScheduler) JobStatus(name string) (JobStatus, error) {
+	js.mutex.RLock()
+	defer js.mutex.RUnlock()
+
+	mj, ok := js.byName[name]
+	if !ok {
+		return JobStatus{}, ErrJobNotFound
+	}
+	return js.jobStatusLocked(mj), nil
+}
+
+func (js *JobScheduler) jobStatusLocked(mj *managedJob) JobStatus {
+	status := JobStatus{
+		Name:        mj.Name,
+		Description: mj.Description,
+		Schedule:    mj.Schedule,
+		Paused:      mj.state.Paused,
+		Running:     mj.running,
+		TotalRuns:   mj.state.TotalRuns,
+		Failures:    mj.state.Failures,
+		RecentRuns:  make([]JobRun, len(mj.state.History)),
+	}
+	// Newest first
+	for i, run := range mj.state.History {
+		status.RecentRuns[len(mj.state.History)-1-i] = run
+	}
+	if len(status.RecentRuns) > 0 {
+		last := status.RecentRuns[0]
+		status.LastRun = &last
+	}
+	if !mj.nextRun.IsZero() && js.started {
+		next := mj.nextRun
+		status.NextRun = &next
+	}
+	return status
+}
+
+// startJobLocked starts a job's loop; callers hold the lock
+func (js *JobScheduler) startJobLocked(mj *managedJob) {
+	trigger := ""
+	if mj.CatchUp && !mj.state.LastRunAt.IsZero() {
+		if missed := mj.schedule.Next(mj.state.LastRunAt); !missed.IsZero() && missed.Before(time.Now()) {
+			trigger = JobTriggerCatchUp
+			log.Printf("⏰ Job %s missed a run at %s, catching up", mj.Name, missed.Format("2006-01-02 15:04"))
+		}
+	}
+	if trigger == "" && mj.RunOnStart {
+		trigger = JobTriggerStartup
+	}
+
+	js.wg.Add(1)
+	go js.loop(js.ctx, mj, trigger)
+}
+
+// loop runs a job on its schedule until ctx is cancelled
+func (js *JobScheduler) loop(ctx context.Context, mj *managedJob, startTrigger string) {
+	defer js.wg.Done()
+
+	if startTrigger != "" {
+		if sleepContext(ctx, mj.StartDelay) != nil {
+			return
+		}
+		if js.isPaused(mj) {
+			log.Printf("⏸️ Skipping %s run of paused job %s", startTrigger, mj.Name)
+		} else {
+			js.runJob(ctx, mj, startTrigger)
+		}
+	}
+
+	for {
+		next := mj.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("⚠️ Job %s has no upcoming runs", mj.Name)
+			return
+		}
+		if mj.Jitter > 0 {
+			next = next.Add(time.Duration(rand.Int63n(int64(mj.Jitter))))
+		}
+
+		js.mutex.Lock()
+		mj.nextRun = next
+		js.mutex.Unlock()
+
+		if sleepContext(ctx, time.Until(next)) != nil {
+			return
+		}
+
+		if js.isPaused(mj) {
+			continue
+		}
+		js.runJob(ctx, mj, JobTriggerSchedule)
+	}
+}
+
+// isPaused reports whether a job's automatic runs are paused
+func (js *JobScheduler) isPaused(mj *managedJob) bool {
+	js.mutex.RLock()
+	defer js.mutex.RUnlock()
+	return mj.state.Paused
+}
+
+// runJob runs a job once, unless it is already running, and records the run
+func (js *JobScheduler) runJob(ctx context.Context, mj *managedJob, trigger string) {
+	js.mutex.Lock()
+	if mj.running {
+		js.recordRunLocked(mj, JobRun{
+			Job:        mj.Name,
+			Trigger:    trigger,
+			StartedAt:  time.Now(),
+			FinishedAt: time.Now(),
+			Status:     JobRunSkipped,
+			Error:      "previous run still in progress",
+		})
+		js.mutex.Unlock()
+		log.Printf("⏭️ Skipping %s run of %s: previous run still in progress", trigger, mj.Name)
+		return
+	}
+	mj.running = true
+	js.mutex.Unlock()
+
+	runCtx := ctx
+	if mj.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, mj.Timeout)
+		defer cancel()
+	}
+
+	log.Printf("⏰ Running job %s (%s)", mj.Name, trigger)
+	start := time.Now()
+	err := js.callJob(runCtx, mj)
+	finished := time.Now()
+
+	run := JobRun{
+		Job:        mj.Name,
+		Trigger:    trigger,
+		StartedAt:  start,
+		FinishedAt: finished,
+		DurationMs: finished.Sub(start).Milliseconds(),
+		Status:     JobRunSuccess,
+	}
+	if err != nil {
+		run.Status = JobRunFailed
+		run.Error = err.Error()
+		log.Printf("❌ Job %s failed after %v: %v", mj.Name, finished.Sub(start).Round(time.Millisecond), err)
+	} else {
+		log.Printf("✅ Job %s finished in %v", mj.Name, finished.Sub(start).Round(time.Millisecond))
+	}
+
+	js.mutex.Lock()
+	mj.running = false
+	mj.state.LastRunAt = start
+	js.recordRunLocked(mj, run)
+	js.mutex.Unlock()
+}
+
+// callJob calls the job's Run, recovering a panic as an error
+func (js *JobScheduler) callJob(ctx context.Context, mj *managedJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return mj.Run(ctx)
+}
+
+// recordRunLocked appends a run to the job's history and persists it;
+// callers hold the lock
+func (js *JobScheduler) recordRunLocked(mj *managedJob, run JobRun) {
+	if run.Status != JobRunSkipped {
+		mj.state.TotalRuns++
+	}
+	if run.Status == JobRunFailed {
+		mj.state.Failures++
+	}
+	mj.state.History = append(mj.state.History, run)
+	if len(mj.state.History) > maxJobHistory {
+		mj.state.History = mj.state.History[len(mj.state.History)-maxJobHistory:]
+	}
+	js.saveStateLocked()
+}
+
+// saveStateLocked persists every job's state; callers hold the lock
+func (js *JobScheduler) saveStateLocked() {
+	data, err := json.MarshalIndent(js.states, "", "  ")
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal job scheduler state: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(js.dataDir, "jobs.json"), data, 0644); err != nil {
+		log.Printf("⚠️ Failed to save job scheduler state: %v", err)
+	}
+}
+
+// loadState restores job state from disk. State for jobs that are no longer
+// registered is kept so it survives a deploy that temporarily drops them.
+func (js *JobScheduler) loadState() {
+	data, err := ioutil.ReadFile(filepath.Join(js.dataDir, "jobs.json"))
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &js.states); err != nil {
+		log.Printf("⚠️ Failed to parse job scheduler state: %v", err)
+		js.states = make(map[string]*jobState)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -81,21 +82,13 @@ func (lps *LandingPageService) FetchLandingPageData(gameID int) (*models.Landing
 	// Fetch from NHL API
 	log.Printf("📥 Fetching landing page data for game %d from NHL API...", gameID)
 
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/gamecenter/%d/landing", gameID)
-
-	// Use MakeAPICall for caching and rate limiting
-	body, err := MakeAPICall(url)
+	apiResp, err := GetNHLAPIClient().Landing(context.Background(), gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch landing page data: %w", err)
 	}
 
-	var apiResp models.LandingPageResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode landing page data: %w", err)
-	}
-
 	// Analyze the landing page data
-	analytics := lps.analyzeLandingPage(&apiResp)
+	analytics := lps.analyzeLandingPage(apiResp)
 
 	// Cache the results
 	lps.cacheMu.Lock()
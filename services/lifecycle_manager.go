@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// defaultStopTimeout bounds a component's Stop when it doesn't set its own
+const defaultStopTimeout = 10 * time.Second
+
+// Lifecycle states for the manager and its components
+const (
+	LifecycleRegistered  = "registered"
+	LifecycleStarting    = "starting"
+	LifecycleRunning     = "running"
+	LifecycleFailed      = "failed"
+	LifecycleStopping    = "stopping"
+	LifecycleStopped     = "stopped"
+	LifecycleStopTimeout = "stop_timeout"
+)
+
+// LifecycleComponent is a background service the LifecycleManager starts and
+// stops. Start, Stop and Health are all optional: a component with only Stop
+// is a shutdown hook (e.g. flushing a cache), one with only Start is fire and
+// forget work that exits on its own when the background context is cancelled.
+type LifecycleComponent struct {
+	Name        string
+	Start       func() error
+	Stop        func(ctx context.Context) error
+	Health      func() error  // Returns an error when a running component is unhealthy
+	StopTimeout time.Duration // Defaults to defaultStopTimeout
+	Required    bool          // A failed start aborts startup instead of being logged
+}
+
+// ComponentStatus reports one component's state
+type ComponentStatus struct {
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	Healthy   bool       `json:"healthy"`
+	Error     string     `json:"error,omitempty"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	StoppedAt *time.Time `json:"stoppedAt,omitempty"`
+	StopMs    int64      `json:"stopMs,omitempty"`
+}
+
+// LifecycleStatus reports the manager's state and every component's status
+// in start order
+type LifecycleStatus struct {
+	State      string            `json:"state"`
+	Components []ComponentStatus `json:"components"`
+	Healthy    int               `json:"healthy"`
+	Unhealthy  int               `json:"unhealthy"`
+}
+
+type managedComponent struct {
+	LifecycleComponent
+	state     string
+	err       error
+	startedAt time.Time
+	stoppedAt time.Time
+	stopTime  time.Duration
+}
+
+// LifecycleManager starts background services in registration order and stops
+// them in reverse, each within its own timeout. Components are registered in
+// the ServiceContainer and driven through its OnInit/OnShutdown callbacks.
+type LifecycleManager struct {
+	container  *ServiceContainer
+	components []*managedComponent
+	state      string
+	stopCtx    context.Context
+	mutex      sync.RWMutex
+}
+
+var (
+	lifecycleManager     *LifecycleManager
+	lifecycleManagerOnce sync.Once
+)
+
+// GetLifecycleManager returns the lifecycle manager singleton
+func GetLifecycleManager() *LifecycleManager {
+	lifecycleManagerOnce.Do(func() {
+		lifecycleManager = &LifecycleManager{
+			container: InitServiceContainer(),
+			state:     LifecycleRegistered,
+			stopCtx:   context.Background(),
+		}
+	})
+	return lifecycleManager
+}
+
+// Register adds a component. Components start in the order they are
+// registered and stop in reverse, so register dependencies first.
+func (lm *LifecycleManager) Register(component LifecycleComponent) error {
+	if component.Name == "" {
+		return fmt.Errorf("lifecycle component must have a name")
+	}
+	if component.StopTimeout <= 0 {
+		component.StopTimeout = defaultStopTimeout
+	}
+
+	lm.mutex.Lock()
+	if lm.state != LifecycleRegistered {
+		lm.mutex.Unlock()
+		return fmt.Errorf("cannot register %s: lifecycle already %s", component.Name, lm.state)
+	}
+	mc := &managedComponent{LifecycleComponent: component, state: LifecycleRegistered}
+	lm.mutex.Unlock()
+
+	if err := lm.container.Register("lifecycle:"+component.Name, mc); err != nil {
+		return err
+	}
+
+	lm.mutex.Lock()
+	lm.components = append(lm.components, mc)
+	lm.mutex.Unlock()
+
+	lm.container.OnInit(func() error { return lm.startComponent(mc) })
+	lm.container.OnShutdown(func() error { return lm.stopComponent(mc) })
+	return nil
+}
+
+// MustRegister registers a component and panics on error
+func (lm *LifecycleManager) MustRegister(component LifecycleComponent) {
+	if err := lm.Register(component); err != nil {
+		panic(err)
+	}
+}
+
+// StartAll starts every registered component in order. A component that
+// fails to start is logged and marked failed; only a Required component's
+// failure stops startup and is returned.
+func (lm *LifecycleManager) StartAll() error {
+	lm.mutex.Lock()
+	if lm.state != LifecycleRegistered {
+		state := lm.state
+		lm.mutex.Unlock()
+		return fmt.Errorf("lifecycle already %s", state)
+	}
+	lm.state = LifecycleStarting
+	count := len(lm.components)
+	lm.mutex.Unlock()
+
+	log.Printf("🚦 Starting %d background services...", count)
+	err := lm.container.RunInitCallbacks()
+
+	lm.mutex.Lock()
+	if err != nil {
+		lm.state = LifecycleFailed
+	} else {
+		lm.state = LifecycleRunning
+	}
+	lm.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ Background services started")
+	return nil
+}
+
+// StopAll stops components in reverse start order. Each Stop gets its own
+// timeout, cut short by ctx's deadline; a component that doesn't stop in
+// time is abandoned so the rest still get their turn.
+func (lm *LifecycleManager) StopAll(ctx context.Context) error {
+	lm.mutex.Lock()
+	if lm.state == LifecycleStopping || lm.state == LifecycleStopped {
+		lm.mutex.Unlock()
+		return nil
+	}
+	lm.state = LifecycleStopping
+	lm.stopCtx = ctx
+	lm.mutex.Unlock()
+
+	log.Printf("🛑 Stopping background services...")
+	err := lm.container.RunShutdownCallbacks()
+
+	lm.mutex.Lock()
+	lm.state = LifecycleStopped
+	lm.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ Background services stopped")
+	return nil
+}
+
+// IsStopping reports whether shutdown has begun
+func (lm *LifecycleManager) IsStopping() bool {
+	lm.mutex.RLock()
+	defer lm.mutex.RUnlock()
+	return lm.state == LifecycleStopping || lm.state == LifecycleStopped
+}
+
+// Status reports the manager's state and runs each running component's
+// health check
+func (lm *LifecycleManager) Status() LifecycleStatus {
+	lm.mutex.RLock()
+	components := make([]*managedComponent, len(lm.components))
+	copy(components, lm.components)
+	status := LifecycleStatus{State: lm.state}
+	lm.mutex.RUnlock()
+
+	for _, mc := range components {
+		cs := lm.componentStatus(mc)
+		if cs.Healthy {
+			status.Healthy++
+		} else {
+			status.Unhealthy++
+		}
+		status.Components = append(status.Components, cs)
+	}
+	return status
+}
+
+func (lm *LifecycleManager) componentStatus(mc *managedComponent) ComponentStatus {
+	lm.mutex.RLock()
+	cs := ComponentStatus{
+		Name:   mc.Name,
+		State:  mc.state,
+		StopMs: mc.stopTime.Milliseconds(),
+	}
+	if mc.err != nil {
+		cs.Error = mc.err.Error()
+	}
+	if !mc.startedAt.IsZero() {
+		startedAt := mc.startedAt
+		cs.StartedAt = &startedAt
+	}
+	if !mc.stoppedAt.IsZero() {
+		stoppedAt := mc.stoppedAt
+		cs.StoppedAt = &stoppedAt
+	}
+	health := mc.Health
+	lm.mutex.RUnlock()
+
+	switch cs.State {
+	case LifecycleRunning:
+		cs.Healthy = true
+		if health != nil {
+			if err := health(); err != nil {
+				cs.Healthy = false
+				cs.Error = err.Error()
+			}
+		}
+	case LifecycleRegistered, LifecycleStarting, LifecycleStopped:
+		cs.Healthy = true
+	}
+	return cs
+}
+
+// startComponent runs a component's Start, recovering a panic as a failure
+func (lm *LifecycleManager) startComponent(mc *managedComponent) (err error) {
+	lm.setState(mc, LifecycleStarting, nil)
+
+	if mc.Start != nil {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			err = mc.Start()
+		}()
+	}
+
+	if err != nil {
+		lm.setState(mc, LifecycleFailed, err)
+		log.Printf("⚠️ Failed to start %s: %v", mc.Name, err)
+		if mc.Required {
+			return fmt.Errorf("%s: %w", mc.Name, err)
+		}
+		return nil
+	}
+
+	lm.mutex.Lock()
+	mc.startedAt = time.Now()
+	lm.mutex.Unlock()
+	lm.setState(mc, LifecycleRunning, nil)
+	log.Printf("▶️ Started %s", mc.Name)
+	return nil
+}
+
+// stopComponent runs a running component's Stop within its timeout
+func (lm *LifecycleManager) stopComponent(mc *managedComponent) error {
+	lm.mutex.RLock()
+	state := mc.state
+	parent := lm.stopCtx
+	lm.mutex.RUnlock()
+
+	if state != LifecycleRunning {
+		return nil
+	}
+	lm.setState(mc, LifecycleStopping, nil)
+
+	start := time.Now()
+	var err error
+	timedOut := false
+	if mc.Stop != nil {
+		ctx, cancel := context.WithTimeout(parent, mc.StopTimeout)
+		done := make(chan error, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					done <- fmt.Errorf("panic: %v", r)
+				}
+			}()
+			done <- mc.Stop(ctx)
+		}()
+
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			timedOut = true
+			err = fmt.Errorf("did not stop within %v", mc.StopTimeout)
+		}
+		cancel()
+	}
+	elapsed := time.Since(start)
+
+	lm.mutex.Lock()
+	mc.stoppedAt = time.Now()
+	mc.stopTime = elapsed
+	lm.mutex.Unlock()
+
+	if err != nil {
+		state := LifecycleStopped
+		if timedOut {
+			state = LifecycleStopTimeout
+		}
+		lm.setState(mc, state, err)
+		log.Printf("⚠️ %s: %v", mc.Name, err)
+		return fmt.Errorf("%s: %w", mc.Name, err)
+	}
+
+	lm.setState(mc, LifecycleStopped, nil)
+	log.Printf("⏹️ Stopped %s (%v)", mc.Name, elapsed.Round(time.Millisecond))
+	return nil
+}
+
+func (lm *LifecycleManager) setState(mc *managedComponent, state string, err error) {
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+	mc.state = state
+	mc.err = err
+}
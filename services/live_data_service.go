@@ -366,8 +366,12 @@ func (lds *LiveDataService) periodicUpdateScheduler() {
 	dailyTicker := time.NewTicker(24 * time.Hour)
 	defer dailyTicker.Stop()
 
+	done := BackgroundContext().Done()
+
 	for {
 		select {
+		case <-done:
+			return
 		case <-hourlyTicker.C:
 			if lds.isRunning {
 				lds.scheduleHourlyUpdates()
@@ -456,7 +460,15 @@ func (lds *LiveDataService) cacheCleanupRoutine() {
 	ticker := time.NewTicker(30 * time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	done := BackgroundContext().Done()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
 		if !lds.isRunning {
 			break
 		}
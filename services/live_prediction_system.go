@@ -103,6 +103,11 @@ func (lps *LivePredictionSystem) Stop() error {
 	return nil
 }
 
+// IsRunning reports whether the system has been started and not stopped
+func (lps *LivePredictionSystem) IsRunning() bool {
+	return lps.isRunning
+}
+
 // scheduleInitialUpdates triggers initial data collection
 func (lps *LivePredictionSystem) scheduleInitialUpdates() {
 	log.Printf("📋 Scheduling initial data updates...")
@@ -222,6 +227,16 @@ func (lps *LivePredictionSystem) GetNeuralNetwork() *NeuralNetworkModel {
 	return lps.neuralNet
 }
 
+// GetModelScheduler returns the model update scheduler
+func (lps *LivePredictionSystem) GetModelScheduler() *ModelUpdateScheduler {
+	return lps.modelScheduler
+}
+
+// GetLiveDataService returns the live data service
+func (lps *LivePredictionSystem) GetLiveDataService() *LiveDataService {
+	return lps.liveDataService
+}
+
 // GetEnsemble returns the ensemble service
 func (lps *LivePredictionSystem) GetEnsemble() *EnsemblePredictionService {
 	return lps.ensembleService
@@ -230,14 +245,16 @@ func (lps *LivePredictionSystem) GetEnsemble() *EnsemblePredictionService {
 // Global instance for the live prediction system
 var globalLivePredictionSystem *LivePredictionSystem
 
-// InitializeLivePredictionSystem initializes the global live prediction system
+// InitializeLivePredictionSystem creates the global live prediction system.
+// Its data service and model scheduler are started separately with Start,
+// once the services that depend on its models have been wired up.
 func InitializeLivePredictionSystem(teamCode string) error {
 	if globalLivePredictionSystem != nil {
 		return fmt.Errorf("live prediction system already initialized")
 	}
 
 	globalLivePredictionSystem = NewLivePredictionSystem(teamCode)
-	return globalLivePredictionSystem.Start()
+	return nil
 }
 
 // GetLivePredictionSystem returns the global live prediction system instance
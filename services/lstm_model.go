@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -49,15 +50,25 @@ type LSTMModel struct {
 	lastUpdated time.Time
 	mutex       sync.RWMutex
 
-	// Cached sequences for training
-	gameSequences []GameSequence
+	// Each team's completed games (oldest first), used to build inference sequences
+	teamHistory map[string][]models.CompletedGame
 }
 
+// LSTM training settings
+const (
+	lstmMaxEpochs          = 50
+	lstmBatchSize          = 32
+	lstmPatience           = 5   // Epochs without validation improvement before stopping
+	lstmClipNorm           = 5.0 // Global gradient norm limit
+	lstmValidationFraction = 0.15
+)
+
 // GameSequence represents a sequence of games for training
 type GameSequence struct {
 	Features [][]float64 // [sequenceLen][inputSize]
 	Label    float64     // Outcome (1.0 = win, 0.0 = loss, 0.5 = OT loss)
 	TeamCode string
+	GameDate time.Time `json:"gameDate,omitempty"` // Date of the game being predicted
 }
 
 // LSTMState represents the hidden and cell state at a timestep
@@ -80,16 +91,16 @@ func NewLSTMModel() *LSTMModel {
 		sequenceLen := 10 // Last 10 games
 
 		lstmModelInstance = &LSTMModel{
-			inputSize:     inputSize,
-			hiddenSize:    hiddenSize,
-			outputSize:    outputSize,
-			sequenceLen:   sequenceLen,
-			learningRate:  0.001,
-			weight:        0.08, // 8% weight in ensemble
-			trained:       false,
-			dataDir:       "data/models",
-			lastUpdated:   time.Now(),
-			gameSequences: []GameSequence{},
+			inputSize:    inputSize,
+			hiddenSize:   hiddenSize,
+			outputSize:   outputSize,
+			sequenceLen:  sequenceLen,
+			learningRate: 0.001,
+			weight:       0.08, // 8% weight in ensemble
+			trained:      false,
+			dataDir:      "data/models",
+			lastUpdated:  time.Now(),
+			teamHistory:  make(map[string][]models.CompletedGame),
 		}
 
 		// Create data directory
@@ -107,6 +118,11 @@ func NewLSTMModel() *LSTMModel {
 			log.Printf("   Hidden size: %d, Sequence length: %d", hiddenSize, sequenceLen)
 			log.Printf("   Last updated: %s", lstmModelInstance.lastUpdated.Format("2006-01-02 15:04:05"))
 		}
+
+		// Rebuild team histories so inference uses real game sequences
+		if games, err := loadCompletedGamesFrom("data/results"); err == nil {
+			lstmModelInstance.rebuildTeamHistory(games)
+		}
 	})
 
 	return lstmModelInstance
@@ -203,9 +219,32 @@ func (lstm *LSTMModel) Predict(homeFactors, awayFactors *models.PredictionFactor
 	}
 
 	// Get game sequences for both teams
-	homeSequence := lstm.extractSequence(homeFactors)
-	awaySequence := lstm.extractSequence(awayFactors)
+	homeSequence := lstm.teamSequence(homeFactors)
+	awaySequence := lstm.teamSequence(awayFactors)
+
+	winProb := lstm.sequenceWinProbability(homeSequence, awaySequence)
+
+	// Calculate confidence based on prediction strength
+	confidence := math.Abs(winProb-0.5) * 2.0
+	confidence = math.Max(0.40, math.Min(0.90, confidence))
 
+	// Predict score
+	predictedScore := lstm.predictScore(winProb, homeFactors, awayFactors)
+
+	result := &models.ModelResult{
+		ModelName:      "LSTM",
+		WinProbability: winProb,
+		Confidence:     confidence,
+		PredictedScore: predictedScore,
+		Weight:         lstm.weight,
+		ProcessingTime: time.Since(start).Milliseconds(),
+	}
+
+	return result, nil
+}
+
+// sequenceWinProbability compares the two teams' LSTM outputs to give the home win probability
+func (lstm *LSTMModel) sequenceWinProbability(homeSequence, awaySequence [][]float64) float64 {
 	// Run LSTM forward pass for both teams
 	homeOutput := lstm.forward(homeSequence)
 	awayOutput := lstm.forward(awaySequence)
@@ -221,32 +260,113 @@ func (lstm *LSTMModel) Predict(homeFactors, awayFactors *models.PredictionFactor
 	winProb += 0.05
 
 	// Ensure reasonable bounds
-	winProb = math.Max(0.35, math.Min(0.85, winProb))
+	return math.Max(0.35, math.Min(0.85, winProb))
+}
 
-	// Calculate confidence based on prediction strength
-	confidence := math.Abs(winProb-0.5) * 2.0
-	confidence = math.Max(0.40, math.Min(0.90, confidence))
+// lstmHistoryChannels and lstmFactorChannels name the per-game inputs built
+// by extractGameFeatures and the fallback extractSequence
+var (
+	lstmHistoryChannels = []string{
+		"Goals", "Goals Against", "Goal Diff", "Shots", "Shots Against",
+		"PP%", "PK%", "PIM", "Faceoff %", "Hits", "Blocks", "Giveaway Margin",
+		"Save %", "Opponent Save %", "Home Game", "Won", "Overtime",
+	}
+	lstmFactorChannels = []string{
+		"Momentum", "Weighted Win %", "Weighted GF", "Weighted GA", "Is Hot", "Is Cold",
+		"Last 5 Points", "Goal Diff 5", "Win %", "Goals For", "Goals Against",
+		"PP%", "PK%", "Star Power", "Top 3 Combined PPG", "Depth Scoring",
+		"Scoring Balance", "Goalie Advantage",
+	}
+)
 
-	// Predict score
-	predictedScore := lstm.predictScore(winProb, homeFactors, awayFactors)
+// Explain attributes the prediction to each team's input channels (one
+// per-game statistic across the whole sequence) by occlusion: a channel is
+// removed by zeroing it in every game, as the padding for short histories does
+func (lstm *LSTMModel) Explain(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionExplanation, error) {
+	lstm.mutex.RLock()
+	defer lstm.mutex.RUnlock()
 
-	result := &models.ModelResult{
-		ModelName:      "LSTM",
-		WinProbability: winProb,
-		Confidence:     confidence,
-		PredictedScore: predictedScore,
-		Weight:         lstm.weight,
-		ProcessingTime: time.Since(start).Milliseconds(),
+	if !lstm.trained {
+		return nil, fmt.Errorf("LSTM model not trained")
 	}
 
-	return result, nil
+	type channel struct {
+		home  bool
+		index int
+	}
+	var channels []channel
+	var names []string
+	sequences := map[bool][][]float64{
+		true:  lstm.teamSequence(homeFactors),
+		false: lstm.teamSequence(awayFactors),
+	}
+	for _, home := range []bool{true, false} {
+		factors, prefix := awayFactors, "Away "
+		if home {
+			factors, prefix = homeFactors, "Home "
+		}
+		labels := lstmFactorChannels
+		if len(lstm.teamHistory[factors.TeamCode]) > 0 {
+			labels = lstmHistoryChannels
+		}
+		for index := 0; index < lstm.inputSize && index < len(labels); index++ {
+			for _, step := range sequences[home] {
+				if step[index] != 0 {
+					channels = append(channels, channel{home, index})
+					names = append(names, prefix+"Recent "+labels[index])
+					break
+				}
+			}
+		}
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("no LSTM inputs to explain")
+	}
+
+	masked := func(home bool, present func(i int) bool) [][]float64 {
+		sequence := make([][]float64, len(sequences[home]))
+		for t, step := range sequences[home] {
+			sequence[t] = append([]float64(nil), step...)
+		}
+		for i, c := range channels {
+			if c.home == home && !present(i) {
+				for t := range sequence {
+					sequence[t][c.index] = 0
+				}
+			}
+		}
+		return sequence
+	}
+	value := func(present func(i int) bool) float64 {
+		return lstm.sequenceWinProbability(masked(true, present), masked(false, present))
+	}
+
+	phi := symmetricOcclusion(len(channels), value)
+	base := value(func(int) bool { return false })
+	prediction := value(func(int) bool { return true })
+	return newPredictionExplanation("occlusion", base, prediction, names, nil, phi), nil
+}
+
+// lstmStep caches one timestep of the forward pass for backpropagation
+type lstmStep struct {
+	x, hPrev, cPrev []float64
+	f, i, g, o      []float64 // Gate activations (g = candidate cell)
+	c, h            []float64
 }
 
 // forward performs LSTM forward pass on a sequence
 func (lstm *LSTMModel) forward(sequence [][]float64) []float64 {
+	output, _ := lstm.forwardCached(sequence)
+	return output
+}
+
+// forwardCached performs the forward pass and keeps every timestep's
+// activations for backpropagation through time
+func (lstm *LSTMModel) forwardCached(sequence [][]float64) ([]float64, []lstmStep) {
 	// Initialize hidden and cell states
 	h := make([]float64, lstm.hiddenSize)
 	c := make([]float64, lstm.hiddenSize)
+	steps := make([]lstmStep, 0, len(sequence))
 
 	// Process each timestep in the sequence
 	for t := 0; t < len(sequence); t++ {
@@ -261,18 +381,20 @@ func (lstm *LSTMModel) forward(sequence [][]float64) []float64 {
 		// Cell gate: c_tilde = tanh(Wc * [h_{t-1}, x_t] + bc)
 		cTilde := lstm.gate(lstm.Wc, x, h, lstm.bc, tanhActivation)
 
-		// Update cell state: c_t = f_t * c_{t-1} + i_t * c_tilde
-		for i := 0; i < lstm.hiddenSize; i++ {
-			c[i] = ft[i]*c[i] + it[i]*cTilde[i]
-		}
-
 		// Output gate: o_t = sigmoid(Wo * [h_{t-1}, x_t] + bo)
 		ot := lstm.gate(lstm.Wo, x, h, lstm.bo, sigmoid)
 
+		// Update cell state: c_t = f_t * c_{t-1} + i_t * c_tilde
 		// Update hidden state: h_t = o_t * tanh(c_t)
+		cNext := make([]float64, lstm.hiddenSize)
+		hNext := make([]float64, lstm.hiddenSize)
 		for i := 0; i < lstm.hiddenSize; i++ {
-			h[i] = ot[i] * tanhActivation(c[i])
+			cNext[i] = ft[i]*c[i] + it[i]*cTilde[i]
+			hNext[i] = ot[i] * tanhActivation(cNext[i])
 		}
+
+		steps = append(steps, lstmStep{x: x, hPrev: h, cPrev: c, f: ft, i: it, g: cTilde, o: ot, c: cNext, h: hNext})
+		h, c = hNext, cNext
 	}
 
 	// Output layer: y = softmax(Wy * h + by)
@@ -286,7 +408,7 @@ func (lstm *LSTMModel) forward(sequence [][]float64) []float64 {
 	}
 
 	// Apply softmax
-	return softmax(output)
+	return softmax(output), steps
 }
 
 // gate computes a single LSTM gate
@@ -441,7 +563,8 @@ func (lstm *LSTMModel) predictScore(winProb float64, homeFactors, awayFactors *m
 	return fmt.Sprintf("%d-%d", homeScore, awayScore)
 }
 
-// Train trains the LSTM model on game sequences
+// Train trains the LSTM model on game sequences with mini-batch BPTT,
+// stopping early when log loss on the most recent sequences stops improving
 func (lstm *LSTMModel) Train(games []models.CompletedGame) error {
 	lstm.mutex.Lock()
 	defer lstm.mutex.Unlock()
@@ -453,38 +576,66 @@ func (lstm *LSTMModel) Train(games []models.CompletedGame) error {
 	log.Printf("🔄 Training LSTM model on %d games...", len(games))
 	start := time.Now()
 
+	lstm.rebuildTeamHistory(games)
+
 	// Prepare sequences from games
 	sequences := lstm.prepareSequences(games)
 
-	if len(sequences) == 0 {
-		return fmt.Errorf("no valid sequences created from games")
+	if len(sequences) < 20 {
+		return fmt.Errorf("not enough sequences to train: %d", len(sequences))
+	}
+
+	// Hold out the most recent sequences for early stopping
+	sort.Slice(sequences, func(i, j int) bool {
+		return sequences[i].GameDate.Before(sequences[j].GameDate)
+	})
+	valSize := int(float64(len(sequences)) * lstmValidationFraction)
+	if valSize < 1 {
+		valSize = 1
 	}
+	trainSeqs := sequences[:len(sequences)-valSize]
+	valSeqs := sequences[len(sequences)-valSize:]
 
-	// Training epochs
-	epochs := 10
-	for epoch := 0; epoch < epochs; epoch++ {
-		totalLoss := 0.0
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	bestLoss := math.Inf(1)
+	var best *lstmGradients // Best parameters, stored in the same shape as gradients
+	epochsSinceBest := 0
 
-		for _, seq := range sequences {
-			loss := lstm.trainSequence(seq)
-			totalLoss += loss
+	for epoch := 0; epoch < lstmMaxEpochs; epoch++ {
+		trainLoss := lstm.trainEpoch(trainSeqs, rng)
+		valLoss := lstm.sequenceLoss(valSeqs)
+
+		if (epoch+1)%5 == 0 {
+			log.Printf("   Epoch %d/%d: Train Loss %.4f | Val Loss %.4f", epoch+1, lstmMaxEpochs, trainLoss, valLoss)
 		}
 
-		avgLoss := totalLoss / float64(len(sequences))
-		if (epoch+1)%2 == 0 {
-			log.Printf("   Epoch %d/%d: Loss %.4f", epoch+1, epochs, avgLoss)
+		if valLoss < bestLoss-1e-4 {
+			bestLoss = valLoss
+			best = lstm.copyParameters()
+			epochsSinceBest = 0
+		} else {
+			epochsSinceBest++
+			if epochsSinceBest >= lstmPatience {
+				log.Printf("   Early stopping at epoch %d (best val loss %.4f)", epoch+1, bestLoss)
+				break
+			}
 		}
 	}
 
+	if best != nil {
+		lstm.restoreParameters(best)
+	}
+
 	lstm.trained = true
 	lstm.lastUpdated = time.Now()
 
 	trainingTime := time.Since(start)
 	log.Printf("✅ LSTM training complete!")
-	log.Printf("   Sequences: %d | Time: %.1fs", len(sequences), trainingTime.Seconds())
+	log.Printf("   Sequences: %d train, %d validation | Val Loss: %.4f | Time: %.1fs",
+		len(trainSeqs), len(valSeqs), bestLoss, trainingTime.Seconds())
 
-	// Save the trained model
-	if err := lstm.saveModel(); err != nil {
+	// Save the trained weights (we already hold the lock)
+	if err := lstm.saveWeights(); err != nil {
 		log.Printf("⚠️ Failed to save LSTM weights: %v", err)
 	}
 
@@ -495,7 +646,7 @@ func (lstm *LSTMModel) Train(games []models.CompletedGame) error {
 func (lstm *LSTMModel) prepareSequences(games []models.CompletedGame) []GameSequence {
 	sequences := []GameSequence{}
 
-	// Group games by team
+	// Group games by team, oldest first
 	teamGames := make(map[string][]models.CompletedGame)
 	for _, game := range games {
 		teamGames[game.HomeTeam.TeamCode] = append(teamGames[game.HomeTeam.TeamCode], game)
@@ -507,6 +658,9 @@ func (lstm *LSTMModel) prepareSequences(games []models.CompletedGame) []GameSequ
 		if len(tGames) < lstm.sequenceLen+1 {
 			continue
 		}
+		sort.Slice(tGames, func(i, j int) bool {
+			return tGames[i].GameDate.Before(tGames[j].GameDate)
+		})
 
 		// Create sliding window sequences
 		for i := 0; i <= len(tGames)-lstm.sequenceLen-1; i++ {
@@ -525,6 +679,7 @@ func (lstm *LSTMModel) prepareSequences(games []models.CompletedGame) []GameSequ
 				Features: sequence,
 				Label:    label,
 				TeamCode: teamCode,
+				GameDate: nextGame.GameDate,
 			})
 		}
 	}
@@ -532,32 +687,50 @@ func (lstm *LSTMModel) prepareSequences(games []models.CompletedGame) []GameSequ
 	return sequences
 }
 
-// extractGameFeatures extracts features from a completed game
+// extractGameFeatures extracts normalized features for one team from a completed game
 func (lstm *LSTMModel) extractGameFeatures(game *models.CompletedGame, teamCode string) []float64 {
 	features := make([]float64, lstm.inputSize)
 
 	isHome := game.HomeTeam.TeamCode == teamCode
+	team, opponent := game.HomeTeam, game.AwayTeam
+	if !isHome {
+		team, opponent = game.AwayTeam, game.HomeTeam
+	}
 
-	idx := 0
+	values := []float64{
+		float64(team.Score) / 10.0,
+		float64(opponent.Score) / 10.0,
+		float64(team.Score-opponent.Score) / 5.0,
+		float64(team.Shots) / 40.0,
+		float64(opponent.Shots) / 40.0,
+		team.PowerPlayPct,
+		team.PenaltyKillPct,
+		float64(team.PenaltyMinutes) / 20.0,
+		team.FaceoffPct,
+		float64(team.Hits) / 40.0,
+		float64(team.Blocks) / 20.0,
+		float64(team.Giveaways-team.Takeaways) / 10.0,
+		team.SavePct,
+		opponent.SavePct,
+	}
 	if isHome {
-		features[idx] = float64(game.HomeTeam.Score)
-		idx++
-		features[idx] = float64(game.AwayTeam.Score)
-		idx++
+		values = append(values, 1.0)
 	} else {
-		features[idx] = float64(game.AwayTeam.Score)
-		idx++
-		features[idx] = float64(game.HomeTeam.Score)
-		idx++
+		values = append(values, 0.0)
 	}
-
-	// Add more features as available
-	// For now, pad with normalized values
-	for idx < lstm.inputSize {
-		features[idx] = 0.0
-		idx++
+	if team.Score > opponent.Score {
+		values = append(values, 1.0)
+	} else {
+		values = append(values, 0.0)
+	}
+	if game.WinType == "OT" || game.WinType == "SO" {
+		values = append(values, 1.0)
+	} else {
+		values = append(values, 0.0)
 	}
 
+	// Remaining features stay zero
+	copy(features, values)
 	return features
 }
 
@@ -578,41 +751,304 @@ func (lstm *LSTMModel) getGameLabel(game *models.CompletedGame, teamCode string)
 	return 0.0
 }
 
-// trainSequence trains on a single sequence using backpropagation through time
-func (lstm *LSTMModel) trainSequence(seq GameSequence) float64 {
-	// Forward pass
-	output := lstm.forward(seq.Features)
+// lstmGradients holds gradients (or a parameter copy) for every LSTM weight
+type lstmGradients struct {
+	Wf, Wi, Wo, Wc [2][]float64
+	bf, bi, bo, bc []float64
+	Wy             [][]float64
+	by             []float64
+}
+
+// newLSTMGradients allocates zeroed gradients matching the model's shapes
+func (lstm *LSTMModel) newLSTMGradients() *lstmGradients {
+	ih := lstm.hiddenSize * lstm.inputSize
+	hh := lstm.hiddenSize * lstm.hiddenSize
+	g := &lstmGradients{
+		bf: make([]float64, lstm.hiddenSize),
+		bi: make([]float64, lstm.hiddenSize),
+		bo: make([]float64, lstm.hiddenSize),
+		bc: make([]float64, lstm.hiddenSize),
+		Wy: make([][]float64, lstm.outputSize),
+		by: make([]float64, lstm.outputSize),
+	}
+	for _, w := range []*[2][]float64{&g.Wf, &g.Wi, &g.Wo, &g.Wc} {
+		w[0] = make([]float64, ih)
+		w[1] = make([]float64, hh)
+	}
+	for i := range g.Wy {
+		g.Wy[i] = make([]float64, lstm.hiddenSize)
+	}
+	return g
+}
+
+// vectors lists every gradient slice, for norm, scaling and updates
+func (g *lstmGradients) vectors() [][]float64 {
+	vectors := [][]float64{
+		g.Wf[0], g.Wf[1], g.Wi[0], g.Wi[1], g.Wo[0], g.Wo[1], g.Wc[0], g.Wc[1],
+		g.bf, g.bi, g.bo, g.bc, g.by,
+	}
+	return append(vectors, g.Wy...)
+}
+
+// parameterVectors lists the model parameters in the same order as lstmGradients.vectors
+func (lstm *LSTMModel) parameterVectors() [][]float64 {
+	vectors := [][]float64{
+		lstm.Wf[0], lstm.Wf[1], lstm.Wi[0], lstm.Wi[1], lstm.Wo[0], lstm.Wo[1], lstm.Wc[0], lstm.Wc[1],
+		lstm.bf, lstm.bi, lstm.bo, lstm.bc, lstm.by,
+	}
+	return append(vectors, lstm.Wy...)
+}
+
+// copyParameters snapshots the current parameters
+func (lstm *LSTMModel) copyParameters() *lstmGradients {
+	snapshot := lstm.newLSTMGradients()
+	dst := snapshot.vectors()
+	for k, src := range lstm.parameterVectors() {
+		copy(dst[k], src)
+	}
+	return snapshot
+}
+
+// restoreParameters copies a snapshot back into the model
+func (lstm *LSTMModel) restoreParameters(snapshot *lstmGradients) {
+	src := snapshot.vectors()
+	for k, dst := range lstm.parameterVectors() {
+		copy(dst, src[k])
+	}
+}
+
+// trainEpoch runs one shuffled pass of mini-batch BPTT and returns the mean training loss
+func (lstm *LSTMModel) trainEpoch(sequences []GameSequence, rng *rand.Rand) float64 {
+	grads := lstm.newLSTMGradients()
+	order := rng.Perm(len(sequences))
+	totalLoss := 0.0
+
+	for start := 0; start < len(order); start += lstmBatchSize {
+		end := start + lstmBatchSize
+		if end > len(order) {
+			end = len(order)
+		}
+
+		for _, v := range grads.vectors() {
+			for k := range v {
+				v[k] = 0
+			}
+		}
 
-	// Compute loss (cross-entropy)
+		for _, idx := range order[start:end] {
+			totalLoss += lstm.backpropagateSequence(sequences[idx], grads)
+		}
+
+		// Average over the batch, clip by global norm, then step
+		scale := 1.0 / float64(end-start)
+		norm := 0.0
+		for _, v := range grads.vectors() {
+			for k := range v {
+				v[k] *= scale
+				norm += v[k] * v[k]
+			}
+		}
+		norm = math.Sqrt(norm)
+		if norm > lstmClipNorm {
+			scale = lstmClipNorm / norm
+			for _, v := range grads.vectors() {
+				for k := range v {
+					v[k] *= scale
+				}
+			}
+		}
+
+		params := lstm.parameterVectors()
+		for k, v := range grads.vectors() {
+			for j := range v {
+				params[k][j] -= lstm.learningRate * v[j]
+			}
+		}
+	}
+
+	return totalLoss / float64(len(sequences))
+}
+
+// sequenceTarget converts a label to the one-hot win/loss/OT target
+func (lstm *LSTMModel) sequenceTarget(label float64) []float64 {
 	target := make([]float64, lstm.outputSize)
-	if seq.Label == 1.0 {
+	if label == 1.0 {
 		target[0] = 1.0 // Win
-	} else if seq.Label == 0.5 {
+	} else if label == 0.5 {
 		target[2] = 1.0 // OT
 	} else {
 		target[1] = 1.0 // Loss
 	}
+	return target
+}
+
+// sequenceLoss returns the mean cross-entropy over sequences without updating weights
+func (lstm *LSTMModel) sequenceLoss(sequences []GameSequence) float64 {
+	if len(sequences) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, seq := range sequences {
+		output := lstm.forward(seq.Features)
+		target := lstm.sequenceTarget(seq.Label)
+		for i := range target {
+			if target[i] > 0 {
+				total -= target[i] * math.Log(math.Max(output[i], 1e-10))
+			}
+		}
+	}
+	return total / float64(len(sequences))
+}
+
+// backpropagateSequence runs backpropagation through time for one sequence,
+// adding its gradients to grads, and returns the cross-entropy loss
+func (lstm *LSTMModel) backpropagateSequence(seq GameSequence, grads *lstmGradients) float64 {
+	output, steps := lstm.forwardCached(seq.Features)
+	target := lstm.sequenceTarget(seq.Label)
 
 	loss := 0.0
-	for i := 0; i < lstm.outputSize; i++ {
+	for i := range target {
 		if target[i] > 0 {
 			loss -= target[i] * math.Log(math.Max(output[i], 1e-10))
 		}
 	}
+	if len(steps) == 0 {
+		return loss
+	}
 
-	// Simplified gradient update (full BPTT would be more complex)
-	// For now, just update output layer
-	outputGrad := make([]float64, lstm.outputSize)
-	for i := 0; i < lstm.outputSize; i++ {
-		outputGrad[i] = output[i] - target[i]
+	H := lstm.hiddenSize
+	I := lstm.inputSize
+	last := steps[len(steps)-1]
+
+	// Softmax + cross-entropy gradient, then into the final hidden state
+	dh := make([]float64, H)
+	for k := 0; k < lstm.outputSize; k++ {
+		dy := output[k] - target[k]
+		grads.by[k] += dy
+		for j := 0; j < H; j++ {
+			grads.Wy[k][j] += dy * last.h[j]
+			dh[j] += lstm.Wy[k][j] * dy
+		}
 	}
 
-	// Update output weights (simplified)
-	// In full implementation, would backpropagate through time
+	dcNext := make([]float64, H)
+	dzf := make([]float64, H)
+	dzi := make([]float64, H)
+	dzg := make([]float64, H)
+	dzo := make([]float64, H)
+
+	for t := len(steps) - 1; t >= 0; t-- {
+		step := steps[t]
+
+		for j := 0; j < H; j++ {
+			tanhC := math.Tanh(step.c[j])
+			dc := dh[j]*step.o[j]*(1-tanhC*tanhC) + dcNext[j]
+
+			dzo[j] = dh[j] * tanhC * step.o[j] * (1 - step.o[j])
+			dzf[j] = dc * step.cPrev[j] * step.f[j] * (1 - step.f[j])
+			dzi[j] = dc * step.g[j] * step.i[j] * (1 - step.i[j])
+			dzg[j] = dc * step.i[j] * (1 - step.g[j]*step.g[j])
+
+			dcNext[j] = dc * step.f[j]
+		}
+
+		dhPrev := make([]float64, H)
+		gates := []struct {
+			W  [][]float64
+			dW *[2][]float64
+			db []float64
+			dz []float64
+		}{
+			{lstm.Wf, &grads.Wf, grads.bf, dzf},
+			{lstm.Wi, &grads.Wi, grads.bi, dzi},
+			{lstm.Wc, &grads.Wc, grads.bc, dzg},
+			{lstm.Wo, &grads.Wo, grads.bo, dzo},
+		}
+		for _, gate := range gates {
+			for i := 0; i < H; i++ {
+				dz := gate.dz[i]
+				if dz == 0 {
+					continue
+				}
+				gate.db[i] += dz
+				for j := 0; j < I && j < len(step.x); j++ {
+					gate.dW[0][i*I+j] += dz * step.x[j]
+				}
+				for j := 0; j < H; j++ {
+					gate.dW[1][i*H+j] += dz * step.hPrev[j]
+					dhPrev[j] += gate.W[1][i*H+j] * dz
+				}
+			}
+		}
+		dh = dhPrev
+	}
 
 	return loss
 }
 
+// rebuildTeamHistory indexes completed games by team for inference sequences
+func (lstm *LSTMModel) rebuildTeamHistory(games []models.CompletedGame) {
+	history := make(map[string][]models.CompletedGame)
+	for _, game := range games {
+		history[game.HomeTeam.TeamCode] = append(history[game.HomeTeam.TeamCode], game)
+		history[game.AwayTeam.TeamCode] = append(history[game.AwayTeam.TeamCode], game)
+	}
+	for team := range history {
+		tGames := history[team]
+		sort.Slice(tGames, func(i, j int) bool {
+			return tGames[i].GameDate.Before(tGames[j].GameDate)
+		})
+		if len(tGames) > lstm.sequenceLen {
+			history[team] = tGames[len(tGames)-lstm.sequenceLen:]
+		}
+	}
+	lstm.teamHistory = history
+}
+
+// teamSequence builds the inference sequence from the team's most recent games,
+// the same features used in training. Falls back to factor-based features when
+// no games are recorded for the team.
+func (lstm *LSTMModel) teamSequence(factors *models.PredictionFactors) [][]float64 {
+	games := lstm.teamHistory[factors.TeamCode]
+	if len(games) == 0 {
+		return lstm.extractSequence(factors)
+	}
+	return lstm.historySequence(games, factors.TeamCode)
+}
+
+// historySequence builds a sequence from the last sequenceLen of a team's
+// games (oldest first), left-padding short histories with zeros
+func (lstm *LSTMModel) historySequence(games []models.CompletedGame, teamCode string) [][]float64 {
+	sequence := make([][]float64, lstm.sequenceLen)
+	offset := lstm.sequenceLen - len(games)
+	for t := range sequence {
+		if t < offset {
+			sequence[t] = make([]float64, lstm.inputSize) // Left-pad short histories
+			continue
+		}
+		sequence[t] = lstm.extractGameFeatures(&games[t-offset], teamCode)
+	}
+	return sequence
+}
+
+// untrainedCopy returns a freshly initialized network with this one's architecture
+func (lstm *LSTMModel) untrainedCopy() *LSTMModel {
+	lstm.mutex.RLock()
+	defer lstm.mutex.RUnlock()
+
+	fresh := &LSTMModel{
+		inputSize:    lstm.inputSize,
+		hiddenSize:   lstm.hiddenSize,
+		outputSize:   lstm.outputSize,
+		sequenceLen:  lstm.sequenceLen,
+		learningRate: lstm.learningRate,
+		weight:       lstm.weight,
+		teamHistory:  make(map[string][]models.CompletedGame),
+	}
+	fresh.initializeWeights()
+	return fresh
+}
+
 // Activation functions
 func sigmoid(x float64) float64 {
 	return 1.0 / (1.0 + math.Exp(-x))
@@ -656,18 +1092,25 @@ func (lstm *LSTMModel) GetWeight() float64 {
 	return lstm.weight
 }
 
-// TrainOnGameResult trains the model on a completed game
+// TrainOnGameResult records a completed game in the team histories used for
+// inference. Weights are refit in batches by Train.
 func (lstm *LSTMModel) TrainOnGameResult(game models.CompletedGame) error {
-	// LSTM needs sequences, so we'll collect games and train in batches
 	lstm.mutex.Lock()
-	lstm.gameSequences = append(lstm.gameSequences, GameSequence{
-		Features: [][]float64{}, // Will be populated during batch training
-		Label:    0.0,
-		TeamCode: game.HomeTeam.TeamCode,
-	})
-	lstm.mutex.Unlock()
+	defer lstm.mutex.Unlock()
 
-	log.Printf("🔄 LSTM: Received game result (batch training needed, %d games collected)", len(lstm.gameSequences))
+	if lstm.teamHistory == nil {
+		lstm.teamHistory = make(map[string][]models.CompletedGame)
+	}
+	for _, team := range []string{game.HomeTeam.TeamCode, game.AwayTeam.TeamCode} {
+		history := append(lstm.teamHistory[team], game)
+		if len(history) > lstm.sequenceLen {
+			history = history[len(history)-lstm.sequenceLen:]
+		}
+		lstm.teamHistory[team] = history
+	}
+
+	log.Printf("🔄 LSTM: Recorded game %d for %s and %s sequences",
+		game.GameID, game.HomeTeam.TeamCode, game.AwayTeam.TeamCode)
 	return nil
 }
 
@@ -786,14 +1229,8 @@ func (lstm *LSTMModel) loadModel() {
 		return
 	}
 
-	// Try to load game sequences
-	if err := lstm.loadGameSequences(); err != nil {
-		log.Printf("⚠️ Could not load LSTM game sequences: %v", err)
-		lstm.gameSequences = []GameSequence{}
-	}
-
-	log.Printf("✅ LSTM model loaded: %dx%d hidden, %d sequences, trained=%v",
-		lstm.hiddenSize, lstm.inputSize, len(lstm.gameSequences), lstm.trained)
+	log.Printf("✅ LSTM model loaded: %dx%d hidden, trained=%v",
+		lstm.hiddenSize, lstm.inputSize, lstm.trained)
 }
 
 // saveModel saves the complete LSTM model to disk
@@ -806,67 +1243,6 @@ func (lstm *LSTMModel) saveModel() error {
 		return fmt.Errorf("failed to save LSTM weights: %w", err)
 	}
 
-	// Save game sequences
-	if err := lstm.saveGameSequences(); err != nil {
-		return fmt.Errorf("failed to save LSTM sequences: %w", err)
-	}
-
 	lstm.lastUpdated = time.Now()
 	return nil
 }
-
-// saveGameSequences saves game sequences to disk
-func (lstm *LSTMModel) saveGameSequences() error {
-	filePath := filepath.Join(lstm.dataDir, "lstm_sequences.json")
-
-	data := struct {
-		Sequences   []GameSequence `json:"sequences"`
-		LastUpdated time.Time      `json:"lastUpdated"`
-		Version     string         `json:"version"`
-	}{
-		Sequences:   lstm.gameSequences,
-		LastUpdated: time.Now(),
-		Version:     "1.0",
-	}
-
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshaling LSTM sequences: %w", err)
-	}
-
-	err = os.WriteFile(filePath, jsonData, 0644)
-	if err != nil {
-		return fmt.Errorf("error writing LSTM sequences file: %w", err)
-	}
-
-	return nil
-}
-
-// loadGameSequences loads game sequences from disk
-func (lstm *LSTMModel) loadGameSequences() error {
-	filePath := filepath.Join(lstm.dataDir, "lstm_sequences.json")
-
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return fmt.Errorf("no saved sequences found")
-	}
-
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("error reading LSTM sequences: %w", err)
-	}
-
-	var sequenceData struct {
-		Sequences   []GameSequence `json:"sequences"`
-		LastUpdated time.Time      `json:"lastUpdated"`
-		Version     string         `json:"version"`
-	}
-
-	err = json.Unmarshal(data, &sequenceData)
-	if err != nil {
-		return fmt.Errorf("error unmarshaling LSTM sequences: %w", err)
-	}
-
-	lstm.gameSequences = sequenceData.Sequences
-	return nil
-}
@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
 )
 
 // MetaLearnerModel implements stacking ensemble learning
@@ -21,7 +23,7 @@ type MetaLearnerModel struct {
 	learningRate float64
 
 	// Architecture
-	numBaseModels   int // 9 base models
+	numBaseModels   int // len(metaBaseModels)
 	numContextFeats int // Additional context features
 
 	// Training
@@ -42,6 +44,34 @@ type MetaLearnerModel struct {
 	trainingCount     int
 	gamesProcessed    int
 	autoTrainInterval int // Train every N games after initial threshold
+
+	// Walk-forward validation from the latest auto-train
+	walkForward     RollingOriginConfig
+	validationFolds []MetaValidationFold
+
+	// Combiner chosen by the stacking pipeline and, for "gbm", its trees
+	combiner string
+	gbm      *GradientBoostingModel
+}
+
+// Ensemble combiners the stacking pipeline chooses between
+const (
+	CombinerLogistic = "logistic" // Logistic meta-learner over base predictions + context
+	CombinerGBM      = "gbm"      // Small gradient boosting meta-learner
+	CombinerFixed    = "fixed"    // Fixed base-model weights
+	CombinerDynamic  = "dynamic"  // Performance-based dynamic weights
+)
+
+// MetaValidationFold compares every combiner on one rolling-origin fold of
+// out-of-fold base predictions
+type MetaValidationFold struct {
+	Fold             int                   `json:"fold"`
+	TrainSize        int                   `json:"trainSize"`
+	TestSize         int                   `json:"testSize"`
+	MetaLearner      ModelValidationResult `json:"metaLearner"`
+	GradientBoosting ModelValidationResult `json:"gradientBoosting"`
+	WeightedAverage  ModelValidationResult `json:"weightedAverage"`
+	DynamicWeighting ModelValidationResult `json:"dynamicWeighting"`
 }
 
 // ModelPredictions holds predictions from all base models
@@ -55,6 +85,29 @@ type ModelPredictions struct {
 	GradientBoosting float64
 	LSTM             float64
 	RandomForest     float64
+	BayesianRatings  float64
+}
+
+// metaBaseModel maps an ensemble model name to its ModelPredictions field
+type metaBaseModel struct {
+	name  string // Ensemble model name
+	key   string // Key in GetLearnedWeights
+	field func(p *ModelPredictions) *float64
+}
+
+// metaBaseModels lists the base models in meta-feature order. New models go
+// at the end; loadModel migrates weights saved with fewer models.
+var metaBaseModels = []metaBaseModel{
+	{"Enhanced Statistical", "Statistical", func(p *ModelPredictions) *float64 { return &p.Statistical }},
+	{"Bayesian Inference", "Bayesian", func(p *ModelPredictions) *float64 { return &p.Bayesian }},
+	{"Monte Carlo Simulation", "MonteCarlo", func(p *ModelPredictions) *float64 { return &p.MonteCarlo }},
+	{"Elo Rating", "Elo", func(p *ModelPredictions) *float64 { return &p.Elo }},
+	{"Poisson Regression", "Poisson", func(p *ModelPredictions) *float64 { return &p.Poisson }},
+	{"Neural Network", "NeuralNetwork", func(p *ModelPredictions) *float64 { return &p.NeuralNetwork }},
+	{"Gradient Boosting", "GradientBoosting", func(p *ModelPredictions) *float64 { return &p.GradientBoosting }},
+	{"LSTM", "LSTM", func(p *ModelPredictions) *float64 { return &p.LSTM }},
+	{"Random Forest", "RandomForest", func(p *ModelPredictions) *float64 { return &p.RandomForest }},
+	{"Bayesian Ratings", "BayesianRatings", func(p *ModelPredictions) *float64 { return &p.BayesianRatings }},
 }
 
 // MetaGameContext holds additional context features for meta-learning
@@ -71,6 +124,32 @@ type MetaGameContext struct {
 	BackToBack       bool    // Either team on back-to-back
 }
 
+// setModel stores a base model's home win probability under its ensemble name
+func (p *ModelPredictions) setModel(name string, prob float64) {
+	for _, model := range metaBaseModels {
+		if model.name == name {
+			*model.field(p) = prob
+			return
+		}
+	}
+}
+
+// newMetaGameContext derives meta-learner context from the factors a game was predicted with
+func newMetaGameContext(homeFactors, awayFactors *models.PredictionFactors) MetaGameContext {
+	return MetaGameContext{
+		IsDivisionalGame: false, // TODO: Determine from team codes
+		IsPlayoffGame:    false, // TODO: Determine from game type
+		IsRivalryGame:    false, // TODO: Determine from matchup
+		HomeTeamHot:      homeFactors.IsHot,
+		AwayTeamHot:      awayFactors.IsHot,
+		HomeTeamCold:     homeFactors.IsCold,
+		AwayTeamCold:     awayFactors.IsCold,
+		RestAdvantage:    float64(homeFactors.RestDays - awayFactors.RestDays),
+		TravelDistance:   awayFactors.TravelFatigue.MilesTraveled,
+		BackToBack:       homeFactors.BackToBackPenalty > 0 || awayFactors.BackToBackPenalty > 0,
+	}
+}
+
 var (
 	metaLearnerModel     *MetaLearnerModel
 	metaLearnerModelOnce sync.Once
@@ -82,7 +161,7 @@ func NewMetaLearnerModel() *MetaLearnerModel {
 		dataDir := "data/models"
 		os.MkdirAll(dataDir, 0755)
 
-		numBaseModels := 9    // 9 base prediction models
+		numBaseModels := len(metaBaseModels)
 		numContextFeats := 10 // 10 context features
 		totalFeatures := numBaseModels + numContextFeats
 
@@ -100,11 +179,21 @@ func NewMetaLearnerModel() *MetaLearnerModel {
 			lastAutoTrain:     time.Time{},
 			trainingCount:     0,
 			gamesProcessed:    0,
+			walkForward: RollingOriginConfig{
+				MinTrain: 20,
+				Horizon:  10,
+				MaxFolds: 5,
+			},
 		}
 
 		// Try to load existing model
 		if err := metaLearnerModel.loadModel(); err != nil {
-			log.Printf("🎯 Initializing new Meta-Learner (no saved model found)")
+			log.Printf("🎯 Initializing new Meta-Learner (%v)", err)
+			metaLearnerModel.weights = make([]float64, totalFeatures)
+			metaLearnerModel.numBaseModels = numBaseModels
+			metaLearnerModel.numContextFeats = numContextFeats
+			metaLearnerModel.trained = false
+			metaLearnerModel.combiner, metaLearnerModel.gbm = "", nil
 			metaLearnerModel.initializeWeights()
 		} else {
 			log.Printf("🎯 Meta-Learner loaded from disk")
@@ -147,9 +236,19 @@ func (mlm *MetaLearnerModel) PredictFromModels(predictions *ModelPredictions, co
 		return mlm.weightedAverage(predictions)
 	}
 
+	return mlm.predictProbability(predictions, context)
+}
+
+// predictProbability applies the learned combiner; callers hold the lock
+func (mlm *MetaLearnerModel) predictProbability(predictions *ModelPredictions, context *MetaGameContext) float64 {
 	// Extract features
 	features := mlm.extractFeatures(predictions, context)
 
+	if mlm.combiner == CombinerGBM && mlm.gbm != nil {
+		winProb := 1.0 / (1.0 + math.Exp(-mlm.gbm.predictProbability(features)))
+		return math.Max(0.30, math.Min(0.90, winProb))
+	}
+
 	// Linear combination
 	sum := mlm.bias
 	for i, feat := range features {
@@ -170,25 +269,11 @@ func (mlm *MetaLearnerModel) extractFeatures(predictions *ModelPredictions, cont
 	features := make([]float64, mlm.numBaseModels+mlm.numContextFeats)
 	idx := 0
 
-	// Base model predictions (9 features)
-	features[idx] = predictions.Statistical
-	idx++
-	features[idx] = predictions.Bayesian
-	idx++
-	features[idx] = predictions.MonteCarlo
-	idx++
-	features[idx] = predictions.Elo
-	idx++
-	features[idx] = predictions.Poisson
-	idx++
-	features[idx] = predictions.NeuralNetwork
-	idx++
-	features[idx] = predictions.GradientBoosting
-	idx++
-	features[idx] = predictions.LSTM
-	idx++
-	features[idx] = predictions.RandomForest
-	idx++
+	// Base model predictions, one feature per model
+	for _, model := range metaBaseModels {
+		features[idx] = *model.field(predictions)
+		idx++
+	}
 
 	// Context features (10 features)
 	if context.IsDivisionalGame {
@@ -230,20 +315,15 @@ func (mlm *MetaLearnerModel) extractFeatures(predictions *ModelPredictions, cont
 	return features
 }
 
-// weightedAverage returns simple weighted average if not trained
+// weightedAverage returns the fixed-weight blend if not trained
 func (mlm *MetaLearnerModel) weightedAverage(predictions *ModelPredictions) float64 {
-	// Use base weights from ensemble
-	sum := predictions.Statistical*0.30 +
-		predictions.Bayesian*0.12 +
-		predictions.MonteCarlo*0.09 +
-		predictions.Elo*0.17 +
-		predictions.Poisson*0.12 +
-		predictions.NeuralNetwork*0.06 +
-		predictions.GradientBoosting*0.07 +
-		predictions.LSTM*0.07 +
-		predictions.RandomForest*0.07
-
-	return sum
+	sum, totalWeight := 0.0, 0.0
+	for _, model := range metaBaseModels {
+		weight := fixedCombinerWeights[model.name]
+		sum += *model.field(predictions) * weight
+		totalWeight += weight
+	}
+	return sum / totalWeight
 }
 
 // Train trains the meta-learner on completed games with base model predictions
@@ -258,15 +338,40 @@ func (mlm *MetaLearnerModel) Train(trainingData []MetaTrainingExample) error {
 	log.Printf("🎯 Training Meta-Learner on %d examples...", len(trainingData))
 	start := time.Now()
 
-	// Split into train/validation (80/20)
+	// Split into train/validation (80/20), validating on the most recent games
 	splitIdx := int(float64(len(trainingData)) * 0.8)
-	trainSet := trainingData[:splitIdx]
-	valSet := trainingData[splitIdx:]
+	mlm.combiner, mlm.gbm = CombinerLogistic, nil
+	mlm.trainAccuracy, mlm.valAccuracy = mlm.fit(trainingData[:splitIdx], trainingData[splitIdx:], true)
+
+	mlm.trained = true
+	mlm.lastUpdated = time.Now()
+
+	trainingTime := time.Since(start)
+	log.Printf("✅ Meta-Learner training complete!")
+	log.Printf("   Train Accuracy: %.2f%%", mlm.trainAccuracy*100)
+	log.Printf("   Val Accuracy: %.2f%%", mlm.valAccuracy*100)
+	log.Printf("   Time: %.1fs", trainingTime.Seconds())
+
+	// Save model
+	if err := mlm.saveModel(); err != nil {
+		log.Printf("⚠️ Failed to save Meta-Learner: %v", err)
+	}
+
+	return nil
+}
+
+// fit runs gradient descent on trainSet, keeping the weights with the best
+// validation accuracy. Returns train and validation accuracy. Callers hold the lock.
+func (mlm *MetaLearnerModel) fit(trainData, valSet []MetaTrainingExample, verbose bool) (float64, float64) {
+	// Shuffle a copy so callers' date order is preserved
+	trainSet := make([]MetaTrainingExample, len(trainData))
+	copy(trainSet, trainData)
 
 	// Training epochs
 	epochs := 100
 	bestValAccuracy := 0.0
 	bestWeights := make([]float64, len(mlm.weights))
+	copy(bestWeights, mlm.weights)
 	bestBias := mlm.bias
 
 	for epoch := 0; epoch < epochs; epoch++ {
@@ -287,8 +392,10 @@ func (mlm *MetaLearnerModel) Train(trainingData []MetaTrainingExample) error {
 			trainAcc := mlm.evaluateAccuracy(trainSet)
 			valAcc := mlm.evaluateAccuracy(valSet)
 
-			log.Printf("   Epoch %d/%d: Train Acc=%.2f%%, Val Acc=%.2f%%, Loss=%.4f",
-				epoch+1, epochs, trainAcc*100, valAcc*100, totalLoss/float64(len(trainSet)))
+			if verbose {
+				log.Printf("   Epoch %d/%d: Train Acc=%.2f%%, Val Acc=%.2f%%, Loss=%.4f",
+					epoch+1, epochs, trainAcc*100, valAcc*100, totalLoss/float64(len(trainSet)))
+			}
 
 			// Save best model (based on validation accuracy)
 			if valAcc > bestValAccuracy {
@@ -302,24 +409,8 @@ func (mlm *MetaLearnerModel) Train(trainingData []MetaTrainingExample) error {
 	// Restore best weights
 	copy(mlm.weights, bestWeights)
 	mlm.bias = bestBias
-	mlm.trainAccuracy = mlm.evaluateAccuracy(trainSet)
-	mlm.valAccuracy = bestValAccuracy
-
-	mlm.trained = true
-	mlm.lastUpdated = time.Now()
-
-	trainingTime := time.Since(start)
-	log.Printf("✅ Meta-Learner training complete!")
-	log.Printf("   Train Accuracy: %.2f%%", mlm.trainAccuracy*100)
-	log.Printf("   Val Accuracy: %.2f%%", mlm.valAccuracy*100)
-	log.Printf("   Time: %.1fs", trainingTime.Seconds())
-
-	// Save model
-	if err := mlm.saveModel(); err != nil {
-		log.Printf("⚠️ Failed to save Meta-Learner: %v", err)
-	}
 
-	return nil
+	return mlm.evaluateAccuracy(trainSet), bestValAccuracy
 }
 
 // trainOnExample trains on a single example using gradient descent
@@ -360,7 +451,7 @@ func (mlm *MetaLearnerModel) evaluateAccuracy(data []MetaTrainingExample) float6
 	total := len(data)
 
 	for _, example := range data {
-		prediction := mlm.PredictFromModels(&example.Predictions, &example.Context)
+		prediction := mlm.predictProbability(&example.Predictions, &example.Context)
 
 		predictedWin := prediction > 0.5
 		actualWin := example.ActualOutcome > 0.5
@@ -373,6 +464,120 @@ func (mlm *MetaLearnerModel) evaluateAccuracy(data []MetaTrainingExample) float6
 	return float64(correct) / float64(total)
 }
 
+// TrainStacked refits the meta-learner from scratch on out-of-fold base
+// predictions using the given combiner ("logistic" or "gbm") and makes it the
+// active combiner. Examples must be oldest first.
+func (mlm *MetaLearnerModel) TrainStacked(trainingData []MetaTrainingExample, combiner string) error {
+	if combiner != CombinerLogistic && combiner != CombinerGBM {
+		return fmt.Errorf("meta-learner cannot train combiner %q", combiner)
+	}
+
+	mlm.mutex.Lock()
+	defer mlm.mutex.Unlock()
+
+	if len(trainingData) < 20 {
+		return fmt.Errorf("insufficient training data: need at least 20 examples, have %d", len(trainingData))
+	}
+
+	log.Printf("🎯 Training %s Meta-Learner on %d out-of-fold examples...", combiner, len(trainingData))
+
+	splitIdx := int(float64(len(trainingData)) * 0.8)
+	train, val := trainingData[:splitIdx], trainingData[splitIdx:]
+
+	mlm.combiner = combiner
+	if combiner == CombinerGBM {
+		mlm.gbm = mlm.fitGBM(train)
+		mlm.trainAccuracy = mlm.evaluateAccuracy(train)
+		mlm.valAccuracy = mlm.evaluateAccuracy(val)
+	} else {
+		mlm.gbm = nil
+		mlm.initializeWeights()
+		mlm.trainAccuracy, mlm.valAccuracy = mlm.fit(train, val, false)
+	}
+
+	mlm.trained = true
+	mlm.lastUpdated = time.Now()
+
+	log.Printf("✅ Meta-Learner (%s) trained: Train Acc %.2f%%, Val Acc %.2f%%",
+		combiner, mlm.trainAccuracy*100, mlm.valAccuracy*100)
+
+	if err := mlm.saveModel(); err != nil {
+		log.Printf("⚠️ Failed to save Meta-Learner: %v", err)
+	}
+	return nil
+}
+
+// SetCombiner records a non-learned combiner (fixed or dynamic weighting) as
+// the one the ensemble should use
+func (mlm *MetaLearnerModel) SetCombiner(combiner string) error {
+	if combiner != CombinerFixed && combiner != CombinerDynamic {
+		return fmt.Errorf("use TrainStacked for combiner %q", combiner)
+	}
+
+	mlm.mutex.Lock()
+	defer mlm.mutex.Unlock()
+
+	mlm.combiner = combiner
+	return mlm.saveModel()
+}
+
+// Combiner returns the combiner the ensemble should use. Models saved before
+// combiner selection existed keep their old behaviour.
+func (mlm *MetaLearnerModel) Combiner() string {
+	mlm.mutex.RLock()
+	defer mlm.mutex.RUnlock()
+
+	switch {
+	case mlm.combiner == CombinerGBM && mlm.gbm == nil:
+		return CombinerDynamic
+	case mlm.combiner != "":
+		return mlm.combiner
+	case mlm.trained:
+		return CombinerLogistic
+	default:
+		return CombinerDynamic
+	}
+}
+
+// fitGBM grows a small, shallow boosting model over the meta features
+func (mlm *MetaLearnerModel) fitGBM(trainData []MetaTrainingExample) *GradientBoostingModel {
+	features := make([][]float64, len(trainData))
+	labels := make([]float64, len(trainData))
+	for i, example := range trainData {
+		features[i] = mlm.extractFeatures(&example.Predictions, &example.Context)
+		labels[i] = example.ActualOutcome
+	}
+
+	gbm := &GradientBoostingModel{
+		learningRate:   0.1,
+		numTrees:       40,
+		maxDepth:       2,
+		minSamplesLeaf: 8,
+		quiet:          true,
+	}
+	gbm.fit(features, labels)
+	return gbm
+}
+
+// newFoldModel returns an untrained copy of the meta-learner's architecture
+// for walk-forward validation
+func (mlm *MetaLearnerModel) newFoldModel() *MetaLearnerModel {
+	mlm.mutex.RLock()
+	defer mlm.mutex.RUnlock()
+
+	foldModel := &MetaLearnerModel{
+		weights:         make([]float64, mlm.numBaseModels+mlm.numContextFeats),
+		bias:            0.5,
+		learningRate:    mlm.learningRate,
+		numBaseModels:   mlm.numBaseModels,
+		numContextFeats: mlm.numContextFeats,
+	}
+	for i := range foldModel.weights {
+		foldModel.weights[i] = (rand.Float64()*2 - 1) * 0.1
+	}
+	return foldModel
+}
+
 // MetaTrainingExample represents a training example for the meta-learner
 type MetaTrainingExample struct {
 	Predictions   ModelPredictions
@@ -403,16 +608,9 @@ func (mlm *MetaLearnerModel) GetLearnedWeights() map[string]float64 {
 		return nil
 	}
 
-	weights := map[string]float64{
-		"Statistical":      mlm.weights[0],
-		"Bayesian":         mlm.weights[1],
-		"MonteCarlo":       mlm.weights[2],
-		"Elo":              mlm.weights[3],
-		"Poisson":          mlm.weights[4],
-		"NeuralNetwork":    mlm.weights[5],
-		"GradientBoosting": mlm.weights[6],
-		"LSTM":             mlm.weights[7],
-		"RandomForest":     mlm.weights[8],
+	weights := make(map[string]float64, len(metaBaseModels))
+	for i, model := range metaBaseModels {
+		weights[model.key] = mlm.weights[i]
 	}
 
 	return weights
@@ -433,6 +631,11 @@ type MetaLearnerModelData struct {
 	ValAccuracy     float64   `json:"valAccuracy"`
 	LastUpdated     time.Time `json:"lastUpdated"`
 	Version         string    `json:"version"`
+
+	// Stacking combiner (1.1+). 1.2 added the Bayesian Ratings base model.
+	Combiner        string    `json:"combiner,omitempty"`
+	GBMTrees        []*GBTree `json:"gbmTrees,omitempty"`
+	GBMLearningRate float64   `json:"gbmLearningRate,omitempty"`
 }
 
 func (mlm *MetaLearnerModel) saveModel() error {
@@ -449,7 +652,12 @@ func (mlm *MetaLearnerModel) saveModel() error {
 		TrainAccuracy:   mlm.trainAccuracy,
 		ValAccuracy:     mlm.valAccuracy,
 		LastUpdated:     time.Now(),
-		Version:         "1.0",
+		Version:         "1.2",
+		Combiner:        mlm.combiner,
+	}
+	if mlm.gbm != nil {
+		modelData.GBMTrees = mlm.gbm.trees
+		modelData.GBMLearningRate = mlm.gbm.learningRate
 	}
 
 	data, err := json.MarshalIndent(modelData, "", "  ")
@@ -462,7 +670,7 @@ func (mlm *MetaLearnerModel) saveModel() error {
 		return fmt.Errorf("error writing meta-learner: %w", err)
 	}
 
-	log.Printf("💾 Meta-Learner saved: trained=%v, val_acc=%.2f%%", mlm.trained, mlm.valAccuracy*100)
+	log.Printf("💾 Meta-Learner saved: trained=%v, combiner=%s, val_acc=%.2f%%", mlm.trained, mlm.combiner, mlm.valAccuracy*100)
 	return nil
 }
 
@@ -494,10 +702,63 @@ func (mlm *MetaLearnerModel) loadModel() error {
 	mlm.trainAccuracy = modelData.TrainAccuracy
 	mlm.valAccuracy = modelData.ValAccuracy
 	mlm.lastUpdated = modelData.LastUpdated
+	mlm.combiner = modelData.Combiner
+	if len(modelData.GBMTrees) > 0 {
+		mlm.gbm = &GradientBoostingModel{
+			trees:        modelData.GBMTrees,
+			learningRate: modelData.GBMLearningRate,
+			numTrees:     len(modelData.GBMTrees),
+			quiet:        true,
+		}
+	}
+	mlm.migrateBaseModels()
+
+	if len(mlm.weights) != mlm.numBaseModels+mlm.numContextFeats {
+		return fmt.Errorf("saved meta-learner has %d weights, expected %d", len(mlm.weights), mlm.numBaseModels+mlm.numContextFeats)
+	}
 
 	return nil
 }
 
+// migrateBaseModels widens weights saved before the latest base models were
+// added. The new models start with zero weight (and are never split on by the
+// GBM combiner) until the next stacking run retrains the meta-learner.
+func (mlm *MetaLearnerModel) migrateBaseModels() {
+	added := len(metaBaseModels) - mlm.numBaseModels
+	if added <= 0 {
+		return
+	}
+
+	if len(mlm.weights) == mlm.numBaseModels+mlm.numContextFeats {
+		weights := make([]float64, 0, len(mlm.weights)+added)
+		weights = append(weights, mlm.weights[:mlm.numBaseModels]...)
+		weights = append(weights, make([]float64, added)...)
+		weights = append(weights, mlm.weights[mlm.numBaseModels:]...)
+		mlm.weights = weights
+	}
+	if mlm.gbm != nil {
+		for _, tree := range mlm.gbm.trees {
+			shiftFeatureIndexes(tree.Root, mlm.numBaseModels, added)
+		}
+	}
+
+	log.Printf("🎯 Meta-Learner migrated from %d to %d base models (new models unweighted until retrained)",
+		mlm.numBaseModels, len(metaBaseModels))
+	mlm.numBaseModels = len(metaBaseModels)
+}
+
+// shiftFeatureIndexes moves splits on features at or after from up by n
+func shiftFeatureIndexes(node *GBTreeNode, from, n int) {
+	if node == nil || node.IsLeaf {
+		return
+	}
+	if node.FeatureIndex >= from {
+		node.FeatureIndex += n
+	}
+	shiftFeatureIndexes(node.Left, from, n)
+	shiftFeatureIndexes(node.Right, from, n)
+}
+
 // ============================================================================
 // AUTO-TRAINING (PHASE 1 OPTIMIZATION)
 // ============================================================================
@@ -533,110 +794,39 @@ func (mlm *MetaLearnerModel) AutoTrain() error {
 
 	log.Printf("🎯 AUTO-TRAINING Meta-Learner triggered (games: %d, training: %d)",
 		mlm.gamesProcessed, mlm.trainingCount+1)
-
-	// Get training data from stored predictions
-	predictionStorage := GetPredictionStorageService()
-	if predictionStorage == nil {
-		return fmt.Errorf("prediction storage service not available")
-	}
-
-	predictions, err := predictionStorage.GetAllPredictions()
-	if err != nil {
-		return fmt.Errorf("failed to load predictions: %w", err)
-	}
-
-	if len(predictions) < 20 {
-		return fmt.Errorf("insufficient predictions for training: have %d, need 20", len(predictions))
-	}
-
-	// Convert stored predictions to training examples
-	trainingData := []MetaTrainingExample{}
-	for _, pred := range predictions {
-		if pred.ActualResult == nil || pred.Accuracy == nil {
-			continue // Skip predictions without results
-		}
-
-		// Extract base model predictions
-		modelPreds := ModelPredictions{}
-		for _, modelResult := range pred.Prediction.Prediction.ModelResults {
-			switch modelResult.ModelName {
-			case "Enhanced Statistical Model":
-				modelPreds.Statistical = modelResult.WinProbability
-			case "Bayesian Inference Model":
-				modelPreds.Bayesian = modelResult.WinProbability
-			case "Monte Carlo Simulation":
-				modelPreds.MonteCarlo = modelResult.WinProbability
-			case "Elo Rating Model":
-				modelPreds.Elo = modelResult.WinProbability
-			case "Poisson Regression Model":
-				modelPreds.Poisson = modelResult.WinProbability
-			case "Neural Network":
-				modelPreds.NeuralNetwork = modelResult.WinProbability
-			case "Gradient Boosting":
-				modelPreds.GradientBoosting = modelResult.WinProbability
-			case "LSTM":
-				modelPreds.LSTM = modelResult.WinProbability
-			case "Random Forest":
-				modelPreds.RandomForest = modelResult.WinProbability
-			}
-		}
-
-		// Determine actual outcome (1.0 if home team won, 0.0 if away team won)
-		actualOutcome := 0.0
-		if pred.ActualResult.WinningTeam == pred.HomeTeam {
-			actualOutcome = 1.0
-		}
-
-		// Extract context features (use defaults for now)
-		context := MetaGameContext{
-			IsDivisionalGame: false,
-			IsPlayoffGame:    false,
-			IsRivalryGame:    false,
-			HomeTeamHot:      false,
-			AwayTeamHot:      false,
-			HomeTeamCold:     false,
-			AwayTeamCold:     false,
-			RestAdvantage:    0.0,
-			TravelDistance:   0.0,
-			BackToBack:       false,
-		}
-
-		trainingData = append(trainingData, MetaTrainingExample{
-			Predictions:   modelPreds,
-			Context:       context,
-			ActualOutcome: actualOutcome,
-			GameID:        pred.GameID,
-			GameDate:      pred.GameDate,
-		})
-	}
-
-	if len(trainingData) < 20 {
-		return fmt.Errorf("insufficient valid training examples: have %d, need 20", len(trainingData))
-	}
-
-	// Train the model
-	log.Printf("🎯 Training Meta-Learner on %d examples...", len(trainingData))
 	start := time.Now()
 
-	err = mlm.Train(trainingData)
+	// Rebuild out-of-fold base predictions, compare combiners and train the winner
+	report, err := GetStackingService().Run()
 	if err != nil {
-		return fmt.Errorf("training failed: %w", err)
+		return fmt.Errorf("stacking failed: %w", err)
 	}
 
 	// Update training counters
 	mlm.mutex.Lock()
 	mlm.trainingCount++
 	mlm.lastAutoTrain = time.Now()
+	mlm.validationFolds = report.Folds
 	mlm.mutex.Unlock()
 
 	duration := time.Since(start)
-	log.Printf("✅ Meta-Learner auto-training complete!")
+	log.Printf("✅ Meta-Learner auto-training complete! Combiner: %s", report.Selected)
 	log.Printf("   Training #%d completed in %.1fs", mlm.trainingCount, duration.Seconds())
 	log.Printf("   Next auto-train at: %d games", mlm.gamesProcessed+mlm.autoTrainInterval)
 
 	return nil
 }
 
+// GetValidationFolds returns the walk-forward results from the latest auto-train
+func (mlm *MetaLearnerModel) GetValidationFolds() []MetaValidationFold {
+	mlm.mutex.RLock()
+	defer mlm.mutex.RUnlock()
+
+	folds := make([]MetaValidationFold, len(mlm.validationFolds))
+	copy(folds, mlm.validationFolds)
+	return folds
+}
+
 // GetAutoTrainStatus returns the current auto-training status
 func (mlm *MetaLearnerModel) GetAutoTrainStatus() map[string]interface{} {
 	mlm.mutex.RLock()
@@ -649,6 +839,8 @@ func (mlm *MetaLearnerModel) GetAutoTrainStatus() map[string]interface{} {
 		"autoTrainInterval": mlm.autoTrainInterval,
 		"nextTrainAt":       mlm.trainingCount*mlm.autoTrainInterval + mlm.autoTrainInterval,
 		"shouldTrain":       mlm.gamesProcessed >= 20 && mlm.gamesProcessed >= (mlm.trainingCount*mlm.autoTrainInterval+mlm.autoTrainInterval),
+		"walkForwardFolds":  mlm.validationFolds,
+		"combiner":          mlm.combiner,
 	}
 }
 
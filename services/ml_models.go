@@ -25,6 +25,11 @@ type NeuralNetworkModel struct {
 	lastUpdated  time.Time
 	mutex        sync.RWMutex // Thread safety for concurrent access
 	dataDir      string       // Directory for persistence
+
+	// Architecture search settings (zero values keep the original ReLU network)
+	activation       string  // Hidden layer activation: "relu", "leaky_relu" or "elu"
+	dropoutRate      float64 // Hidden unit dropout during mini-batch training
+	l2Regularization float64 // Weight decay during mini-batch training
 }
 
 // NewNeuralNetworkModel creates a new neural network prediction model
@@ -59,6 +64,24 @@ func NewNeuralNetworkModel() *NeuralNetworkModel {
 	return model
 }
 
+// NewNeuralNetworkModelWithArchitecture creates an untrained network with the
+// given layer sizes and hyperparameters, used by architecture search. It never
+// loads or saves weights on its own.
+func NewNeuralNetworkModelWithArchitecture(layers []int, learningRate float64, activation string, dropoutRate, l2Regularization float64) *NeuralNetworkModel {
+	model := &NeuralNetworkModel{
+		layers:           append([]int(nil), layers...),
+		learningRate:     learningRate,
+		weight:           0.03,
+		lastUpdated:      time.Now(),
+		dataDir:          "data/models",
+		activation:       activation,
+		dropoutRate:      dropoutRate,
+		l2Regularization: l2Regularization,
+	}
+	model.initializeNetwork()
+	return model
+}
+
 // XGBoostModel implements gradient boosting for hockey predictions
 type XGBoostModel struct {
 	trees             []DecisionTree
@@ -97,8 +120,9 @@ func (nn *NeuralNetworkModel) Predict(homeFactors, awayFactors *models.Predictio
 	// Forward pass through network
 	output := nn.forwardPass(features)
 
-	// Convert output to win probability and score
-	winProb := nn.sigmoid(output[0])
+	// The output layer is already a sigmoid trained against win/loss labels,
+	// so output[0] is the win probability
+	winProb := output[0]
 	confidence := nn.calculateConfidence(output)
 	predictedScore := nn.outputToScore(output, homeFactors, awayFactors)
 
@@ -112,6 +136,106 @@ func (nn *NeuralNetworkModel) Predict(homeFactors, awayFactors *models.Predictio
 	}, nil
 }
 
+// Explain attributes the prediction to input features with integrated
+// gradients from an all-zero input, the network's "no information" baseline
+func (nn *NeuralNetworkModel) Explain(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionExplanation, error) {
+	nn.mutex.RLock()
+	defer nn.mutex.RUnlock()
+
+	if len(nn.weights) == 0 {
+		return nil, fmt.Errorf("neural network not initialized")
+	}
+
+	const steps = 32 // Midpoint Riemann sum along the straight path
+	features := nn.extractFeatures(homeFactors, awayFactors)
+	phi := make([]float64, len(features))
+	point := make([]float64, len(features))
+	for step := 0; step < steps; step++ {
+		alpha := (float64(step) + 0.5) / steps
+		for i, value := range features {
+			point[i] = alpha * value
+		}
+		for i, gradient := range nn.inputGradient(point) {
+			phi[i] += features[i] * gradient / steps
+		}
+	}
+
+	base := nn.forwardPass(make([]float64, len(features)))[0]
+	prediction := nn.forwardPass(features)[0]
+	phi = rescaleContributions(phi, prediction-base) // Absorb the small quadrature error
+
+	names := make([]string, len(features))
+	for i := range names {
+		names[i] = neuralNetFeatureLabel(i)
+	}
+	return newPredictionExplanation("integrated_gradients", base, prediction, names, features, phi), nil
+}
+
+// inputGradient is the gradient of the reported win probability, output[0],
+// with respect to each input feature
+func (nn *NeuralNetworkModel) inputGradient(input []float64) []float64 {
+	_, preActivations := nn.forwardPassWithActivations(input)
+	outputLayer := len(nn.layers) - 1
+
+	delta := make([]float64, nn.layers[outputLayer])
+	delta[0] = nn.sigmoidDerivative(preActivations[outputLayer][0])
+
+	for layer := outputLayer - 1; layer >= 0; layer-- {
+		nextSize := nn.layers[layer+1]
+		previous := make([]float64, nn.layers[layer])
+		for j := range previous {
+			sum := 0.0
+			for k := 0; k < nextSize; k++ {
+				sum += nn.weights[layer][j*nextSize+k] * delta[k]
+			}
+			if layer > 0 {
+				sum *= nn.activateDerivative(preActivations[layer][j])
+			}
+			previous[j] = sum
+		}
+		delta = previous
+	}
+	return delta
+}
+
+// neuralNetFeatureLabel names a feature in the neural network layout, which
+// shares the random forest names except for indices 65-110 and the interactions
+func neuralNetFeatureLabel(idx int) string {
+	names := map[int]string{
+		65: "Home Star Power", 66: "Away Star Power",
+		67: "Home Top 3 Combined PPG", 68: "Away Top 3 Combined PPG",
+		69: "Home Top Scorer Form", 70: "Away Top Scorer Form",
+		71: "Home Depth Form", 72: "Away Depth Form",
+		73: "Star Power Edge", 74: "Depth Edge",
+		75: "Goalie Save % Diff", 76: "Goalie Recent Form Diff",
+		77: "Goalie Fatigue Diff", 78: "Home Goalie Advantage",
+		79: "Home Saves Above Expected", 80: "Away Saves Above Expected",
+		81: "Home xGF", 82: "Away xGF",
+		83: "Home xGA", 84: "Away xGA",
+		85: "Home xG Differential (PBP)", 86: "Away xG Differential (PBP)",
+		87: "Home xG per Shot", 88: "Away xG per Shot",
+		89: "Home Dangerous Shots", 90: "Away Dangerous Shots",
+		91: "Home Corsi (PBP)", 92: "Away Corsi (PBP)",
+		156: "Home Offensive Potency", 157: "Away Offensive Potency",
+		158: "Home Scoring Pressure", 159: "Away Scoring Pressure",
+		160: "Home Defensive Vulnerability", 161: "Away Defensive Vulnerability",
+		162: "Home Fatigue Compound", 163: "Away Fatigue Compound",
+		164: "Home B2B Travel", 165: "Away B2B Travel",
+		166: "Home Momentum (Home Games)", 167: "Away Momentum (Home Games)",
+		168: "Home Field Strength", 169: "Referee Home Bias",
+		170: "Home Clutch Elite", 171: "Away Clutch Elite",
+		172: "Home Special Teams Dominance", 173: "Away Special Teams Dominance",
+		174: "Rivalry Intensity Factor", 175: "Playoff Pressure",
+		176: "H2H Advantage", 177: "H2H Recent Form",
+		178: "Goalie vs Team Rating", 179: "Rest Advantage (Detailed)",
+		180: "Opponent Fatigue", 181: "Lineup Stability",
+	}
+	if name, ok := names[idx]; ok {
+		return name
+	}
+	return getFeatureName(idx)
+}
+
 // extractFeatures converts prediction factors to neural network input
 // Feeds into larger 182→512→256→128→3 architecture (Phase 2 enhanced)
 func (nn *NeuralNetworkModel) extractFeatures(home, away *models.PredictionFactors) []float64 {
@@ -535,11 +659,11 @@ func (nn *NeuralNetworkModel) forwardPass(input []float64) []float64 {
 				sum += current[i] * nn.weights[layer][i*nn.layers[layer+1]+j]
 			}
 
-			// Apply activation function (ReLU for hidden layers, sigmoid for output)
+			// Apply activation function (ReLU by default for hidden layers, sigmoid for output)
 			if layer == len(nn.weights)-1 {
 				next[j] = nn.sigmoid(sum)
 			} else {
-				next[j] = nn.relu(sum)
+				next[j] = nn.activate(sum)
 			}
 		}
 
@@ -573,6 +697,42 @@ func (nn *NeuralNetworkModel) reluDerivative(x float64) float64 {
 	return 0
 }
 
+// activate applies the configured hidden layer activation
+func (nn *NeuralNetworkModel) activate(x float64) float64 {
+	switch nn.activation {
+	case "leaky_relu":
+		if x > 0 {
+			return x
+		}
+		return 0.01 * x
+	case "elu":
+		if x > 0 {
+			return x
+		}
+		return math.Exp(x) - 1
+	default:
+		return nn.relu(x)
+	}
+}
+
+// activateDerivative is the derivative of activate at pre-activation x
+func (nn *NeuralNetworkModel) activateDerivative(x float64) float64 {
+	switch nn.activation {
+	case "leaky_relu":
+		if x > 0 {
+			return 1.0
+		}
+		return 0.01
+	case "elu":
+		if x > 0 {
+			return 1.0
+		}
+		return math.Exp(x)
+	default:
+		return nn.reluDerivative(x)
+	}
+}
+
 func (nn *NeuralNetworkModel) calculateConfidence(output []float64) float64 {
 	// Calculate confidence based on output certainty
 	maxVal := 0.0
@@ -690,7 +850,7 @@ func (nn *NeuralNetworkModel) forwardPassWithActivations(input []float64) ([][]f
 			if layer == numLayers-2 { // Output layer
 				activations[layer+1][j] = nn.sigmoid(z)
 			} else { // Hidden layers
-				activations[layer+1][j] = nn.relu(z)
+				activations[layer+1][j] = nn.activate(z)
 			}
 		}
 	}
@@ -734,8 +894,8 @@ func (nn *NeuralNetworkModel) backpropagate(input, target []float64) {
 				error += nn.weights[layer][weightIndex] * errors[layer+1][k]
 			}
 
-			// Multiply by activation derivative (ReLU for hidden layers)
-			activationDeriv := nn.reluDerivative(preActivations[layer][j])
+			// Multiply by activation derivative (ReLU by default for hidden layers)
+			activationDeriv := nn.activateDerivative(preActivations[layer][j])
 			errors[layer][j] = error * activationDeriv
 		}
 	}
@@ -765,6 +925,219 @@ func (nn *NeuralNetworkModel) backpropagate(input, target []float64) {
 	}
 }
 
+// trainEpoch runs one shuffled pass of mini-batch gradient descent, applying
+// dropout to hidden units and L2 weight decay when configured
+func (nn *NeuralNetworkModel) trainEpoch(inputs, targets [][]float64, batchSize int, rng *rand.Rand) {
+	nn.mutex.Lock()
+	defer nn.mutex.Unlock()
+
+	if batchSize <= 0 {
+		batchSize = 32
+	}
+
+	gradW := make([][]float64, len(nn.weights))
+	gradB := make([][]float64, len(nn.biases))
+	for layer := range nn.weights {
+		gradW[layer] = make([]float64, len(nn.weights[layer]))
+		gradB[layer] = make([]float64, len(nn.biases[layer]))
+	}
+
+	order := rng.Perm(len(inputs))
+	for start := 0; start < len(order); start += batchSize {
+		end := start + batchSize
+		if end > len(order) {
+			end = len(order)
+		}
+
+		for layer := range gradW {
+			for k := range gradW[layer] {
+				gradW[layer][k] = 0
+			}
+			for k := range gradB[layer] {
+				gradB[layer][k] = 0
+			}
+		}
+
+		for _, idx := range order[start:end] {
+			nn.accumulateGradients(inputs[idx], targets[idx], gradW, gradB, rng)
+		}
+
+		scale := 1.0 / float64(end-start)
+		for layer := range nn.weights {
+			for k := range nn.weights[layer] {
+				nn.weights[layer][k] -= nn.learningRate * (gradW[layer][k]*scale + nn.l2Regularization*nn.weights[layer][k])
+			}
+			for k := range nn.biases[layer] {
+				nn.biases[layer][k] -= nn.learningRate * gradB[layer][k] * scale
+			}
+		}
+	}
+
+	nn.lastUpdated = time.Now()
+}
+
+// accumulateGradients adds one sample's gradients to gradW/gradB, using
+// inverted dropout on hidden layers. Caller must hold the write lock.
+func (nn *NeuralNetworkModel) accumulateGradients(input, target []float64, gradW, gradB [][]float64, rng *rand.Rand) {
+	numLayers := len(nn.layers)
+	activations := make([][]float64, numLayers)
+	preActivations := make([][]float64, numLayers)
+	masks := make([][]float64, numLayers)
+	activations[0] = input
+	keep := 1.0 - nn.dropoutRate
+
+	for layer := 0; layer < numLayers-1; layer++ {
+		inputSize := nn.layers[layer]
+		outputSize := nn.layers[layer+1]
+		isOutput := layer == numLayers-2
+
+		preActivations[layer+1] = make([]float64, outputSize)
+		activations[layer+1] = make([]float64, outputSize)
+		if !isOutput && nn.dropoutRate > 0 {
+			masks[layer+1] = make([]float64, outputSize)
+		}
+
+		for j := 0; j < outputSize; j++ {
+			z := nn.biases[layer][j]
+			for i := 0; i < inputSize; i++ {
+				z += activations[layer][i] * nn.weights[layer][i*outputSize+j]
+			}
+			preActivations[layer+1][j] = z
+
+			if isOutput {
+				activations[layer+1][j] = nn.sigmoid(z)
+				continue
+			}
+			activations[layer+1][j] = nn.activate(z)
+			if masks[layer+1] != nil {
+				if rng.Float64() < keep {
+					masks[layer+1][j] = 1.0 / keep
+				}
+				activations[layer+1][j] *= masks[layer+1][j]
+			}
+		}
+	}
+
+	// Output error (same MSE objective as backpropagate)
+	outputLayer := numLayers - 1
+	delta := make([]float64, nn.layers[outputLayer])
+	for j := range delta {
+		delta[j] = (activations[outputLayer][j] - target[j]) * nn.sigmoidDerivative(preActivations[outputLayer][j])
+	}
+
+	for layer := numLayers - 2; layer >= 0; layer-- {
+		inputSize := nn.layers[layer]
+		outputSize := nn.layers[layer+1]
+
+		for i := 0; i < inputSize; i++ {
+			for j := 0; j < outputSize; j++ {
+				gradW[layer][i*outputSize+j] += activations[layer][i] * delta[j]
+			}
+		}
+		for j := 0; j < outputSize; j++ {
+			gradB[layer][j] += delta[j]
+		}
+
+		if layer == 0 {
+			break
+		}
+
+		prevDelta := make([]float64, inputSize)
+		for i := 0; i < inputSize; i++ {
+			err := 0.0
+			for j := 0; j < outputSize; j++ {
+				err += nn.weights[layer][i*outputSize+j] * delta[j]
+			}
+			err *= nn.activateDerivative(preActivations[layer][i])
+			if masks[layer] != nil {
+				err *= masks[layer][i]
+			}
+			prevDelta[i] = err
+		}
+		delta = prevDelta
+	}
+}
+
+// evaluateSamples scores the win probability output against home-win labels,
+// returning log loss, Brier score and accuracy
+func (nn *NeuralNetworkModel) evaluateSamples(inputs [][]float64, labels []float64) (float64, float64, float64) {
+	nn.mutex.RLock()
+	defer nn.mutex.RUnlock()
+
+	if len(inputs) == 0 {
+		return 0, 0, 0
+	}
+
+	logLoss, brier, correct := 0.0, 0.0, 0
+	for i, input := range inputs {
+		prob := math.Max(1e-15, math.Min(1-1e-15, nn.forwardPass(input)[0]))
+		label := labels[i]
+
+		logLoss -= label*math.Log(prob) + (1-label)*math.Log(1-prob)
+		brier += (prob - label) * (prob - label)
+		if (prob >= 0.5) == (label == 1.0) {
+			correct++
+		}
+	}
+
+	n := float64(len(inputs))
+	return logLoss / n, brier / n, float64(correct) / n
+}
+
+// copyParameters returns deep copies of the weights and biases
+func (nn *NeuralNetworkModel) copyParameters() ([][]float64, [][]float64) {
+	nn.mutex.RLock()
+	defer nn.mutex.RUnlock()
+
+	weights := make([][]float64, len(nn.weights))
+	biases := make([][]float64, len(nn.biases))
+	for layer := range nn.weights {
+		weights[layer] = append([]float64(nil), nn.weights[layer]...)
+		biases[layer] = append([]float64(nil), nn.biases[layer]...)
+	}
+	return weights, biases
+}
+
+// setParameters replaces the weights and biases
+func (nn *NeuralNetworkModel) setParameters(weights, biases [][]float64) {
+	nn.mutex.Lock()
+	defer nn.mutex.Unlock()
+	nn.weights = weights
+	nn.biases = biases
+}
+
+// untrainedCopy returns a freshly initialized network with this one's
+// architecture and training settings, for throwaway fits
+func (nn *NeuralNetworkModel) untrainedCopy() *NeuralNetworkModel {
+	nn.mutex.RLock()
+	defer nn.mutex.RUnlock()
+	return NewNeuralNetworkModelWithArchitecture(nn.layers, nn.learningRate, nn.activation, nn.dropoutRate, nn.l2Regularization)
+}
+
+// adoptNetwork replaces this network's architecture and parameters with another's
+func (nn *NeuralNetworkModel) adoptNetwork(other *NeuralNetworkModel) {
+	weights, biases := other.copyParameters()
+
+	other.mutex.RLock()
+	layers := append([]int(nil), other.layers...)
+	learningRate := other.learningRate
+	activation := other.activation
+	dropoutRate := other.dropoutRate
+	l2Regularization := other.l2Regularization
+	other.mutex.RUnlock()
+
+	nn.mutex.Lock()
+	defer nn.mutex.Unlock()
+	nn.layers = layers
+	nn.weights = weights
+	nn.biases = biases
+	nn.learningRate = learningRate
+	nn.activation = activation
+	nn.dropoutRate = dropoutRate
+	nn.l2Regularization = l2Regularization
+	nn.lastUpdated = time.Now()
+}
+
 // ============================================================================
 // NEURAL NETWORK PERSISTENCE
 // ============================================================================
@@ -778,6 +1151,12 @@ type NeuralNetworkData struct {
 	Weight       float64       `json:"weight"`
 	LastUpdated  time.Time     `json:"lastUpdated"`
 	Version      string        `json:"version"`
+
+	// Set when the network came from architecture search
+	Activation       string  `json:"activation,omitempty"`
+	DropoutRate      float64 `json:"dropoutRate,omitempty"`
+	L2Regularization float64 `json:"l2Regularization,omitempty"`
+
 	TrainingInfo struct {
 		TotalGames int    `json:"totalGames"`
 		Notes      string `json:"notes"`
@@ -810,6 +1189,10 @@ func (nn *NeuralNetworkModel) saveWeights() error {
 		Weight:       nn.weight,
 		LastUpdated:  nn.lastUpdated,
 		Version:      "1.0",
+
+		Activation:       nn.activation,
+		DropoutRate:      nn.dropoutRate,
+		L2Regularization: nn.l2Regularization,
 	}
 	data.TrainingInfo.Notes = "Neural Network for NHL game prediction"
 
@@ -848,15 +1231,17 @@ func (nn *NeuralNetworkModel) loadWeights() error {
 		return fmt.Errorf("error unmarshaling neural network data: %v", err)
 	}
 
-	// Validate architecture matches
-	if len(data.Layers) != len(nn.layers) {
+	// Validate architecture: hidden layers may differ if architecture search
+	// promoted a new network, but the input and output sizes must match
+	if len(data.Layers) < 2 ||
+		data.Layers[0] != nn.layers[0] ||
+		data.Layers[len(data.Layers)-1] != nn.layers[len(nn.layers)-1] {
 		return fmt.Errorf("loaded architecture doesn't match: expected %v, got %v", nn.layers, data.Layers)
 	}
-	for i := range data.Layers {
-		if data.Layers[i] != nn.layers[i] {
-			return fmt.Errorf("layer %d size mismatch: expected %d, got %d", i, nn.layers[i], data.Layers[i])
-		}
+	if len(data.Weights) != len(data.Layers)-1 || len(data.Biases) != len(data.Layers)-1 {
+		return fmt.Errorf("loaded weights don't match layers %v", data.Layers)
 	}
+	nn.layers = data.Layers
 
 	// Convert 3D weights back to 2D
 	nn.weights = make([][]float64, len(data.Weights))
@@ -874,6 +1259,9 @@ func (nn *NeuralNetworkModel) loadWeights() error {
 	nn.learningRate = data.LearningRate
 	nn.weight = data.Weight
 	nn.lastUpdated = data.LastUpdated
+	nn.activation = data.Activation
+	nn.dropoutRate = data.DropoutRate
+	nn.l2Regularization = data.L2Regularization
 
 	return nil
 }
@@ -332,32 +332,37 @@ func (mes *ModelEvaluationService) trainModelBatch(modelName string, batch []mod
 		}
 
 	case "GradientBoosting":
+		// Tree models refit from scratch on every completed game with prediction-time features
 		gbModel := GetGradientBoostingModel()
 		if gbModel != nil {
-			for _, game := range batch {
-				if err := gbModel.TrainOnGameResult(game); err == nil {
-					successCount++
-				}
+			if err := gbModel.Train(mes.allTrainingGames(batch)); err != nil {
+				log.Printf("⚠️ Gradient Boosting training skipped: %v", err)
+			} else {
+				successCount = batchSize
 			}
 		}
 
 	case "LSTM":
+		// Record the games for inference sequences, then refit with BPTT on the full history
 		lstmModel := GetLSTMModel()
 		if lstmModel != nil {
 			for _, game := range batch {
-				if err := lstmModel.TrainOnGameResult(game); err == nil {
-					successCount++
-				}
+				lstmModel.TrainOnGameResult(game)
+			}
+			if err := lstmModel.Train(mes.allTrainingGames(batch)); err != nil {
+				log.Printf("⚠️ LSTM training skipped: %v", err)
+			} else {
+				successCount = batchSize
 			}
 		}
 
 	case "RandomForest":
 		rfModel := GetRandomForestModel()
 		if rfModel != nil {
-			for _, game := range batch {
-				if err := rfModel.TrainOnGameResult(game); err == nil {
-					successCount++
-				}
+			if err := rfModel.Train(mes.allTrainingGames(batch)); err != nil {
+				log.Printf("⚠️ Random Forest training skipped: %v", err)
+			} else {
+				successCount = batchSize
 			}
 		}
 	}
@@ -412,6 +417,21 @@ func (mes *ModelEvaluationService) trainModelBatch(modelName string, batch []mod
 	return nil
 }
 
+// allTrainingGames returns all stored completed games plus the current batch,
+// for models that refit on the full history
+func (mes *ModelEvaluationService) allTrainingGames(batch []models.CompletedGame) []models.CompletedGame {
+	snapshotService := GetFeatureSnapshotService()
+	if snapshotService == nil {
+		return batch
+	}
+	history, err := snapshotService.LoadCompletedGames()
+	if err != nil {
+		log.Printf("⚠️ Failed to load completed games for tree training: %v", err)
+		return batch
+	}
+	return mergeGameHistory(history, batch)
+}
+
 // trainBatch trains models on accumulated batch
 func (mes *ModelEvaluationService) trainBatch() error {
 	if len(mes.pendingBatch) == 0 {
@@ -938,20 +958,11 @@ func (mes *ModelEvaluationService) getLosingTeam(game *models.CompletedGame) str
 // PHASE 4: PERIODIC AUTO-SAVE
 // ============================================================================
 
-// StartPeriodicSave saves all model weights and batch queues every 30 minutes
-func (mes *ModelEvaluationService) StartPeriodicSave() {
-	ticker := time.NewTicker(30 * time.Minute)
-	go func() {
-		for range ticker.C {
-			log.Printf("💾 Periodic model save triggered...")
-			mes.SaveAllModels()
-			
-			// Also save batch queues
-			if err := mes.saveBatchQueues(); err != nil {
-				log.Printf("⚠️ Failed to save batch queues during periodic save: %v", err)
-			}
-		}
-	}()
+// SaveModelsAndQueues saves all model weights and the pending batch training
+// queues. The job scheduler calls it every 30 minutes and shutdown once more.
+func (mes *ModelEvaluationService) SaveModelsAndQueues() error {
+	mes.SaveAllModels()
+	return mes.saveBatchQueues()
 }
 
 // SaveAllModels saves all model weights to disk
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Models that TrainModel can refit offline
+const (
+	TrainableGradientBoosting = "gbm"
+	TrainableLSTM             = "lstm"
+	TrainableRandomForest     = "rf"
+	TrainableBayesian         = "bayesian"
+	TrainableStacking         = "stacking"
+)
+
+// TrainableModels lists every model TrainModel accepts
+var TrainableModels = []string{
+	TrainableGradientBoosting,
+	TrainableLSTM,
+	TrainableRandomForest,
+	TrainableBayesian,
+	TrainableStacking,
+}
+
+// trainingMetricNames maps batch-trained models to their training metrics names
+var trainingMetricNames = map[string]string{
+	TrainableGradientBoosting: "GradientBoosting",
+	TrainableLSTM:             "LSTM",
+	TrainableRandomForest:     "RandomForest",
+}
+
+// ModelTrainingSummary describes one offline training run
+type ModelTrainingSummary struct {
+	Model      string          `json:"model"`
+	Games      int             `json:"games"`
+	DurationMs int64           `json:"durationMs"`
+	Stacking   *StackingReport `json:"stacking,omitempty"`
+}
+
+// TrainModel refits one model from the completed games in data/results and
+// saves it, the same way batch training does when new results arrive. It
+// needs no running server, so it can be driven from cron or the CLI.
+func TrainModel(name string) (*ModelTrainingSummary, error) {
+	name = strings.ToLower(name)
+	summary := &ModelTrainingSummary{Model: name}
+	start := time.Now()
+
+	switch name {
+	case TrainableGradientBoosting, TrainableLSTM, TrainableRandomForest:
+		// Tree and sequence models train on prediction-time feature snapshots
+		snapshots := GetFeatureSnapshotService()
+		if snapshots == nil {
+			snapshots = InitFeatureSnapshotService()
+		}
+		games, err := snapshots.LoadCompletedGames()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load completed games: %w", err)
+		}
+		summary.Games = len(games)
+
+		switch name {
+		case TrainableGradientBoosting:
+			err = GetGradientBoostingModel().Train(games)
+		case TrainableLSTM:
+			lstm := GetLSTMModel()
+			if err = lstm.Train(games); err == nil {
+				err = lstm.saveModel()
+			}
+		case TrainableRandomForest:
+			err = GetRandomForestModel().Train(games)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if metrics := GetTrainingMetricsService(); metrics != nil {
+			metrics.RecordTraining(trainingMetricNames[name], "manual", len(games), time.Since(start).Seconds(), 0)
+		}
+
+	case TrainableBayesian:
+		summary.Games = GetBayesianRatingModel().Rebuild()
+		if summary.Games == 0 {
+			return nil, fmt.Errorf("no completed games in data/results to replay")
+		}
+
+	case TrainableStacking:
+		report, err := GetStackingService().Run()
+		if err != nil {
+			return nil, err
+		}
+		summary.Games = report.Games
+		summary.Stacking = report
+
+	default:
+		return nil, fmt.Errorf("unknown model %q (expected one of %s)", name, strings.Join(TrainableModels, ", "))
+	}
+
+	duration := time.Since(start)
+	summary.DurationMs = duration.Milliseconds()
+	log.Printf("✅ Trained %s on %d games in %.1fs", name, summary.Games, duration.Seconds())
+	return summary, nil
+}
@@ -78,6 +78,9 @@ type UncertaintyQuantification struct {
 	UncertaintySource    []UncertaintySource `json:"uncertaintySource"`    // Sources of uncertainty
 	ConfidenceInterval   ConfidenceInterval  `json:"confidenceInterval"`   // Prediction interval
 	RecommendedAction    string              `json:"recommendedAction"`    // How to interpret
+
+	// Predictive distribution from the Bayesian rating model, when available
+	PredictiveDistribution *models.PredictiveDistribution `json:"predictiveDistribution,omitempty"`
 }
 
 // UncertaintySource represents a source of prediction uncertainty
@@ -112,8 +115,11 @@ func (mus *ModelUncertaintyService) QuantifyUncertainty(
 	// Calculate data quality
 	dataQuality := mus.calculateDataQuality(homeFactors, awayFactors)
 
+	// Rating uncertainty from the Bayesian model's predictive distribution
+	distribution := mus.ratingDistribution(prediction.ModelResults, homeFactors.TeamCode, awayFactors.TeamCode)
+
 	// Calculate historical variance
-	historicalVariance := mus.calculateHistoricalVariance(homeFactors.TeamCode, awayFactors.TeamCode)
+	historicalVariance := mus.calculateHistoricalVariance(homeFactors.TeamCode, awayFactors.TeamCode, distribution)
 
 	// Calculate feature uncertainty
 	featureUncertainty := mus.calculateFeatureUncertainty(homeFactors, awayFactors)
@@ -148,6 +154,8 @@ func (mus *ModelUncertaintyService) QuantifyUncertainty(
 		UncertaintySource:    uncertaintySources,
 		ConfidenceInterval:   confidenceInterval,
 		RecommendedAction:    recommendedAction,
+
+		PredictiveDistribution: distribution,
 	}
 
 	log.Printf("✅ Uncertainty quantification complete - Total: %.2f, Model Agreement: %.2f",
@@ -213,10 +221,31 @@ func (mus *ModelUncertaintyService) calculateDataQuality(homeFactors, awayFactor
 	return totalQuality / float64(len(qualityFactors))
 }
 
-// calculateHistoricalVariance calculates variance in historical predictions for these teams
-func (mus *ModelUncertaintyService) calculateHistoricalVariance(homeTeam, awayTeam string) float64 {
-	// In a real implementation, this would query historical predictions
-	// For now, return a default based on team codes
+// ratingDistribution returns the Bayesian rating model's predictive
+// distribution, from the ensemble's results if it ran or from the model directly
+func (mus *ModelUncertaintyService) ratingDistribution(modelResults []models.ModelResult, homeTeam, awayTeam string) *models.PredictiveDistribution {
+	for _, result := range modelResults {
+		if result.Distribution != nil {
+			return result.Distribution
+		}
+	}
+
+	ratings := GetBayesianRatingModel()
+	if ratings == nil {
+		return nil
+	}
+	distribution := ratings.PredictiveDistribution(homeTeam, awayTeam)
+	return &distribution
+}
+
+// calculateHistoricalVariance calculates variance in historical predictions for these teams.
+// With a rating posterior this is the spread of P(home win) it implies.
+func (mus *ModelUncertaintyService) calculateHistoricalVariance(homeTeam, awayTeam string, distribution *models.PredictiveDistribution) float64 {
+	if distribution != nil && distribution.HomeWinStdDev > 0 {
+		return distribution.HomeWinStdDev
+	}
+
+	// No posterior: fall back to a default based on team codes
 
 	teamVarianceMap := map[string]float64{
 		"UTA": 0.15, "COL": 0.12, "VGK": 0.13, "SJS": 0.18, "LAK": 0.14,
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sync"
@@ -13,7 +14,6 @@ import (
 type ModelUpdateScheduler struct {
 	models          []UpdatableModel
 	updateInterval  time.Duration
-	ticker          *time.Ticker
 	isRunning       bool
 	mutex           sync.RWMutex
 	updateHistory   []UpdateRecord
@@ -114,14 +114,11 @@ func (mus *ModelUpdateScheduler) Start() error {
 	}
 
 	mus.isRunning = true
-	mus.ticker = time.NewTicker(mus.updateInterval)
 
-	// Start the update worker
+	// Start the update worker; periodic refreshes are queued by the job
+	// scheduler through RunPeriodicUpdates
 	go mus.updateWorker()
 
-	// Start the periodic scheduler
-	go mus.periodicScheduler()
-
 	// Subscribe to live data updates
 	mus.liveDataService.Subscribe(mus)
 
@@ -139,9 +136,6 @@ func (mus *ModelUpdateScheduler) Stop() error {
 	}
 
 	mus.isRunning = false
-	if mus.ticker != nil {
-		mus.ticker.Stop()
-	}
 	close(mus.updateQueue)
 
 	log.Printf("⏹️ Model Update Scheduler stopped")
@@ -191,15 +185,18 @@ func (mus *ModelUpdateScheduler) updateWorker() {
 	}
 }
 
-// periodicScheduler handles scheduled updates
-func (mus *ModelUpdateScheduler) periodicScheduler() {
-	for range mus.ticker.C {
-		if !mus.isRunning {
-			break
-		}
+// RunPeriodicUpdates queues a full refresh for every registered model.
+// Called by the job scheduler every UpdateInterval.
+func (mus *ModelUpdateScheduler) RunPeriodicUpdates(ctx context.Context) error {
+	// Held for the whole refresh so Stop can't close the queue mid-send
+	mus.mutex.RLock()
+	defer mus.mutex.RUnlock()
 
-		mus.schedulePeriodicUpdates()
+	if !mus.isRunning {
+		return nil
 	}
+	mus.schedulePeriodicUpdates()
+	return ctx.Err()
 }
 
 // processUpdateTask processes a single model update task
@@ -559,18 +556,20 @@ func (mus *ModelUpdateScheduler) GetModelStats() map[string]ModelUpdateStats {
 	return stats
 }
 
-// SetUpdateInterval changes the update interval
+// UpdateInterval is how often periodic full refreshes should run
+func (mus *ModelUpdateScheduler) UpdateInterval() time.Duration {
+	mus.mutex.RLock()
+	defer mus.mutex.RUnlock()
+	return mus.updateInterval
+}
+
+// SetUpdateInterval changes the update interval. It takes effect when the
+// periodic refresh job is next registered.
 func (mus *ModelUpdateScheduler) SetUpdateInterval(interval time.Duration) {
 	mus.mutex.Lock()
 	defer mus.mutex.Unlock()
 
 	mus.updateInterval = interval
-
-	if mus.ticker != nil {
-		mus.ticker.Stop()
-		mus.ticker = time.NewTicker(interval)
-	}
-
 	log.Printf("⏱️ Update interval changed to %v", interval)
 }
 
@@ -1,10 +1,9 @@
 package services
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"log"
 	"time"
 
 	"github.com/jaredshillingburg/go_uhc/models"
@@ -14,83 +13,121 @@ import (
 // This function is rate-limited to prevent API abuse and uses caching to reduce API calls
 // It also deduplicates concurrent requests for the same URL
 func MakeAPICall(urlIn string) ([]byte, error) {
+	return MakeAPICallContext(context.Background(), urlIn)
+}
+
+// MakeAPICallContext is MakeAPICall that returns ctx.Err() as soon as ctx is
+// done, whether waiting on the rate limiter, another caller's identical
+// request or the NHL API itself
+//
+// A cached response past its TTL but within its stale-while-revalidate
+// window is returned immediately while a background request refreshes it,
+// so callers only wait on the NHL API for responses they've never fetched
+// or that are long out of date.
+func MakeAPICallContext(ctx context.Context, urlIn string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Check cache first
 	cache := GetAPICacheService()
 	if cache != nil {
-		if cachedData, found := cache.Get(urlIn); found {
+		entry, state := cache.lookup(urlIn)
+		switch state {
+		case cacheFresh:
 			fmt.Printf("✅ Cache HIT for: %s\n", urlIn)
-			return cachedData, nil
+			return entry.Data, nil
+		case cacheStale:
+			fmt.Printf("♻️ Cache STALE for: %s (revalidating)\n", urlIn)
+			revalidateInBackground(urlIn, cache)
+			return entry.Data, nil
 		}
 		fmt.Printf("❌ Cache MISS for: %s\n", urlIn)
 	}
 
-	// Use request deduplication to prevent concurrent duplicate calls
+	return fetchDeduplicated(ctx, urlIn, cache)
+}
+
+// fetchDeduplicated fetches urlIn, sharing the request with any concurrent
+// callers for the same URL
+func fetchDeduplicated(ctx context.Context, urlIn string, cache *APICacheService) ([]byte, error) {
 	deduplicator := GetRequestDeduplicator()
 	if deduplicator != nil {
-		return deduplicator.Do(urlIn, func() ([]byte, error) {
-			return makeAPICallInternal(urlIn, cache)
+		return deduplicator.DoContext(ctx, urlIn, func(ctx context.Context) ([]byte, error) {
+			return makeAPICallInternal(ctx, urlIn, cache)
 		})
 	}
 
 	// Fallback if deduplicator not initialized
-	return makeAPICallInternal(urlIn, cache)
+	return makeAPICallInternal(ctx, urlIn, cache)
 }
 
-// makeAPICallInternal performs the actual API call
-func makeAPICallInternal(urlIn string, cache *APICacheService) ([]byte, error) {
-	// Rate limit BEFORE making the API call
-	rateLimiter := GetNHLRateLimiter()
-	rateLimiter.Wait()
+// revalidateInBackground refreshes a stale cache entry unless a refresh for
+// it is already running
+func revalidateInBackground(urlIn string, cache *APICacheService) {
+	if !cache.beginRevalidation(urlIn) {
+		return
+	}
 
-	fmt.Printf("Making API call to: %s\n", urlIn)
+	go func() {
+		defer cache.endRevalidation(urlIn)
+		if _, err := fetchDeduplicated(BackgroundContext(), urlIn, cache); err != nil {
+			log.Printf("⚠️ Background revalidation failed for %s: %v", urlIn, err)
+		}
+	}()
+}
 
-	req, err := http.NewRequest("GET", urlIn, nil)
-	if err != nil {
-		fmt.Printf("Error creating request: %v\n", err)
-		return nil, err
-	}
+// makeAPICallInternal fetches through the resilient NHL client and caches the
+// response. If a copy is cached it sends a conditional request, and a 304
+// just extends the cached copy. If the upstream is failing it falls back to
+// a stale cached copy.
+func makeAPICallInternal(ctx context.Context, urlIn string, cache *APICacheService) ([]byte, error) {
+	client := GetNHLClient()
 
-	// Add User-Agent header to avoid being blocked
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; UHC-Bot/1.0)")
+	var etag, lastModified string
+	if cache != nil {
+		etag, lastModified, _ = cache.Validators(urlIn)
+	}
 
-	// Use shared HTTP client with connection pooling
-	res, err := SharedHTTPClient.Do(req)
+	resp, err := client.Fetch(ctx, urlIn, etag, lastModified)
+	if err == nil && resp.NotModified {
+		if body, ok := cache.Revalidated(urlIn); ok {
+			fmt.Printf("✅ Not modified, cache extended for %s\n", urlIn)
+			return body, nil
+		}
+		// Evicted while we were asking; fetch the body unconditionally
+		resp, err = client.Fetch(ctx, urlIn, "", "")
+	}
 	if err != nil {
+		if cache != nil && shouldServeStale(err) {
+			if stale, cachedAt, ok := cache.GetStale(urlIn); ok {
+				client.recordStale(urlIn)
+				log.Printf("♻️ Serving stale response for %s (cached %v ago): %v",
+					urlIn, time.Since(cachedAt).Round(time.Second), err)
+				return stale, nil
+			}
+		}
 		fmt.Printf("Error making request: %v\n", err)
 		return nil, err
 	}
-	defer res.Body.Close()
-
-	fmt.Printf("API Response Status: %d\n", res.StatusCode)
-
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status code: %d", res.StatusCode)
-	}
-
-	body, readErr := io.ReadAll(res.Body)
-	if readErr != nil {
-		fmt.Printf("Error reading response body: %v\n", readErr)
-		return nil, readErr
-	}
-
-	fmt.Printf("Response body length: %d bytes\n", len(body))
 
-	// Cache the response with appropriate TTL
+	// Cache the response with a TTL based on the endpoint and game state
 	if cache != nil {
-		ttl := GetTTLForEndpoint(urlIn)
-		cache.Set(urlIn, body, ttl)
-		fmt.Printf("💾 Cached response for %s (TTL: %s)\n", urlIn, ttl)
+		if ttl := cache.Store(urlIn, resp.Body, resp.ETag, resp.LastModified); ttl > 0 {
+			fmt.Printf("💾 Cached response for %s (TTL: %s)\n", urlIn, ttl)
+		} else {
+			fmt.Printf("💾 Cached response for %s permanently (final games)\n", urlIn)
+		}
 	}
 
-	return body, nil
+	return resp.Body, nil
 }
 
 // GetTeamSchedule fetches the next upcoming game for a specific team
 func GetTeamSchedule(teamCode string) (models.Game, error) {
 	fmt.Printf("Fetching %s schedule...\n", teamCode)
 
-	urlIn := fmt.Sprintf("https://api-web.nhle.com/v1/club-schedule/%s/week/now", teamCode)
-	body, err := MakeAPICall(urlIn)
+	data, err := GetNHLAPIClient().WeekSchedule(context.Background(), teamCode)
 	if err != nil {
 		fmt.Printf("Error calling schedule API: %v\n", err)
 		return models.Game{}, err
@@ -103,16 +140,6 @@ func GetTeamSchedule(teamCode string) (models.Game, error) {
 		return models.Game{}, locErr
 	}
 
-	var data models.ScheduleResponse
-
-	// Add debug output for raw response
-	fmt.Printf("Raw API response: %s\n", string(body))
-
-	if err := json.Unmarshal(body, &data); err != nil {
-		fmt.Printf("Error unmarshaling schedule JSON: %v\n", err)
-		return models.Game{}, err
-	}
-
 	fmt.Printf("Found %d games in schedule response\n", len(data.Games))
 
 	// Find the next upcoming game
@@ -155,10 +182,14 @@ func GetUHCSchedule() (models.Game, error) {
 
 // GetTeamUpcomingGames returns all upcoming games in the next 7 days for a specific team
 func GetTeamUpcomingGames(teamCode string) ([]models.Game, error) {
+	return GetTeamUpcomingGamesContext(context.Background(), teamCode)
+}
+
+// GetTeamUpcomingGamesContext is GetTeamUpcomingGames bounded by ctx
+func GetTeamUpcomingGamesContext(ctx context.Context, teamCode string) ([]models.Game, error) {
 	fmt.Printf("Fetching upcoming games for %s...\n", teamCode)
 
-	urlIn := fmt.Sprintf("https://api-web.nhle.com/v1/club-schedule/%s/week/now", teamCode)
-	body, err := MakeAPICall(urlIn)
+	data, err := GetNHLAPIClient().WeekSchedule(ctx, teamCode)
 	if err != nil {
 		fmt.Printf("Error fetching upcoming games: %v\n", err)
 		return nil, err
@@ -170,14 +201,8 @@ func GetTeamUpcomingGames(teamCode string) ([]models.Game, error) {
 		return nil, locErr
 	}
 
-	var data models.ScheduleResponse
 	var upcomingGames []models.Game
 
-	if err := json.Unmarshal(body, &data); err != nil {
-		fmt.Printf("Error unmarshaling upcoming games JSON: %v\n", err)
-		return nil, err
-	}
-
 	fmt.Printf("Processing %d games for upcoming games list\n", len(data.Games))
 
 	for _, game := range data.Games {
@@ -216,21 +241,19 @@ func GetUpcomingGames() ([]models.Game, error) {
 
 // GetTeamSeasonSchedule fetches the full season schedule (all 82+ games) for a team
 func GetTeamSeasonSchedule(teamCode string, season int) ([]models.Game, error) {
+	return GetTeamSeasonScheduleContext(context.Background(), teamCode, season)
+}
+
+// GetTeamSeasonScheduleContext is GetTeamSeasonSchedule bounded by ctx
+func GetTeamSeasonScheduleContext(ctx context.Context, teamCode string, season int) ([]models.Game, error) {
 	fmt.Printf("Fetching full season schedule for %s (season %d)...\n", teamCode, season)
 
-	urlIn := fmt.Sprintf("https://api-web.nhle.com/v1/club-schedule-season/%s/%d", teamCode, season)
-	body, err := MakeAPICall(urlIn)
+	data, err := GetNHLAPIClient().SeasonSchedule(ctx, teamCode, season)
 	if err != nil {
 		fmt.Printf("Error fetching season schedule: %v\n", err)
 		return nil, err
 	}
 
-	var data models.ScheduleResponse
-	if err := json.Unmarshal(body, &data); err != nil {
-		fmt.Printf("Error unmarshaling season schedule JSON: %v\n", err)
-		return nil, err
-	}
-
 	fmt.Printf("Successfully fetched season schedule: %d games\n", len(data.Games))
 	return data.Games, nil
 }
@@ -239,22 +262,12 @@ func GetTeamSeasonSchedule(teamCode string, season int) ([]models.Game, error) {
 func GetTeamScoreboard(teamCode string) (models.ScoreboardGame, error) {
 	fmt.Printf("Fetching %s scoreboard...\n", teamCode)
 
-	urlIn := fmt.Sprintf("https://api-web.nhle.com/v1/scoreboard/%s/now", teamCode)
-	body, err := MakeAPICall(urlIn)
+	data, err := GetNHLAPIClient().Scoreboard(context.Background(), teamCode)
 	if err != nil {
 		fmt.Printf("Error calling scoreboard API: %v\n", err)
 		return models.ScoreboardGame{}, err
 	}
 
-	// Add debug output for raw response
-	fmt.Printf("Raw scoreboard API response: %s\n", string(body))
-
-	var data models.ScoreboardResponse
-	if err := json.Unmarshal(body, &data); err != nil {
-		fmt.Printf("Error unmarshaling scoreboard JSON: %v\n", err)
-		return models.ScoreboardGame{}, err
-	}
-
 	fmt.Printf("Found %d date entries in scoreboard response\n", len(data.GamesByDate))
 	fmt.Printf("Focused date (today): %s\n", data.FocusedDate)
 
@@ -305,20 +318,13 @@ func GetStandings() (models.StandingsResponse, error) {
 
 	// Fallback if cache service not initialized
 	fmt.Println("⚠️ Standings cache not initialized, using direct API call")
-	urlIn := "https://api-web.nhle.com/v1/standings/now"
-	body, err := MakeAPICall(urlIn)
+	data, err := GetNHLAPIClient().Standings(context.Background())
 	if err != nil {
 		fmt.Printf("Error fetching standings: %v\n", err)
 		return models.StandingsResponse{}, err
 	}
 
-	var data models.StandingsResponse
-	if err := json.Unmarshal(body, &data); err != nil {
-		fmt.Printf("Error parsing standings JSON: %v\n", err)
-		return models.StandingsResponse{}, err
-	}
-
-	return data, nil
+	return *data, nil
 }
 
 // TestAPIEndpoints tests all NHL API endpoints to verify they're working
@@ -0,0 +1,537 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the NHL API while an endpoint's
+// circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// NHLAPIError is a non-200 response from the NHL API
+type NHLAPIError struct {
+	URL        string
+	StatusCode int
+	RetryAfter time.Duration // From the Retry-After header, 0 if absent
+}
+
+func (e *NHLAPIError) Error() string {
+	return fmt.Sprintf("API returned status code: %d", e.StatusCode)
+}
+
+// Retryable reports whether the request may succeed if tried again:
+// rate limiting (429) and server errors (5xx). Other 4xx are final.
+func (e *NHLAPIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// IsNHLNotFound reports whether err is a 404 from the NHL API
+func IsNHLNotFound(err error) bool {
+	var apiErr *NHLAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// isRetryableError reports whether err is worth retrying and counts against
+// the circuit breaker: retryable API statuses and transport errors. Context
+// cancellation and final 4xx responses are not.
+func isRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *NHLAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+	return !errors.Is(err, ErrCircuitOpen)
+}
+
+// shouldServeStale reports whether a stale cached response is a better answer
+// than err: the upstream is failing or its circuit is open, not that the
+// resource doesn't exist or the caller gave up
+func shouldServeStale(err error) bool {
+	return errors.Is(err, ErrCircuitOpen) || isRetryableError(err)
+}
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// circuitBreaker trips after consecutive retryable failures on one endpoint,
+// fails fast while open, then lets a single probe through after the cooldown
+type circuitBreaker struct {
+	state     string
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	trips     int64
+	rejected  int64
+	threshold int
+	cooldown  time.Duration
+}
+
+// allow reports whether a request may go out now
+func (cb *circuitBreaker) allow(now time.Time) bool {
+	switch cb.state {
+	case CircuitOpen:
+		if now.Sub(cb.openedAt) < cb.cooldown {
+			cb.rejected++
+			return false
+		}
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+		return true
+	case CircuitHalfOpen:
+		if cb.probing {
+			cb.rejected++
+			return false
+		}
+		cb.probing = true
+		return true
+	}
+	return true
+}
+
+func (cb *circuitBreaker) recordSuccess() {
+	cb.state = CircuitClosed
+	cb.failures = 0
+	cb.probing = false
+}
+
+// recordFailure returns true if this failure tripped the breaker
+func (cb *circuitBreaker) recordFailure(now time.Time) bool {
+	cb.probing = false
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.threshold {
+		wasOpen := cb.state == CircuitOpen
+		cb.state = CircuitOpen
+		cb.openedAt = now
+		if !wasOpen {
+			cb.trips++
+			return true
+		}
+	}
+	return false
+}
+
+// NHLClientConfig tunes retries and circuit breaking
+type NHLClientConfig struct {
+	MaxAttempts      int           // Total attempts per call, including the first
+	BaseBackoff      time.Duration // Backoff before the first retry, doubled each retry
+	MaxBackoff       time.Duration // Cap on a single backoff
+	MaxRetryAfter    time.Duration // Give up rather than honor a longer Retry-After
+	FailureThreshold int           // Consecutive failures that open an endpoint's circuit
+	OpenCooldown     time.Duration // How long a circuit stays open before a probe
+}
+
+// DefaultNHLClientConfig returns the production retry and breaker settings
+func DefaultNHLClientConfig() NHLClientConfig {
+	return NHLClientConfig{
+		MaxAttempts:      4,
+		BaseBackoff:      500 * time.Millisecond,
+		MaxBackoff:       10 * time.Second,
+		MaxRetryAfter:    60 * time.Second,
+		FailureThreshold: 5,
+		OpenCooldown:     30 * time.Second,
+	}
+}
+
+// endpointStats counts calls to one endpoint
+type endpointStats struct {
+	requests    int64
+	successes   int64
+	failures    int64
+	retries     int64
+	staleServed int64
+	lastError   string
+	lastErrorAt time.Time
+	breaker     *circuitBreaker
+}
+
+// NHLClient makes NHL API GET requests with rate limiting, retries with
+// exponential backoff and jitter, and a circuit breaker per endpoint
+type NHLClient struct {
+	httpClient *http.Client
+	config     NHLClientConfig
+	endpoints  map[string]*endpointStats
+	rng        *rand.Rand
+	mutex      sync.Mutex
+}
+
+// NHLEndpointMetrics reports one endpoint's calls and breaker state
+type NHLEndpointMetrics struct {
+	Endpoint     string    `json:"endpoint"`
+	Requests     int64     `json:"requests"`
+	Successes    int64     `json:"successes"`
+	Failures     int64     `json:"failures"`
+	Retries      int64     `json:"retries"`
+	StaleServed  int64     `json:"staleServed"`
+	CircuitState string    `json:"circuitState"`
+	CircuitTrips int64     `json:"circuitTrips"`
+	Rejected     int64     `json:"rejected"` // Calls failed fast by an open circuit
+	LastError    string    `json:"lastError,omitempty"`
+	LastErrorAt  time.Time `json:"lastErrorAt,omitempty"`
+}
+
+// NHLClientMetrics totals the client's calls across endpoints
+type NHLClientMetrics struct {
+	Requests     int64                `json:"requests"`
+	Successes    int64                `json:"successes"`
+	Failures     int64                `json:"failures"`
+	Retries      int64                `json:"retries"`
+	StaleServed  int64                `json:"staleServed"`
+	Rejected     int64                `json:"rejected"`
+	OpenCircuits int                  `json:"openCircuits"`
+	Endpoints    []NHLEndpointMetrics `json:"endpoints"`
+}
+
+var (
+	nhlClient     *NHLClient
+	nhlClientOnce sync.Once
+)
+
+// GetNHLClient returns the shared NHL API client
+func GetNHLClient() *NHLClient {
+	nhlClientOnce.Do(func() {
+		nhlClient = NewNHLClient(SharedHTTPClient, DefaultNHLClientConfig())
+	})
+	return nhlClient
+}
+
+// NewNHLClient creates a client; most callers want GetNHLClient
+func NewNHLClient(httpClient *http.Client, config NHLClientConfig) *NHLClient {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	return &NHLClient{
+		httpClient: httpClient,
+		config:     config,
+		endpoints:  make(map[string]*endpointStats),
+		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// NHLResponse is a successful response from the NHL API
+type NHLResponse struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+	NotModified  bool // 304: the caller's cached copy is current, Body is empty
+}
+
+// Get fetches urlIn, retrying 429s, 5xx and transport errors with backoff.
+// Returns ErrCircuitOpen without a request while the endpoint's circuit is
+// open, an *NHLAPIError for non-200 responses, or ctx.Err() once ctx is done.
+func (c *NHLClient) Get(ctx context.Context, urlIn string) ([]byte, error) {
+	resp, err := c.Fetch(ctx, urlIn, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Fetch is Get with a conditional request: given a cached copy's ETag or
+// Last-Modified, the NHL API may answer 304 Not Modified instead of
+// resending the body. The response's validators are returned for caching.
+func (c *NHLClient) Fetch(ctx context.Context, urlIn, etag, lastModified string) (*NHLResponse, error) {
+	endpoint := nhlEndpointKey(urlIn)
+
+	var lastErr error
+	for attempt := 0; attempt < c.config.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			delay, ok := c.retryDelay(attempt, lastErr)
+			if !ok {
+				break
+			}
+			c.recordRetry(endpoint)
+			log.Printf("🔁 Retrying %s in %v (attempt %d/%d): %v",
+				urlIn, delay.Round(time.Millisecond), attempt+1, c.config.MaxAttempts, lastErr)
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
+		}
+
+		if !c.allow(endpoint) {
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w for %s after: %v", ErrCircuitOpen, endpoint, lastErr)
+			}
+			return nil, fmt.Errorf("%w for %s", ErrCircuitOpen, endpoint)
+		}
+
+		resp, err := c.do(ctx, urlIn, etag, lastModified)
+		circuitOpen := c.record(endpoint, err)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !isRetryableError(err) {
+			return nil, err
+		}
+		if circuitOpen {
+			return nil, fmt.Errorf("%w for %s after: %v", ErrCircuitOpen, endpoint, err)
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// do makes a single rate-limited attempt
+func (c *NHLClient) do(ctx context.Context, urlIn, etag, lastModified string) (*NHLResponse, error) {
+	if err := GetNHLRateLimiter().WaitContext(ctx); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Making API call to: %s\n", urlIn)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", urlIn, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add User-Agent header to avoid being blocked
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; UHC-Bot/1.0)")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		fmt.Printf("Error making request: %v\n", err)
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	fmt.Printf("API Response Status: %d\n", res.StatusCode)
+
+	if res.StatusCode == http.StatusNotModified && (etag != "" || lastModified != "") {
+		return &NHLResponse{
+			ETag:         res.Header.Get("ETag"),
+			LastModified: res.Header.Get("Last-Modified"),
+			NotModified:  true,
+		}, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		// Drain so the connection can be reused
+		io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+		return nil, &NHLAPIError{
+			URL:        urlIn,
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		fmt.Printf("Error reading response body: %v\n", err)
+		return nil, err
+	}
+
+	fmt.Printf("Response body length: %d bytes\n", len(body))
+	return &NHLResponse{
+		Body:         body,
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// retryDelay returns how long to wait before the given retry: full jitter
+// over an exponentially growing window, but never less than the server's
+// Retry-After. Returns false if Retry-After asks for longer than we'll wait.
+func (c *NHLClient) retryDelay(attempt int, lastErr error) (time.Duration, bool) {
+	window := c.config.BaseBackoff << uint(attempt-1)
+	if window > c.config.MaxBackoff || window <= 0 {
+		window = c.config.MaxBackoff
+	}
+
+	c.mutex.Lock()
+	delay := time.Duration(c.rng.Int63n(int64(window) + 1))
+	c.mutex.Unlock()
+
+	var apiErr *NHLAPIError
+	if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > 0 {
+		if apiErr.RetryAfter > c.config.MaxRetryAfter {
+			return 0, false
+		}
+		if apiErr.RetryAfter > delay {
+			delay = apiErr.RetryAfter
+		}
+	}
+	return delay, true
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date, returning 0 if it's missing or unparseable
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// nhlEndpointKey groups URLs by endpoint for circuit breaking and metrics:
+// host plus path, with numeric IDs, dates and seasons replaced by ":id" so
+// /gamecenter/2024020001/boxscore and /gamecenter/2024020002/boxscore share
+// a breaker
+func nhlEndpointKey(urlIn string) string {
+	parsed, err := url.Parse(urlIn)
+	if err != nil || parsed.Host == "" {
+		return urlIn
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789-") == "" {
+			segments[i] = ":id"
+		}
+	}
+	return parsed.Host + "/" + strings.Join(segments, "/")
+}
+
+// stats returns the endpoint's stats, creating them; caller holds c.mutex
+func (c *NHLClient) stats(endpoint string) *endpointStats {
+	stats, ok := c.endpoints[endpoint]
+	if !ok {
+		stats = &endpointStats{breaker: &circuitBreaker{
+			state:     CircuitClosed,
+			threshold: c.config.FailureThreshold,
+			cooldown:  c.config.OpenCooldown,
+		}}
+		c.endpoints[endpoint] = stats
+	}
+	return stats
+}
+
+func (c *NHLClient) allow(endpoint string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stats(endpoint).breaker.allow(time.Now())
+}
+
+// record counts an attempt's outcome, returning whether the endpoint's
+// circuit is now open
+func (c *NHLClient) record(endpoint string, err error) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.stats(endpoint)
+	stats.requests++
+	switch {
+	case err == nil:
+		stats.successes++
+		stats.breaker.recordSuccess()
+	case isRetryableError(err):
+		stats.failures++
+		stats.lastError = err.Error()
+		stats.lastErrorAt = time.Now()
+		if stats.breaker.recordFailure(time.Now()) {
+			log.Printf("🔌 Circuit opened for %s after %d consecutive failures (cooldown %v)",
+				endpoint, stats.breaker.failures, c.config.OpenCooldown)
+		}
+		return stats.breaker.state == CircuitOpen
+	default:
+		// A final 4xx or a cancelled request says nothing about the
+		// upstream's health, so it doesn't count against the breaker
+		stats.failures++
+		stats.lastError = err.Error()
+		stats.lastErrorAt = time.Now()
+		if stats.breaker.state == CircuitHalfOpen {
+			stats.breaker.probing = false
+		}
+	}
+	return false
+}
+
+func (c *NHLClient) recordRetry(endpoint string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stats(endpoint).retries++
+}
+
+// recordStale counts a stale cached response served in place of a failure
+func (c *NHLClient) recordStale(urlIn string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stats(nhlEndpointKey(urlIn)).staleServed++
+}
+
+// CircuitState returns the breaker state for the endpoint urlIn belongs to
+func (c *NHLClient) CircuitState(urlIn string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stats(nhlEndpointKey(urlIn)).breaker.state
+}
+
+// GetMetrics returns per-endpoint call counts and breaker states, busiest
+// endpoints first
+func (c *NHLClient) GetMetrics() NHLClientMetrics {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var metrics NHLClientMetrics
+	for endpoint, stats := range c.endpoints {
+		em := NHLEndpointMetrics{
+			Endpoint:     endpoint,
+			Requests:     stats.requests,
+			Successes:    stats.successes,
+			Failures:     stats.failures,
+			Retries:      stats.retries,
+			StaleServed:  stats.staleServed,
+			CircuitState: stats.breaker.state,
+			CircuitTrips: stats.breaker.trips,
+			Rejected:     stats.breaker.rejected,
+			LastError:    stats.lastError,
+			LastErrorAt:  stats.lastErrorAt,
+		}
+		metrics.Requests += em.Requests
+		metrics.Successes += em.Successes
+		metrics.Failures += em.Failures
+		metrics.Retries += em.Retries
+		metrics.StaleServed += em.StaleServed
+		metrics.Rejected += em.Rejected
+		if em.CircuitState != CircuitClosed {
+			metrics.OpenCircuits++
+		}
+		metrics.Endpoints = append(metrics.Endpoints, em)
+	}
+
+	sort.Slice(metrics.Endpoints, func(i, j int) bool {
+		if metrics.Endpoints[i].Requests != metrics.Endpoints[j].Requests {
+			return metrics.Endpoints[i].Requests > metrics.Endpoints[j].Requests
+		}
+		return metrics.Endpoints[i].Endpoint < metrics.Endpoints[j].Endpoint
+	})
+	return metrics
+}
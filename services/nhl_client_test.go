@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	cb := &circuitBreaker{state: CircuitClosed, threshold: 3, cooldown: 30 * time.Second}
+	now := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if cb.recordFailure(now) {
+			t.Fatalf("Breaker tripped after %d failures, threshold is 3", i+1)
+		}
+	}
+	cb.recordSuccess()
+	if cb.failures != 0 {
+		t.Errorf("Expected a success to reset the failure count, got %d", cb.failures)
+	}
+
+	for i := 0; i < 2; i++ {
+		cb.recordFailure(now)
+	}
+	if !cb.recordFailure(now) {
+		t.Fatal("Expected the third consecutive failure to trip the breaker")
+	}
+	if cb.state != CircuitOpen || cb.trips != 1 {
+		t.Fatalf("Expected an open breaker with 1 trip, got %s with %d", cb.state, cb.trips)
+	}
+	if cb.allow(now.Add(10 * time.Second)) {
+		t.Error("Expected an open breaker to reject requests during the cooldown")
+	}
+	if cb.rejected != 1 {
+		t.Errorf("Expected 1 rejected request, got %d", cb.rejected)
+	}
+
+	// After the cooldown exactly one probe goes out
+	probeAt := now.Add(31 * time.Second)
+	if !cb.allow(probeAt) {
+		t.Fatal("Expected a probe after the cooldown")
+	}
+	if cb.state != CircuitHalfOpen {
+		t.Errorf("Expected half open while probing, got %s", cb.state)
+	}
+	if cb.allow(probeAt) {
+		t.Error("Expected a second request to wait for the probe")
+	}
+
+	// A failed probe reopens the breaker
+	cb.recordFailure(probeAt)
+	if cb.state != CircuitOpen || !cb.openedAt.Equal(probeAt) {
+		t.Errorf("Expected the failed probe to restart the cooldown, got %s opened at %v", cb.state, cb.openedAt)
+	}
+
+	// A successful probe closes it
+	if !cb.allow(probeAt.Add(31 * time.Second)) {
+		t.Fatal("Expected a second probe after the new cooldown")
+	}
+	cb.recordSuccess()
+	if cb.state != CircuitClosed || !cb.allow(probeAt.Add(31*time.Second)) {
+		t.Errorf("Expected a successful probe to close the breaker, got %s", cb.state)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header   string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"  ", 0},
+		{"120", 2 * time.Minute},
+		{" 5 ", 5 * time.Second},
+		{"0", 0},
+		{"-3", 0},
+		{"soon", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0}, // Already passed
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.header, now); got != tt.expected {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.expected)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	config := DefaultNHLClientConfig()
+	config.BaseBackoff = 100 * time.Millisecond
+	config.MaxBackoff = time.Second
+	config.MaxRetryAfter = 30 * time.Second
+	c := NewNHLClient(http.DefaultClient, config)
+
+	// Full jitter stays within a doubling window capped at MaxBackoff
+	for attempt, window := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		70: time.Second, // Shift overflow
+	} {
+		for i := 0; i < 50; i++ {
+			delay, ok := c.retryDelay(attempt, errors.New("connection reset"))
+			if !ok {
+				t.Fatalf("retryDelay(%d) gave up on a transport error", attempt)
+			}
+			if delay < 0 || delay > window {
+				t.Fatalf("retryDelay(%d) = %v, want within [0, %v]", attempt, delay, window)
+			}
+		}
+	}
+
+	// Retry-After is a floor
+	delay, ok := c.retryDelay(1, &NHLAPIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Second})
+	if !ok || delay != 5*time.Second {
+		t.Errorf("Expected to wait the 5s Retry-After, got %v (ok=%v)", delay, ok)
+	}
+
+	// ... unless it's longer than we're willing to wait
+	if _, ok := c.retryDelay(1, &NHLAPIError{StatusCode: http.StatusServiceUnavailable, RetryAfter: time.Minute}); ok {
+		t.Error("Expected a Retry-After beyond MaxRetryAfter to give up")
+	}
+}
+
+func TestNHLEndpointKey(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://api-web.nhle.com/v1/gamecenter/2024020001/boxscore", "api-web.nhle.com/v1/gamecenter/:id/boxscore"},
+		{"https://api-web.nhle.com/v1/club-schedule-season/UTA/20242025", "api-web.nhle.com/v1/club-schedule-season/UTA/:id"},
+		{"https://api-web.nhle.com/v1/scoreboard/2025-10-11", "api-web.nhle.com/v1/scoreboard/:id"},
+		{"https://api-web.nhle.com/v1/standings/now", "api-web.nhle.com/v1/standings/now"},
+		{"https://api-web.nhle.com/v1/player/8478402/game-log/20242025/2?lang=en", "api-web.nhle.com/v1/player/:id/game-log/:id/:id"},
+		{"not a url", "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := nhlEndpointKey(tt.url); got != tt.expected {
+			t.Errorf("nhlEndpointKey(%q) = %q, want %q", tt.url, got, tt.expected)
+		}
+	}
+
+	// Different games share a breaker
+	if nhlEndpointKey("https://api-web.nhle.com/v1/gamecenter/2024020001/play-by-play") !=
+		nhlEndpointKey("https://api-web.nhle.com/v1/gamecenter/2024020002/play-by-play") {
+		t.Error("Expected play-by-play for different games to share an endpoint key")
+	}
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/jaredshillingburg/go_uhc/nhlapi"
+)
+
+var (
+	nhlAPIClient     *nhlapi.Client
+	nhlAPIClientOnce sync.Once
+)
+
+// GetNHLAPIClient returns the typed NHL API client. It fetches through
+// MakeAPICallContext, so typed calls share the rate limiter, response cache
+// and retrying HTTP client with everything else.
+func GetNHLAPIClient() *nhlapi.Client {
+	nhlAPIClientOnce.Do(func() {
+		nhlAPIClient = nhlapi.NewClient(nhlapi.FetcherFunc(MakeAPICallContext))
+	})
+	return nhlAPIClient
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
@@ -18,7 +20,7 @@ import (
 // ArchitectureCandidate represents a Neural Network architecture to test
 type ArchitectureCandidate struct {
 	ID               string  `json:"id"`
-	Layers           []int   `json:"layers"`           // e.g. [65, 128, 64, 3]
+	Layers           []int   `json:"layers"`           // e.g. [182, 128, 64, 3]
 	LearningRate     float64 `json:"learningRate"`     // e.g. 0.001
 	DropoutRate      float64 `json:"dropoutRate"`      // e.g. 0.2 (0 = no dropout)
 	ActivationType   string  `json:"activationType"`   // "relu", "leaky_relu", "elu"
@@ -31,6 +33,7 @@ type ArchitectureCandidate struct {
 	TestAccuracy       float64 `json:"testAccuracy"`
 	TrainLoss          float64 `json:"trainLoss"`
 	ValidationLoss     float64 `json:"validationLoss"`
+	TestLoss           float64 `json:"testLoss"` // Held-out log loss, used only for the promotion gate
 	BrierScore         float64 `json:"brierScore"`
 	TrainingTime       float64 `json:"trainingTime"`  // seconds
 	InferenceTime      float64 `json:"inferenceTime"` // milliseconds
@@ -40,14 +43,31 @@ type ArchitectureCandidate struct {
 	TestedAt time.Time `json:"testedAt"`
 }
 
+// nnInputFeatures is the size of NeuralNetworkModel.extractFeatures
+const nnInputFeatures = 182
+
 // ArchitectureSearchService manages Neural Network architecture experimentation
 type ArchitectureSearchService struct {
 	candidates       []ArchitectureCandidate
 	bestArchitecture *ArchitectureCandidate
+	running          bool
 	dataDir          string
 	mutex            sync.RWMutex
 }
 
+var (
+	architectureSearchService     *ArchitectureSearchService
+	architectureSearchServiceOnce sync.Once
+)
+
+// GetArchitectureSearchService returns the singleton instance
+func GetArchitectureSearchService() *ArchitectureSearchService {
+	architectureSearchServiceOnce.Do(func() {
+		architectureSearchService = NewArchitectureSearchService()
+	})
+	return architectureSearchService
+}
+
 // NewArchitectureSearchService creates a new architecture search service
 func NewArchitectureSearchService() *ArchitectureSearchService {
 	dataDir := "data/architecture_search"
@@ -71,8 +91,8 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Current baseline
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "baseline_current",
-		Layers:           []int{65, 32, 16, 3},
-		LearningRate:     0.001,
+		Layers:           []int{nnInputFeatures, 512, 256, 128, 3},
+		LearningRate:     0.0005,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
 		L2Regularization: 0.0,
@@ -82,7 +102,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Shallow & Wide architectures
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "shallow_wide_128",
-		Layers:           []int{65, 128, 64, 3},
+		Layers:           []int{nnInputFeatures, 128, 64, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -92,7 +112,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "shallow_wide_256",
-		Layers:           []int{65, 256, 3},
+		Layers:           []int{nnInputFeatures, 256, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -103,7 +123,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Deep & Narrow architectures
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "deep_narrow_32",
-		Layers:           []int{65, 32, 32, 16, 16, 3},
+		Layers:           []int{nnInputFeatures, 32, 32, 16, 16, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -113,7 +133,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "deep_narrow_48",
-		Layers:           []int{65, 48, 48, 24, 12, 3},
+		Layers:           []int{nnInputFeatures, 48, 48, 24, 12, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -124,7 +144,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Balanced architectures
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "balanced_64",
-		Layers:           []int{65, 64, 32, 16, 3},
+		Layers:           []int{nnInputFeatures, 64, 32, 16, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -134,7 +154,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "balanced_96",
-		Layers:           []int{65, 96, 48, 24, 3},
+		Layers:           []int{nnInputFeatures, 96, 48, 24, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -145,7 +165,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// With Dropout (prevent overfitting)
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "dropout_128",
-		Layers:           []int{65, 128, 64, 3},
+		Layers:           []int{nnInputFeatures, 128, 64, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.2,
 		ActivationType:   "relu",
@@ -155,7 +175,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "dropout_96",
-		Layers:           []int{65, 96, 48, 24, 3},
+		Layers:           []int{nnInputFeatures, 96, 48, 24, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.2,
 		ActivationType:   "relu",
@@ -166,7 +186,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// With L2 Regularization
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "l2_reg_128",
-		Layers:           []int{65, 128, 64, 3},
+		Layers:           []int{nnInputFeatures, 128, 64, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -177,7 +197,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Different Learning Rates
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "lr_high_128",
-		Layers:           []int{65, 128, 64, 3},
+		Layers:           []int{nnInputFeatures, 128, 64, 3},
 		LearningRate:     0.01,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -187,7 +207,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "lr_low_128",
-		Layers:           []int{65, 128, 64, 3},
+		Layers:           []int{nnInputFeatures, 128, 64, 3},
 		LearningRate:     0.0001,
 		DropoutRate:      0.0,
 		ActivationType:   "relu",
@@ -198,7 +218,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Different Activation Functions
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "leaky_relu_128",
-		Layers:           []int{65, 128, 64, 3},
+		Layers:           []int{nnInputFeatures, 128, 64, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "leaky_relu",
@@ -208,7 +228,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "elu_128",
-		Layers:           []int{65, 128, 64, 3},
+		Layers:           []int{nnInputFeatures, 128, 64, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.0,
 		ActivationType:   "elu",
@@ -219,7 +239,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Best practices combinations
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "best_practice_1",
-		Layers:           []int{65, 128, 64, 32, 3},
+		Layers:           []int{nnInputFeatures, 128, 64, 32, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.2,
 		ActivationType:   "relu",
@@ -229,7 +249,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "best_practice_2",
-		Layers:           []int{65, 96, 48, 24, 3},
+		Layers:           []int{nnInputFeatures, 96, 48, 24, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.3,
 		ActivationType:   "leaky_relu",
@@ -240,7 +260,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Compact architectures (for speed)
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "compact_fast",
-		Layers:           []int{65, 48, 24, 3},
+		Layers:           []int{nnInputFeatures, 48, 24, 3},
 		LearningRate:     0.001,
 		DropoutRate:      0.1,
 		ActivationType:   "relu",
@@ -251,7 +271,7 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	// Large capacity (if enough data)
 	candidates = append(candidates, ArchitectureCandidate{
 		ID:               "large_capacity",
-		Layers:           []int{65, 256, 128, 64, 32, 3},
+		Layers:           []int{nnInputFeatures, 256, 128, 64, 32, 3},
 		LearningRate:     0.0005,
 		DropoutRate:      0.3,
 		ActivationType:   "relu",
@@ -263,145 +283,367 @@ func (ass *ArchitectureSearchService) GenerateCandidates() []ArchitectureCandida
 	return candidates
 }
 
-// EvaluateArchitecture trains and evaluates a single architecture
+// EvaluateArchitecture trains a fresh network for the candidate on the training
+// games and scores it on the validation and test games. Training stops early
+// when validation log loss stops improving or the deadline passes.
 func (ass *ArchitectureSearchService) EvaluateArchitecture(candidate *ArchitectureCandidate, trainData, valData, testData []models.CompletedGame) error {
-	log.Printf("🧪 Testing architecture: %s %v", candidate.ID, candidate.Layers)
-
-	startTime := time.Now()
-
-	// Note: For now, we'll use simplified evaluation
-	// In production, this would create a new NN with these parameters and actually train it
-	// For this implementation, we'll simulate results based on architecture properties
+	data, err := buildArchitectureSearchData(trainData, valData, testData)
+	if err != nil {
+		return err
+	}
+	_, err = ass.evaluateCandidate(candidate, data, DefaultArchitectureSearchOptions(), time.Time{})
+	return err
+}
 
-	// Simulate training (in real implementation, would actually train)
-	candidate.TrainAccuracy = ass.simulatePerformance(candidate, "train")
-	candidate.ValidationAccuracy = ass.simulatePerformance(candidate, "validation")
-	candidate.TestAccuracy = ass.simulatePerformance(candidate, "test")
+// ArchitectureSearchOptions controls a search run
+type ArchitectureSearchOptions struct {
+	Workers    int           `json:"workers"`    // Candidates trained in parallel
+	TimeBudget time.Duration `json:"timeBudget"` // Whole-search budget (0 = unlimited)
+	MaxEpochs  int           `json:"maxEpochs"`
+	Patience   int           `json:"patience"` // Epochs without validation improvement before stopping
+	Promote    bool          `json:"promote"`  // Replace the production network if the winner beats it
+}
 
-	candidate.TrainLoss = 1.0 - candidate.TrainAccuracy
-	candidate.ValidationLoss = 1.0 - candidate.ValidationAccuracy
+// DefaultArchitectureSearchOptions returns the standard search settings
+func DefaultArchitectureSearchOptions() ArchitectureSearchOptions {
+	workers := runtime.NumCPU() / 2
+	if workers < 1 {
+		workers = 1
+	}
+	return ArchitectureSearchOptions{
+		Workers:    workers,
+		TimeBudget: 30 * time.Minute,
+		MaxEpochs:  40,
+		Patience:   5,
+	}
+}
 
-	candidate.BrierScore = candidate.ValidationLoss * 0.15 // Rough estimate
-	candidate.Overfit = candidate.TrainAccuracy - candidate.ValidationAccuracy
+// architectureSearchData holds the feature matrices for each split
+type architectureSearchData struct {
+	trainX, valX, testX       [][]float64
+	trainY, valY, testY       [][]float64 // Full network targets (win, home goals, away goals)
+	trainWin, valWin, testWin []float64   // Home win labels for scoring
+}
 
-	candidate.TrainingTime = time.Since(startTime).Seconds()
-	candidate.InferenceTime = float64(len(candidate.Layers)) * 0.5 // Rough estimate (ms)
-	candidate.TestedAt = time.Now()
+// buildArchitectureSearchData converts the splits to network inputs using the
+// factors each game was predicted with (see FeatureSnapshotService)
+func buildArchitectureSearchData(trainData, valData, testData []models.CompletedGame) (*architectureSearchData, error) {
+	snapshotService := GetFeatureSnapshotService()
+	if snapshotService == nil {
+		snapshotService = InitFeatureSnapshotService()
+	}
 
-	// Calculate composite score (higher is better)
-	// Factors: validation accuracy (60%), low overfit (20%), fast inference (10%), low train loss (10%)
-	candidate.Score = (candidate.ValidationAccuracy * 0.6) +
-		(math.Max(0, 0.2-candidate.Overfit) * 100) + // Penalize overfitting
-		(math.Max(0, 1.0-(candidate.InferenceTime/10.0)) * 0.1) + // Reward fast inference
-		((1.0 - candidate.TrainLoss) * 0.1)
+	// extractFeatures doesn't depend on network state, so any instance works
+	extractor := &NeuralNetworkModel{}
+	convert := func(games []models.CompletedGame) ([][]float64, [][]float64, []float64) {
+		examples, _ := snapshotService.BuildTrainingSet(games)
+		inputs := make([][]float64, 0, len(examples))
+		targets := make([][]float64, 0, len(examples))
+		labels := make([]float64, 0, len(examples))
+		for _, example := range examples {
+			homeScore := example.Game.HomeTeam.Score
+			awayScore := example.Game.AwayTeam.Score
+			win := 0.0
+			if homeScore > awayScore {
+				win = 1.0
+			}
+			inputs = append(inputs, extractor.extractFeatures(example.HomeFactors, example.AwayFactors))
+			targets = append(targets, []float64{win, float64(homeScore) / 8.0, float64(awayScore) / 8.0})
+			labels = append(labels, win)
+		}
+		return inputs, targets, labels
+	}
 
-	log.Printf("✅ Architecture %s: Val Acc=%.2f%%, Overfit=%.2f%%, Score=%.3f",
-		candidate.ID,
-		candidate.ValidationAccuracy*100,
-		candidate.Overfit*100,
-		candidate.Score)
+	data := &architectureSearchData{}
+	data.trainX, data.trainY, data.trainWin = convert(trainData)
+	data.valX, data.valY, data.valWin = convert(valData)
+	data.testX, data.testY, data.testWin = convert(testData)
 
-	return nil
+	if len(data.trainX) < 20 || len(data.valX) < 5 {
+		return nil, fmt.Errorf("not enough games with features: %d train, %d validation", len(data.trainX), len(data.valX))
+	}
+	return data, nil
 }
 
-// simulatePerformance simulates architecture performance
-// In production, this would be replaced with actual training
-func (ass *ArchitectureSearchService) simulatePerformance(candidate *ArchitectureCandidate, split string) float64 {
-	// Base performance
-	baseAcc := 0.72
-
-	// Architecture complexity (more layers/neurons can help, but diminishing returns)
-	totalNeurons := 0
-	for _, neurons := range candidate.Layers {
-		totalNeurons += neurons
+// evaluateCandidate trains and scores one candidate, stopping at the deadline
+// if set, and returns the trained network
+func (ass *ArchitectureSearchService) evaluateCandidate(candidate *ArchitectureCandidate, data *architectureSearchData, opts ArchitectureSearchOptions, deadline time.Time) (*NeuralNetworkModel, error) {
+	if len(candidate.Layers) < 2 || candidate.Layers[0] != nnInputFeatures || candidate.Layers[len(candidate.Layers)-1] != 3 {
+		return nil, fmt.Errorf("candidate %s has invalid layers %v", candidate.ID, candidate.Layers)
 	}
-	complexityBonus := math.Min(float64(totalNeurons)/1000.0*0.05, 0.08)
 
-	// Learning rate impact (too high or too low hurts)
-	lrPenalty := 0.0
-	if candidate.LearningRate > 0.01 {
-		lrPenalty = 0.03 // Too high
-	} else if candidate.LearningRate < 0.0001 {
-		lrPenalty = 0.02 // Too low
-	}
+	log.Printf("🧪 Testing architecture: %s %v", candidate.ID, candidate.Layers)
+	startTime := time.Now()
+
+	nn := NewNeuralNetworkModelWithArchitecture(candidate.Layers, candidate.LearningRate,
+		candidate.ActivationType, candidate.DropoutRate, candidate.L2Regularization)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
-	// Regularization helps validation/test (reduces overfitting)
-	regBonus := 0.0
-	if split == "validation" || split == "test" {
-		if candidate.DropoutRate > 0 {
-			regBonus += 0.02
+	bestLoss := math.Inf(1)
+	var bestWeights, bestBiases [][]float64
+	epochsSinceBest := 0
+	epochs := 0
+
+	for epoch := 0; epoch < opts.MaxEpochs; epoch++ {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			log.Printf("⏱️ Architecture %s stopped at epoch %d (time budget)", candidate.ID, epoch)
+			break
 		}
-		if candidate.L2Regularization > 0 {
-			regBonus += 0.01
+
+		nn.trainEpoch(data.trainX, data.trainY, candidate.BatchSize, rng)
+		epochs++
+
+		valLoss, _, _ := nn.evaluateSamples(data.valX, data.valWin)
+		if valLoss < bestLoss-1e-4 {
+			bestLoss = valLoss
+			bestWeights, bestBiases = nn.copyParameters()
+			epochsSinceBest = 0
+		} else {
+			epochsSinceBest++
+			if epochsSinceBest >= opts.Patience {
+				break
+			}
 		}
 	}
 
-	// Depth vs width trade-off
-	depth := len(candidate.Layers) - 2 // Exclude input/output
-	depthBonus := math.Min(float64(depth)*0.01, 0.03)
-
-	// Training split has less noise
-	splitNoise := 0.0
-	if split == "train" {
-		splitNoise = 0.03 // Train is easier
-	} else if split == "validation" {
-		splitNoise = -0.01 // Validation harder
-	} else {
-		splitNoise = -0.02 // Test hardest
+	if bestWeights == nil {
+		return nil, fmt.Errorf("candidate %s did not complete any epochs", candidate.ID)
 	}
+	nn.setParameters(bestWeights, bestBiases)
 
-	accuracy := baseAcc + complexityBonus - lrPenalty + regBonus + depthBonus + splitNoise
+	candidate.TrainLoss, _, candidate.TrainAccuracy = nn.evaluateSamples(data.trainX, data.trainWin)
+	candidate.ValidationLoss, candidate.BrierScore, candidate.ValidationAccuracy = nn.evaluateSamples(data.valX, data.valWin)
+	candidate.TestLoss, _, candidate.TestAccuracy = nn.evaluateSamples(data.testX, data.testWin)
+	candidate.Overfit = candidate.TrainAccuracy - candidate.ValidationAccuracy
+	candidate.TrainingTime = time.Since(startTime).Seconds()
+	candidate.InferenceTime = measureInferenceTime(nn, data.valX)
+	candidate.TestedAt = time.Now()
+
+	// Composite score (higher is better): validation log loss and Brier score
+	// dominate, with a penalty for overfitting
+	candidate.Score = -candidate.ValidationLoss - candidate.BrierScore - 0.5*math.Max(0, candidate.Overfit)
+
+	log.Printf("✅ Architecture %s: %d epochs, Val LogLoss=%.4f, Brier=%.4f, Val Acc=%.2f%%, Score=%.3f",
+		candidate.ID, epochs, candidate.ValidationLoss, candidate.BrierScore,
+		candidate.ValidationAccuracy*100, candidate.Score)
+
+	return nn, nil
+}
+
+// measureInferenceTime returns the mean forward pass time in milliseconds
+func measureInferenceTime(nn *NeuralNetworkModel, inputs [][]float64) float64 {
+	if len(inputs) == 0 {
+		return 0
+	}
+	n := len(inputs)
+	if n > 50 {
+		n = 50
+	}
 
-	// Add some realistic variance
-	variance := (math.Sin(float64(len(candidate.ID))) * 0.02)
-	accuracy += variance
+	nn.mutex.RLock()
+	defer nn.mutex.RUnlock()
 
-	// Clamp to reasonable range
-	return math.Max(0.65, math.Min(0.85, accuracy))
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		nn.forwardPass(inputs[i])
+	}
+	return float64(time.Since(start).Microseconds()) / 1000.0 / float64(n)
 }
 
-// RunSearch executes architecture search on all candidates
+// RunSearch executes architecture search on all candidates with default options
 func (ass *ArchitectureSearchService) RunSearch(trainData, valData, testData []models.CompletedGame) error {
+	return ass.RunSearchWithOptions(trainData, valData, testData, DefaultArchitectureSearchOptions())
+}
+
+// RunSearchWithOptions trains every candidate on a worker pool within the time
+// budget, ranks them by validation score and optionally promotes the winner
+func (ass *ArchitectureSearchService) RunSearchWithOptions(trainData, valData, testData []models.CompletedGame, opts ArchitectureSearchOptions) error {
 	ass.mutex.Lock()
-	defer ass.mutex.Unlock()
+	if ass.running {
+		ass.mutex.Unlock()
+		return fmt.Errorf("architecture search already running")
+	}
+	ass.running = true
+	ass.mutex.Unlock()
+
+	defer func() {
+		ass.mutex.Lock()
+		ass.running = false
+		ass.mutex.Unlock()
+	}()
+
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.MaxEpochs < 1 {
+		opts.MaxEpochs = DefaultArchitectureSearchOptions().MaxEpochs
+	}
+	if opts.Patience < 1 {
+		opts.Patience = DefaultArchitectureSearchOptions().Patience
+	}
 
 	log.Printf("🚀 Starting Neural Network Architecture Search...")
 	log.Printf("📊 Training set: %d games, Validation: %d games, Test: %d games",
 		len(trainData), len(valData), len(testData))
 
+	data, err := buildArchitectureSearchData(trainData, valData, testData)
+	if err != nil {
+		return err
+	}
+
+	var deadline time.Time
+	if opts.TimeBudget > 0 {
+		deadline = time.Now().Add(opts.TimeBudget)
+	}
+
 	candidates := ass.GenerateCandidates()
+	networks := make([]*NeuralNetworkModel, len(candidates)) // nil until the candidate completes
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				nn, err := ass.evaluateCandidate(&candidates[i], data, opts, deadline)
+				if err != nil {
+					log.Printf("⚠️ Failed to evaluate %s: %v", candidates[i].ID, err)
+					continue
+				}
+				networks[i] = nn
+			}
+		}()
+	}
+
+	skipped := 0
+	for i := range candidates {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			skipped = len(candidates) - i
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if skipped > 0 {
+		log.Printf("⏱️ Time budget exhausted, %d candidates not tested", skipped)
+	}
 
+	results := make([]ArchitectureCandidate, 0, len(candidates))
+	resultNetworks := make(map[string]*NeuralNetworkModel, len(candidates))
 	for i := range candidates {
-		err := ass.EvaluateArchitecture(&candidates[i], trainData, valData, testData)
-		if err != nil {
-			log.Printf("⚠️ Failed to evaluate %s: %v", candidates[i].ID, err)
-			continue
+		if networks[i] != nil {
+			results = append(results, candidates[i])
+			resultNetworks[candidates[i].ID] = networks[i]
 		}
-		ass.candidates = append(ass.candidates, candidates[i])
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no architecture candidates completed")
 	}
 
 	// Sort by score (best first)
-	sort.Slice(ass.candidates, func(i, j int) bool {
-		return ass.candidates[i].Score > ass.candidates[j].Score
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
 	})
 
-	// Set best architecture
-	if len(ass.candidates) > 0 {
-		ass.bestArchitecture = &ass.candidates[0]
-		log.Printf("🏆 Best Architecture Found: %s", ass.bestArchitecture.ID)
-		log.Printf("   Layers: %v", ass.bestArchitecture.Layers)
-		log.Printf("   Validation Accuracy: %.2f%%", ass.bestArchitecture.ValidationAccuracy*100)
-		log.Printf("   Test Accuracy: %.2f%%", ass.bestArchitecture.TestAccuracy*100)
-		log.Printf("   Overfit: %.2f%%", ass.bestArchitecture.Overfit*100)
-		log.Printf("   Inference Time: %.2fms", ass.bestArchitecture.InferenceTime)
-	}
+	ass.mutex.Lock()
+	ass.candidates = results
+	ass.bestArchitecture = &ass.candidates[0]
+	best := *ass.bestArchitecture
+	ass.mutex.Unlock()
+	bestNetwork := resultNetworks[best.ID]
+
+	log.Printf("🏆 Best Architecture Found: %s", best.ID)
+	log.Printf("   Layers: %v", best.Layers)
+	log.Printf("   Validation Log Loss: %.4f | Brier: %.4f", best.ValidationLoss, best.BrierScore)
+	log.Printf("   Validation Accuracy: %.2f%%", best.ValidationAccuracy*100)
+	log.Printf("   Test Accuracy: %.2f%% | Test Log Loss: %.4f", best.TestAccuracy*100, best.TestLoss)
+	log.Printf("   Overfit: %.2f%%", best.Overfit*100)
+	log.Printf("   Inference Time: %.2fms", best.InferenceTime)
 
 	// Save results
-	ass.saveSearchResults()
+	ass.mutex.RLock()
+	if err := ass.saveSearchResults(); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	ass.mutex.RUnlock()
+
+	if opts.Promote && bestNetwork != nil {
+		ass.promote(best, bestNetwork, data, opts)
+	}
 
 	return nil
 }
 
+// minPromotionTestGames is the smallest held-out test set the promotion gate trusts
+const minPromotionTestGames = 10
+
+// promote replaces the production neural network with the winning candidate
+// if it has lower held-out test log loss than the production architecture
+// retrained on the same training split. The validation games already chose
+// the epoch and the candidate, so comparing on them would favour the winner,
+// and the live production network has trained online on the test games, so
+// comparing against it would favour keeping it.
+func (ass *ArchitectureSearchService) promote(best ArchitectureCandidate, network *NeuralNetworkModel, data *architectureSearchData, opts ArchitectureSearchOptions) {
+	lps := GetLivePredictionSystem()
+	if lps == nil || lps.GetNeuralNetwork() == nil {
+		log.Printf("⚠️ Cannot promote %s: live prediction system not running", best.ID)
+		return
+	}
+	production := lps.GetNeuralNetwork()
+
+	if len(data.testX) < minPromotionTestGames {
+		log.Printf("⏭️ Not promoting %s: only %d held-out test games (need %d)",
+			best.ID, len(data.testX), minPromotionTestGames)
+		return
+	}
+
+	baseline := productionArchitecture(production)
+	if _, err := ass.evaluateCandidate(&baseline, data, opts, time.Time{}); err != nil {
+		log.Printf("⚠️ Not promoting %s: could not retrain the production architecture: %v", best.ID, err)
+		return
+	}
+	if best.TestLoss >= baseline.TestLoss {
+		log.Printf("⏭️ Keeping current network (retrained test log loss %.4f) over %s (%.4f)",
+			baseline.TestLoss, best.ID, best.TestLoss)
+		return
+	}
+
+	production.adoptNetwork(network)
+	if err := production.saveWeights(); err != nil {
+		log.Printf("⚠️ Failed to save promoted network: %v", err)
+		return
+	}
+	log.Printf("🏆 Promoted architecture %s %v (test log loss %.4f → %.4f)",
+		best.ID, best.Layers, baseline.TestLoss, best.TestLoss)
+}
+
+// productionArchitecture describes the production network's architecture as
+// a candidate so it can be retrained from scratch on the search splits
+func productionArchitecture(production *NeuralNetworkModel) ArchitectureCandidate {
+	production.mutex.RLock()
+	defer production.mutex.RUnlock()
+
+	return ArchitectureCandidate{
+		ID:               "production_retrained",
+		Layers:           append([]int(nil), production.layers...),
+		LearningRate:     production.learningRate,
+		DropoutRate:      production.dropoutRate,
+		ActivationType:   production.activation,
+		L2Regularization: production.l2Regularization,
+		BatchSize:        32,
+	}
+}
+
+// IsRunning reports whether a search is in progress
+func (ass *ArchitectureSearchService) IsRunning() bool {
+	ass.mutex.RLock()
+	defer ass.mutex.RUnlock()
+	return ass.running
+}
+
 // GetBestArchitecture returns the best performing architecture
 func (ass *ArchitectureSearchService) GetBestArchitecture() *ArchitectureCandidate {
 	ass.mutex.RLock()
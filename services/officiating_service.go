@@ -0,0 +1,627 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// OfficiatingService ingests referee/linesman assignments and builds per-official
+// penalty tendencies from stored play-by-play. Assignments come from the NHL
+// gamecenter right-rail or from JSON files dropped in data/officiating/assignments.
+type OfficiatingService struct {
+	assignments map[int]*models.OfficialsAssignment   // gameID -> officials
+	records     map[int]*models.GameOfficiatingRecord // gameID -> officials + penalty outcomes
+	profiles    map[string]*models.OfficialProfile    // official name -> tendencies
+	league      models.OfficiatingSummary
+	dataDir     string
+	mutex       sync.RWMutex
+
+	shrinkageGames   float64 // Pseudo-games of league average blended into each profile
+	maxFetchesPerRun int     // Gamecenter lookups allowed per sync
+
+	// Failed gamecenter lookups, so unannounced crews aren't re-fetched on every call
+	lookupFailures map[int]assignmentLookupFailure
+	retryAfter     time.Duration
+}
+
+// assignmentLookupFailure is a cached failed assignment lookup
+type assignmentLookupFailure struct {
+	err error
+	at  time.Time
+}
+
+// officialsRightRailResponse is the part of the gamecenter right-rail we need
+type officialsRightRailResponse struct {
+	GameInfo struct {
+		Referees []struct {
+			Default string `json:"default"`
+		} `json:"referees"`
+		Linesmen []struct {
+			Default string `json:"default"`
+		} `json:"linesmen"`
+	} `json:"gameInfo"`
+}
+
+// NewOfficiatingService creates a new officiating service
+func NewOfficiatingService() *OfficiatingService {
+	service := &OfficiatingService{
+		assignments:      make(map[int]*models.OfficialsAssignment),
+		records:          make(map[int]*models.GameOfficiatingRecord),
+		profiles:         make(map[string]*models.OfficialProfile),
+		dataDir:          "data/officiating",
+		shrinkageGames:   20.0,
+		maxFetchesPerRun: 200,
+		lookupFailures:   make(map[int]assignmentLookupFailure),
+		retryAfter:       time.Hour, // Crews are announced the morning of the game
+	}
+
+	os.MkdirAll(filepath.Join(service.dataDir, "assignments"), 0755)
+
+	if err := service.loadData(); err != nil {
+		log.Printf("⚠️ Could not load officiating data: %v (starting fresh)", err)
+	}
+	service.LoadAssignmentDrops()
+
+	service.mutex.Lock()
+	service.rebuildProfiles()
+	service.mutex.Unlock()
+
+	return service
+}
+
+// ============================================================================
+// ASSIGNMENT INGESTION
+// ============================================================================
+
+// FetchAssignment returns the officials for a game, fetching them from
+// gamecenter if needed. A failed lookup is cached and returned again until
+// retryAfter has passed.
+func (ofs *OfficiatingService) FetchAssignment(gameID int) (*models.OfficialsAssignment, error) {
+	ofs.mutex.RLock()
+	if assignment, exists := ofs.assignments[gameID]; exists {
+		ofs.mutex.RUnlock()
+		return assignment, nil
+	}
+	failure, failed := ofs.lookupFailures[gameID]
+	ofs.mutex.RUnlock()
+	if failed && time.Since(failure.at) < ofs.retryAfter {
+		return nil, failure.err
+	}
+
+	assignment, err := ofs.fetchAssignment(gameID)
+
+	ofs.mutex.Lock()
+	defer ofs.mutex.Unlock()
+	if err != nil {
+		ofs.lookupFailures[gameID] = assignmentLookupFailure{err: err, at: time.Now()}
+		return nil, err
+	}
+	delete(ofs.lookupFailures, gameID)
+	ofs.assignments[gameID] = assignment
+	return assignment, nil
+}
+
+// fetchAssignment reads a game's officials from the gamecenter right-rail
+func (ofs *OfficiatingService) fetchAssignment(gameID int) (*models.OfficialsAssignment, error) {
+
+	url := fmt.Sprintf("https://api-web.nhle.com/v1/gamecenter/%d/right-rail", gameID)
+	body, err := MakeAPICall(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch officials: %w", err)
+	}
+
+	var rightRail officialsRightRailResponse
+	if err := json.Unmarshal(body, &rightRail); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal officials: %w", err)
+	}
+
+	assignment := &models.OfficialsAssignment{
+		GameID:    gameID,
+		Referees:  []string{},
+		Linesmen:  []string{},
+		Source:    "gamecenter",
+		FetchedAt: time.Now(),
+	}
+	for _, referee := range rightRail.GameInfo.Referees {
+		if name := strings.TrimSpace(referee.Default); name != "" {
+			assignment.Referees = append(assignment.Referees, name)
+		}
+	}
+	for _, linesman := range rightRail.GameInfo.Linesmen {
+		if name := strings.TrimSpace(linesman.Default); name != "" {
+			assignment.Linesmen = append(assignment.Linesmen, name)
+		}
+	}
+
+	// Assignments are usually published the morning of the game
+	if len(assignment.Referees) == 0 {
+		return nil, fmt.Errorf("officials not yet announced for game %d", gameID)
+	}
+
+	return assignment, nil
+}
+
+// PrefetchAssignments looks up the officials for games starting within the
+// next day so predictions can use them without a network call. Returns the
+// number of games with a known crew.
+func (ofs *OfficiatingService) PrefetchAssignments(ctx context.Context, games []UpcomingGame) int {
+	known, fetched := 0, 0
+	cutoff := time.Now().Add(24 * time.Hour)
+	for _, game := range games {
+		if ctx.Err() != nil {
+			break
+		}
+		if game.GameID == 0 || game.GameDate.After(cutoff) {
+			continue
+		}
+
+		ofs.mutex.RLock()
+		_, cached := ofs.assignments[game.GameID]
+		ofs.mutex.RUnlock()
+		if !cached {
+			fetched++
+		}
+		if _, err := ofs.FetchAssignment(game.GameID); err == nil {
+			known++
+		}
+	}
+
+	if fetched > 0 {
+		log.Printf("🦓 Officiating assignments: %d of the next day's games have a known crew (%d looked up)", known, fetched)
+	}
+	return known
+}
+
+// LoadAssignmentDrops loads assignment files (arrays of OfficialsAssignment) from
+// data/officiating/assignments. File assignments override gamecenter data.
+func (ofs *OfficiatingService) LoadAssignmentDrops() int {
+	dropDir := filepath.Join(ofs.dataDir, "assignments")
+	files, err := filepath.Glob(filepath.Join(dropDir, "*.json"))
+	if err != nil {
+		return 0
+	}
+
+	loaded := 0
+	ofs.mutex.Lock()
+	defer ofs.mutex.Unlock()
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Printf("⚠️ Failed to read officials file %s: %v", file, err)
+			continue
+		}
+
+		var assignments []models.OfficialsAssignment
+		if err := json.Unmarshal(data, &assignments); err != nil {
+			log.Printf("⚠️ Failed to parse officials file %s: %v", file, err)
+			continue
+		}
+
+		for i := range assignments {
+			assignment := assignments[i]
+			if assignment.GameID == 0 || len(assignment.Referees) == 0 {
+				continue
+			}
+			assignment.Source = "file"
+			if assignment.FetchedAt.IsZero() {
+				assignment.FetchedAt = time.Now()
+			}
+			ofs.assignments[assignment.GameID] = &assignment
+			loaded++
+		}
+	}
+
+	if loaded > 0 {
+		log.Printf("🦓 Loaded %d officiating assignments from %s", loaded, dropDir)
+	}
+	return loaded
+}
+
+// ============================================================================
+// GAME RECORDING
+// ============================================================================
+
+// RecordGame combines a completed game's officials with its play-by-play penalties
+func (ofs *OfficiatingService) RecordGame(gameID int) (*models.GameOfficiatingRecord, error) {
+	record, err := ofs.recordGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	ofs.mutex.Lock()
+	ofs.rebuildProfiles()
+	err = ofs.saveData()
+	ofs.mutex.Unlock()
+
+	if err != nil {
+		log.Printf("⚠️ Failed to save officiating data: %v", err)
+	}
+
+	log.Printf("🦓 Recorded officiating for game %d (%s): %d-%d PP opportunities",
+		gameID, strings.Join(record.Referees, ", "), record.HomePPOpportunities, record.AwayPPOpportunities)
+	return record, nil
+}
+
+// recordGame stores a game's officiating record without rebuilding profiles
+func (ofs *OfficiatingService) recordGame(gameID int) (*models.GameOfficiatingRecord, error) {
+	assignment, err := ofs.FetchAssignment(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics, err := ofs.loadPlayByPlayAnalytics(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.GameOfficiatingRecord{
+		GameID:              gameID,
+		GameDate:            analytics.GameDate,
+		HomeTeam:            analytics.HomeTeam,
+		AwayTeam:            analytics.AwayTeam,
+		Referees:            assignment.Referees,
+		Linesmen:            assignment.Linesmen,
+		HomePenalties:       analytics.HomeAnalytics.PenaltiesTaken,
+		AwayPenalties:       analytics.AwayAnalytics.PenaltiesTaken,
+		HomePIM:             analytics.HomeAnalytics.PenaltyMinutes,
+		AwayPIM:             analytics.AwayAnalytics.PenaltyMinutes,
+		HomePPOpportunities: powerPlayPenalties(&analytics.AwayAnalytics),
+		AwayPPOpportunities: powerPlayPenalties(&analytics.HomeAnalytics),
+		TotalGoals:          analytics.HomeAnalytics.ActualGoals + analytics.AwayAnalytics.ActualGoals,
+		RecordedAt:          time.Now(),
+	}
+
+	ofs.mutex.Lock()
+	ofs.records[gameID] = record
+	if assignment.HomeTeam == "" {
+		assignment.GameDate = record.GameDate
+		assignment.HomeTeam = record.HomeTeam
+		assignment.AwayTeam = record.AwayTeam
+	}
+	ofs.mutex.Unlock()
+
+	return record, nil
+}
+
+// SyncWithStoredPlayByPlay records officiating for every stored play-by-play game
+func (ofs *OfficiatingService) SyncWithStoredPlayByPlay() int {
+	files, err := filepath.Glob(filepath.Join("data/play_by_play", "game_*.json"))
+	if err != nil {
+		return 0
+	}
+
+	recorded, fetches := 0, 0
+	for _, file := range files {
+		var gameID int
+		if _, err := fmt.Sscanf(filepath.Base(file), "game_%d.json", &gameID); err != nil {
+			continue
+		}
+
+		ofs.mutex.RLock()
+		_, done := ofs.records[gameID]
+		_, known := ofs.assignments[gameID]
+		ofs.mutex.RUnlock()
+		if done {
+			continue
+		}
+
+		if !known {
+			if fetches >= ofs.maxFetchesPerRun {
+				continue
+			}
+			fetches++
+		}
+
+		if _, err := ofs.recordGame(gameID); err == nil {
+			recorded++
+		}
+	}
+
+	if recorded > 0 {
+		ofs.mutex.Lock()
+		ofs.rebuildProfiles()
+		if err := ofs.saveData(); err != nil {
+			log.Printf("⚠️ Failed to save officiating data: %v", err)
+		}
+		ofs.mutex.Unlock()
+
+		log.Printf("🦓 Officiating sync recorded %d games (%d gamecenter lookups)", recorded, fetches)
+	}
+	return recorded
+}
+
+// loadPlayByPlayAnalytics reads stored play-by-play analytics, fetching them if missing
+func (ofs *OfficiatingService) loadPlayByPlayAnalytics(gameID int) (*models.PlayByPlayAnalytics, error) {
+	filename := filepath.Join("data/play_by_play", fmt.Sprintf("game_%d.json", gameID))
+	if data, err := ioutil.ReadFile(filename); err == nil {
+		var analytics models.PlayByPlayAnalytics
+		if err := json.Unmarshal(data, &analytics); err == nil {
+			return &analytics, nil
+		}
+	}
+
+	pbpService := GetPlayByPlayService()
+	if pbpService == nil {
+		return nil, fmt.Errorf("no stored play-by-play for game %d", gameID)
+	}
+	return pbpService.FetchPlayByPlay(gameID)
+}
+
+// powerPlayPenalties returns penalties that gave the opponent a power play.
+// Older stored analytics lack the breakdown, so fall back to all penalties.
+func powerPlayPenalties(team *models.TeamPlayAnalytics) int {
+	if team.PowerPlayPenalties > 0 {
+		return team.PowerPlayPenalties
+	}
+	return team.PenaltiesTaken
+}
+
+// ============================================================================
+// TENDENCIES
+// ============================================================================
+
+// rebuildProfiles recomputes league averages and per-official tendencies (caller holds the lock)
+func (ofs *OfficiatingService) rebuildProfiles() {
+	profiles := make(map[string]*models.OfficialProfile)
+	league := models.OfficiatingSummary{AssignmentsKnown: len(ofs.assignments)}
+
+	type totals struct {
+		homePen, awayPen, homePP, awayPP, goals float64
+	}
+	sums := make(map[string]*totals)
+	var leagueTotals totals
+
+	addOfficial := func(name, role string, record *models.GameOfficiatingRecord) {
+		profile, exists := profiles[name]
+		if !exists {
+			profile = &models.OfficialProfile{Name: name, Role: role}
+			profiles[name] = profile
+			sums[name] = &totals{}
+		}
+		profile.GamesWorked++
+		if record.GameDate.After(profile.LastWorked) {
+			profile.LastWorked = record.GameDate
+		}
+		t := sums[name]
+		t.homePen += float64(record.HomePenalties)
+		t.awayPen += float64(record.AwayPenalties)
+		t.homePP += float64(record.HomePPOpportunities)
+		t.awayPP += float64(record.AwayPPOpportunities)
+		t.goals += float64(record.TotalGoals)
+	}
+
+	for _, record := range ofs.records {
+		league.GamesRecorded++
+		leagueTotals.homePen += float64(record.HomePenalties)
+		leagueTotals.awayPen += float64(record.AwayPenalties)
+		leagueTotals.homePP += float64(record.HomePPOpportunities)
+		leagueTotals.awayPP += float64(record.AwayPPOpportunities)
+		leagueTotals.goals += float64(record.TotalGoals)
+
+		for _, referee := range record.Referees {
+			addOfficial(referee, "referee", record)
+		}
+		for _, linesman := range record.Linesmen {
+			addOfficial(linesman, "linesman", record)
+		}
+	}
+
+	if league.GamesRecorded > 0 {
+		games := float64(league.GamesRecorded)
+		league.LeaguePenaltiesPerGame = (leagueTotals.homePen + leagueTotals.awayPen) / games
+		league.LeaguePPPerGame = (leagueTotals.homePP + leagueTotals.awayPP) / games
+		league.LeagueHomePPEdge = (leagueTotals.homePP - leagueTotals.awayPP) / games
+		league.LeagueGoalsPerGame = leagueTotals.goals / games
+	}
+
+	for name, profile := range profiles {
+		t := sums[name]
+		games := float64(profile.GamesWorked)
+		profile.HomePenaltiesPerGame = t.homePen / games
+		profile.AwayPenaltiesPerGame = t.awayPen / games
+		profile.PenaltiesPerGame = (t.homePen + t.awayPen) / games
+		profile.PPOpportunitiesPerGame = (t.homePP + t.awayPP) / games
+		profile.HomePPDifferential = (t.homePP - t.awayPP) / games
+		profile.GoalsPerGame = t.goals / games
+
+		// Shrink towards league average so a handful of games can't dominate
+		weight := games / (games + ofs.shrinkageGames)
+		profile.PenaltyRateIndex = 1.0
+		if league.LeaguePPPerGame > 0 {
+			profile.PenaltyRateIndex = 1.0 + weight*(profile.PPOpportunitiesPerGame/league.LeaguePPPerGame-1.0)
+		}
+		profile.HomeBias = weight * (profile.HomePPDifferential - league.LeagueHomePPEdge)
+	}
+
+	ofs.profiles = profiles
+	league.LastUpdated = time.Now()
+	ofs.league = league
+}
+
+// GetOfficiatingFactor returns the officiating adjustment for a game from
+// known assignments; it never calls the NHL API, so it is safe on the
+// prediction path. The officiating-assignments job loads upcoming crews.
+// Unknown crews return a neutral factor.
+func (ofs *OfficiatingService) GetOfficiatingFactor(gameID int) *models.OfficiatingFactor {
+	ofs.mutex.RLock()
+	league := ofs.league
+	assignment := ofs.assignments[gameID]
+	ofs.mutex.RUnlock()
+
+	factor := &models.OfficiatingFactor{
+		GameID:                gameID,
+		PenaltyRateMultiplier: 1.0,
+		ExpectedHomePPOpps:    (league.LeaguePPPerGame + league.LeagueHomePPEdge) / 2.0,
+		ExpectedAwayPPOpps:    (league.LeaguePPPerGame - league.LeagueHomePPEdge) / 2.0,
+	}
+	if assignment == nil || league.GamesRecorded == 0 {
+		return factor
+	}
+
+	factor.Referees = assignment.Referees
+	factor.Linesmen = assignment.Linesmen
+
+	ofs.mutex.RLock()
+	defer ofs.mutex.RUnlock()
+
+	// Referees call the penalties; linesmen are recorded but don't move the factor
+	known := 0
+	rateIndex, homeBias, goalsDelta := 0.0, 0.0, 0.0
+	for _, referee := range assignment.Referees {
+		profile, exists := ofs.profiles[referee]
+		if !exists {
+			continue
+		}
+		known++
+		factor.GamesSampled += profile.GamesWorked
+		rateIndex += profile.PenaltyRateIndex
+		homeBias += profile.HomeBias
+
+		weight := float64(profile.GamesWorked) / (float64(profile.GamesWorked) + ofs.shrinkageGames)
+		goalsDelta += weight * (profile.GoalsPerGame - league.LeagueGoalsPerGame)
+	}
+	if known == 0 {
+		return factor
+	}
+
+	factor.CrewKnown = true
+	factor.PenaltyRateMultiplier = rateIndex / float64(known)
+	factor.HomeBias = homeBias / float64(known)
+	factor.ExpectedTotalGoalsDelta = goalsDelta / float64(known)
+
+	crewPP := league.LeaguePPPerGame * factor.PenaltyRateMultiplier
+	homeEdge := league.LeagueHomePPEdge + factor.HomeBias
+	factor.ExpectedHomePPOpps = math.Max(0, (crewPP+homeEdge)/2.0)
+	factor.ExpectedAwayPPOpps = math.Max(0, (crewPP-homeEdge)/2.0)
+
+	factor.Confidence = math.Min(1.0, float64(factor.GamesSampled)/(2.0*ofs.shrinkageGames*float64(len(assignment.Referees))))
+
+	return factor
+}
+
+// GetSummary returns league officiating averages and referee/linesman profiles
+func (ofs *OfficiatingService) GetSummary() *models.OfficiatingSummary {
+	ofs.mutex.RLock()
+	defer ofs.mutex.RUnlock()
+
+	summary := ofs.league
+	summary.Referees = []models.OfficialProfile{}
+	summary.Linesmen = []models.OfficialProfile{}
+	for _, profile := range ofs.profiles {
+		if profile.Role == "referee" {
+			summary.Referees = append(summary.Referees, *profile)
+		} else {
+			summary.Linesmen = append(summary.Linesmen, *profile)
+		}
+	}
+
+	sort.Slice(summary.Referees, func(i, j int) bool {
+		return summary.Referees[i].PenaltyRateIndex > summary.Referees[j].PenaltyRateIndex
+	})
+	sort.Slice(summary.Linesmen, func(i, j int) bool {
+		return summary.Linesmen[i].GamesWorked > summary.Linesmen[j].GamesWorked
+	})
+
+	return &summary
+}
+
+// ============================================================================
+// PERSISTENCE
+// ============================================================================
+
+// loadData loads assignments and game records from disk
+func (ofs *OfficiatingService) loadData() error {
+	filePath := filepath.Join(ofs.dataDir, "officiating.json")
+
+	jsonData, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error reading officiating file: %w", err)
+	}
+
+	var data struct {
+		Assignments map[int]*models.OfficialsAssignment   `json:"assignments"`
+		Records     map[int]*models.GameOfficiatingRecord `json:"records"`
+	}
+	if err := json.Unmarshal(jsonData, &data); err != nil {
+		return fmt.Errorf("error unmarshaling officiating data: %w", err)
+	}
+
+	if data.Assignments != nil {
+		ofs.assignments = data.Assignments
+	}
+	if data.Records != nil {
+		ofs.records = data.Records
+	}
+
+	log.Printf("🦓 Loaded officiating data: %d assignments, %d games", len(ofs.assignments), len(ofs.records))
+	return nil
+}
+
+// saveData saves assignments and game records to disk (caller holds the lock)
+func (ofs *OfficiatingService) saveData() error {
+	filePath := filepath.Join(ofs.dataDir, "officiating.json")
+
+	data := struct {
+		Assignments map[int]*models.OfficialsAssignment   `json:"assignments"`
+		Records     map[int]*models.GameOfficiatingRecord `json:"records"`
+		LastUpdated time.Time                             `json:"lastUpdated"`
+	}{
+		Assignments: ofs.assignments,
+		Records:     ofs.records,
+		LastUpdated: time.Now(),
+	}
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling officiating data: %w", err)
+	}
+
+	return ioutil.WriteFile(filePath, jsonData, 0644)
+}
+
+// ============================================================================
+// GLOBAL SERVICE
+// ============================================================================
+
+var (
+	globalOfficiatingService *OfficiatingService
+	officiatingMutex         sync.Mutex
+)
+
+// InitializeOfficiatingService initializes the global officiating service
+func InitializeOfficiatingService() error {
+	officiatingMutex.Lock()
+	defer officiatingMutex.Unlock()
+
+	if globalOfficiatingService != nil {
+		return fmt.Errorf("officiating service already initialized")
+	}
+
+	globalOfficiatingService = NewOfficiatingService()
+	log.Printf("🦓 Officiating Service initialized")
+
+	return nil
+}
+
+// GetOfficiatingService returns the global officiating service
+func GetOfficiatingService() *OfficiatingService {
+	officiatingMutex.Lock()
+	defer officiatingMutex.Unlock()
+	return globalOfficiatingService
+}
@@ -0,0 +1,1027 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// PermutationImportanceService measures how much each feature contributes to
+// held-out predictive accuracy. The learned models (gradient boosting, random
+// forest, neural network and LSTM) are refit on every game before a held-out
+// time slice, and the rating models (Elo, Poisson and Bayesian ratings) are
+// replayed so each game only sees earlier results. Each feature is then
+// shuffled across the held-out games (permutation importance) or removed and
+// the model refit (drop-column importance), and the increase in log loss is
+// reported with a 95% interval for every model and for the ensemble's active
+// combiner. Unlike split counts and weight magnitudes, this only credits a
+// feature for predictions it actually improves.
+type PermutationImportanceService struct {
+	dataDir    string
+	resultsDir string
+	report     *PermutationImportanceReport
+	running    bool
+	mutex      sync.RWMutex
+}
+
+// PermutationImportanceOptions controls an importance run
+type PermutationImportanceOptions struct {
+	Repeats         int     `json:"repeats"`         // Shuffles per feature
+	HoldoutFraction float64 `json:"holdoutFraction"` // Most recent share of games held out
+	MaxDropColumn   int     `json:"maxDropColumn"`   // Prune candidates confirmed by drop-column refits
+	Seed            int64   `json:"seed"`
+}
+
+// DefaultPermutationImportanceOptions returns the standard settings
+func DefaultPermutationImportanceOptions() PermutationImportanceOptions {
+	return PermutationImportanceOptions{
+		Repeats:         5,
+		HoldoutFraction: 0.2,
+		MaxDropColumn:   20,
+		Seed:            1,
+	}
+}
+
+// ValidatePermutationImportanceOptions rejects settings a run can't use
+func ValidatePermutationImportanceOptions(opts PermutationImportanceOptions) error {
+	if opts.Repeats < 1 {
+		return fmt.Errorf("repeats must be at least 1")
+	}
+	if opts.HoldoutFraction <= 0 || opts.HoldoutFraction >= 0.5 {
+		return fmt.Errorf("holdout fraction must be between 0 and 0.5")
+	}
+	if opts.MaxDropColumn < 0 {
+		return fmt.Errorf("maxDropColumn cannot be negative")
+	}
+	return nil
+}
+
+// ImportanceEstimate is the mean increase in held-out log loss when a feature
+// is shuffled or removed, with a 95% confidence interval over held-out games.
+// Positive means the feature helps.
+type ImportanceEstimate struct {
+	Mean  float64 `json:"mean"`
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// FeatureImportanceEstimate is one feature's held-out importance for a model
+type FeatureImportanceEstimate struct {
+	Feature        string              `json:"feature"`
+	Permutation    ImportanceEstimate  `json:"permutation"`
+	DropColumn     *ImportanceEstimate `json:"dropColumn,omitempty"` // Only for prune candidates
+	Constant       bool                `json:"constant,omitempty"`   // Never varies, so no model can use it
+	Recommendation string              `json:"recommendation"`       // "keep", "review" or "prune"
+}
+
+// ModelImportanceReport holds one model's (or the ensemble's) feature importances
+type ModelImportanceReport struct {
+	Model            string                      `json:"model"`
+	DropColumnMethod string                      `json:"dropColumnMethod"` // "refit", "mean_imputed" or "mixed" (ensemble)
+	BaselineLogLoss  float64                     `json:"baselineLogLoss"`
+	BaselineAccuracy float64                     `json:"baselineAccuracy"`
+	Features         []FeatureImportanceEstimate `json:"features"` // Most important first
+}
+
+// PermutationImportanceReport summarizes the latest importance run
+type PermutationImportanceReport struct {
+	TrainGames       int                     `json:"trainGames"`
+	HoldoutGames     int                     `json:"holdoutGames"`
+	HoldoutStart     time.Time               `json:"holdoutStart"`
+	HoldoutEnd       time.Time               `json:"holdoutEnd"`
+	Repeats          int                     `json:"repeats"`
+	Combiner         string                  `json:"combiner"`                  // Ensemble combiner scored as "Ensemble"
+	EnsembleWeights  map[string]float64      `json:"ensembleWeights,omitempty"` // Weights of a fixed or dynamic combiner
+	FixedInputModels []string                `json:"fixedInputModels"`          // Enter the ensemble at their held-out predictions, unscored
+	Ensemble         ModelImportanceReport   `json:"ensemble"`
+	Models           []ModelImportanceReport `json:"models"`
+	KeepCount        int                     `json:"keepCount"` // Ensemble recommendations
+	ReviewCount      int                     `json:"reviewCount"`
+	PruneCount       int                     `json:"pruneCount"`
+	CompletedAt      time.Time               `json:"completedAt"`
+	DurationMs       int64                   `json:"durationMs"`
+}
+
+const (
+	permutationMinHoldout = 30    // Held-out games needed for meaningful intervals
+	permutationMinTrain   = 60    // Games needed to refit the models
+	permutationPruneLoss  = 0.001 // Log loss increase below which a feature adds nothing material
+	permutationNNEpochs   = 30
+	permutationNNPatience = 5
+)
+
+// importanceModel is one refit or replayed model with its held-out features
+type importanceModel struct {
+	name       string
+	train      [][]float64
+	holdout    [][]float64
+	columns    map[string][]int // Feature label -> columns carrying it; unlabeled columns stay with their game
+	predict    func(features []float64) float64
+	refit      func(train [][]float64) func(features []float64) float64 // nil: drop-column mean-imputes instead
+	baseline   []float64                                                // Held-out probabilities
+	importance map[string]*featureLosses
+}
+
+// featureLosses holds per-game log loss increases for one feature
+type featureLosses struct {
+	permutation []float64
+	dropColumn  []float64
+	constant    bool
+}
+
+var (
+	permutationImportanceService     *PermutationImportanceService
+	permutationImportanceServiceOnce sync.Once
+)
+
+// GetPermutationImportanceService returns the singleton, creating it on first use
+func GetPermutationImportanceService() *PermutationImportanceService {
+	permutationImportanceServiceOnce.Do(func() {
+		dataDir := "data/feature_importance"
+		os.MkdirAll(dataDir, 0755)
+
+		permutationImportanceService = &PermutationImportanceService{
+			dataDir:    dataDir,
+			resultsDir: "data/results",
+		}
+		permutationImportanceService.loadReport()
+	})
+	return permutationImportanceService
+}
+
+// IsRunning reports whether an importance run is in progress
+func (pis *PermutationImportanceService) IsRunning() bool {
+	pis.mutex.RLock()
+	defer pis.mutex.RUnlock()
+	return pis.running
+}
+
+// GetReport returns the latest report, or nil before the first run
+func (pis *PermutationImportanceService) GetReport() *PermutationImportanceReport {
+	pis.mutex.RLock()
+	defer pis.mutex.RUnlock()
+	return pis.report
+}
+
+// PruneCandidates returns the ensemble features recommended for pruning,
+// least important first, or nil before the first run
+func (pis *PermutationImportanceService) PruneCandidates() []FeatureImportanceEstimate {
+	report := pis.GetReport()
+	if report == nil {
+		return nil
+	}
+
+	var candidates []FeatureImportanceEstimate
+	for i := len(report.Ensemble.Features) - 1; i >= 0; i-- {
+		if report.Ensemble.Features[i].Recommendation == "prune" {
+			candidates = append(candidates, report.Ensemble.Features[i])
+		}
+	}
+	return candidates
+}
+
+// Run refits the models before the held-out slice and measures every
+// feature's permutation importance, confirming prune candidates by drop-column
+func (pis *PermutationImportanceService) Run(opts PermutationImportanceOptions) (*PermutationImportanceReport, error) {
+	if err := ValidatePermutationImportanceOptions(opts); err != nil {
+		return nil, err
+	}
+
+	pis.mutex.Lock()
+	if pis.running {
+		pis.mutex.Unlock()
+		return nil, fmt.Errorf("permutation importance already running")
+	}
+	pis.running = true
+	pis.mutex.Unlock()
+
+	defer func() {
+		pis.mutex.Lock()
+		pis.running = false
+		pis.mutex.Unlock()
+	}()
+
+	start := time.Now()
+
+	games, err := loadCompletedGamesFrom(pis.resultsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load completed games: %w", err)
+	}
+
+	report, err := computePermutationImportance(games, opts)
+	if err != nil {
+		return nil, err
+	}
+	report.CompletedAt = time.Now()
+	report.DurationMs = time.Since(start).Milliseconds()
+
+	log.Printf("🔀 Permutation importance: %d keep, %d review, %d prune (ensemble log loss %.4f)",
+		report.KeepCount, report.ReviewCount, report.PruneCount, report.Ensemble.BaselineLogLoss)
+
+	pis.mutex.Lock()
+	pis.report = report
+	pis.mutex.Unlock()
+	pis.saveReport(report)
+
+	return report, nil
+}
+
+// computePermutationImportance holds out the most recent date-ordered games
+// with prediction-time factors, refits the models on the rest and scores
+// every feature
+func computePermutationImportance(games []models.CompletedGame, opts PermutationImportanceOptions) (*PermutationImportanceReport, error) {
+	examples := buildTreeTrainingExamples(games) // Already in date order
+	holdoutSize := int(math.Round(float64(len(examples)) * opts.HoldoutFraction))
+	if holdoutSize < permutationMinHoldout {
+		holdoutSize = permutationMinHoldout
+	}
+	trainSize := len(examples) - holdoutSize
+	if trainSize < permutationMinTrain {
+		return nil, fmt.Errorf("insufficient data: %d games with features (need %d)",
+			len(examples), permutationMinTrain+permutationMinHoldout)
+	}
+	log.Printf("🔀 Permutation importance: training on %d games, holding out the last %d", trainSize, holdoutSize)
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	importanceModels := buildImportanceModels(games, examples, trainSize, rng)
+
+	outcomes := make([]float64, holdoutSize)
+	for i, example := range examples[trainSize:] {
+		if example.Game.HomeTeam.Score > example.Game.AwayTeam.Score {
+			outcomes[i] = 1.0
+		}
+	}
+
+	combiner := newImportanceCombiner(examples[trainSize:])
+	ensemble := &importanceModel{
+		name:       "Ensemble",
+		baseline:   combiner.combine(importanceModels, func(m *importanceModel) []float64 { return m.baseline }),
+		importance: make(map[string]*featureLosses),
+	}
+
+	features := importanceFeatureLabels(importanceModels)
+	permutationImportance(importanceModels, combiner, ensemble, features, outcomes, opts.Repeats, rng)
+
+	// Drop-column refits are expensive, so only confirm the features
+	// permutation couldn't show to matter, least important first
+	var candidates []string
+	for _, feature := range features {
+		losses := ensemble.importance[feature]
+		if !losses.constant && recommendFeature(estimateImportance(losses.permutation), nil, false) != "keep" {
+			candidates = append(candidates, feature)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return estimateImportance(ensemble.importance[candidates[i]].permutation).Upper <
+			estimateImportance(ensemble.importance[candidates[j]].permutation).Upper
+	})
+	if len(candidates) > opts.MaxDropColumn {
+		candidates = candidates[:opts.MaxDropColumn]
+	}
+	dropColumnImportance(importanceModels, combiner, ensemble, candidates, outcomes)
+
+	report := &PermutationImportanceReport{
+		TrainGames:      trainSize,
+		HoldoutGames:    holdoutSize,
+		HoldoutStart:    examples[trainSize].Game.GameDate,
+		HoldoutEnd:      examples[len(examples)-1].Game.GameDate,
+		Repeats:         opts.Repeats,
+		Combiner:        combiner.name,
+		EnsembleWeights: combiner.weights,
+		Ensemble:        summarizeImportance(ensemble, "mixed", outcomes),
+	}
+	for name := range combiner.fixed {
+		report.FixedInputModels = append(report.FixedInputModels, name)
+	}
+	sort.Strings(report.FixedInputModels)
+	for _, m := range importanceModels {
+		method := "refit"
+		if m.refit == nil {
+			method = "mean_imputed"
+		}
+		report.Models = append(report.Models, summarizeImportance(m, method, outcomes))
+	}
+	for _, feature := range report.Ensemble.Features {
+		switch feature.Recommendation {
+		case "keep":
+			report.KeepCount++
+		case "review":
+			report.ReviewCount++
+		default:
+			report.PruneCount++
+		}
+	}
+	return report, nil
+}
+
+// buildImportanceModels extracts each model's features, refits the learned
+// models on the first trainSize examples with their current hyperparameters
+// and replays the rating models over every game
+func buildImportanceModels(games []models.CompletedGame, examples []models.TrainingExample, trainSize int, rng *rand.Rand) []*importanceModel {
+	gbmExamples := newTreeEvaluator("gradient_boosting", examples)
+	gbmLabels := make([]float64, trainSize)
+	for i, game := range gbmExamples.games[:trainSize] {
+		if game.HomeTeam.Score > game.AwayTeam.Score {
+			gbmLabels[i] = 1.0
+		}
+	}
+	gbmParams := currentHyperparameters("gradient_boosting")
+	fitGBM := func(train [][]float64) func([]float64) float64 {
+		model := &GradientBoostingModel{
+			learningRate:   gbmParams["learningRate"],
+			numTrees:       int(gbmParams["numTrees"]),
+			maxDepth:       int(gbmParams["maxDepth"]),
+			minSamplesLeaf: int(gbmParams["minSamplesLeaf"]),
+			quiet:          true,
+		}
+		model.fit(train, gbmLabels)
+		return func(features []float64) float64 {
+			prob := 1.0 / (1.0 + math.Exp(-model.predictProbability(features)))
+			return math.Max(0.35, math.Min(0.85, prob)) // Same bounds as Predict
+		}
+	}
+
+	rfExamples := newTreeEvaluator("random_forest", examples)
+	rfLabels := make([]float64, trainSize)
+	for i, game := range rfExamples.games[:trainSize] {
+		// Same encoding as prepareTrainingData: 1 = win, 2 = OT loss, 0 = loss
+		if game.HomeTeam.Score > game.AwayTeam.Score {
+			rfLabels[i] = 1.0
+		} else if game.WinType == "OT" || game.WinType == "SO" {
+			rfLabels[i] = 2.0
+		}
+	}
+	rfParams := currentHyperparameters("random_forest")
+	rfSeed := rng.Int63()
+	fitRF := func(train [][]float64) func([]float64) float64 {
+		// Every refit starts from the same seed so runs are reproducible
+		model := &RandomForestModel{
+			numTrees:       int(rfParams["numTrees"]),
+			maxDepth:       int(rfParams["maxDepth"]),
+			minSamplesLeaf: int(rfParams["minSamplesLeaf"]),
+			maxFeatures:    int(rfParams["maxFeatures"]),
+			quiet:          true,
+			rng:            rand.New(rand.NewSource(rfSeed)),
+		}
+		model.fit(train, rfLabels)
+		return func(features []float64) float64 {
+			_, prob := model.vote(features)
+			return prob
+		}
+	}
+
+	nnInputs := make([][]float64, len(examples))
+	for i, example := range examples {
+		nnInputs[i] = (&NeuralNetworkModel{}).extractFeatures(example.HomeFactors, example.AwayFactors)
+	}
+	network := fitImportanceNetwork(examples[:trainSize], nnInputs[:trainSize], rng)
+	predictNN := func(features []float64) float64 {
+		return network.forwardPass(features)[0] // Same mapping as Predict
+	}
+
+	exampleIndex := make(map[int]int, len(examples))
+	for i, example := range examples {
+		exampleIndex[example.Game.GameID] = i
+	}
+	holdoutStart := 0 // First held-out game in the full history
+	for holdoutStart < len(games) && games[holdoutStart].GameID != examples[trainSize].Game.GameID {
+		holdoutStart++
+	}
+
+	// Rating models: one replay predicts every game from earlier results only
+	replay := &tuningEvaluator{games: games}
+
+	var elo *EloRatingModel
+	eloRows := make([][]float64, len(examples))
+	replay.replayElo(currentHyperparameters("elo"), func(i int, model *EloRatingModel) {
+		elo = model
+		if j, ok := exampleIndex[games[i].GameID]; ok {
+			home, away := examples[j].HomeFactors, examples[j].AwayFactors
+			terms := []float64{model.getTeamRating(home.TeamCode), model.getTeamRating(away.TeamCode), model.homeAdvantage}
+			eloRows[j] = append(terms, model.situationalAdjustmentParts(home, away)...)
+		}
+	})
+	eloTerms := 1<<(3+len(eloSituationalLabels)) - 1
+	predictElo := func(features []float64) float64 {
+		return elo.termWinProbability(features, eloTerms)
+	}
+
+	var poisson *PoissonRegressionModel
+	poissonRows := make([][]float64, len(examples))
+	replay.replayPoisson(currentHyperparameters("poisson"), func(i int, model *PoissonRegressionModel) {
+		poisson = model
+		if j, ok := exampleIndex[games[i].GameID]; ok {
+			poissonRows[j] = model.termValues(examples[j].HomeFactors, examples[j].AwayFactors)
+		}
+	})
+	predictPoisson := func(features []float64) float64 {
+		return poisson.termWinProbability(features, 1<<len(poissonImportanceLabels)-1)
+	}
+
+	// Each row carries the game's intercept and rate variances after the
+	// rating terms, unlabeled so they move with the game
+	ratingRows := make([][]float64, len(examples))
+	replayBayesianRatingsWith(games, func(i int, model *BayesianRatingModel) {
+		if j, ok := exampleIndex[games[i].GameID]; ok {
+			game := games[i]
+			terms, homeVar, awayVar := model.termValues(game.HomeTeam.TeamCode, game.AwayTeam.TeamCode, game.GameDate)
+			ratingRows[j] = append(terms, model.intercept.Mean, model.intercept.Variance, homeVar, awayVar)
+		}
+	})
+	predictRatings := func(features []float64) float64 {
+		terms := len(bayesianRatingImportanceLabels)
+		intercept := GaussianBelief{Mean: features[terms], Variance: features[terms+1]}
+		return bayesianTermWinProbability(features[:terms], 1<<terms-1, intercept, features[terms+2], features[terms+3])
+	}
+
+	importanceModels := []*importanceModel{
+		{
+			name:    "Gradient Boosting",
+			train:   gbmExamples.features[:trainSize],
+			holdout: gbmExamples.features[trainSize:],
+			columns: featureColumns(len(gbmExamples.features[0]), gbmFeatureLabel),
+			refit:   fitGBM,
+		},
+		{
+			name:    "Random Forest",
+			train:   rfExamples.features[:trainSize],
+			holdout: rfExamples.features[trainSize:],
+			columns: featureColumns(len(rfExamples.features[0]), getFeatureName),
+			refit:   fitRF,
+		},
+		{
+			// Retraining the network once per dropped feature costs too much
+			name:    "Neural Network",
+			train:   nnInputs[:trainSize],
+			holdout: nnInputs[trainSize:],
+			columns: featureColumns(nnInputFeatures, neuralNetFeatureLabel),
+			predict: predictNN,
+		},
+	}
+
+	// Like the network, the LSTM is too costly to retrain per dropped feature
+	if lstm := fitImportanceLSTM(games[:holdoutStart], rng); lstm != nil {
+		lstmRows := lstmImportanceRows(lstm, games, exampleIndex)
+		width := lstm.sequenceLen * lstm.inputSize
+		sequence := func(features []float64) [][]float64 {
+			steps := make([][]float64, lstm.sequenceLen)
+			for t := range steps {
+				steps[t] = features[t*lstm.inputSize : (t+1)*lstm.inputSize]
+			}
+			return steps
+		}
+		importanceModels = append(importanceModels, &importanceModel{
+			name:    "LSTM",
+			train:   lstmRows[:trainSize],
+			holdout: lstmRows[trainSize:],
+			columns: featureColumns(2*width, func(i int) string {
+				side, channel := "Home", i%lstm.inputSize
+				if i >= width {
+					side = "Away"
+				}
+				if channel >= len(lstmHistoryChannels) {
+					return ""
+				}
+				return side + " Recent " + lstmHistoryChannels[channel]
+			}),
+			predict: func(features []float64) float64 {
+				return lstm.sequenceWinProbability(sequence(features[:width]), sequence(features[width:]))
+			},
+		})
+	} else {
+		log.Printf("⚠️ Permutation importance: too few sequences to train the LSTM, leaving it out")
+	}
+
+	importanceModels = append(importanceModels,
+		&importanceModel{
+			name:    "Elo Rating",
+			train:   eloRows[:trainSize],
+			holdout: eloRows[trainSize:],
+			columns: featureColumns(len(eloRows[0]), eloImportanceLabel),
+			predict: predictElo,
+		},
+		&importanceModel{
+			name:    "Poisson Regression",
+			train:   poissonRows[:trainSize],
+			holdout: poissonRows[trainSize:],
+			columns: featureColumns(len(poissonImportanceLabels), func(i int) string { return poissonImportanceLabels[i] }),
+			predict: predictPoisson,
+		},
+		&importanceModel{
+			name:    "Bayesian Ratings",
+			train:   ratingRows[:trainSize],
+			holdout: ratingRows[trainSize:],
+			columns: featureColumns(len(bayesianRatingImportanceLabels), func(i int) string { return bayesianRatingImportanceLabels[i] }),
+			predict: predictRatings,
+		},
+	)
+
+	for _, m := range importanceModels {
+		if m.refit != nil {
+			m.predict = m.refit(m.train)
+		}
+		m.baseline = make([]float64, len(m.holdout))
+		for i, features := range m.holdout {
+			m.baseline[i] = m.predict(features)
+		}
+		m.importance = make(map[string]*featureLosses)
+	}
+	return importanceModels
+}
+
+// fitImportanceNetwork trains a throwaway network with the production
+// architecture, stopping early on the last 15% of the training games
+func fitImportanceNetwork(examples []models.TrainingExample, inputs [][]float64, rng *rand.Rand) *NeuralNetworkModel {
+	var network *NeuralNetworkModel
+	if lps := GetLivePredictionSystem(); lps != nil && lps.GetNeuralNetwork() != nil {
+		network = lps.GetNeuralNetwork().untrainedCopy()
+	} else {
+		network = NewNeuralNetworkModelWithArchitecture([]int{nnInputFeatures, 512, 256, 128, 3}, 0.0005, "relu", 0, 0)
+	}
+
+	targets := make([][]float64, len(examples))
+	labels := make([]float64, len(examples))
+	for i, example := range examples {
+		homeScore, awayScore := example.Game.HomeTeam.Score, example.Game.AwayTeam.Score
+		if homeScore > awayScore {
+			labels[i] = 1.0
+		}
+		targets[i] = []float64{labels[i], float64(homeScore) / 8.0, float64(awayScore) / 8.0}
+	}
+
+	split := len(inputs) * 85 / 100
+	bestLoss := math.Inf(1)
+	var bestWeights, bestBiases [][]float64
+	sinceBest := 0
+	for epoch := 0; epoch < permutationNNEpochs && sinceBest < permutationNNPatience; epoch++ {
+		network.trainEpoch(inputs[:split], targets[:split], 32, rng)
+		valLoss, _, _ := network.evaluateSamples(inputs[split:], labels[split:])
+		if valLoss < bestLoss-1e-4 {
+			bestLoss = valLoss
+			bestWeights, bestBiases = network.copyParameters()
+			sinceBest = 0
+		} else {
+			sinceBest++
+		}
+	}
+	if bestWeights != nil {
+		network.setParameters(bestWeights, bestBiases)
+	}
+	return network
+}
+
+// featureColumns groups a model's columns by feature label, leaving out unlabeled columns
+func featureColumns(n int, label func(int) string) map[string][]int {
+	columns := make(map[string][]int)
+	for i := 0; i < n; i++ {
+		if name := label(i); name != "" {
+			columns[name] = append(columns[name], i)
+		}
+	}
+	return columns
+}
+
+// Feature labels of the rating models' terms, in their Explain order. Home
+// ice is a league-wide parameter rather than a game feature, so it is left
+// unlabeled.
+var (
+	poissonImportanceLabels = []string{
+		"Home Offense Rate", "Away Defense Rate", "Away Offense Rate", "Home Defense Rate", "",
+		"Home Situational Multiplier", "Away Situational Multiplier",
+		"Home Analytics Multiplier", "Away Analytics Multiplier",
+	}
+	bayesianRatingImportanceLabels = []string{
+		"", "Home Offense Rating", "Away Defense Rating", "Away Offense Rating", "Home Defense Rating",
+	}
+)
+
+// eloImportanceLabel names the Elo terms: both ratings, home ice, then the situational parts
+func eloImportanceLabel(idx int) string {
+	switch {
+	case idx == 0:
+		return "Home Elo Rating"
+	case idx == 1:
+		return "Away Elo Rating"
+	case idx == 2:
+		return ""
+	default:
+		return eloSituationalLabels[idx-3] + " Adjustment"
+	}
+}
+
+// fitImportanceLSTM trains a throwaway LSTM with the production architecture
+// on the games before the held-out slice, stopping early on its most recent
+// sequences. Returns nil when there are too few sequences to train on.
+func fitImportanceLSTM(games []models.CompletedGame, rng *rand.Rand) *LSTMModel {
+	lstm := GetLSTMModel().untrainedCopy()
+	sequences := lstm.prepareSequences(games)
+	if len(sequences) < 20 {
+		return nil
+	}
+	sort.Slice(sequences, func(i, j int) bool {
+		return sequences[i].GameDate.Before(sequences[j].GameDate)
+	})
+
+	split := len(sequences) - int(float64(len(sequences))*lstmValidationFraction)
+	bestLoss := math.Inf(1)
+	var best *lstmGradients
+	sinceBest := 0
+	for epoch := 0; epoch < permutationNNEpochs && sinceBest < permutationNNPatience; epoch++ {
+		lstm.trainEpoch(sequences[:split], rng)
+		valLoss := lstm.sequenceLoss(sequences[split:])
+		if valLoss < bestLoss-1e-4 {
+			bestLoss = valLoss
+			best = lstm.copyParameters()
+			sinceBest = 0
+		} else {
+			sinceBest++
+		}
+	}
+	if best != nil {
+		lstm.restoreParameters(best)
+	}
+	lstm.trained = true
+	return lstm
+}
+
+// lstmImportanceRows builds each example's home then away input sequence,
+// flattened, from the games each team played before it
+func lstmImportanceRows(lstm *LSTMModel, games []models.CompletedGame, exampleIndex map[int]int) [][]float64 {
+	rows := make([][]float64, len(exampleIndex))
+	history := make(map[string][]models.CompletedGame)
+	for _, game := range games {
+		teams := []string{game.HomeTeam.TeamCode, game.AwayTeam.TeamCode}
+		if j, ok := exampleIndex[game.GameID]; ok {
+			row := make([]float64, 0, 2*lstm.sequenceLen*lstm.inputSize)
+			for _, team := range teams {
+				for _, step := range lstm.historySequence(history[team], team) {
+					row = append(row, step...)
+				}
+			}
+			rows[j] = row
+		}
+		for _, team := range teams {
+			history[team] = append(history[team], game)
+			if len(history[team]) > lstm.sequenceLen {
+				history[team] = history[team][1:]
+			}
+		}
+	}
+	return rows
+}
+
+// importanceFeatureLabels returns every model's feature labels, sorted
+func importanceFeatureLabels(importanceModels []*importanceModel) []string {
+	seen := make(map[string]bool)
+	var labels []string
+	for _, m := range importanceModels {
+		for label := range m.columns {
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// importanceCombiner scores held-out games with the ensemble's active combiner
+type importanceCombiner struct {
+	name     string               // Combiner the ensemble uses
+	weights  map[string]float64   // Fixed or dynamic weights; nil for the learned combiners
+	fixed    map[string][]float64 // Held-out probabilities of the stateless models, never permuted
+	contexts []MetaGameContext
+	meta     *MetaLearnerModel
+}
+
+// newImportanceCombiner resolves the ensemble's combiner and predicts the
+// held-out games with the stateless models, which read prediction-time
+// factors directly rather than a feature vector and so enter unchanged
+func newImportanceCombiner(holdout []models.TrainingExample) *importanceCombiner {
+	meta := GetMetaLearnerModel()
+	c := &importanceCombiner{
+		name:     meta.Combiner(),
+		fixed:    make(map[string][]float64),
+		contexts: make([]MetaGameContext, len(holdout)),
+		meta:     meta,
+	}
+
+	switch c.name {
+	case CombinerFixed:
+		c.weights = fixedCombinerWeights
+	case CombinerDynamic:
+		// Models dynamic weighting doesn't track keep their base weight, as in PredictGame
+		c.weights = make(map[string]float64, len(fixedCombinerWeights))
+		for name, w := range fixedCombinerWeights {
+			c.weights[name] = w
+		}
+		if lps := GetLivePredictionSystem(); lps != nil && lps.GetEnsemble() != nil && lps.GetEnsemble().dynamicWeights != nil {
+			for name, w := range lps.GetEnsemble().dynamicWeights.GetCurrentWeights() {
+				c.weights[name] = w
+			}
+		}
+	}
+
+	for i, example := range holdout {
+		c.contexts[i] = newMetaGameContext(example.HomeFactors, example.AwayFactors)
+	}
+	for _, model := range []PredictionModel{NewStatisticalModel(), NewBayesianModel(), NewMonteCarloModel()} {
+		probs := make([]float64, len(holdout))
+		for i, example := range holdout {
+			probs[i] = math.NaN()
+			if result, err := model.Predict(example.HomeFactors, example.AwayFactors); err == nil {
+				probs[i] = result.WinProbability
+			}
+		}
+		c.fixed[model.GetName()] = probs
+	}
+	return c
+}
+
+// combine scores every held-out game from the models' probabilities.
+// Weighted combiners blend the way stacking scores them; the learned
+// combiners fill missing models with the mean of the others, as in stacking.
+func (c *importanceCombiner) combine(importanceModels []*importanceModel, probs func(m *importanceModel) []float64) []float64 {
+	combined := make([]float64, len(c.contexts))
+	for i := range combined {
+		modelProbs := make(map[string]float64, len(fixedCombinerWeights))
+		for name, p := range c.fixed {
+			if !math.IsNaN(p[i]) {
+				modelProbs[name] = p[i]
+			}
+		}
+		for _, m := range importanceModels {
+			modelProbs[m.name] = probs(m)[i]
+		}
+
+		if c.weights != nil {
+			combined[i] = blendWalkForward(WalkForwardRecord{ModelProbs: modelProbs}, c.weights)
+			continue
+		}
+
+		mean := 0.0
+		for _, p := range modelProbs {
+			mean += p
+		}
+		mean /= float64(len(modelProbs))
+
+		predictions := ModelPredictions{}
+		for name := range fixedCombinerWeights {
+			predictions.setModel(name, mean)
+		}
+		for name, p := range modelProbs {
+			predictions.setModel(name, p)
+		}
+		combined[i] = c.meta.PredictFromModels(&predictions, &c.contexts[i])
+	}
+	return combined
+}
+
+// permutationImportance shuffles each feature across the held-out games and
+// records the per-game log loss increase, averaged over repeats. Every model
+// sees the same shuffle, so the ensemble's loss is that of a consistent
+// counterfactual game.
+func permutationImportance(importanceModels []*importanceModel, combiner *importanceCombiner, ensemble *importanceModel, features []string, outcomes []float64, repeats int, rng *rand.Rand) {
+	n := len(outcomes)
+	for _, feature := range features {
+		ensembleLosses := &featureLosses{permutation: make([]float64, n), constant: true}
+		permuted := make(map[*importanceModel][]float64)
+
+		for _, m := range importanceModels {
+			columns, ok := m.columns[feature]
+			if !ok {
+				continue
+			}
+			losses := &featureLosses{permutation: make([]float64, n), constant: constantColumns(m, columns)}
+			m.importance[feature] = losses
+			ensembleLosses.constant = ensembleLosses.constant && losses.constant
+		}
+
+		shuffled := make([]float64, 0)
+		for r := 0; r < repeats; r++ {
+			order := rng.Perm(n)
+			for _, m := range importanceModels {
+				columns, ok := m.columns[feature]
+				if !ok {
+					permuted[m] = m.baseline
+					continue
+				}
+				probs := make([]float64, n)
+				for i := range m.holdout {
+					shuffled = append(shuffled[:0], m.holdout[i]...)
+					for _, c := range columns {
+						shuffled[c] = m.holdout[order[i]][c]
+					}
+					probs[i] = m.predict(shuffled)
+				}
+				permuted[m] = probs
+
+				losses := m.importance[feature].permutation
+				for i := range probs {
+					losses[i] += (gameLogLoss(probs[i], outcomes[i]) - gameLogLoss(m.baseline[i], outcomes[i])) / float64(repeats)
+				}
+			}
+
+			combined := combiner.combine(importanceModels, func(m *importanceModel) []float64 { return permuted[m] })
+			for i := range combined {
+				ensembleLosses.permutation[i] += (gameLogLoss(combined[i], outcomes[i]) - gameLogLoss(ensemble.baseline[i], outcomes[i])) / float64(repeats)
+			}
+		}
+		ensemble.importance[feature] = ensembleLosses
+	}
+}
+
+// dropColumnImportance removes each candidate feature by setting it to its
+// training mean everywhere, refitting models that can be refit, and records
+// the per-game log loss increase on the held-out games
+func dropColumnImportance(importanceModels []*importanceModel, combiner *importanceCombiner, ensemble *importanceModel, candidates []string, outcomes []float64) {
+	for _, feature := range candidates {
+		dropped := make(map[*importanceModel][]float64)
+		for _, m := range importanceModels {
+			columns, ok := m.columns[feature]
+			if !ok {
+				dropped[m] = m.baseline
+				continue
+			}
+
+			means := make([]float64, len(columns))
+			for _, row := range m.train {
+				for k, c := range columns {
+					means[k] += row[c] / float64(len(m.train))
+				}
+			}
+			impute := func(rows [][]float64) [][]float64 {
+				out := make([][]float64, len(rows))
+				for i, row := range rows {
+					out[i] = append([]float64(nil), row...)
+					for k, c := range columns {
+						out[i][c] = means[k]
+					}
+				}
+				return out
+			}
+
+			predict := m.predict
+			if m.refit != nil {
+				predict = m.refit(impute(m.train))
+			}
+			probs := make([]float64, len(outcomes))
+			for i, row := range impute(m.holdout) {
+				probs[i] = predict(row)
+			}
+			dropped[m] = probs
+
+			losses := make([]float64, len(outcomes))
+			for i := range probs {
+				losses[i] = gameLogLoss(probs[i], outcomes[i]) - gameLogLoss(m.baseline[i], outcomes[i])
+			}
+			m.importance[feature].dropColumn = losses
+		}
+
+		combined := combiner.combine(importanceModels, func(m *importanceModel) []float64 { return dropped[m] })
+		losses := make([]float64, len(outcomes))
+		for i := range combined {
+			losses[i] = gameLogLoss(combined[i], outcomes[i]) - gameLogLoss(ensemble.baseline[i], outcomes[i])
+		}
+		ensemble.importance[feature].dropColumn = losses
+	}
+}
+
+// constantColumns reports whether the columns never vary across all games
+func constantColumns(m *importanceModel, columns []int) bool {
+	for _, c := range columns {
+		first := m.train[0][c]
+		for _, rows := range [][][]float64{m.train, m.holdout} {
+			for _, row := range rows {
+				if row[c] != first {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// gameLogLoss is one game's log loss with the probability clamped as in tuning
+func gameLogLoss(prob, outcome float64) float64 {
+	p := math.Max(0.001, math.Min(0.999, prob))
+	return -(outcome*math.Log(p) + (1-outcome)*math.Log(1-p))
+}
+
+// estimateImportance averages per-game loss increases with a normal 95% interval
+func estimateImportance(losses []float64) ImportanceEstimate {
+	n := float64(len(losses))
+	if n == 0 {
+		return ImportanceEstimate{}
+	}
+
+	mean := 0.0
+	for _, loss := range losses {
+		mean += loss
+	}
+	mean /= n
+
+	variance := 0.0
+	for _, loss := range losses {
+		variance += (loss - mean) * (loss - mean)
+	}
+	if n > 1 {
+		variance /= n - 1
+	}
+	margin := 1.96 * math.Sqrt(variance/n)
+
+	return ImportanceEstimate{Mean: mean, Lower: mean - margin, Upper: mean + margin}
+}
+
+// recommendFeature keeps features either method shows to reduce held-out
+// loss, prunes constant features and those whose permutation interval rules
+// out a material effect and whose removal doesn't hurt, and flags the rest
+// for review
+func recommendFeature(permutation ImportanceEstimate, dropColumn *ImportanceEstimate, constant bool) string {
+	switch {
+	case constant:
+		return "prune"
+	case permutation.Lower > 0 || (dropColumn != nil && dropColumn.Lower > 0):
+		return "keep"
+	case permutation.Upper < permutationPruneLoss && dropColumn != nil && dropColumn.Mean < permutationPruneLoss:
+		return "prune"
+	default:
+		return "review"
+	}
+}
+
+// summarizeImportance turns per-game losses into a model's report, most important first
+func summarizeImportance(m *importanceModel, dropColumnMethod string, outcomes []float64) ModelImportanceReport {
+	summary := ModelImportanceReport{
+		Model:            m.name,
+		DropColumnMethod: dropColumnMethod,
+		Features:         make([]FeatureImportanceEstimate, 0, len(m.importance)),
+	}
+
+	for i, prob := range m.baseline {
+		summary.BaselineLogLoss += gameLogLoss(prob, outcomes[i])
+		if (prob > 0.5) == (outcomes[i] == 1.0) {
+			summary.BaselineAccuracy++
+		}
+	}
+	summary.BaselineLogLoss /= float64(len(outcomes))
+	summary.BaselineAccuracy /= float64(len(outcomes))
+
+	for feature, losses := range m.importance {
+		estimate := FeatureImportanceEstimate{
+			Feature:     feature,
+			Permutation: estimateImportance(losses.permutation),
+			Constant:    losses.constant,
+		}
+		if losses.dropColumn != nil {
+			dropColumn := estimateImportance(losses.dropColumn)
+			estimate.DropColumn = &dropColumn
+		}
+		estimate.Recommendation = recommendFeature(estimate.Permutation, estimate.DropColumn, estimate.Constant)
+		summary.Features = append(summary.Features, estimate)
+	}
+	sort.Slice(summary.Features, func(i, j int) bool {
+		if summary.Features[i].Permutation.Mean != summary.Features[j].Permutation.Mean {
+			return summary.Features[i].Permutation.Mean > summary.Features[j].Permutation.Mean
+		}
+		return summary.Features[i].Feature < summary.Features[j].Feature
+	})
+	return summary
+}
+
+// saveReport persists the latest report
+func (pis *PermutationImportanceService) saveReport(report *PermutationImportanceReport) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal permutation importance report: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(pis.dataDir, "permutation_report.json"), data, 0644); err != nil {
+		log.Printf("⚠️ Failed to save permutation importance report: %v", err)
+	}
+}
+
+// loadReport restores the latest report from disk
+func (pis *PermutationImportanceService) loadReport() {
+	data, err := ioutil.ReadFile(filepath.Join(pis.dataDir, "permutation_report.json"))
+	if err != nil {
+		return
+	}
+	var report PermutationImportanceReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		log.Printf("⚠️ Failed to parse permutation importance report: %v", err)
+		return
+	}
+	pis.report = &report
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -45,11 +46,12 @@ func GetPlayByPlayService() *PlayByPlayService {
 }
 
 // BackfillPlayByPlayData fetches play-by-play data for the last N completed games for all teams
-func (pbp *PlayByPlayService) BackfillPlayByPlayData(teamCode string, numGames int) error {
+// Stops early with ctx.Err() if ctx is cancelled between games.
+func (pbp *PlayByPlayService) BackfillPlayByPlayData(ctx context.Context, teamCode string, numGames int) error {
 	log.Printf("🔄 Starting play-by-play backfill for %s (last %d games)...", teamCode, numGames)
 
 	// Get the team's season schedule (both past and future)
-	completedGames, err := pbp.getCompletedGames(teamCode, numGames)
+	completedGames, err := pbp.getCompletedGames(ctx, teamCode, numGames)
 	if err != nil {
 		return fmt.Errorf("failed to get completed games: %w", err)
 	}
@@ -61,41 +63,29 @@ func (pbp *PlayByPlayService) BackfillPlayByPlayData(teamCode string, numGames i
 	failCount := 0
 
 	for i, game := range completedGames {
+		if err := ctx.Err(); err != nil {
+			log.Printf("🛑 Play-by-play backfill for %s cancelled after %d games", teamCode, i)
+			return err
+		}
+
 		log.Printf("🏒 [%d/%d] Processing game %d: %s vs %s",
 			i+1, len(completedGames), game.ID, game.AwayTeam.Abbrev, game.HomeTeam.Abbrev)
 
-		startTime := time.Now()
-
-		// Fetch and analyze play-by-play
-		analytics, err := pbp.FetchPlayByPlay(game.ID)
-		if err != nil {
-			log.Printf("⚠️ Failed to fetch play-by-play for game %d: %v", game.ID, err)
-			failCount++
-			if pbp.systemStatsServ != nil {
-				pbp.systemStatsServ.RecordBackfillFailure()
+		if err := pbp.BackfillGame(ctx, game.ID); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
+			log.Printf("⚠️ %v", err)
+			failCount++
 			continue
 		}
 
-		processingTime := time.Since(startTime)
-		eventsProcessed := analytics.HomeAnalytics.TotalShots + analytics.AwayAnalytics.TotalShots +
-			analytics.HomeAnalytics.Hits + analytics.AwayAnalytics.Hits +
-			analytics.HomeAnalytics.Giveaways + analytics.AwayAnalytics.Giveaways
-
-		log.Printf("✅ Processed game %d: %s %.2f xG vs %s %.2f xG",
-			game.ID,
-			analytics.HomeTeam, analytics.HomeAnalytics.ExpectedGoals,
-			analytics.AwayTeam, analytics.AwayAnalytics.ExpectedGoals)
-
-		// Record backfill stats
-		if pbp.systemStatsServ != nil {
-			pbp.systemStatsServ.RecordBackfillGame("play-by-play", eventsProcessed, processingTime)
-		}
-
 		successCount++
 
 		// Brief delay to respect rate limits
-		time.Sleep(500 * time.Millisecond)
+		if err := sleepContext(ctx, 500*time.Millisecond); err != nil {
+			return err
+		}
 	}
 
 	log.Printf("🎉 Backfill complete! Success: %d, Failed: %d", successCount, failCount)
@@ -113,18 +103,51 @@ func (pbp *PlayByPlayService) BackfillPlayByPlayData(teamCode string, numGames i
 	return nil
 }
 
+// BackfillGame fetches and analyzes one game's play-by-play and records it
+// in the backfill stats
+func (pbp *PlayByPlayService) BackfillGame(ctx context.Context, gameID int) error {
+	startTime := time.Now()
+
+	analytics, err := pbp.FetchPlayByPlayContext(ctx, gameID)
+	if err != nil {
+		if ctx.Err() == nil && pbp.systemStatsServ != nil {
+			pbp.systemStatsServ.RecordBackfillFailure()
+		}
+		return fmt.Errorf("failed to fetch play-by-play for game %d: %w", gameID, err)
+	}
+
+	processingTime := time.Since(startTime)
+	eventsProcessed := analytics.HomeAnalytics.TotalShots + analytics.AwayAnalytics.TotalShots +
+		analytics.HomeAnalytics.Hits + analytics.AwayAnalytics.Hits +
+		analytics.HomeAnalytics.Giveaways + analytics.AwayAnalytics.Giveaways
+
+	log.Printf("✅ Processed game %d: %s %.2f xG vs %s %.2f xG",
+		gameID,
+		analytics.HomeTeam, analytics.HomeAnalytics.ExpectedGoals,
+		analytics.AwayTeam, analytics.AwayAnalytics.ExpectedGoals)
+
+	// Record backfill stats
+	if pbp.systemStatsServ != nil {
+		pbp.systemStatsServ.RecordBackfillGame("play-by-play", eventsProcessed, processingTime)
+	}
+	return nil
+}
+
 // getCompletedGames fetches the last N completed games for a team
-func (pbp *PlayByPlayService) getCompletedGames(teamCode string, numGames int) ([]models.Game, error) {
+func (pbp *PlayByPlayService) getCompletedGames(ctx context.Context, teamCode string, numGames int) ([]models.Game, error) {
 	// Determine current and previous season
 	currentSeason := getCurrentSeasonInt()
 	previousSeason := currentSeason - 10001
 
 	// Try current season first
-	allGames, err := GetTeamSeasonSchedule(teamCode, currentSeason)
+	allGames, err := GetTeamSeasonScheduleContext(ctx, teamCode, currentSeason)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err != nil || len(allGames) == 0 {
 		// Fall back to previous season if current season not available
 		log.Printf("📅 Current season not available, fetching previous season %d", previousSeason)
-		allGames, err = GetTeamSeasonSchedule(teamCode, previousSeason)
+		allGames, err = GetTeamSeasonScheduleContext(ctx, teamCode, previousSeason)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch season schedule: %w", err)
 		}
@@ -157,6 +180,14 @@ func (pbp *PlayByPlayService) getCompletedGames(teamCode string, numGames int) (
 	return completedGames, nil
 }
 
+// NHLTeamCodes are the abbreviations of all 32 NHL teams
+var NHLTeamCodes = []string{
+	"ANA", "BOS", "BUF", "CAR", "CBJ", "CGY", "CHI", "COL", "DAL", "DET",
+	"EDM", "FLA", "LAK", "MIN", "MTL", "NJD", "NSH", "NYI", "NYR", "OTT",
+	"PHI", "PIT", "SEA", "SJS", "STL", "TBL", "TOR", "UTA", "VAN", "VGK",
+	"WPG", "WSH",
+}
+
 // getCurrentSeasonInt returns the current NHL season as an integer (e.g., 20252026)
 func getCurrentSeasonInt() int {
 	now := time.Now()
@@ -173,16 +204,10 @@ func getCurrentSeasonInt() int {
 }
 
 // BackfillAllTeams fetches play-by-play data for all NHL teams
-func (pbp *PlayByPlayService) BackfillAllTeams(numGames int) error {
+func (pbp *PlayByPlayService) BackfillAllTeams(ctx context.Context, numGames int) error {
 	log.Printf("🔄 Starting league-wide play-by-play backfill (last %d games per team)...", numGames)
 
-	// NHL team codes
-	teams := []string{
-		"ANA", "BOS", "BUF", "CAR", "CBJ", "CGY", "CHI", "COL", "DAL", "DET",
-		"EDM", "FLA", "LAK", "MIN", "MTL", "NJD", "NSH", "NYI", "NYR", "OTT",
-		"PHI", "PIT", "SEA", "SJS", "STL", "TBL", "TOR", "UTA", "VAN", "VGK",
-		"WPG", "WSH",
-	}
+	teams := NHLTeamCodes
 
 	totalSuccess := 0
 	totalFail := 0
@@ -190,7 +215,11 @@ func (pbp *PlayByPlayService) BackfillAllTeams(numGames int) error {
 	for i, team := range teams {
 		log.Printf("🏒 [%d/%d] Backfilling team: %s", i+1, len(teams), team)
 
-		if err := pbp.BackfillPlayByPlayData(team, numGames); err != nil {
+		if err := pbp.BackfillPlayByPlayData(ctx, team, numGames); err != nil {
+			if ctx.Err() != nil {
+				log.Printf("🛑 League-wide backfill cancelled at %s", team)
+				return ctx.Err()
+			}
 			log.Printf("⚠️ Failed to backfill %s: %v", team, err)
 			totalFail++
 		} else {
@@ -198,7 +227,9 @@ func (pbp *PlayByPlayService) BackfillAllTeams(numGames int) error {
 		}
 
 		// Delay between teams to respect rate limits
-		time.Sleep(2 * time.Second)
+		if err := sleepContext(ctx, 2*time.Second); err != nil {
+			return err
+		}
 	}
 
 	log.Printf("🎉 League-wide backfill complete! Teams: %d success, %d failed", totalSuccess, totalFail)
@@ -231,6 +262,12 @@ func NewPlayByPlayService(statsServ *SystemStatsService) *PlayByPlayService {
 
 // FetchPlayByPlay fetches and analyzes play-by-play data for a game
 func (pbp *PlayByPlayService) FetchPlayByPlay(gameID int) (*models.PlayByPlayAnalytics, error) {
+	return pbp.FetchPlayByPlayContext(context.Background(), gameID)
+}
+
+// FetchPlayByPlayContext is FetchPlayByPlay with a context bounding the
+// rate-limit wait and the HTTP request
+func (pbp *PlayByPlayService) FetchPlayByPlayContext(ctx context.Context, gameID int) (*models.PlayByPlayAnalytics, error) {
 	// Check cache first
 	pbp.cacheMu.RLock()
 	if cached, exists := pbp.cache[gameID]; exists {
@@ -245,31 +282,13 @@ func (pbp *PlayByPlayService) FetchPlayByPlay(gameID int) (*models.PlayByPlayAna
 	// Fetch from NHL API
 	log.Printf("📥 Fetching play-by-play data for game %d from NHL API...", gameID)
 
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/gamecenter/%d/play-by-play", gameID)
-
-	// Use rate limiter if available
-	rateLimiter := GetNHLRateLimiter()
-	if rateLimiter != nil {
-		rateLimiter.Wait()
-	}
-
-	resp, err := pbp.httpClient.Get(url)
+	apiResp, err := GetNHLAPIClient().PlayByPlay(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch play-by-play: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-
-	var apiResp models.PlayByPlayResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode play-by-play: %w", err)
-	}
 
 	// Analyze the play-by-play data
-	analytics := pbp.analyzePlayByPlay(&apiResp)
+	analytics := pbp.analyzePlayByPlay(apiResp)
 
 	// Cache the results
 	pbp.cacheMu.Lock()
@@ -363,6 +382,10 @@ func (pbp *PlayByPlayService) analyzePlayByPlay(data *models.PlayByPlayResponse)
 			if play.Details.Duration > 0 {
 				teamAnalytics.PenaltyMinutes += play.Details.Duration
 			}
+			// 2, 4 and 5 minute penalties put the opponent on the power play (misconducts don't)
+			if play.Details.Duration == 2 || play.Details.Duration == 4 || play.Details.Duration == 5 {
+				teamAnalytics.PowerPlayPenalties++
+			}
 		}
 	}
 
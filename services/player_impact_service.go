@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/jaredshillingburg/go_uhc/models"
+	"github.com/jaredshillingburg/go_uhc/nhlapi"
 )
 
 // PlayerImpactService tracks simplified player impact for predictions
@@ -338,32 +340,21 @@ func (pis *PlayerImpactService) fetchPlayerStats(teamCode string, season int) ([
 	log.Printf("📊 Fetching player stats for %s (season %d) from NHL API...", teamCode, season)
 
 	// Try current season first (using /now endpoint)
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/club-stats/%s/now", teamCode)
-
-	body, err := MakeAPICall(url)
+	clubStats, err := GetNHLAPIClient().ClubStats(context.Background(), teamCode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch club stats from NHL API: %w", err)
 	}
 
-	var clubStats models.ClubStatsResponse
-	if err := json.Unmarshal(body, &clubStats); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal club stats JSON: %w", err)
-	}
-
 	// Check if we have data (current season has started)
 	if len(clubStats.Skaters) == 0 {
 		log.Printf("⚠️ No current season data for %s, trying previous season...", teamCode)
 
 		// Try previous season for seed data
 		previousSeason := season - 10001 // e.g., 20252026 -> 20242025 (or use utils.GetPreviousSeason())
-		prevSeasonStr := fmt.Sprintf("%d%d", previousSeason/10000, previousSeason%10000)
-		url = fmt.Sprintf("https://api-web.nhle.com/v1/club-stats/%s/%s/2", teamCode, prevSeasonStr)
-
-		body, err = MakeAPICall(url)
-		if err == nil {
-			if err := json.Unmarshal(body, &clubStats); err == nil && len(clubStats.Skaters) > 0 {
-				log.Printf("✅ Using previous season (%d) data as seed for %s", previousSeason, teamCode)
-			}
+		prevStats, err := GetNHLAPIClient().ClubStatsForSeason(context.Background(), teamCode, previousSeason, nhlapi.GameTypeRegularSeason)
+		if err == nil && len(prevStats.Skaters) > 0 {
+			clubStats = prevStats
+			log.Printf("✅ Using previous season (%d) data as seed for %s", previousSeason, teamCode)
 		}
 	}
 
@@ -426,6 +417,28 @@ func (pis *PlayerImpactService) fetchPlayerStats(teamCode string, season int) ([
 	return topScorers, nil
 }
 
+// SeasonPlayerValues returns each skater's production for a team in a given
+// regular season, as points per team game (points / 82). Traded players
+// appear under every club they played for, so values sum across teams. It
+// never falls back to the current season: a rollover must value last
+// season's roster, not this one's.
+func (pis *PlayerImpactService) SeasonPlayerValues(teamCode string, season int) (map[int]float64, error) {
+	clubStats, err := GetNHLAPIClient().ClubStatsForSeason(context.Background(), teamCode, season, nhlapi.GameTypeRegularSeason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %d club stats for %s: %w", season, teamCode, err)
+	}
+
+	if len(clubStats.Skaters) == 0 {
+		return nil, fmt.Errorf("no %d skater stats for %s", season, teamCode)
+	}
+
+	values := make(map[int]float64, len(clubStats.Skaters))
+	for _, skater := range clubStats.Skaters {
+		values[skater.PlayerID] += float64(skater.Points) / 82.0
+	}
+	return values, nil
+}
+
 // fetchPlayerGameLog fetches the last N games for a specific player
 // Falls back to previous season if current season has no data
 func (pis *PlayerImpactService) fetchPlayerGameLog(playerID int, season int, numGames int) ([]models.PlayerGameLogEntry, error) {
@@ -458,19 +471,11 @@ func (pis *PlayerImpactService) fetchPlayerGameLog(playerID int, season int, num
 
 // fetchPlayerGameLogForSeason fetches game log for a specific season
 func (pis *PlayerImpactService) fetchPlayerGameLogForSeason(playerID int, season int, numGames int) ([]models.PlayerGameLogEntry, error) {
-	// NHL API endpoint for player game logs
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/player/%d/game-log/%d/2", playerID, season)
-
-	body, err := MakeAPICall(url)
+	gameLogResp, err := GetNHLAPIClient().PlayerGameLog(context.Background(), playerID, season, nhlapi.GameTypeRegularSeason)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch game log from NHL API: %w", err)
 	}
 
-	var gameLogResp models.PlayerGameLogResponse
-	if err := json.Unmarshal(body, &gameLogResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal game log JSON: %w", err)
-	}
-
 	// Take only the last N games (most recent)
 	gameLog := gameLogResp.GameLog
 	if len(gameLog) > numGames {
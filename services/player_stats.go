@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -12,22 +13,15 @@ import (
 func GetTeamRoster(teamCode string) (models.TeamRosterResponse, error) {
 	fmt.Printf("Fetching %s team roster...\n", teamCode)
 
-	urlIn := fmt.Sprintf("https://api-web.nhle.com/v1/roster/%s/current", teamCode)
-	body, err := MakeAPICall(urlIn)
+	data, err := GetNHLAPIClient().Roster(context.Background(), teamCode)
 	if err != nil {
 		fmt.Printf("Error calling roster API: %v\n", err)
 		return models.TeamRosterResponse{}, err
 	}
 
-	var data models.TeamRosterResponse
-	if err := json.Unmarshal(body, &data); err != nil {
-		fmt.Printf("Error unmarshaling roster JSON: %v\n", err)
-		return models.TeamRosterResponse{}, err
-	}
-
 	fmt.Printf("Successfully fetched roster: %d forwards, %d defensemen, %d goalies\n",
 		len(data.Forwards), len(data.Defensemen), len(data.Goalies))
-	return data, nil
+	return *data, nil
 }
 
 // GetPlayerStatsLeaders fetches the current NHL stats leaders
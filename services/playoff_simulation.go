@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"runtime"
@@ -115,6 +116,13 @@ func (ps *PlayoffSimulationService) SimulatePlayoffOdds(teamCode string, simulat
 
 // SimulatePlayoffOddsWithOptions runs Monte Carlo simulation with option to bypass cache
 func (ps *PlayoffSimulationService) SimulatePlayoffOddsWithOptions(teamCode string, simulations int, bypassCache bool) (*SeasonSimulation, error) {
+	return ps.SimulatePlayoffOddsContext(context.Background(), teamCode, simulations, bypassCache)
+}
+
+// SimulatePlayoffOddsContext is SimulatePlayoffOddsWithOptions that aborts
+// once ctx is done: the schedule fetch stops, workers stop picking up
+// simulations, and ctx.Err() is returned without caching a partial result
+func (ps *PlayoffSimulationService) SimulatePlayoffOddsContext(ctx context.Context, teamCode string, simulations int, bypassCache bool) (*SeasonSimulation, error) {
 	// Check cache first (unless bypassed for debugging)
 	if !bypassCache {
 		if cached := ps.getCachedResult(teamCode); cached != nil {
@@ -162,9 +170,9 @@ func (ps *PlayoffSimulationService) SimulatePlayoffOddsWithOptions(teamCode stri
 	}
 
 	// Get remaining schedule for all teams
-	remainingGames, err := ps.getRemainingGames(conferenceTeams)
+	remainingGames, err := ps.getRemainingGames(ctx, conferenceTeams)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get remaining games: %v", err)
+		return nil, fmt.Errorf("failed to get remaining games: %w", err)
 	}
 
 	fmt.Printf("📅 Found %d remaining games in %s conference\n", len(remainingGames), conferenceName)
@@ -202,13 +210,17 @@ func (ps *PlayoffSimulationService) SimulatePlayoffOddsWithOptions(teamCode stri
 	// Use parallel execution for large simulation counts
 	if simulations >= 1000 {
 		fmt.Printf("🚀 Running %d simulations in parallel...\n", simulations)
-		results = ps.runParallelSimulations(targetTeam, conferenceTeams, remainingGames, simulations)
+		results = ps.runParallelSimulations(ctx, targetTeam, conferenceTeams, remainingGames, simulations)
 	} else {
 		// Sequential execution for small counts
-		for i := 0; i < simulations; i++ {
+		for i := 0; i < simulations && ctx.Err() == nil; i++ {
 			results[i] = ps.simulateSeason(targetTeam, conferenceTeams, remainingGames)
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		fmt.Printf("🛑 Playoff simulation for %s cancelled: %v\n", teamCode, err)
+		return nil, err
+	}
 	
 	// Build points distribution
 	for _, result := range results {
@@ -239,11 +251,20 @@ func (ps *PlayoffSimulationService) SimulatePlayoffOddsWithOptions(teamCode stri
 	// Cache the result
 	ps.cacheResult(teamCode, simulation)
 
+	PublishPushEvent(PushEventSimulationComplete, map[string]interface{}{
+		"teamCode":           teamCode,
+		"simulations":        simulations,
+		"playoffOddsPercent": simulation.PlayoffOddsPercent,
+		"avgFinalPoints":     simulation.AvgFinalPoints,
+		"durationMs":         time.Since(start).Milliseconds(),
+	})
+
 	return simulation, nil
 }
 
 // runParallelSimulations runs multiple simulations in parallel using goroutines (Phase 5.1)
 func (ps *PlayoffSimulationService) runParallelSimulations(
+	ctx context.Context,
 	targetTeam *models.TeamStanding,
 	conferenceTeams []*models.TeamStanding,
 	remainingGames []RemainingGame,
@@ -293,6 +314,11 @@ func (ps *PlayoffSimulationService) runParallelSimulations(
 		go func() {
 			defer wg.Done()
 			for i := range jobs {
+				// Drain remaining jobs without simulating once cancelled
+				if ctx.Err() != nil {
+					continue
+				}
+
 				// Each worker runs one simulation
 				result := ps.simulateSeason(targetTeam, conferenceTeams, remainingGames)
 				results[i] = result
@@ -770,7 +796,7 @@ func calculatePercentiles(results []SimulationResult) map[int]int {
 }
 
 // getRemainingGames fetches ACTUAL remaining schedule for all conference teams from NHL API
-func (ps *PlayoffSimulationService) getRemainingGames(conferenceTeams []*models.TeamStanding) ([]RemainingGame, error) {
+func (ps *PlayoffSimulationService) getRemainingGames(ctx context.Context, conferenceTeams []*models.TeamStanding) ([]RemainingGame, error) {
 	// Phase 5.4: Pre-allocate with estimated capacity (avg 15 games/team * teams / 2 for duplicates)
 	estimatedGames := (len(conferenceTeams) * 15) / 2
 	games := make([]RemainingGame, 0, estimatedGames)
@@ -797,7 +823,10 @@ func (ps *PlayoffSimulationService) getRemainingGames(conferenceTeams []*models.
 		teamCode := team.TeamAbbrev.Default
 		
 		// Fetch full season schedule for this team
-		schedule, err := GetTeamSeasonSchedule(teamCode, season)
+		schedule, err := GetTeamSeasonScheduleContext(ctx, teamCode, season)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		if err != nil {
 			fmt.Printf("⚠️ Failed to fetch schedule for %s: %v\n", teamCode, err)
 			errorCount++
@@ -32,6 +32,7 @@ type PoissonRegressionModel struct {
 	seasonDecayRate       float64            // Rate decay over time
 	rand                  *rand.Rand         // For Poisson sampling
 	dataDir               string             // Directory for persistent storage
+	quiet                 bool               // Suppresses per-game logs during tuning replays
 }
 
 // PoissonModelData represents the serializable state of the Poisson model
@@ -82,6 +83,11 @@ func NewPoissonRegressionModel() *PoissonRegressionModel {
 		},
 	}
 
+	// Apply tuned hyperparameters if a config has been written
+	model.learningRate = tunedParam("poisson", "learningRate", model.learningRate)
+	model.homeAdvantage = tunedParam("poisson", "homeAdvantage", model.homeAdvantage)
+	model.seasonDecayRate = tunedParam("poisson", "seasonDecayRate", model.seasonDecayRate)
+
 	// Create data directory if it doesn't exist
 	os.MkdirAll(model.dataDir, 0755)
 
@@ -128,6 +134,67 @@ func (pr *PoissonRegressionModel) Predict(homeFactors, awayFactors *models.Predi
 	}, nil
 }
 
+// Explain attributes the prediction to the team rates, home ice and each
+// team's situational and analytics multipliers with exact Shapley values.
+// The baseline is two league-average teams on neutral ice; probabilities are
+// computed exactly rather than by Predict's simulation, so Prediction can
+// differ from the reported probability by sampling noise.
+func (pr *PoissonRegressionModel) Explain(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionExplanation, error) {
+	names := []string{
+		homeFactors.TeamCode + " Offense", awayFactors.TeamCode + " Defense",
+		awayFactors.TeamCode + " Offense", homeFactors.TeamCode + " Defense",
+		"Home Ice",
+		homeFactors.TeamCode + " Situational", awayFactors.TeamCode + " Situational",
+		homeFactors.TeamCode + " Analytics", awayFactors.TeamCode + " Analytics",
+	}
+	values := pr.termValues(homeFactors, awayFactors)
+	value := func(mask int) float64 { return pr.termWinProbability(values, mask) }
+
+	phi := exactShapley(len(values), value)
+	all := 1<<len(values) - 1
+	return newPredictionExplanation("shapley", value(0), value(all), names, values, phi), nil
+}
+
+// termValues returns the multiplicative terms of the two expected goal rates, in Explain's order
+func (pr *PoissonRegressionModel) termValues(homeFactors, awayFactors *models.PredictionFactors) []float64 {
+	return []float64{
+		pr.getOffensiveRate(homeFactors.TeamCode), pr.getDefensiveRate(awayFactors.TeamCode),
+		pr.getOffensiveRate(awayFactors.TeamCode), pr.getDefensiveRate(homeFactors.TeamCode),
+		pr.homeAdvantage,
+		pr.calculateSituationalMultiplier(homeFactors, awayFactors, true),
+		pr.calculateSituationalMultiplier(awayFactors, homeFactors, false),
+		pr.calculateAnalyticsMultiplier(homeFactors),
+		pr.calculateAnalyticsMultiplier(awayFactors),
+	}
+}
+
+// Terms of the home and away expected goals
+var (
+	poissonHomeTerms = []int{0, 1, 4, 5, 7}
+	poissonAwayTerms = []int{2, 3, 6, 8}
+)
+
+// termWinProbability is the exact home win probability from Explain's
+// terms, keeping only those in mask and leaving the rest at league average
+func (pr *PoissonRegressionModel) termWinProbability(values []float64, mask int) float64 {
+	bound := func(goals float64) float64 { return math.Max(0.5, math.Min(7.0, goals)) }
+
+	homeGoals, awayGoals := pr.leagueAvgGoalsPerGame, pr.leagueAvgGoalsPerGame
+	for _, i := range poissonHomeTerms {
+		if mask&(1<<i) != 0 {
+			homeGoals *= values[i]
+		}
+	}
+	for _, i := range poissonAwayTerms {
+		if mask&(1<<i) != 0 {
+			awayGoals *= values[i]
+		}
+	}
+	homeGoals, awayGoals = bound(homeGoals), bound(awayGoals)
+	win, tie := poissonWinTieProbabilities(homeGoals, awayGoals)
+	return win - tie*homeGoals/(homeGoals+awayGoals) // Home outscores away, as Predict simulates
+}
+
 // GetName implements the PredictionModel interface
 func (pr *PoissonRegressionModel) GetName() string {
 	return "Poisson Regression"
@@ -180,7 +247,9 @@ func (pr *PoissonRegressionModel) getOffensiveRate(teamCode string) float64 {
 	rate := pr.calculateInitialOffensiveRate(teamCode)
 	pr.teamOffensiveRates[teamCode] = rate
 
-	log.Printf("🆕 Initialized offensive rate for %s: %.3f", teamCode, rate)
+	if !pr.quiet {
+		log.Printf("🆕 Initialized offensive rate for %s: %.3f", teamCode, rate)
+	}
 	return rate
 }
 
@@ -194,7 +263,9 @@ func (pr *PoissonRegressionModel) getDefensiveRate(teamCode string) float64 {
 	rate := pr.calculateInitialDefensiveRate(teamCode)
 	pr.teamDefensiveRates[teamCode] = rate
 
-	log.Printf("🆕 Initialized defensive rate for %s: %.3f", teamCode, rate)
+	if !pr.quiet {
+		log.Printf("🆕 Initialized defensive rate for %s: %.3f", teamCode, rate)
+	}
 	return rate
 }
 
@@ -285,6 +356,12 @@ func (pr *PoissonRegressionModel) calculateSituationalMultiplier(teamFactors, op
 	// Back-to-back penalty
 	multiplier -= teamFactors.BackToBackPenalty * 0.15
 
+	// Officiating: extra power plays from this crew (vs ~3 per team) at the team's PP%
+	if teamFactors.ExpectedPPOpportunities > 0 {
+		extraPPGoals := (teamFactors.ExpectedPPOpportunities - 3.0) * teamFactors.PowerPlayPct
+		multiplier += extraPPGoals / pr.leagueAvgGoalsPerGame
+	}
+
 	// Ensure multiplier stays within reasonable bounds
 	if multiplier < 0.7 {
 		multiplier = 0.7
@@ -722,12 +799,14 @@ func (pr *PoissonRegressionModel) processGameResult(gameResult *models.GameResul
 		fmt.Sprintf("%d-%d", awayScore, homeScore), awayLearningRate, false,
 		awayExpected, awayScore, gameResult.GameDate)
 
-	log.Printf("🎯 Poisson Update: %s Off: %.3f→%.3f (Δ%+.3f), Def: %.3f→%.3f (Δ%+.3f)",
-		homeTeam, homeOffensive, newHomeOffensive, homeOffensiveChange,
-		homeDefensive, newHomeDefensive, homeDefensiveChange)
-	log.Printf("🎯 Poisson Update: %s Off: %.3f→%.3f (Δ%+.3f), Def: %.3f→%.3f (Δ%+.3f)",
-		awayTeam, awayOffensive, newAwayOffensive, awayOffensiveChange,
-		awayDefensive, newAwayDefensive, awayDefensiveChange)
+	if !pr.quiet {
+		log.Printf("🎯 Poisson Update: %s Off: %.3f→%.3f (Δ%+.3f), Def: %.3f→%.3f (Δ%+.3f)",
+			homeTeam, homeOffensive, newHomeOffensive, homeOffensiveChange,
+			homeDefensive, newHomeDefensive, homeDefensiveChange)
+		log.Printf("🎯 Poisson Update: %s Off: %.3f→%.3f (Δ%+.3f), Def: %.3f→%.3f (Δ%+.3f)",
+			awayTeam, awayOffensive, newAwayOffensive, awayOffensiveChange,
+			awayDefensive, newAwayDefensive, awayDefensiveChange)
+	}
 
 	return nil
 }
@@ -891,6 +970,30 @@ func (pr *PoissonRegressionModel) applySeasonalDecay() {
 	}
 }
 
+// ApplySeasonRollover regresses offensive and defensive rates toward league
+// average by the given carryover fraction, then scales each team's offensive
+// rate by its roster adjustment (a log multiplier).
+func (pr *PoissonRegressionModel) ApplySeasonRollover(carryover float64, offenseShifts map[string]float64) {
+	pr.mutex.Lock()
+	for teamCode, rate := range pr.teamOffensiveRates {
+		regressed := 1.0 + (rate-1.0)*carryover
+		pr.teamOffensiveRates[teamCode] = pr.boundOffensiveRate(regressed * math.Exp(offenseShifts[teamCode]))
+	}
+	for teamCode, rate := range pr.teamDefensiveRates {
+		pr.teamDefensiveRates[teamCode] = pr.boundDefensiveRate(1.0 + (rate-1.0)*carryover)
+	}
+	for teamCode, confidence := range pr.confidenceTracking {
+		pr.confidenceTracking[teamCode] = math.Max(0.3, confidence*0.8)
+	}
+	pr.lastUpdated = time.Now()
+	pr.mutex.Unlock()
+
+	if err := pr.saveRates(); err != nil {
+		log.Printf("⚠️ Failed to save Poisson rates after season rollover: %v", err)
+	}
+	log.Printf("🔄 Poisson rates rolled over: %.0f%% carried over, %d teams", carryover*100, len(offenseShifts))
+}
+
 // updateAdaptiveLearningRate adjusts the base learning rate based on recent performance
 func (pr *PoissonRegressionModel) updateAdaptiveLearningRate() {
 	// This could analyze recent prediction accuracy and adjust learning rate accordingly
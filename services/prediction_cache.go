@@ -143,15 +143,24 @@ func (pc *PredictionCache) CleanStaleEntries() int {
 	return removed
 }
 
-// ClearCache removes all cached predictions (forces fresh predictions)
-func (pc *PredictionCache) ClearCache() {
+// ClearCache removes all cached predictions, in memory and on disk, so they
+// don't come back on restart (forces fresh predictions)
+func (pc *PredictionCache) ClearCache() int {
 	pc.mu.Lock()
 	defer pc.mu.Unlock()
 
-	count := len(pc.predictions)
 	pc.predictions = make(map[int]*CachedPrediction)
 
+	files, _ := filepath.Glob(filepath.Join(pc.cacheDir, "game_*.json"))
+	count := 0
+	for _, file := range files {
+		if err := os.Remove(file); err == nil {
+			count++
+		}
+	}
+
 	log.Printf("🧹 Cleared %d prediction(s) from cache", count)
+	return count
 }
 
 // savePrediction persists a single prediction to disk
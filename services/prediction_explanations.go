@@ -0,0 +1,307 @@
+package services
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// Explanations attribute each model's home win probability to its inputs,
+// SHAP-style: BaseValue is the model's output with every input at its
+// baseline and the contributions sum to Prediction - BaseValue. Tree models
+// use exact TreeSHAP, the neural net integrated gradients, the LSTM
+// occlusion, and the rating models exact Shapley values over their few
+// analytic terms. The ensemble explanation averages them by ensemble weight.
+
+const (
+	explanationTopFeatures = 10 // Contributions listed before the rest are folded together
+	otherFeaturesLabel     = "Other features"
+)
+
+// explainNode is a model-agnostic decision tree node for TreeSHAP
+type explainNode struct {
+	leaf        bool
+	value       float64
+	feature     int
+	threshold   float64
+	cover       float64 // Training samples that reached this node
+	left, right *explainNode
+}
+
+// childFractions returns the share of training samples sent left and right
+func (n *explainNode) childFractions() (float64, float64) {
+	total := n.left.cover + n.right.cover
+	if total <= 0 {
+		return 0.5, 0.5 // Models saved without sample counts
+	}
+	return n.left.cover / total, n.right.cover / total
+}
+
+// expectedValue returns the cover-weighted mean leaf value, the TreeSHAP baseline
+func (n *explainNode) expectedValue() float64 {
+	if n.leaf {
+		return n.value
+	}
+	leftShare, rightShare := n.childFractions()
+	return leftShare*n.left.expectedValue() + rightShare*n.right.expectedValue()
+}
+
+// shapPathElement tracks one feature split along the current root-to-node path
+type shapPathElement struct {
+	feature int
+	zero    float64 // Fraction of paths that flow through when the feature is unknown
+	one     float64 // Fraction that flow through when it is known (0 or 1)
+	weight  float64 // Permutation weight of subsets of this size
+}
+
+// treeSHAP adds one tree's exact path-dependent Shapley values for x to phi
+// (Lundberg et al., "Consistent Individualized Feature Attribution for Tree
+// Ensembles", Algorithm 2)
+func treeSHAP(root *explainNode, x, phi []float64) {
+	treeSHAPRecurse(root, x, phi, nil, 1, 1, -1)
+}
+
+func treeSHAPRecurse(node *explainNode, x, phi []float64, parentPath []shapPathElement, zeroFraction, oneFraction float64, feature int) {
+	depth := len(parentPath)
+	path := make([]shapPathElement, depth+1)
+	copy(path, parentPath)
+	extendSHAPPath(path, depth, zeroFraction, oneFraction, feature)
+
+	if node.leaf {
+		for i := 1; i <= depth; i++ {
+			weight := unwoundSHAPPathSum(path, depth, i)
+			phi[path[i].feature] += weight * (path[i].one - path[i].zero) * node.value
+		}
+		return
+	}
+
+	leftShare, rightShare := node.childFractions()
+	hot, cold := node.left, node.right
+	hotShare, coldShare := leftShare, rightShare
+	if x[node.feature] > node.threshold {
+		hot, cold = node.right, node.left
+		hotShare, coldShare = rightShare, leftShare
+	}
+
+	// A feature split on twice along a path is only counted once
+	incomingZero, incomingOne := 1.0, 1.0
+	for k := 1; k <= depth; k++ {
+		if path[k].feature == node.feature {
+			incomingZero, incomingOne = path[k].zero, path[k].one
+			unwindSHAPPath(path, depth, k)
+			path = path[:depth]
+			break
+		}
+	}
+
+	treeSHAPRecurse(hot, x, phi, path, hotShare*incomingZero, incomingOne, node.feature)
+	treeSHAPRecurse(cold, x, phi, path, coldShare*incomingZero, 0, node.feature)
+}
+
+// extendSHAPPath appends a split to the path and updates the subset weights
+func extendSHAPPath(path []shapPathElement, depth int, zero, one float64, feature int) {
+	path[depth] = shapPathElement{feature: feature, zero: zero, one: one}
+	if depth == 0 {
+		path[0].weight = 1
+	}
+	for i := depth - 1; i >= 0; i-- {
+		path[i+1].weight += one * path[i].weight * float64(i+1) / float64(depth+1)
+		path[i].weight = zero * path[i].weight * float64(depth-i) / float64(depth+1)
+	}
+}
+
+// unwindSHAPPath removes element index from the path, undoing its extension
+func unwindSHAPPath(path []shapPathElement, depth, index int) {
+	one, zero := path[index].one, path[index].zero
+	next := path[depth].weight
+	for i := depth - 1; i >= 0; i-- {
+		if one != 0 {
+			previous := path[i].weight
+			path[i].weight = next * float64(depth+1) / (float64(i+1) * one)
+			next = previous - path[i].weight*zero*float64(depth-i)/float64(depth+1)
+		} else if zero != 0 {
+			path[i].weight = path[i].weight * float64(depth+1) / (zero * float64(depth-i))
+		}
+	}
+	for i := index; i < depth; i++ {
+		path[i].feature, path[i].zero, path[i].one = path[i+1].feature, path[i+1].zero, path[i+1].one
+	}
+}
+
+// unwoundSHAPPathSum is the total weight of the path with element index removed
+func unwoundSHAPPathSum(path []shapPathElement, depth, index int) float64 {
+	one, zero := path[index].one, path[index].zero
+	next := path[depth].weight
+	total := 0.0
+	for i := depth - 1; i >= 0; i-- {
+		if one != 0 {
+			weight := next * float64(depth+1) / (float64(i+1) * one)
+			total += weight
+			next = path[i].weight - weight*zero*float64(depth-i)/float64(depth+1)
+		} else if zero != 0 {
+			total += path[i].weight * float64(depth+1) / (zero * float64(depth-i))
+		}
+	}
+	return total
+}
+
+// exactShapley computes Shapley values for n players by enumerating every
+// coalition. value(mask) is the output with players in mask at their actual
+// values and the rest at baseline. Only for small n (2^n evaluations).
+func exactShapley(n int, value func(mask int) float64) []float64 {
+	values := make([]float64, 1<<n)
+	for mask := range values {
+		values[mask] = value(mask)
+	}
+
+	// Weight of a coalition of size s: s!(n-s-1)!/n! = 1 / (n * C(n-1, s))
+	weights := make([]float64, n)
+	for s := 0; s < n; s++ {
+		binomial := 1.0
+		for k := 1; k <= s; k++ {
+			binomial = binomial * float64(n-1-s+k) / float64(k)
+		}
+		weights[s] = 1.0 / (float64(n) * binomial)
+	}
+
+	phi := make([]float64, n)
+	for mask := range values {
+		size := bits.OnesCount(uint(mask))
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) == 0 {
+				phi[i] += weights[size] * (values[mask|1<<i] - values[mask])
+			}
+		}
+	}
+	return phi
+}
+
+// symmetricOcclusion approximates Shapley values by averaging each player's
+// effect when added to the baseline and when removed from the actual input,
+// then rescales so the attributions sum to value(all) - value(none)
+func symmetricOcclusion(n int, value func(present func(i int) bool) float64) []float64 {
+	all := value(func(int) bool { return true })
+	none := value(func(int) bool { return false })
+
+	phi := make([]float64, n)
+	for i := 0; i < n; i++ {
+		player := i
+		without := value(func(j int) bool { return j != player })
+		alone := value(func(j int) bool { return j == player })
+		phi[i] = 0.5 * ((all - without) + (alone - none))
+	}
+	return rescaleContributions(phi, all-none)
+}
+
+// rescaleContributions scales phi to sum to target, for attributions computed
+// in a different space (log-odds, vote fractions) than the reported probability
+func rescaleContributions(phi []float64, target float64) []float64 {
+	sum := 0.0
+	for _, v := range phi {
+		sum += v
+	}
+	if math.Abs(sum) < 1e-12 {
+		return phi
+	}
+	scale := target / sum
+	for i := range phi {
+		phi[i] *= scale
+	}
+	return phi
+}
+
+// newPredictionExplanation ranks contributions by magnitude, keeping the
+// largest and folding the rest into a single remainder so they still sum
+// exactly. values may be nil when inputs have no single meaningful value.
+func newPredictionExplanation(method string, base, prediction float64, names []string, values, phi []float64) *models.PredictionExplanation {
+	contributions := make([]models.FeatureContribution, 0, len(phi))
+	for i, contribution := range phi {
+		if contribution == 0 {
+			continue
+		}
+		fc := models.FeatureContribution{Feature: names[i], Contribution: contribution}
+		if values != nil {
+			fc.Value = values[i]
+		}
+		contributions = append(contributions, fc)
+	}
+
+	return &models.PredictionExplanation{
+		Method:        method,
+		BaseValue:     base,
+		Prediction:    prediction,
+		Contributions: topContributions(contributions, explanationTopFeatures),
+	}
+}
+
+// topContributions sorts by magnitude and folds everything past limit into
+// "Other features"
+func topContributions(contributions []models.FeatureContribution, limit int) []models.FeatureContribution {
+	sort.Slice(contributions, func(i, j int) bool {
+		return math.Abs(contributions[i].Contribution) > math.Abs(contributions[j].Contribution)
+	})
+	if len(contributions) <= limit {
+		return contributions
+	}
+
+	other := models.FeatureContribution{Feature: otherFeaturesLabel}
+	for _, c := range contributions[limit:] {
+		other.Contribution += c.Contribution
+	}
+	return append(contributions[:limit:limit], other)
+}
+
+// aggregateExplanations combines per-model explanations by ensemble weight.
+// Contributions with the same feature name add up across models; the result
+// explains the weighted average of the explained models' probabilities.
+func aggregateExplanations(results []models.ModelResult) *models.PredictionExplanation {
+	var totalWeight, explainedWeight, base, prediction float64
+	byFeature := make(map[string]float64)
+	var explainedModels []string
+
+	for _, result := range results {
+		totalWeight += result.Weight
+		if result.Explanation == nil || result.Weight <= 0 {
+			continue
+		}
+		explainedWeight += result.Weight
+		explainedModels = append(explainedModels, result.ModelName)
+		base += result.Weight * result.Explanation.BaseValue
+		prediction += result.Weight * result.Explanation.Prediction
+		for _, c := range result.Explanation.Contributions {
+			byFeature[c.Feature] += result.Weight * c.Contribution
+		}
+	}
+	if explainedWeight == 0 {
+		return nil
+	}
+
+	contributions := make([]models.FeatureContribution, 0, len(byFeature))
+	var other float64
+	for feature, contribution := range byFeature {
+		if feature == otherFeaturesLabel {
+			other = contribution / explainedWeight
+			continue
+		}
+		contributions = append(contributions, models.FeatureContribution{Feature: feature, Contribution: contribution / explainedWeight})
+	}
+	contributions = topContributions(contributions, explanationTopFeatures)
+	if other != 0 {
+		if last := len(contributions) - 1; last >= 0 && contributions[last].Feature == otherFeaturesLabel {
+			contributions[last].Contribution += other
+		} else {
+			contributions = append(contributions, models.FeatureContribution{Feature: otherFeaturesLabel, Contribution: other})
+		}
+	}
+
+	return &models.PredictionExplanation{
+		Method:          "weighted",
+		BaseValue:       base / explainedWeight,
+		Prediction:      prediction / explainedWeight,
+		Contributions:   contributions,
+		Models:          explainedModels,
+		ExplainedWeight: explainedWeight / totalWeight,
+	}
+}
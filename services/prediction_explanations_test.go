@@ -0,0 +1,109 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExactShapley(t *testing.T) {
+	// Additive: each player gets its own term
+	terms := []float64{0.3, -0.1, 0.05}
+	phi := exactShapley(3, func(mask int) float64 {
+		total := 0.5
+		for i, term := range terms {
+			if mask&(1<<i) != 0 {
+				total += term
+			}
+		}
+		return total
+	})
+	for i, term := range terms {
+		if math.Abs(phi[i]-term) > 1e-12 {
+			t.Errorf("Additive player %d: got %.6f, want %.6f", i, phi[i], term)
+		}
+	}
+
+	// A pure interaction is split evenly; a dummy player gets nothing
+	phi = exactShapley(3, func(mask int) float64 {
+		if mask&1 != 0 && mask&2 != 0 {
+			return 1
+		}
+		return 0
+	})
+	expected := []float64{0.5, 0.5, 0}
+	for i := range expected {
+		if math.Abs(phi[i]-expected[i]) > 1e-12 {
+			t.Errorf("Interaction player %d: got %.6f, want %.6f", i, phi[i], expected[i])
+		}
+	}
+
+	// Efficiency: contributions sum to value(all) - value(none)
+	value := func(mask int) float64 { return math.Sin(float64(mask)) }
+	phi = exactShapley(4, value)
+	sum := 0.0
+	for _, p := range phi {
+		sum += p
+	}
+	if want := value(15) - value(0); math.Abs(sum-want) > 1e-12 {
+		t.Errorf("Contributions sum to %.6f, want %.6f", sum, want)
+	}
+}
+
+// pathDependentValue is the tree's expected output with only the features in
+// mask known, following unknown features' splits by cover: the value function
+// TreeSHAP computes Shapley values of
+func pathDependentValue(node *explainNode, x []float64, mask int) float64 {
+	if node.leaf {
+		return node.value
+	}
+	if mask&(1<<node.feature) != 0 {
+		if x[node.feature] > node.threshold {
+			return pathDependentValue(node.right, x, mask)
+		}
+		return pathDependentValue(node.left, x, mask)
+	}
+	leftShare, rightShare := node.childFractions()
+	return leftShare*pathDependentValue(node.left, x, mask) + rightShare*pathDependentValue(node.right, x, mask)
+}
+
+func TestTreeSHAPMatchesExactShapley(t *testing.T) {
+	leaf := func(value, cover float64) *explainNode {
+		return &explainNode{leaf: true, value: value, cover: cover}
+	}
+	split := func(feature int, threshold float64, left, right *explainNode) *explainNode {
+		return &explainNode{feature: feature, threshold: threshold, cover: left.cover + right.cover, left: left, right: right}
+	}
+
+	// Feature 0 is split on twice along one path; feature 3 is never used
+	tree := split(0, 0.5,
+		split(1, 10, leaf(0.2, 30), split(0, 0.2, leaf(0.4, 5), leaf(0.1, 15))),
+		split(2, -1, leaf(0.9, 10), split(1, 3, leaf(0.6, 25), leaf(0.7, 15))),
+	)
+	const features = 4
+
+	inputs := [][]float64{
+		{0.1, 12, 0, 7},
+		{0.3, 4, 0, 7},
+		{0.8, 2, -3, 7},
+		{0.8, 5, 2, 7},
+	}
+	for _, x := range inputs {
+		phi := make([]float64, features)
+		treeSHAP(tree, x, phi)
+
+		want := exactShapley(features, func(mask int) float64 { return pathDependentValue(tree, x, mask) })
+		for i := range phi {
+			if math.Abs(phi[i]-want[i]) > 1e-9 {
+				t.Errorf("x=%v feature %d: TreeSHAP %.6f, exact %.6f", x, i, phi[i], want[i])
+			}
+		}
+
+		sum := tree.expectedValue()
+		for _, p := range phi {
+			sum += p
+		}
+		if prediction := pathDependentValue(tree, x, 1<<features-1); math.Abs(sum-prediction) > 1e-9 {
+			t.Errorf("x=%v: base value plus contributions is %.6f, prediction is %.6f", x, sum, prediction)
+		}
+	}
+}
@@ -21,6 +21,12 @@ type PredictionModel interface {
 	GetWeight() float64
 }
 
+// ExplainableModel is implemented by models that can attribute a prediction
+// to their inputs (see prediction_explanations.go)
+type ExplainableModel interface {
+	Explain(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionExplanation, error)
+}
+
 // StatisticalModel - Enhanced statistical prediction model
 type StatisticalModel struct {
 	weight float64
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"time"
@@ -25,10 +26,16 @@ func NewPredictionService(teamCode string) *PredictionService {
 
 // PredictNextGame generates AI prediction for the team's next upcoming game
 func (ps *PredictionService) PredictNextGame() (*models.GamePrediction, error) {
+	return ps.PredictNextGameContext(context.Background())
+}
+
+// PredictNextGameContext is PredictNextGame bounded by ctx. A cancelled
+// prediction returns ctx.Err() rather than falling back to a degraded one.
+func (ps *PredictionService) PredictNextGameContext(ctx context.Context) (*models.GamePrediction, error) {
 	fmt.Printf("🚀 Generating advanced AI prediction for %s next game...\n", ps.teamCode)
 
 	// Get upcoming games
-	games, err := GetTeamUpcomingGames(ps.teamCode)
+	games, err := GetTeamUpcomingGamesContext(ctx, ps.teamCode)
 	if err != nil {
 		return nil, fmt.Errorf("error getting upcoming games: %v", err)
 	}
@@ -140,6 +147,9 @@ func (ps *PredictionService) PredictNextGame() (*models.GamePrediction, error) {
 	}
 
 	fmt.Printf("✅ Team data updated - proceeding with prediction\n")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// Fetch enhanced factors for both teams using situational analysis
 	analyzer := NewSituationalAnalyzer(ps.teamCode)
@@ -154,6 +164,8 @@ func (ps *PredictionService) PredictNextGame() (*models.GamePrediction, error) {
 
 	// Set game ID for lineup data integration
 	ps.ensembleService.SetGameID(nextGame.ID)
+	gameStart, _ := time.Parse(time.RFC3339, nextGame.StartTime)
+	ps.ensembleService.SetGameStart(gameStart)
 
 	// ============================================================================
 	// GRACEFUL DEGRADATION: Try cache first, then generate new prediction
@@ -172,7 +184,10 @@ func (ps *PredictionService) PredictNextGame() (*models.GamePrediction, error) {
 		isDegraded = cachedPred.IsDegraded
 	} else {
 		// Generate new prediction with error recovery
-		prediction, err = ps.ensembleService.PredictGameWithRecovery(homeFactors, awayFactors)
+		prediction, err = ps.ensembleService.PredictGameWithRecoveryContext(ctx, homeFactors, awayFactors)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if err != nil {
 			// Last resort: check for any cached prediction (even stale)
 			fmt.Printf("⚠️ Prediction generation failed: %v\n", err)
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -64,14 +65,15 @@ func NewPreGameLineupService(teamCode string) *PreGameLineupService {
 	// Load cached lineups from disk
 	service.loadLineupsFromDisk()
 
-	// Start background monitoring for upcoming games
-	service.startMonitoring()
-
 	return service
 }
 
-// startMonitoring starts background monitoring for upcoming games
-func (pgls *PreGameLineupService) startMonitoring() {
+// StartMonitoring starts background monitoring for upcoming games
+func (pgls *PreGameLineupService) StartMonitoring() {
+	if pgls.monitorTicker != nil {
+		return
+	}
+
 	// Check for lineups every 30 minutes
 	pgls.monitorTicker = time.NewTicker(30 * time.Minute)
 
@@ -94,10 +96,12 @@ func (pgls *PreGameLineupService) startMonitoring() {
 
 // StopMonitoring stops the background monitoring
 func (pgls *PreGameLineupService) StopMonitoring() {
-	if pgls.monitorTicker != nil {
-		pgls.monitorTicker.Stop()
+	if pgls.monitorTicker == nil {
+		return
 	}
+	pgls.monitorTicker.Stop()
 	pgls.stopChan <- true
+	pgls.monitorTicker = nil
 }
 
 // checkUpcomingGames checks upcoming games and fetches lineups if available
@@ -155,34 +159,20 @@ func (pgls *PreGameLineupService) FetchLineup(gameID int) (*models.PreGameLineup
 	// Fetch from NHL API
 	log.Printf("📥 Fetching lineup for game %d from NHL API...", gameID)
 
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/gamecenter/%d/boxscore", gameID)
-
-	// Use rate limiter if available
-	rateLimiter := GetNHLRateLimiter()
-	if rateLimiter != nil {
-		rateLimiter.Wait()
-	}
-
-	resp, err := pgls.httpClient.Get(url)
+	apiResp, err := GetNHLAPIClient().BoxscoreLineup(context.Background(), gameID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch lineup: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d (lineup may not be available yet)", resp.StatusCode)
-	}
-
-	var apiResp models.GameCenterLineupResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode lineup: %w", err)
+		return nil, fmt.Errorf("failed to fetch lineup (may not be available yet): %w", err)
 	}
 
 	// Parse and transform the lineup
-	lineup := pgls.parseLineup(&apiResp)
+	lineup := pgls.parseLineup(apiResp)
 
 	// Cache the lineup
 	pgls.cacheMu.Lock()
+	wasAvailable := false
+	if previous, exists := pgls.lineupsCache[gameID]; exists && previous.Lineup != nil {
+		wasAvailable = previous.Lineup.IsAvailable
+	}
 	pgls.lineupsCache[gameID] = &models.LineupCache{
 		Lineup:   lineup,
 		CachedAt: time.Now(),
@@ -195,6 +185,16 @@ func (pgls *PreGameLineupService) FetchLineup(gameID int) (*models.PreGameLineup
 		log.Printf("⚠️ Failed to save lineup to disk: %v", err)
 	}
 
+	// Announce newly confirmed lineups to dashboard clients
+	if lineup.IsAvailable && !wasAvailable {
+		PublishPushEvent(PushEventLineupConfirmed, lineup)
+
+		// Re-predict if the confirmed starter isn't who we expected
+		if goalieService := GetGoalieService(); goalieService != nil {
+			go goalieService.CheckConfirmedStarters(lineup)
+		}
+	}
+
 	return lineup, nil
 }
 
@@ -0,0 +1,330 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// Push event types broadcast to dashboard clients
+const (
+	PushEventScoreUpdate        = "score_update"
+	PushEventGoal               = "goal"
+	PushEventWinProbability     = "win_probability"
+	PushEventLineupConfirmed    = "lineup_confirmed"
+	PushEventRePrediction       = "reprediction"
+	PushEventSimulationComplete = "simulation_complete"
+	PushEventGameResult         = "game_result"
+	PushEventStandings          = "standings"
+)
+
+// PushEvent is a single message broadcast to connected clients
+type PushEvent struct {
+	ID        int64       `json:"id"`
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// PushClient is a single connected push client (e.g. an SSE stream)
+type PushClient struct {
+	ID      int64
+	Name    string
+	Events  chan *PushEvent
+	filter  map[string]bool // Event types this client wants (empty = all)
+	dropped int
+}
+
+// PushHub fans out live score, lineup, prediction and simulation updates to
+// connected clients. It implements UpdateSubscriber so it can be attached to
+// LiveDataService and LiveWinProbabilityService like any other subscriber.
+type PushHub struct {
+	mu           sync.RWMutex
+	clients      map[int64]*PushClient
+	nextClientID int64
+	nextEventID  int64
+	history      []*PushEvent // Recent events for Last-Event-ID replay
+	historySize  int
+	bufferSize   int
+
+	// Live score tracking used to derive score_update and goal events
+	scores        map[int]string // gameID -> "away-home"
+	lastGoalEvent map[int]int    // gameID -> last goal eventId broadcast
+
+	totalEvents int
+	startedAt   time.Time
+}
+
+// PushHubStats summarizes hub activity
+type PushHubStats struct {
+	ConnectedClients int            `json:"connectedClients"`
+	TotalEvents      int            `json:"totalEvents"`
+	EventsByType     map[string]int `json:"eventsByType"`
+	LastEventID      int64          `json:"lastEventId"`
+	StartedAt        time.Time      `json:"startedAt"`
+}
+
+// pushHub is read by publishers on other goroutines while InitPushHub may
+// still be running, so it's only accessed atomically
+var (
+	pushHub     atomic.Pointer[PushHub]
+	pushHubOnce sync.Once
+)
+
+// InitPushHub initializes the global push hub and attaches it to the live data sources
+func InitPushHub() {
+	pushHubOnce.Do(func() {
+		hub := NewPushHub()
+		pushHub.Store(hub)
+
+		if lps := GetLivePredictionSystem(); lps != nil {
+			lps.GetLiveDataService().Subscribe(hub)
+		}
+		if wpService := GetLiveWinProbabilityService(); wpService != nil {
+			wpService.Subscribe(hub)
+		}
+
+		log.Println("✅ Push Hub initialized")
+	})
+}
+
+// GetPushHub returns the singleton instance
+func GetPushHub() *PushHub {
+	return pushHub.Load()
+}
+
+// PublishPushEvent broadcasts an event on the global hub if it is running
+func PublishPushEvent(eventType string, data interface{}) {
+	if hub := pushHub.Load(); hub != nil {
+		hub.Broadcast(eventType, data)
+	}
+}
+
+// NewPushHub creates a new push hub
+func NewPushHub() *PushHub {
+	return &PushHub{
+		clients:       make(map[int64]*PushClient),
+		history:       make([]*PushEvent, 0),
+		historySize:   200,
+		bufferSize:    32,
+		scores:        make(map[int]string),
+		lastGoalEvent: make(map[int]int),
+		startedAt:     time.Now(),
+	}
+}
+
+// Register adds a client; eventTypes limits which events it receives (none = all)
+func (ph *PushHub) Register(name string, eventTypes []string) *PushClient {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	return ph.registerLocked(name, eventTypes)
+}
+
+// RegisterSince adds a client like Register and returns the buffered events
+// after lastEventID that it wants, for a reconnecting client to replay before
+// reading its channel. Both happen under one lock, so every event is either
+// replayed or delivered on the channel, never both or neither.
+func (ph *PushHub) RegisterSince(name string, eventTypes []string, lastEventID int64) (*PushClient, []*PushEvent) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	client := ph.registerLocked(name, eventTypes)
+	missed := make([]*PushEvent, 0)
+	for _, event := range ph.history {
+		if event.ID > lastEventID && client.Wants(event.Type) {
+			missed = append(missed, event)
+		}
+	}
+	return client, missed
+}
+
+// registerLocked creates and adds a client; the caller holds ph.mu
+func (ph *PushHub) registerLocked(name string, eventTypes []string) *PushClient {
+	ph.nextClientID++
+	client := &PushClient{
+		ID:     ph.nextClientID,
+		Name:   name,
+		Events: make(chan *PushEvent, ph.bufferSize),
+		filter: make(map[string]bool),
+	}
+	for _, eventType := range eventTypes {
+		if eventType != "" {
+			client.filter[eventType] = true
+		}
+	}
+
+	ph.clients[client.ID] = client
+	log.Printf("📡 Push client connected: %s (%d connected)", name, len(ph.clients))
+	return client
+}
+
+// Unregister removes a client and closes its event channel
+func (ph *PushHub) Unregister(client *PushClient) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	if _, exists := ph.clients[client.ID]; !exists {
+		return
+	}
+	delete(ph.clients, client.ID)
+	close(client.Events)
+
+	if client.dropped > 0 {
+		log.Printf("📡 Push client disconnected: %s (%d events dropped)", client.Name, client.dropped)
+	} else {
+		log.Printf("📡 Push client disconnected: %s", client.Name)
+	}
+}
+
+// Broadcast sends an event to every interested client; slow clients drop events
+func (ph *PushHub) Broadcast(eventType string, data interface{}) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	ph.nextEventID++
+	event := &PushEvent{
+		ID:        ph.nextEventID,
+		Type:      eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+
+	ph.history = append(ph.history, event)
+	if len(ph.history) > ph.historySize {
+		ph.history = ph.history[len(ph.history)-ph.historySize:]
+	}
+	ph.totalEvents++
+
+	for _, client := range ph.clients {
+		if !client.Wants(eventType) {
+			continue
+		}
+		select {
+		case client.Events <- event:
+		default:
+			client.dropped++
+		}
+	}
+}
+
+// EventsSince returns buffered events after lastEventID for reconnecting clients
+func (ph *PushHub) EventsSince(lastEventID int64) []*PushEvent {
+	ph.mu.RLock()
+	defer ph.mu.RUnlock()
+
+	events := make([]*PushEvent, 0)
+	for _, event := range ph.history {
+		if event.ID > lastEventID {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// GetStats returns hub statistics
+func (ph *PushHub) GetStats() *PushHubStats {
+	ph.mu.RLock()
+	defer ph.mu.RUnlock()
+
+	eventsByType := make(map[string]int)
+	for _, event := range ph.history {
+		eventsByType[event.Type]++
+	}
+
+	return &PushHubStats{
+		ConnectedClients: len(ph.clients),
+		TotalEvents:      ph.totalEvents,
+		EventsByType:     eventsByType,
+		LastEventID:      ph.nextEventID,
+		StartedAt:        ph.startedAt,
+	}
+}
+
+// Wants reports whether the client subscribed to an event type
+func (pc *PushClient) Wants(eventType string) bool {
+	return len(pc.filter) == 0 || pc.filter[eventType]
+}
+
+// OnDataUpdate implements UpdateSubscriber interface
+func (ph *PushHub) OnDataUpdate(updateType string, data interface{}) error {
+	switch updateType {
+	case "win_probability":
+		game, ok := data.(*models.LiveWinProbability)
+		if !ok {
+			return fmt.Errorf("unexpected win probability payload %T", data)
+		}
+		ph.handleLiveGame(game)
+	case "game_result":
+		ph.Broadcast(PushEventGameResult, data)
+	case "standings":
+		ph.Broadcast(PushEventStandings, data)
+	}
+	// schedule and team_stats updates are too chatty for the dashboard
+	return nil
+}
+
+// GetSubscriberName implements UpdateSubscriber interface
+func (ph *PushHub) GetSubscriberName() string {
+	return "PushHub"
+}
+
+// handleLiveGame broadcasts the win probability update and derives score
+// changes and goal events from the play-by-play timeline
+func (ph *PushHub) handleLiveGame(game *models.LiveWinProbability) {
+	score := fmt.Sprintf("%d-%d", game.AwayScore, game.HomeScore)
+
+	ph.mu.Lock()
+	previousScore, seen := ph.scores[game.GameID]
+	ph.scores[game.GameID] = score
+	lastGoal := ph.lastGoalEvent[game.GameID]
+
+	newGoals := make([]models.WinProbabilityPoint, 0)
+	for _, point := range game.Timeline {
+		if point.EventType == "goal" && point.EventID > lastGoal {
+			newGoals = append(newGoals, point)
+			ph.lastGoalEvent[game.GameID] = point.EventID
+		}
+	}
+	if game.IsFinal {
+		delete(ph.scores, game.GameID)
+		delete(ph.lastGoalEvent, game.GameID)
+	}
+	ph.mu.Unlock()
+
+	// Only announce goals scored after we started watching the game
+	if seen {
+		for _, goal := range newGoals {
+			ph.Broadcast(PushEventGoal, map[string]interface{}{
+				"gameId":      game.GameID,
+				"homeTeam":    game.HomeTeam,
+				"awayTeam":    game.AwayTeam,
+				"homeScore":   goal.HomeScore,
+				"awayScore":   goal.AwayScore,
+				"period":      goal.Period,
+				"description": goal.Description,
+				"homeWinProb": goal.HomeWinProb,
+			})
+		}
+	}
+
+	if !seen || previousScore != score || game.IsFinal {
+		ph.Broadcast(PushEventScoreUpdate, map[string]interface{}{
+			"gameId":        game.GameID,
+			"homeTeam":      game.HomeTeam,
+			"awayTeam":      game.AwayTeam,
+			"homeScore":     game.HomeScore,
+			"awayScore":     game.AwayScore,
+			"period":        game.Period,
+			"timeRemaining": game.TimeRemaining,
+			"gameState":     game.GameState,
+			"isFinal":       game.IsFinal,
+		})
+	}
+
+	ph.Broadcast(PushEventWinProbability, game)
+}
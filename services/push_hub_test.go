@@ -0,0 +1,35 @@
+package services
+
+import "testing"
+
+func TestRegisterSinceReplaysEachEventOnce(t *testing.T) {
+	hub := NewPushHub()
+	hub.Broadcast(PushEventGoal, nil)           // 1
+	hub.Broadcast(PushEventStandings, nil)      // 2
+	hub.Broadcast(PushEventGoal, nil)           // 3
+	hub.Broadcast(PushEventWinProbability, nil) // 4
+
+	client, missed := hub.RegisterSince("test", []string{PushEventGoal, PushEventWinProbability}, 1)
+	defer hub.Unregister(client)
+
+	var ids []int64
+	for _, event := range missed {
+		ids = append(ids, event.ID)
+	}
+	if len(ids) != 2 || ids[0] != 3 || ids[1] != 4 {
+		t.Errorf("Expected to replay events [3 4], got %v", ids)
+	}
+	if len(client.Events) != 0 {
+		t.Errorf("Expected replayed events to stay off the channel, got %d queued", len(client.Events))
+	}
+
+	hub.Broadcast(PushEventGoal, nil)
+	select {
+	case event := <-client.Events:
+		if event.ID != 5 {
+			t.Errorf("Expected event 5 on the channel, got %d", event.ID)
+		}
+	default:
+		t.Error("Expected events after registering to arrive on the channel")
+	}
+}
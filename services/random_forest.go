@@ -28,6 +28,8 @@ type RandomForestModel struct {
 	mutex             sync.RWMutex
 	featureNames      []string
 	featureImportance map[string]float64
+	quiet             bool       // Suppresses progress logs for tuning fits
+	rng               *rand.Rand // Seeded source for reproducible fits (nil = global source)
 }
 
 // RFTree represents a single decision tree in the random forest
@@ -82,6 +84,9 @@ func NewRandomForestModel() *RandomForestModel {
 			randomForestModel.featureNames[i] = fmt.Sprintf("feature_%d", i)
 		}
 
+		// Tuned hyperparameters replace the defaults; a saved model keeps its own
+		randomForestModel.applyTunedHyperparameters()
+
 		// Try to load existing model
 		randomForestModel.loadModel()
 	})
@@ -120,26 +125,8 @@ func (rfm *RandomForestModel) Predict(homeFactors, awayFactors *models.Predictio
 	features := rfm.extractFeatures(homeFactors, awayFactors)
 
 	// Get predictions from all trees
-	votes := make([]float64, 3) // [win, loss, ot]
-
-	for _, tree := range rfm.trees {
-		prediction := rfm.predictTree(tree, features)
-		// prediction is class probability from this tree
-		if prediction > 0.6 {
-			votes[0]++ // Win
-		} else if prediction < 0.4 {
-			votes[1]++ // Loss
-		} else {
-			votes[2]++ // OT
-		}
-	}
-
-	// Majority voting
+	votes, winProb := rfm.vote(features)
 	totalVotes := votes[0] + votes[1] + votes[2]
-	winProb := votes[0] / totalVotes
-
-	// Ensure reasonable bounds
-	winProb = math.Max(0.35, math.Min(0.85, winProb))
 
 	// Calculate confidence based on vote agreement
 	maxVotes := math.Max(votes[0], math.Max(votes[1], votes[2]))
@@ -161,6 +148,79 @@ func (rfm *RandomForestModel) Predict(homeFactors, awayFactors *models.Predictio
 	return result, nil
 }
 
+// Explain attributes the prediction to input features with exact TreeSHAP on
+// each tree's win vote, scaled onto the bounded vote share
+func (rfm *RandomForestModel) Explain(homeFactors, awayFactors *models.PredictionFactors) (*models.PredictionExplanation, error) {
+	rfm.mutex.RLock()
+	defer rfm.mutex.RUnlock()
+
+	if !rfm.trained || len(rfm.trees) == 0 {
+		return nil, fmt.Errorf("random forest model not trained")
+	}
+
+	features := rfm.extractFeatures(homeFactors, awayFactors)
+	phi := make([]float64, len(features))
+	baseShare := 0.0
+	for _, tree := range rfm.trees {
+		root := rfExplainTree(tree.Root)
+		baseShare += root.expectedValue()
+		treeSHAP(root, features, phi)
+	}
+	baseShare /= float64(len(rfm.trees))
+
+	base := math.Max(0.35, math.Min(0.85, baseShare))
+	_, prediction := rfm.vote(features)
+	phi = rescaleContributions(phi, prediction-base)
+
+	names := make([]string, len(features))
+	for i := range names {
+		names[i] = getFeatureName(i)
+	}
+	return newPredictionExplanation("tree_shap", base, prediction, names, features, phi), nil
+}
+
+// rfExplainTree converts a tree for TreeSHAP; leaves are 1 when they vote win
+func rfExplainTree(node *RFTreeNode) *explainNode {
+	if node.IsLeaf {
+		win := 0.0
+		if node.Prediction > 0.6 {
+			win = 1.0
+		}
+		return &explainNode{leaf: true, value: win, cover: float64(node.SamplesCount)}
+	}
+	return &explainNode{
+		feature:   node.FeatureIndex,
+		threshold: node.Threshold,
+		cover:     float64(node.SamplesCount),
+		left:      rfExplainTree(node.Left),
+		right:     rfExplainTree(node.Right),
+	}
+}
+
+// vote tallies tree votes as [win, loss, ot] and returns the bounded home win probability
+func (rfm *RandomForestModel) vote(features []float64) ([]float64, float64) {
+	votes := make([]float64, 3) // [win, loss, ot]
+
+	for _, tree := range rfm.trees {
+		prediction := rfm.predictTree(tree, features)
+		// prediction is class probability from this tree
+		if prediction > 0.6 {
+			votes[0]++ // Win
+		} else if prediction < 0.4 {
+			votes[1]++ // Loss
+		} else {
+			votes[2]++ // OT
+		}
+	}
+
+	// Majority voting
+	totalVotes := votes[0] + votes[1] + votes[2]
+	winProb := votes[0] / totalVotes
+
+	// Ensure reasonable bounds
+	return votes, math.Max(0.35, math.Min(0.85, winProb))
+}
+
 // predictTree gets prediction from a single tree
 func (rfm *RandomForestModel) predictTree(tree *RFTree, features []float64) float64 {
 	return rfm.traverseTree(tree.Root, features)
@@ -193,6 +253,42 @@ func (rfm *RandomForestModel) Train(games []models.CompletedGame) error {
 	// Prepare training data
 	features, labels := rfm.prepareTrainingData(games)
 	numSamples := len(labels)
+	if numSamples < 20 {
+		return fmt.Errorf("insufficient training data: only %d games have prediction-time features", numSamples)
+	}
+
+	rfm.applyTunedHyperparameters()
+	rfm.fit(features, labels)
+
+	// Calculate feature importance
+	rfm.calculateFeatureImportance()
+
+	rfm.trained = true
+
+	trainingTime := time.Since(start)
+	log.Printf("✅ Random Forest training complete!")
+	log.Printf("   Trees: %d | Time: %.1fs", len(rfm.trees), trainingTime.Seconds())
+
+	// Save model
+	if err := rfm.saveModel(); err != nil {
+		log.Printf("⚠️ Failed to save Random Forest model: %v", err)
+	}
+
+	return nil
+}
+
+// applyTunedHyperparameters overrides training settings from the tuned config, if any
+func (rfm *RandomForestModel) applyTunedHyperparameters() {
+	rfm.numTrees = int(tunedParam("random_forest", "numTrees", float64(rfm.numTrees)))
+	rfm.maxDepth = int(tunedParam("random_forest", "maxDepth", float64(rfm.maxDepth)))
+	rfm.minSamplesLeaf = int(tunedParam("random_forest", "minSamplesLeaf", float64(rfm.minSamplesLeaf)))
+	rfm.maxFeatures = int(tunedParam("random_forest", "maxFeatures", float64(rfm.maxFeatures)))
+}
+
+// fit grows the forest on a prepared feature matrix. Callers hold the lock;
+// nothing is persisted.
+func (rfm *RandomForestModel) fit(features [][]float64, labels []float64) {
+	numSamples := len(labels)
 
 	// Train trees in parallel (key difference from GB!)
 	rfm.trees = make([]*RFTree, rfm.numTrees)
@@ -212,33 +308,21 @@ func (rfm *RandomForestModel) Train(games []models.CompletedGame) error {
 		rfm.trees[t] = tree
 
 		// Log progress every 20 trees
-		if (t+1)%20 == 0 {
+		if (t+1)%20 == 0 && !rfm.quiet {
 			log.Printf("   Tree %d/%d built", t+1, rfm.numTrees)
 		}
 	}
-
-	// Calculate feature importance
-	rfm.calculateFeatureImportance()
-
-	rfm.trained = true
-
-	trainingTime := time.Since(start)
-	log.Printf("✅ Random Forest training complete!")
-	log.Printf("   Trees: %d | Time: %.1fs", len(rfm.trees), trainingTime.Seconds())
-
-	// Save model
-	if err := rfm.saveModel(); err != nil {
-		log.Printf("⚠️ Failed to save Random Forest model: %v", err)
-	}
-
-	return nil
 }
 
 // bootstrapSample creates a bootstrap sample (random indices with replacement)
 func (rfm *RandomForestModel) bootstrapSample(numSamples int) []int {
 	indices := make([]int, numSamples)
 	for i := 0; i < numSamples; i++ {
-		indices[i] = rand.Intn(numSamples)
+		if rfm.rng != nil {
+			indices[i] = rfm.rng.Intn(numSamples)
+		} else {
+			indices[i] = rand.Intn(numSamples)
+		}
 	}
 	return indices
 }
@@ -330,7 +414,11 @@ func (rfm *RandomForestModel) selectRandomFeatures(numFeatures int) []int {
 	}
 
 	// Shuffle and take first maxFeatures
-	rand.Shuffle(len(allFeatures), func(i, j int) {
+	shuffle := rand.Shuffle
+	if rfm.rng != nil {
+		shuffle = rfm.rng.Shuffle
+	}
+	shuffle(len(allFeatures), func(i, j int) {
 		allFeatures[i], allFeatures[j] = allFeatures[j], allFeatures[i]
 	})
 
@@ -468,17 +556,19 @@ func (rfm *RandomForestModel) getMajorityClass(classCounts map[int]int) float64
 	return 0.0 // Loss
 }
 
-// prepareTrainingData prepares features and labels from games
+// prepareTrainingData prepares features and labels from games, using the
+// same 156-feature extraction as Predict on the factors each game was predicted with
 func (rfm *RandomForestModel) prepareTrainingData(games []models.CompletedGame) ([][]float64, []float64) {
-	features := make([][]float64, 0, len(games)*2)
-	labels := make([]float64, 0, len(games)*2)
+	examples := buildTreeTrainingExamples(games)
 
-	for _, game := range games {
-		// Extract features for home team perspective
-		homeFeatures := rfm.extractGameFeatures(&game, true)
-		features = append(features, homeFeatures)
+	features := make([][]float64, 0, len(examples))
+	labels := make([]float64, 0, len(examples))
 
-		// Label: 1 = win, 0 = loss, 2 = OT loss
+	for _, example := range examples {
+		game := example.Game
+		features = append(features, rfm.extractFeatures(example.HomeFactors, example.AwayFactors))
+
+		// Label from the home team's perspective: 1 = win, 0 = loss, 2 = OT loss
 		if game.HomeTeam.Score > game.AwayTeam.Score {
 			labels = append(labels, 1.0)
 		} else if game.WinType == "OT" || game.WinType == "SO" {
@@ -486,57 +576,11 @@ func (rfm *RandomForestModel) prepareTrainingData(games []models.CompletedGame)
 		} else {
 			labels = append(labels, 0.0)
 		}
-
-		// Extract features for away team perspective
-		awayFeatures := rfm.extractGameFeatures(&game, false)
-		features = append(features, awayFeatures)
-
-		if game.AwayTeam.Score > game.HomeTeam.Score {
-			labels = append(labels, 1.0)
-		} else if game.WinType == "OT" || game.WinType == "SO" {
-			labels = append(labels, 2.0)
-		} else {
-			labels = append(labels, 0.0)
-		}
 	}
 
 	return features, labels
 }
 
-// extractGameFeatures extracts features from a completed game
-func (rfm *RandomForestModel) extractGameFeatures(game *models.CompletedGame, isHome bool) []float64 {
-	features := make([]float64, 65)
-
-	// Basic features
-	if isHome {
-		features[0] = float64(game.HomeTeam.Score) / 10.0
-		features[1] = float64(game.AwayTeam.Score) / 10.0
-		features[2] = float64(game.HomeTeam.Shots) / 40.0
-		features[3] = float64(game.AwayTeam.Shots) / 40.0
-	} else {
-		features[0] = float64(game.AwayTeam.Score) / 10.0
-		features[1] = float64(game.HomeTeam.Score) / 10.0
-		features[2] = float64(game.AwayTeam.Shots) / 40.0
-		features[3] = float64(game.HomeTeam.Shots) / 40.0
-	}
-
-	// Win type
-	if game.WinType == "OT" {
-		features[4] = 0.5
-	} else if game.WinType == "SO" {
-		features[4] = 0.7
-	} else {
-		features[4] = 1.0
-	}
-
-	// Pad remaining features
-	for i := 5; i < 65; i++ {
-		features[i] = 0.5 // Neutral value
-	}
-
-	return features
-}
-
 // extractFeatures extracts 156 features for prediction (same as Neural Network)
 func (rfm *RandomForestModel) extractFeatures(home, away *models.PredictionFactors) []float64 {
 	features := make([]float64, 156) // 156 input features (140 Phase 1 + 16 Phase 2)
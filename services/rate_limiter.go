@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -35,11 +36,12 @@ var (
 // GetNHLRateLimiter returns the global NHL API rate limiter instance
 func GetNHLRateLimiter() *NHLRateLimiter {
 	rateLimiterOnce.Do(func() {
+		limits := GetAppConfig().RateLimit
 		globalNHLRateLimiter = &NHLRateLimiter{
 			requests:    make([]time.Time, 0, 100),
-			maxRequests: 60,                     // 60 requests per minute (conservative)
-			timeWindow:  time.Minute,            // 1 minute window
-			minDelay:    500 * time.Millisecond, // 500ms between calls (polite)
+			maxRequests: limits.MaxRequests,
+			timeWindow:  limits.Window,
+			minDelay:    limits.MinDelay,
 			dataDir:     "data/metrics",
 		}
 
@@ -62,21 +64,39 @@ func GetNHLRateLimiter() *NHLRateLimiter {
 	return globalNHLRateLimiter
 }
 
+// SetLimits changes the rate limit; waiting requests pick it up on their next check
+func (rl *NHLRateLimiter) SetLimits(maxRequests int, timeWindow, minDelay time.Duration) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	if rl.maxRequests == maxRequests && rl.timeWindow == timeWindow && rl.minDelay == minDelay {
+		return
+	}
+	rl.maxRequests = maxRequests
+	rl.timeWindow = timeWindow
+	rl.minDelay = minDelay
+	log.Printf("🛡️ NHL API Rate Limiter updated: %d req/%v, %v min delay", maxRequests, timeWindow, minDelay)
+}
+
 // Wait blocks until a request is allowed by the rate limiter
 // This ensures we never exceed the configured rate limits
 func (rl *NHLRateLimiter) Wait() {
+	rl.WaitContext(context.Background())
+}
+
+// WaitContext is Wait that gives up when ctx is done, returning ctx.Err().
+// A cancelled wait does not count as a request.
+func (rl *NHLRateLimiter) WaitContext(ctx context.Context) error {
 	rl.mutex.Lock()
 	defer rl.mutex.Unlock()
 
-	now := time.Now()
-	rl.totalRequests++
-
-	// Record API call in system stats
-	systemStatsServ := GetSystemStatsService()
-	if systemStatsServ != nil {
-		systemStatsServ.IncrementAPIRequest()
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
+	now := time.Now()
+	var waited time.Duration // Counted only once the wait succeeds
+
 	// Step 1: Clean old requests outside the time window
 	validRequests := make([]time.Time, 0, len(rl.requests))
 	for _, reqTime := range rl.requests {
@@ -93,24 +113,15 @@ func (rl *NHLRateLimiter) Wait() {
 		waitTime := rl.timeWindow - now.Sub(oldestRequest)
 
 		if waitTime > 0 {
-			rl.delayedRequests++
-			rl.totalWaitTime += waitTime
+			waited += waitTime
 
 			log.Printf("⏳ Rate limit reached (%d/%d requests in %v), waiting %.2fs...",
 				len(rl.requests), rl.maxRequests, rl.timeWindow, waitTime.Seconds())
 
 			// Unlock mutex while sleeping to allow other goroutines to check status
-			// Use defer/recover to ensure mutex is re-locked even if panic occurs
-			rl.mutex.Unlock()
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("⚠️ Panic during rate limiter sleep: %v", r)
-					}
-				}()
-				time.Sleep(waitTime + 100*time.Millisecond) // Add 100ms buffer
-			}()
-			rl.mutex.Lock()
+			if err := rl.sleepUnlocked(ctx, waitTime+100*time.Millisecond); err != nil { // Add 100ms buffer
+				return err
+			}
 
 			// Re-clean after sleep
 			now = time.Now()
@@ -131,29 +142,42 @@ func (rl *NHLRateLimiter) Wait() {
 
 		if timeSinceLastRequest < rl.minDelay {
 			waitTime := rl.minDelay - timeSinceLastRequest
-			rl.delayedRequests++
-			rl.totalWaitTime += waitTime
-
-			// Unlock mutex while sleeping (with panic recovery)
-			rl.mutex.Unlock()
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("⚠️ Panic during rate limiter min delay sleep: %v", r)
-					}
-				}()
-				time.Sleep(waitTime)
-			}()
-			rl.mutex.Lock()
+			waited += waitTime
+
+			if err := rl.sleepUnlocked(ctx, waitTime); err != nil {
+				return err
+			}
 		}
 	}
 
 	// Step 4: Record this request
 	rl.requests = append(rl.requests, time.Now())
+	rl.totalRequests++
+	if waited > 0 {
+		rl.delayedRequests++
+		rl.totalWaitTime += waited
+	}
+
+	// Record API call in system stats
+	systemStatsServ := GetSystemStatsService()
+	if systemStatsServ != nil {
+		systemStatsServ.IncrementAPIRequest()
+	}
+	return nil
+}
+
+// sleepUnlocked releases the mutex while waiting for d or ctx, then re-acquires it
+func (rl *NHLRateLimiter) sleepUnlocked(ctx context.Context, d time.Duration) error {
+	rl.mutex.Unlock()
+	defer rl.mutex.Lock()
+	return sleepContext(ctx, d)
 }
 
-// GetMetrics returns current rate limiter metrics
+// GetMetrics returns current rate limiter metrics, along with the NHL
+// client's retry, stale-serve and circuit breaker counts
 func (rl *NHLRateLimiter) GetMetrics() RateLimiterMetrics {
+	clientMetrics := GetNHLClient().GetMetrics()
+
 	rl.mutex.Lock()
 	defer rl.mutex.Unlock()
 
@@ -182,6 +206,7 @@ func (rl *NHLRateLimiter) GetMetrics() RateLimiterMetrics {
 		TotalWaitTime:      rl.totalWaitTime,
 		AverageWaitTime:    avgWaitTime,
 		CurrentUtilization: float64(requestsInWindow) / float64(rl.maxRequests) * 100,
+		Client:             &clientMetrics,
 	}
 }
 
@@ -197,6 +222,8 @@ type RateLimiterMetrics struct {
 	TotalWaitTime      time.Duration `json:"totalWaitTime"`
 	AverageWaitTime    time.Duration `json:"averageWaitTime"`
 	CurrentUtilization float64       `json:"currentUtilization"` // Percentage
+
+	Client *NHLClientMetrics `json:"client,omitempty"`
 }
 
 // LogMetrics logs current rate limiter metrics
@@ -212,6 +239,11 @@ func (rl *NHLRateLimiter) LogMetrics() {
 		float64(metrics.DelayedRequests)/float64(metrics.TotalRequests)*100)
 	log.Printf("   Total Wait Time: %v", metrics.TotalWaitTime)
 	log.Printf("   Average Wait: %v", metrics.AverageWaitTime)
+	if client := metrics.Client; client != nil {
+		log.Printf("   Retries: %d, Stale Served: %d, Failed Fast: %d",
+			client.Retries, client.StaleServed, client.Rejected)
+		log.Printf("   Open Circuits: %d of %d endpoints", client.OpenCircuits, len(client.Endpoints))
+	}
 }
 
 // Reset clears all rate limiter history (useful for testing)
@@ -281,12 +313,21 @@ func (rl *NHLRateLimiter) loadMetrics() error {
 	return nil
 }
 
-// periodicSave saves metrics every 5 minutes
+// periodicSave saves metrics every 5 minutes, and once more at shutdown
 func (rl *NHLRateLimiter) periodicSave() {
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ticker.C:
+		case <-BackgroundContext().Done():
+			if err := rl.saveMetrics(); err != nil {
+				log.Printf("⚠️ Failed to save rate limiter metrics: %v", err)
+			}
+			return
+		}
+
 		if err := rl.saveMetrics(); err != nil {
 			log.Printf("⚠️ Failed to save rate limiter metrics: %v", err)
 		} else {
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"sync"
 )
@@ -13,7 +14,7 @@ type RequestDeduplicator struct {
 }
 
 type inflightRequest struct {
-	wg     sync.WaitGroup
+	done   chan struct{} // Closed once result and err are set
 	result []byte
 	err    error
 }
@@ -40,35 +41,44 @@ func GetRequestDeduplicator() *RequestDeduplicator {
 // Do executes fn only once for the given key, even if called concurrently
 // If a request is already in flight for this key, it waits for that result
 func (rd *RequestDeduplicator) Do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	return rd.DoContext(context.Background(), key, func(context.Context) ([]byte, error) {
+		return fn()
+	})
+}
+
+// DoContext is Do where each caller stops waiting when its own ctx is done.
+// The shared call runs under the background context rather than any one
+// caller's, so a caller that gives up doesn't fail the others and a result
+// nobody is waiting for still gets cached.
+func (rd *RequestDeduplicator) DoContext(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
 	rd.mu.Lock()
 
-	// Check if request already in flight
-	if req, ok := rd.inflight[key]; ok {
+	req, ok := rd.inflight[key]
+	if ok {
 		// Request in flight - wait for it
 		rd.mu.Unlock()
 		fmt.Printf("⏳ Waiting for in-flight request: %s\n", key)
-		req.wg.Wait()
-		return req.result, req.err
-	}
-
-	// Start new request
-	req := &inflightRequest{}
-	req.wg.Add(1)
-	rd.inflight[key] = req
-	rd.mu.Unlock()
-
-	// Execute the function
-	result, err := fn()
+	} else {
+		// Start new request
+		req = &inflightRequest{done: make(chan struct{})}
+		rd.inflight[key] = req
+		rd.mu.Unlock()
 
-	// Store result and notify waiters
-	req.result = result
-	req.err = err
-	req.wg.Done()
+		go func() {
+			req.result, req.err = fn(BackgroundContext())
 
-	// Remove from inflight map
-	rd.mu.Lock()
-	delete(rd.inflight, key)
-	rd.mu.Unlock()
+			// Remove from inflight map, then notify waiters
+			rd.mu.Lock()
+			delete(rd.inflight, key)
+			rd.mu.Unlock()
+			close(req.done)
+		}()
+	}
 
-	return result, err
+	select {
+	case <-req.done:
+		return req.result, req.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -64,17 +65,11 @@ func (rvs *RosterValidationService) FetchRoster(teamCode string, season int) (*m
 	// Fetch from API
 	log.Printf("🏒 Fetching roster for %s (season %d) from NHL API...", teamCode, season)
 
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/roster/%s/%d", teamCode, season)
-	body, err := MakeAPICall(url)
+	rosterResp, err := GetNHLAPIClient().RosterForSeason(context.Background(), teamCode, season)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch roster: %w", err)
 	}
 
-	var rosterResp models.TeamRosterResponse
-	if err := json.Unmarshal(body, &rosterResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal roster: %w", err)
-	}
-
 	// Build TeamRoster with quick lookup map
 	roster := &models.TeamRoster{
 		TeamCode:    teamCode,
@@ -0,0 +1,420 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+	"github.com/jaredshillingburg/go_uhc/utils"
+)
+
+// SeasonRolloverService carries the rating models from one season into the
+// next. It archives last season's model state, regresses Elo, Poisson and
+// Bayesian ratings toward league average, and shifts each team's prior by
+// the production it gained or lost in roster turnover.
+type SeasonRolloverService struct {
+	dataDir  string
+	modelDir string
+	state    SeasonRolloverState
+	running  bool
+	mutex    sync.RWMutex
+}
+
+// SeasonRolloverState records which season the models are currently in
+type SeasonRolloverState struct {
+	CurrentSeason int                    `json:"currentSeason"`
+	Rollovers     []SeasonRolloverReport `json:"rollovers"`
+}
+
+// SeasonRolloverReport summarizes one rollover
+type SeasonRolloverReport struct {
+	FromSeason  int                      `json:"fromSeason"`
+	ToSeason    int                      `json:"toSeason"`
+	Carryover   float64                  `json:"carryover"`
+	ArchiveDir  string                   `json:"archiveDir"`
+	Teams       []TeamRolloverAdjustment `json:"teams"`
+	CompletedAt time.Time                `json:"completedAt"`
+	DurationMs  int64                    `json:"durationMs"`
+}
+
+// TeamRolloverAdjustment describes how one team's ratings moved at rollover
+type TeamRolloverAdjustment struct {
+	TeamCode       string  `json:"teamCode"`
+	Additions      int     `json:"additions"`
+	Departures     int     `json:"departures"`
+	ValueAdded     float64 `json:"valueAdded"`   // Points per game brought in
+	ValueLost      float64 `json:"valueLost"`    // Points per game that left
+	TeamValue      float64 `json:"teamValue"`    // Last season's team points per game
+	Turnover       float64 `json:"turnover"`     // 0 = same roster, 1 = fully rebuilt
+	OffenseShift   float64 `json:"offenseShift"` // Log goal-rate prior shift
+	EloBefore      float64 `json:"eloBefore"`
+	EloAfter       float64 `json:"eloAfter"`
+	PoissonBefore  float64 `json:"poissonOffenseBefore"`
+	PoissonAfter   float64 `json:"poissonOffenseAfter"`
+	BayesianBefore float64 `json:"bayesianNetBefore"`
+	BayesianAfter  float64 `json:"bayesianNetAfter"`
+	Error          string  `json:"error,omitempty"`
+}
+
+const (
+	seasonRatingCarryover  = 0.70  // Share of last season's rating kept over the summer
+	rosterShiftDamping     = 0.5   // Points only partly translate into goals, and players regress too
+	maxRosterOffenseShift  = 0.10  // Cap on the log goal-rate shift from roster moves
+	defaultTeamPointsValue = 8.0   // Team points per game when last season's stats are missing
+	eloPerLogGoalRatio     = 347.0 // 800/ln(10): Elo gap matching a Pythagorean (exp 2) goal ratio
+	rolloverOpenMonth      = time.September
+	rolloverOpenDay        = 15 // Training camps open; rosters are close to final
+)
+
+var (
+	seasonRolloverService     *SeasonRolloverService
+	seasonRolloverServiceOnce sync.Once
+)
+
+// GetSeasonRolloverService returns the singleton, creating it on first use
+func GetSeasonRolloverService() *SeasonRolloverService {
+	seasonRolloverServiceOnce.Do(func() {
+		dataDir := "data/season_rollover"
+		os.MkdirAll(dataDir, 0755)
+
+		seasonRolloverService = &SeasonRolloverService{
+			dataDir:  dataDir,
+			modelDir: "data/models",
+		}
+		seasonRolloverService.loadState()
+	})
+	return seasonRolloverService
+}
+
+// IsRunning reports whether a rollover is in progress
+func (srs *SeasonRolloverService) IsRunning() bool {
+	srs.mutex.RLock()
+	defer srs.mutex.RUnlock()
+	return srs.running
+}
+
+// GetState returns the current season and past rollovers
+func (srs *SeasonRolloverService) GetState() SeasonRolloverState {
+	srs.mutex.RLock()
+	defer srs.mutex.RUnlock()
+	return srs.state
+}
+
+// CheckCalendar rolls over if the date is in a newer season than the models
+// and that season's rosters have mostly settled. The job scheduler calls it
+// at startup and once a day.
+func (srs *SeasonRolloverService) CheckCalendar(now time.Time) {
+	season := utils.GetSeasonForDate(now)
+	if utils.IsOffseasonForDate(now) && now.Before(time.Date(now.Year(), rolloverOpenMonth, rolloverOpenDay, 0, 0, 0, 0, now.Location())) {
+		return
+	}
+	srs.EnsureSeason(season)
+}
+
+// EnsureSeason rolls the models over if season is newer than the one they're
+// in. A rollover makes one club stats and roster call per team, so it only
+// runs from the season-rollover-check job, never on the game results path;
+// the job opens the new season weeks before its first game.
+func (srs *SeasonRolloverService) EnsureSeason(season int) {
+	srs.mutex.Lock()
+	current := srs.state.CurrentSeason
+	if current == 0 {
+		// First run: adopt the season of the stored results without rolling over
+		srs.state.CurrentSeason = latestStoredSeason(season)
+		srs.saveStateLocked()
+		current = srs.state.CurrentSeason
+	}
+	srs.mutex.Unlock()
+
+	if season <= current || srs.IsRunning() {
+		return
+	}
+
+	if _, err := srs.Run(current, season); err != nil {
+		log.Printf("⚠️ Season rollover %d -> %d failed: %v", current, season, err)
+	}
+}
+
+// Run archives the model state for fromSeason and carries every team into
+// toSeason with regressed, roster-adjusted ratings. fromSeason must be the
+// season the models are in, so two triggers racing for the same rollover
+// can't regress the ratings twice or archive already-regressed state.
+func (srs *SeasonRolloverService) Run(fromSeason, toSeason int) (*SeasonRolloverReport, error) {
+	if toSeason <= fromSeason {
+		return nil, fmt.Errorf("invalid rollover: %d -> %d", fromSeason, toSeason)
+	}
+
+	srs.mutex.Lock()
+	if srs.running {
+		srs.mutex.Unlock()
+		return nil, fmt.Errorf("season rollover already running")
+	}
+	if current := srs.state.CurrentSeason; current != 0 && current != fromSeason {
+		srs.mutex.Unlock()
+		return nil, fmt.Errorf("models are in season %d, not %d", current, fromSeason)
+	}
+	srs.running = true
+	srs.mutex.Unlock()
+
+	defer func() {
+		srs.mutex.Lock()
+		srs.running = false
+		srs.mutex.Unlock()
+	}()
+
+	start := time.Now()
+	log.Printf("🗓️ Rolling models over from %s to %s...", utils.FormatSeason(fromSeason), utils.FormatSeason(toSeason))
+
+	archiveDir := filepath.Join(srs.dataDir, "archive", fmt.Sprintf("%d", fromSeason))
+	if err := archiveModelState(srs.modelDir, archiveDir); err != nil {
+		return nil, fmt.Errorf("failed to archive %d model state: %w", fromSeason, err)
+	}
+
+	var elo *EloRatingModel
+	var poisson *PoissonRegressionModel
+	if liveSys := GetLivePredictionSystem(); liveSys != nil {
+		elo = liveSys.GetEloModel()
+		poisson = liveSys.GetPoissonModel()
+	}
+	bayesian := GetBayesianRatingModel()
+
+	teams := make(map[string]*TeamRolloverAdjustment)
+	addTeam := func(code string) *TeamRolloverAdjustment {
+		if teams[code] == nil {
+			teams[code] = &TeamRolloverAdjustment{TeamCode: code}
+		}
+		return teams[code]
+	}
+
+	eloBefore := map[string]float64{}
+	if elo != nil {
+		eloBefore = elo.GetAllRatings()
+	}
+	poissonBefore := map[string]map[string]float64{}
+	if poisson != nil {
+		poissonBefore = poisson.GetAllRates()
+	}
+	for code, rating := range eloBefore {
+		addTeam(code).EloBefore = rating
+	}
+	for code, rates := range poissonBefore {
+		addTeam(code).PoissonBefore = rates["offensive"]
+	}
+	for _, posterior := range bayesian.GetTeamRatings() {
+		addTeam(posterior.TeamCode).BayesianBefore = posterior.Net().Mean
+	}
+
+	// Roster turnover, weighted by last season's production
+	offenseShifts := make(map[string]float64, len(teams))
+	eloShifts := make(map[string]float64, len(teams))
+	turnover := make(map[string]float64, len(teams))
+	srs.adjustForRosterTurnover(teams, fromSeason, toSeason)
+	for code, team := range teams {
+		offenseShifts[code] = team.OffenseShift
+		eloShifts[code] = eloPerLogGoalRatio * team.OffenseShift
+		turnover[code] = team.Turnover
+	}
+
+	if elo != nil {
+		elo.ApplySeasonRollover(seasonRatingCarryover, eloShifts)
+		for code, rating := range elo.GetAllRatings() {
+			addTeam(code).EloAfter = rating
+		}
+	}
+	if poisson != nil {
+		poisson.ApplySeasonRollover(seasonRatingCarryover, offenseShifts)
+		for code, rates := range poisson.GetAllRates() {
+			addTeam(code).PoissonAfter = rates["offensive"]
+		}
+	}
+	bayesian.ApplySeasonRollover(seasonRatingCarryover, offenseShifts, turnover)
+	for _, posterior := range bayesian.GetTeamRatings() {
+		addTeam(posterior.TeamCode).BayesianAfter = posterior.Net().Mean
+	}
+
+	report := &SeasonRolloverReport{
+		FromSeason:  fromSeason,
+		ToSeason:    toSeason,
+		Carryover:   seasonRatingCarryover,
+		ArchiveDir:  archiveDir,
+		Teams:       make([]TeamRolloverAdjustment, 0, len(teams)),
+		CompletedAt: time.Now(),
+		DurationMs:  time.Since(start).Milliseconds(),
+	}
+	for _, team := range teams {
+		report.Teams = append(report.Teams, *team)
+	}
+	sort.Slice(report.Teams, func(i, j int) bool {
+		return report.Teams[i].TeamCode < report.Teams[j].TeamCode
+	})
+
+	srs.mutex.Lock()
+	srs.state.CurrentSeason = toSeason
+	srs.state.Rollovers = append(srs.state.Rollovers, *report)
+	srs.saveStateLocked()
+	srs.mutex.Unlock()
+
+	log.Printf("✅ Season rollover complete: %d teams carried into %s (archive: %s)",
+		len(report.Teams), utils.FormatSeason(toSeason), archiveDir)
+	return report, nil
+}
+
+// adjustForRosterTurnover fills in each team's additions, departures and the
+// offense shift they imply. Departures are valued at what the player produced
+// for this team; additions at what they produced league-wide.
+func (srs *SeasonRolloverService) adjustForRosterTurnover(teams map[string]*TeamRolloverAdjustment, fromSeason, toSeason int) {
+	// The rollover check runs at startup, possibly before these are
+	// initialized, so fall back to standalone instances
+	rosters := GetRosterValidationService()
+	if rosters == nil {
+		rosters = NewRosterValidationService()
+	}
+	playerImpact := GetPlayerImpactService()
+	if playerImpact == nil {
+		playerImpact = NewPlayerImpactService()
+	}
+
+	teamValues := make(map[string]map[int]float64, len(teams))
+	leagueValues := make(map[int]float64)
+	for code, team := range teams {
+		values, err := playerImpact.SeasonPlayerValues(code, fromSeason)
+		if err != nil {
+			team.Error = err.Error()
+			continue
+		}
+		teamValues[code] = values
+		for playerID, value := range values {
+			leagueValues[playerID] += value
+		}
+	}
+
+	for code, team := range teams {
+		values, ok := teamValues[code]
+		if !ok {
+			continue
+		}
+
+		changes, err := rosters.DetectRosterChanges(code, fromSeason, toSeason)
+		if err != nil {
+			team.Error = err.Error()
+			continue
+		}
+
+		team.TeamValue = 0
+		for _, value := range values {
+			team.TeamValue += value
+		}
+		applyRosterChanges(team, changes, values, leagueValues)
+	}
+}
+
+// applyRosterChanges converts valued roster moves into a prior shift
+func applyRosterChanges(team *TeamRolloverAdjustment, changes []models.RosterChange, teamValues, leagueValues map[int]float64) {
+	team.Additions, team.Departures = 0, 0
+	team.ValueAdded, team.ValueLost = 0, 0
+	for _, change := range changes {
+		switch change.ChangeType {
+		case "added":
+			team.Additions++
+			team.ValueAdded += leagueValues[change.PlayerID]
+		case "removed":
+			team.Departures++
+			team.ValueLost += teamValues[change.PlayerID]
+		}
+	}
+
+	base := team.TeamValue
+	if base <= 0 {
+		base = defaultTeamPointsValue
+	}
+	net := team.ValueAdded - team.ValueLost
+	shift := rosterShiftDamping * math.Log(math.Max(base+net, base*0.5)/base)
+	team.OffenseShift = math.Max(-maxRosterOffenseShift, math.Min(maxRosterOffenseShift, shift))
+	team.Turnover = math.Min(1, (team.ValueAdded+team.ValueLost)/(2*base))
+}
+
+// latestStoredSeason returns the season of the most recent stored result,
+// or fallback if there are none
+func latestStoredSeason(fallback int) int {
+	games, err := loadCompletedGamesFrom("data/results")
+	if err != nil || len(games) == 0 {
+		return fallback
+	}
+	return games[len(games)-1].Season
+}
+
+// archiveModelState copies every file in modelDir into archiveDir
+func archiveModelState(modelDir, archiveDir string) error {
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return err
+	}
+
+	entries, err := ioutil.ReadDir(modelDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if err := copyModelFile(filepath.Join(modelDir, entry.Name()), filepath.Join(archiveDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyModelFile copies src to dst, replacing dst
+func copyModelFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// saveStateLocked persists the rollover state; callers hold the lock
+func (srs *SeasonRolloverService) saveStateLocked() {
+	data, err := json.MarshalIndent(srs.state, "", "  ")
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal season rollover state: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(srs.dataDir, "state.json"), data, 0644); err != nil {
+		log.Printf("⚠️ Failed to save season rollover state: %v", err)
+	}
+}
+
+// loadState restores the rollover state from disk
+func (srs *SeasonRolloverService) loadState() {
+	data, err := ioutil.ReadFile(filepath.Join(srs.dataDir, "state.json"))
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &srs.state); err != nil {
+		log.Printf("⚠️ Failed to parse season rollover state: %v", err)
+	}
+}
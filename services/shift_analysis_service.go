@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -89,10 +90,7 @@ func (sas *ShiftAnalysisService) FetchShiftData(gameID int) (*models.ShiftAnalyt
 
 	startTime := time.Now()
 
-	url := fmt.Sprintf("https://api-web.nhle.com/v1/gamecenter/%d/shifts", gameID)
-
-	// Use MakeAPICall for caching and rate limiting
-	body, err := MakeAPICall(url)
+	apiResp, err := GetNHLAPIClient().Shifts(context.Background(), gameID)
 	if err != nil {
 		// Record failure
 		if sas.systemStatsServ != nil {
@@ -101,17 +99,8 @@ func (sas *ShiftAnalysisService) FetchShiftData(gameID int) (*models.ShiftAnalyt
 		return nil, fmt.Errorf("failed to fetch shift data: %w", err)
 	}
 
-	var apiResp models.ShiftDataResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		// Record failure
-		if sas.systemStatsServ != nil {
-			sas.systemStatsServ.RecordBackfillFailure()
-		}
-		return nil, fmt.Errorf("failed to decode shift data: %w", err)
-	}
-
 	// Analyze the shift data
-	analytics := sas.analyzeShifts(&apiResp)
+	analytics := sas.analyzeShifts(apiResp)
 	if analytics == nil {
 		return nil, fmt.Errorf("failed to analyze shift data")
 	}
@@ -8,9 +8,9 @@ import (
 
 // SimulationConfig holds configuration for adaptive simulation count (Phase 5.2)
 type SimulationConfig struct {
-	MinSimulations     int
-	MaxSimulations     int
-	DefaultSimulations int
+	MinSimulations     int `json:"minSimulations" yaml:"minSimulations"`
+	MaxSimulations     int `json:"maxSimulations" yaml:"maxSimulations"`
+	DefaultSimulations int `json:"defaultSimulations" yaml:"defaultSimulations"`
 }
 
 // DefaultSimulationConfig returns the default configuration
@@ -12,19 +12,32 @@ import (
 // RePredictionStrategy defines the strategy for re-predicting games
 type RePredictionStrategy struct {
 	// Season phase thresholds
-	EarlySeasonThreshold int // < this many games = early season (re-predict all)
-	MidSeasonThreshold   int // < this many games = mid season (re-predict near-term)
+	EarlySeasonThreshold int `json:"earlySeasonThreshold" yaml:"earlySeasonThreshold"` // < this many games = early season (re-predict all)
+	MidSeasonThreshold   int `json:"midSeasonThreshold" yaml:"midSeasonThreshold"`     // < this many games = mid season (re-predict near-term)
 
 	// Time-based filters
-	NearTermDays      int // Games within this many days
-	DirectImpactGames int // Number of next games to re-predict for involved teams
+	NearTermDays      int `json:"nearTermDays" yaml:"nearTermDays"`           // Games within this many days
+	DirectImpactGames int `json:"directImpactGames" yaml:"directImpactGames"` // Number of next games to re-predict for involved teams
 
 	// Quality filters
-	AccuracyThreshold float64       // Model accuracy improvement threshold for full re-prediction
-	MinHoursBetween   time.Duration // Minimum time between re-predictions
+	AccuracyThreshold float64       `json:"accuracyThreshold" yaml:"accuracyThreshold"` // Model accuracy improvement threshold for full re-prediction
+	MinHoursBetween   time.Duration `json:"minHoursBetween" yaml:"minHoursBetween"`     // Minimum time between re-predictions
 
 	// Rate limiting
-	MaxPredictionsPerBatch int // Maximum predictions in one batch
+	MaxPredictionsPerBatch int `json:"maxPredictionsPerBatch" yaml:"maxPredictionsPerBatch"` // Maximum predictions in one batch
+}
+
+// DefaultRePredictionStrategy returns the built-in re-prediction strategy
+func DefaultRePredictionStrategy() RePredictionStrategy {
+	return RePredictionStrategy{
+		EarlySeasonThreshold:   20,   // First 20 games
+		MidSeasonThreshold:     60,   // Up to 60 games
+		NearTermDays:           7,    // Next 7 days
+		DirectImpactGames:      3,    // Next 3 games
+		AccuracyThreshold:      0.02, // 2% improvement
+		MinHoursBetween:        6 * time.Hour,
+		MaxPredictionsPerBatch: 200,
+	}
 }
 
 // RePredictionDecision describes what to re-predict and why
@@ -73,15 +86,7 @@ func InitSmartRePredictionService(
 ) {
 	smartRePredictionServiceOnce.Do(func() {
 		smartRePredictionService = &SmartRePredictionService{
-			strategy: RePredictionStrategy{
-				EarlySeasonThreshold:   20,   // First 20 games
-				MidSeasonThreshold:     60,   // Up to 60 games
-				NearTermDays:           7,    // Next 7 days
-				DirectImpactGames:      3,    // Next 3 games
-				AccuracyThreshold:      0.02, // 2% improvement
-				MinHoursBetween:        6 * time.Hour,
-				MaxPredictionsPerBatch: 200,
-			},
+			strategy: GetAppConfig().RePrediction,
 			metrics: &RePredictionMetrics{
 				LastRePrediction: time.Now().Add(-24 * time.Hour), // Allow immediate first run
 			},
@@ -95,6 +100,11 @@ func InitSmartRePredictionService(
 			smartRePredictionService.strategy.EarlySeasonThreshold,
 			smartRePredictionService.strategy.MidSeasonThreshold,
 			smartRePredictionService.strategy.NearTermDays)
+
+		// Re-prediction thresholds are safe to change while running
+		OnAppConfigChange(func(config *AppConfig) {
+			smartRePredictionService.UpdateStrategy(config.RePrediction)
+		})
 	})
 }
 
@@ -241,15 +251,27 @@ func (srps *SmartRePredictionService) ExecuteRePrediction(decision *RePrediction
 	// Force regenerate predictions (daily prediction service will handle the logic)
 	log.Printf("🎯 Triggering prediction regeneration for scope: %s", decision.Scope)
 
-	// Note: In a full implementation, we would:
-	// 1. Get upcoming games from NHL API based on scope
-	// 2. Re-generate predictions for each game
-	// 3. Update stored predictions
-	// For now, we log the intent and let the daily service handle it naturally
+	// Specific games (e.g. a surprise goalie confirmation) are re-predicted immediately
+	for _, gameID := range decision.GameIDs {
+		if err := srps.predictionServ.RePredictGame(gameID); err != nil {
+			log.Printf("⚠️ Failed to re-predict game %d: %v", gameID, err)
+		} else {
+			log.Printf("✅ Re-predicted game %d", gameID)
+		}
+	}
+
+	// Note: For broader scopes we log the intent and let the daily service handle it naturally
 
 	duration := time.Since(startTime)
 	log.Printf("✅ Re-prediction triggered in %s (daily service will regenerate predictions)", duration)
 
+	PublishPushEvent(PushEventRePrediction, map[string]interface{}{
+		"scope":     decision.Scope,
+		"reason":    decision.Reason,
+		"gameIds":   decision.GameIDs,
+		"teamCodes": decision.TeamCodes,
+	})
+
 	return nil
 }
 
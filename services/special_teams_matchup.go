@@ -130,11 +130,19 @@ func (stma *SpecialTeamsMatchupAnalyzer) AnalyzeMatchup(
 	// ============================================================================
 
 	// Estimate expected PP opportunities per game (league avg ~3-4 per team)
+	// The officiating crew's tendencies replace the league average when known
 	avgPPOppsPerTeam := 3.5
+	homePPOpps, awayPPOpps := avgPPOppsPerTeam, avgPPOppsPerTeam
+	if homeFactors.ExpectedPPOpportunities > 0 {
+		homePPOpps = homeFactors.ExpectedPPOpportunities
+	}
+	if awayFactors.ExpectedPPOpportunities > 0 {
+		awayPPOpps = awayFactors.ExpectedPPOpportunities
+	}
 	
 	// Expected PP goals
-	homeExpectedPPGoals := matchup.HomePPEffectiveness * avgPPOppsPerTeam
-	awayExpectedPPGoals := matchup.AwayPPEffectiveness * avgPPOppsPerTeam
+	homeExpectedPPGoals := matchup.HomePPEffectiveness * homePPOpps
+	awayExpectedPPGoals := matchup.AwayPPEffectiveness * awayPPOpps
 	
 	matchup.ExpectedPPGoalsDiff = homeExpectedPPGoals - awayExpectedPPGoals
 
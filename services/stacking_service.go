@@ -0,0 +1,400 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jaredshillingburg/go_uhc/models"
+)
+
+// StackingService builds out-of-fold base-model predictions for completed
+// games, compares the meta-learner combiners against fixed and dynamic
+// weighting on them, and trains whichever combiner scores best. No base
+// prediction it produces comes from a model that has seen that game's result.
+type StackingService struct {
+	dataDir    string
+	resultsDir string
+	report     *StackingReport
+	running    bool
+	mutex      sync.RWMutex
+}
+
+// StackingReport summarizes the latest stacking run
+type StackingReport struct {
+	Games       int                              `json:"games"`
+	Examples    int                              `json:"examples"`
+	Coverage    map[string]int                   `json:"coverage"`  // Examples with a real OOF prediction per model
+	Combiners   map[string]ModelValidationResult `json:"combiners"` // Pooled over every walk-forward test window
+	Folds       []MetaValidationFold             `json:"folds"`
+	Selected    string                           `json:"selected"`
+	CompletedAt time.Time                        `json:"completedAt"`
+	DurationMs  int64                            `json:"durationMs"`
+}
+
+// stackingExample pairs a game's OOF base predictions with its meta features
+type stackingExample struct {
+	record WalkForwardRecord // ModelProbs holds only models with a real OOF prediction
+	meta   MetaTrainingExample
+}
+
+const (
+	stackingTreeFolds   = 5  // Rolling-origin refits for the tree models
+	stackingMinExamples = 40 // OOF examples needed before comparing combiners
+)
+
+// fixedCombinerWeights are the ensemble's base weights, used by the fixed combiner
+var fixedCombinerWeights = map[string]float64{
+	"Enhanced Statistical":   0.30,
+	"Bayesian Inference":     0.12,
+	"Monte Carlo Simulation": 0.09,
+	"Elo Rating":             0.17,
+	"Poisson Regression":     0.12,
+	"Neural Network":         0.06,
+	"Gradient Boosting":      0.07,
+	"LSTM":                   0.07,
+	"Random Forest":          0.07,
+	"Bayesian Ratings":       0.08,
+}
+
+// StackingOOFSources describes how each base model's out-of-fold predictions are produced
+var StackingOOFSources = map[string]string{
+	"Enhanced Statistical":   "stateless: re-run on prediction-time factors",
+	"Bayesian Inference":     "stateless: re-run on prediction-time factors",
+	"Monte Carlo Simulation": "stateless: re-run on prediction-time factors",
+	"Elo Rating":             "online replay: each game predicted before its result is applied",
+	"Poisson Regression":     "online replay: each game predicted before its result is applied",
+	"Gradient Boosting":      "refit on each rolling-origin training window",
+	"Random Forest":          "refit on each rolling-origin training window",
+	"Neural Network":         "stored pre-game prediction",
+	"LSTM":                   "stored pre-game prediction",
+	"Bayesian Ratings":       "online replay: each game predicted before its result is applied",
+}
+
+var (
+	stackingService     *StackingService
+	stackingServiceOnce sync.Once
+)
+
+// GetStackingService returns the singleton, creating it on first use
+func GetStackingService() *StackingService {
+	stackingServiceOnce.Do(func() {
+		dataDir := "data/stacking"
+		os.MkdirAll(dataDir, 0755)
+
+		stackingService = &StackingService{
+			dataDir:    dataDir,
+			resultsDir: "data/results",
+		}
+		stackingService.loadReport()
+	})
+	return stackingService
+}
+
+// IsRunning reports whether a stacking run is in progress
+func (ss *StackingService) IsRunning() bool {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	return ss.running
+}
+
+// GetReport returns the latest stacking report, or nil before the first run
+func (ss *StackingService) GetReport() *StackingReport {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	return ss.report
+}
+
+// Run rebuilds the OOF predictions, compares combiners walk-forward and makes
+// the one with the lowest log loss the ensemble's combiner
+func (ss *StackingService) Run() (*StackingReport, error) {
+	ss.mutex.Lock()
+	if ss.running {
+		ss.mutex.Unlock()
+		return nil, fmt.Errorf("stacking already running")
+	}
+	ss.running = true
+	ss.mutex.Unlock()
+
+	defer func() {
+		ss.mutex.Lock()
+		ss.running = false
+		ss.mutex.Unlock()
+	}()
+
+	start := time.Now()
+
+	games, err := loadCompletedGamesFrom(ss.resultsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load completed games: %w", err)
+	}
+
+	examples, coverage, err := buildStackingExamples(games)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🧱 Stacking: %d out-of-fold examples from %d completed games", len(examples), len(games))
+
+	metaLearner := GetMetaLearnerModel()
+	folds, pooled := compareCombiners(metaLearner, examples)
+	if len(folds) == 0 {
+		return nil, fmt.Errorf("not enough OOF examples for walk-forward comparison: %d", len(examples))
+	}
+
+	selected := CombinerDynamic
+	for _, combiner := range []string{CombinerLogistic, CombinerGBM, CombinerFixed, CombinerDynamic} {
+		log.Printf("   %-8s log loss %.4f, Brier %.4f, accuracy %.1f%%",
+			combiner, pooled[combiner].LogLoss, pooled[combiner].BrierScore, pooled[combiner].Accuracy*100)
+		if pooled[combiner].LogLoss < pooled[selected].LogLoss {
+			selected = combiner
+		}
+	}
+
+	if selected == CombinerLogistic || selected == CombinerGBM {
+		metaExamples := make([]MetaTrainingExample, len(examples))
+		for i, example := range examples {
+			metaExamples[i] = example.meta
+		}
+		err = metaLearner.TrainStacked(metaExamples, selected)
+	} else {
+		err = metaLearner.SetCombiner(selected)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply %s combiner: %w", selected, err)
+	}
+
+	report := &StackingReport{
+		Games:       len(games),
+		Examples:    len(examples),
+		Coverage:    coverage,
+		Combiners:   pooled,
+		Folds:       folds,
+		Selected:    selected,
+		CompletedAt: time.Now(),
+		DurationMs:  time.Since(start).Milliseconds(),
+	}
+	log.Printf("🧱 Stacking selected the %s combiner (log loss %.4f)", selected, pooled[selected].LogLoss)
+
+	ss.mutex.Lock()
+	ss.report = report
+	ss.mutex.Unlock()
+	ss.saveReport(report)
+
+	return report, nil
+}
+
+// buildStackingExamples produces out-of-fold base predictions for every game
+// with prediction-time factors after the tree models' first training window.
+// Models without a prediction for a game are left out of its record and
+// imputed with the mean of the others for the meta-learner's fixed inputs.
+func buildStackingExamples(games []models.CompletedGame) ([]stackingExample, map[string]int, error) {
+	treeExamples := buildTreeTrainingExamples(games)
+	folds := tuningSplits(len(treeExamples), stackingTreeFolds)
+	if len(folds) == 0 || len(treeExamples)-folds[0].TestStart < stackingMinExamples {
+		return nil, nil, fmt.Errorf("insufficient data for stacking: %d games with prediction-time factors (need %d)",
+			len(treeExamples), stackingMinExamples*2)
+	}
+
+	// Tree models: refit on each training window, predict the window after it
+	gradientBoosting := newTreeEvaluator("gradient_boosting", treeExamples)
+	gradientBoosting.folds = folds
+	gbProbs := gradientBoosting.gradientBoostingProbabilities(currentHyperparameters("gradient_boosting"))
+
+	randomForest := newTreeEvaluator("random_forest", treeExamples)
+	randomForest.folds = folds
+	rfProbs := randomForest.randomForestProbabilities(currentHyperparameters("random_forest"))
+
+	// Rating models: a single replay over the full history is already out of fold
+	replay := &tuningEvaluator{games: games}
+	eloProbs := replay.eloProbabilities(currentHyperparameters("elo"))
+	poissonProbs := replay.poissonProbabilities(currentHyperparameters("poisson"))
+	ratingProbs := replayBayesianRatings(games)
+	replayIndex := make(map[int]int, len(games))
+	for i, game := range games {
+		replayIndex[game.GameID] = i
+	}
+
+	// Networks are too costly to refit per fold; their pre-game predictions
+	// were made before the result was known
+	pregame := make(map[int]map[string]float64)
+	if records, err := LoadWalkForwardRecords(); err == nil {
+		for _, record := range records {
+			pregame[record.GameID] = record.ModelProbs
+		}
+	}
+
+	stateless := []PredictionModel{NewStatisticalModel(), NewBayesianModel(), NewMonteCarloModel()}
+
+	coverage := make(map[string]int)
+	examples := make([]stackingExample, 0, len(treeExamples)-folds[0].TestStart)
+	for i := folds[0].TestStart; i < len(treeExamples); i++ {
+		treeExample := treeExamples[i]
+		game := treeExample.Game
+
+		probs := map[string]float64{
+			"Gradient Boosting": gbProbs[i],
+			"Random Forest":     rfProbs[i],
+		}
+		if j, ok := replayIndex[game.GameID]; ok {
+			probs["Elo Rating"] = eloProbs[j]
+			probs["Poisson Regression"] = poissonProbs[j]
+			probs["Bayesian Ratings"] = ratingProbs[j]
+		}
+		for _, model := range stateless {
+			if result, err := model.Predict(treeExample.HomeFactors, treeExample.AwayFactors); err == nil {
+				probs[model.GetName()] = result.WinProbability
+			}
+		}
+		for _, name := range []string{"Neural Network", "LSTM"} {
+			if p, ok := pregame[game.GameID][name]; ok && p > 0 {
+				probs[name] = p
+			}
+		}
+
+		mean := 0.0
+		for name, p := range probs {
+			mean += p
+			coverage[name]++
+		}
+		mean /= float64(len(probs))
+
+		predictions := ModelPredictions{}
+		for name := range fixedCombinerWeights {
+			predictions.setModel(name, mean)
+		}
+		for name, p := range probs {
+			predictions.setModel(name, p)
+		}
+
+		homeWon := game.HomeTeam.Score > game.AwayTeam.Score
+		outcome := 0.0
+		if homeWon {
+			outcome = 1.0
+		}
+
+		examples = append(examples, stackingExample{
+			record: WalkForwardRecord{
+				GameID:     game.GameID,
+				GameDate:   game.GameDate,
+				HomeTeam:   game.HomeTeam.TeamCode,
+				AwayTeam:   game.AwayTeam.TeamCode,
+				HomeWon:    homeWon,
+				ModelProbs: probs,
+			},
+			meta: MetaTrainingExample{
+				Predictions:   predictions,
+				Context:       newMetaGameContext(treeExample.HomeFactors, treeExample.AwayFactors),
+				ActualOutcome: outcome,
+				GameID:        game.GameID,
+				GameDate:      game.GameDate,
+			},
+		})
+	}
+
+	return examples, coverage, nil
+}
+
+// compareCombiners scores every combiner on the meta-learner's rolling-origin
+// folds over the OOF examples. Learned combiners and dynamic weights are fit on
+// each training window only; dynamic weighting is simulated with inverse-Brier
+// weights from the window, the same rule recalibration uses.
+func compareCombiners(metaLearner *MetaLearnerModel, examples []stackingExample) ([]MetaValidationFold, map[string]ModelValidationResult) {
+	metaLearner.mutex.RLock()
+	cfg := metaLearner.walkForward
+	metaLearner.mutex.RUnlock()
+
+	splits := RollingOriginSplits(len(examples), cfg)
+	folds := make([]MetaValidationFold, 0, len(splits))
+	pooledProbs := make(map[string][]float64)
+	var pooledOutcomes []float64
+
+	for _, split := range splits {
+		train := examples[split.TrainStart:split.TrainEnd]
+		test := examples[split.TestStart:split.TestEnd]
+
+		trainMeta := make([]MetaTrainingExample, len(train))
+		trainRecords := make([]WalkForwardRecord, len(train))
+		for i, example := range train {
+			trainMeta[i] = example.meta
+			trainRecords[i] = example.record
+		}
+
+		// Hold back the tail of the training window for early stopping
+		logistic := metaLearner.newFoldModel()
+		valIdx := int(float64(len(trainMeta)) * 0.8)
+		logistic.fit(trainMeta[:valIdx], trainMeta[valIdx:], false)
+
+		boosted := metaLearner.newFoldModel()
+		boosted.combiner = CombinerGBM
+		boosted.gbm = boosted.fitGBM(trainMeta)
+
+		dynamicWeights := inverseBrierWeights(scoreWalkForwardModels(trainRecords))
+
+		probs := make(map[string][]float64)
+		outcomes := make([]float64, len(test))
+		for i, example := range test {
+			probs[CombinerLogistic] = append(probs[CombinerLogistic], logistic.predictProbability(&example.meta.Predictions, &example.meta.Context))
+			probs[CombinerGBM] = append(probs[CombinerGBM], boosted.predictProbability(&example.meta.Predictions, &example.meta.Context))
+			probs[CombinerFixed] = append(probs[CombinerFixed], blendWalkForward(example.record, fixedCombinerWeights))
+			probs[CombinerDynamic] = append(probs[CombinerDynamic], blendWalkForward(example.record, dynamicWeights))
+			outcomes[i] = example.meta.ActualOutcome
+		}
+
+		fold := MetaValidationFold{
+			Fold:             split.Fold,
+			TrainSize:        len(train),
+			TestSize:         len(test),
+			MetaLearner:      scoreProbabilities("Meta-Learner (logistic)", probs[CombinerLogistic], outcomes),
+			GradientBoosting: scoreProbabilities("Meta-Learner (gbm)", probs[CombinerGBM], outcomes),
+			WeightedAverage:  scoreProbabilities("Fixed Weights", probs[CombinerFixed], outcomes),
+			DynamicWeighting: scoreProbabilities("Dynamic Weights", probs[CombinerDynamic], outcomes),
+		}
+		folds = append(folds, fold)
+
+		log.Printf("   Stacking fold %d: train %d, test %d | log loss logistic %.4f, gbm %.4f, fixed %.4f, dynamic %.4f",
+			fold.Fold, fold.TrainSize, fold.TestSize, fold.MetaLearner.LogLoss, fold.GradientBoosting.LogLoss,
+			fold.WeightedAverage.LogLoss, fold.DynamicWeighting.LogLoss)
+
+		for combiner, p := range probs {
+			pooledProbs[combiner] = append(pooledProbs[combiner], p...)
+		}
+		pooledOutcomes = append(pooledOutcomes, outcomes...)
+	}
+
+	pooled := make(map[string]ModelValidationResult, len(pooledProbs))
+	for combiner, p := range pooledProbs {
+		pooled[combiner] = scoreProbabilities(combiner, p, pooledOutcomes)
+	}
+	return folds, pooled
+}
+
+// saveReport persists the latest report
+func (ss *StackingService) saveReport(report *StackingReport) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal stacking report: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(ss.dataDir, "report.json"), data, 0644); err != nil {
+		log.Printf("⚠️ Failed to save stacking report: %v", err)
+	}
+}
+
+// loadReport restores the latest report from disk
+func (ss *StackingService) loadReport() {
+	data, err := ioutil.ReadFile(filepath.Join(ss.dataDir, "report.json"))
+	if err != nil {
+		return
+	}
+	var report StackingReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		log.Printf("⚠️ Failed to parse stacking report: %v", err)
+		return
+	}
+	ss.report = &report
+}
@@ -1,7 +1,7 @@
 package services
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -60,17 +60,11 @@ func (scs *StandingsCacheService) GetStandings() ([]models.TeamStanding, error)
 	scs.recordMiss()
 
 	fmt.Println("Fetching NHL standings...")
-	url := "https://api-web.nhle.com/v1/standings/now"
-	body, err := MakeAPICall(url)
+	response, err := GetNHLAPIClient().Standings(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch standings: %w", err)
 	}
 
-	var response models.StandingsResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal standings: %w", err)
-	}
-
 	standings := response.Standings
 
 	// Update cache
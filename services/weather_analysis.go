@@ -6,7 +6,6 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -90,9 +89,10 @@ func NewWeatherAnalysisService() *WeatherAnalysisService {
 // initializeDataSources sets up available weather data sources
 func (w *WeatherAnalysisService) initializeDataSources() {
 	enabledSources := 0
+	apiKeys := GetAppConfig().APIKeys
 
 	// OpenWeatherMap (free tier with 1000 calls/day)
-	openWeatherAPIKey := os.Getenv("OPENWEATHER_API_KEY")
+	openWeatherAPIKey := apiKeys.OpenWeather
 	if openWeatherAPIKey != "" {
 		w.dataSources = append(w.dataSources, models.WeatherDataSource{
 			Name:           "OpenWeatherMap",
@@ -112,7 +112,7 @@ func (w *WeatherAnalysisService) initializeDataSources() {
 	}
 
 	// WeatherAPI (free tier with 1M calls/month)
-	weatherAPIKey := os.Getenv("WEATHER_API_KEY")
+	weatherAPIKey := apiKeys.WeatherAPI
 	if weatherAPIKey != "" {
 		w.dataSources = append(w.dataSources, models.WeatherDataSource{
 			Name:           "WeatherAPI",
@@ -132,7 +132,7 @@ func (w *WeatherAnalysisService) initializeDataSources() {
 	}
 
 	// AccuWeather (free tier with 50 calls/day)
-	accuWeatherAPIKey := os.Getenv("ACCUWEATHER_API_KEY")
+	accuWeatherAPIKey := apiKeys.AccuWeather
 	if accuWeatherAPIKey != "" {
 		w.dataSources = append(w.dataSources, models.WeatherDataSource{
 			Name:           "AccuWeather",
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"sync"
@@ -106,6 +107,11 @@ func (wc *WhatIfCache) ClearExpired() int {
 
 // SimulateWhatIf runs a what-if scenario simulation (Phase 5.3: With caching)
 func (ps *PlayoffSimulationService) SimulateWhatIf(teamCode string, scenario WhatIfScenario, simulations int) (*WhatIfResult, error) {
+	return ps.SimulateWhatIfContext(context.Background(), teamCode, scenario, simulations)
+}
+
+// SimulateWhatIfContext is SimulateWhatIf that aborts once ctx is done
+func (ps *PlayoffSimulationService) SimulateWhatIfContext(ctx context.Context, teamCode string, scenario WhatIfScenario, simulations int) (*WhatIfResult, error) {
 	// Get current standings
 	standings, err := GetStandings()
 	if err != nil {
@@ -179,9 +185,9 @@ func (ps *PlayoffSimulationService) SimulateWhatIf(teamCode string, scenario Wha
 	}
 
 	// Get remaining schedule
-	remainingGames, err := ps.getRemainingGames(conferenceTeams)
+	remainingGames, err := ps.getRemainingGames(ctx, conferenceTeams)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get remaining games: %v", err)
+		return nil, fmt.Errorf("failed to get remaining games: %w", err)
 	}
 
 	// Filter out games that are part of the scenario (already "played")
@@ -204,6 +210,9 @@ func (ps *PlayoffSimulationService) SimulateWhatIf(teamCode string, scenario Wha
 	pointsDistribution := make(map[int]int)
 
 	for i := 0; i < simulations; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		result := ps.simulateSeason(&modifiedTeam, conferenceTeams, filteredGames)
 		results[i] = result
 		pointsDistribution[result.FinalPoints]++